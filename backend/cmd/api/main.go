@@ -1,14 +1,20 @@
 package main
 
 import (
+	"context"
 	"log"
 	"path/filepath"
 	"runtime"
 
 	authDomain "github.com/bryanriosb/stock-info/internal/auth/domain"
+	backtestDomain "github.com/bryanriosb/stock-info/internal/backtest/domain"
+	brokerageDomain "github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
 	ratingDomain "github.com/bryanriosb/stock-info/internal/rating/domain"
+	recommendationDomain "github.com/bryanriosb/stock-info/internal/recommendation/domain"
 	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
 	userDomain "github.com/bryanriosb/stock-info/internal/user/domain"
+	watchlistDomain "github.com/bryanriosb/stock-info/internal/watchlist/domain"
 	"github.com/bryanriosb/stock-info/shared"
 	"github.com/bryanriosb/stock-info/shared/database"
 	"github.com/bryanriosb/stock-info/shared/router"
@@ -31,9 +37,19 @@ func main() {
 
 	if err := database.RunMigrations(cfg, migrationsPath,
 		&stockDomain.Stock{},
+		&stockDomain.ActionHistory{},
 		&userDomain.User{},
 		&authDomain.RefreshToken{},
 		&ratingDomain.RatingOption{},
+		&priceDomain.PricePoint{},
+		&priceDomain.LastPrice{},
+		&brokerageDomain.Credibility{},
+		&brokerageDomain.ActionAccuracy{},
+		&backtestDomain.Run{},
+		&recommendationDomain.Snapshot{},
+		&recommendationDomain.ScoringProfile{},
+		&recommendationDomain.ScoringRule{},
+		&watchlistDomain.Item{},
 	); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
@@ -47,9 +63,13 @@ func main() {
 
 	// Start server
 	app := newFiberApp()
-	router.Setup(app, database.DB(), cfg)
+	grpcServer := newGRPCServer(cfg)
+	// Background jobs stop with the servers
+	ctx, stopJobs := context.WithCancel(context.Background())
+	router.Setup(ctx, app, grpcServer, database.DB(), cfg)
 
 	go startServer(app, cfg.Server.Port)
+	go startGRPCServer(grpcServer, cfg.Server.GRPCPort)
 
-	gracefulShutdown(app)
+	gracefulShutdown(app, grpcServer, stopJobs)
 }
@@ -2,12 +2,19 @@ package main
 
 import (
 	"log"
+	"net"
 	"time"
 
+	stockinfov1 "github.com/bryanriosb/stock-info/proto/stockinfo/v1"
+	"github.com/bryanriosb/stock-info/shared"
+	"github.com/bryanriosb/stock-info/shared/middleware"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func newFiberApp() *fiber.App {
@@ -34,3 +41,36 @@ func startServer(app *fiber.App, port string) {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+func newGRPCServer(cfg *shared.Config) *grpc.Server {
+	recoverUnary, recoverStream := middleware.GRPCRecover()
+	authUnary, authStream := middleware.GRPCAuth(cfg.JWT.Secret,
+		stockinfov1.AuthService_Login_FullMethodName,
+		stockinfov1.AuthService_Refresh_FullMethodName,
+		stockinfov1.AuthService_Logout_FullMethodName,
+	)
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(recoverUnary, authUnary),
+		grpc.ChainStreamInterceptor(recoverStream, authStream),
+	)
+
+	if cfg.IsDevelopment() {
+		// Lets grpcurl and similar tools discover the services
+		reflection.Register(server)
+	}
+
+	return server
+}
+
+func startGRPCServer(server *grpc.Server, port string) {
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		log.Fatalf("Failed to listen for gRPC: %v", err)
+	}
+
+	log.Printf("gRPC server starting on port %s", port)
+	if err := server.Serve(listener); err != nil {
+		log.Fatalf("Failed to start gRPC server: %v", err)
+	}
+}
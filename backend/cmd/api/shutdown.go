@@ -9,21 +9,38 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
 )
 
-func gracefulShutdown(app *fiber.App) {
+func gracefulShutdown(app *fiber.App, grpcServer *grpc.Server, stopJobs context.CancelFunc) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Println("Shutting down server...")
 
+	// No new job runs start; running ones see their context cancelled
+	stopJobs()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// Drain in-flight RPCs within the same deadline, then cut remaining streams
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
 	if err := app.ShutdownWithContext(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
+
 	log.Println("Server exited")
 }
@@ -0,0 +1,160 @@
+// Command backtest replays recommendation history with a scoring strategy and
+// stores the run next to those started through the API:
+//
+//	go run ./cmd/backtest -strategy momentum -from 2024-01-01 -to 2024-12-31 -param half_life_days=14 -rating hold=4
+//
+// It reads the database settings of the API from the environment and expects
+// a migrated database with imported prices.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/backtest"
+	backtestDomain "github.com/bryanriosb/stock-info/internal/backtest/domain"
+	priceInfra "github.com/bryanriosb/stock-info/internal/price/infrastructure"
+	"github.com/bryanriosb/stock-info/internal/recommendation"
+	"github.com/bryanriosb/stock-info/shared"
+	"github.com/bryanriosb/stock-info/shared/database"
+)
+
+// parameters collects repeated -param name=value flags
+type parameters map[string]float64
+
+func (p parameters) String() string {
+	pairs := make([]string, 0, len(p))
+	for name, value := range p {
+		pairs = append(pairs, fmt.Sprintf("%s=%g", name, value))
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (p parameters) Set(pair string) error {
+	name, raw, ok := strings.Cut(pair, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("expected name=value, got %q", pair)
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fmt.Errorf("parameter %s: %w", name, err)
+	}
+	p[name] = value
+	return nil
+}
+
+// ratings collects repeated -rating label=value flags
+type ratings map[string]int
+
+func (r ratings) String() string {
+	pairs := make([]string, 0, len(r))
+	for label, value := range r {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", label, value))
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (r ratings) Set(pair string) error {
+	label, raw, ok := strings.Cut(pair, "=")
+	if !ok || label == "" {
+		return fmt.Errorf("expected label=value, got %q", pair)
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return fmt.Errorf("rating %s: %w", label, err)
+	}
+	r[label] = value
+	return nil
+}
+
+func main() {
+	params := parameters{}
+	scale := ratings{}
+	strategy := flag.String("strategy", "", "Scoring strategy, balanced when empty")
+	aggregation := flag.String("aggregation", "", "How brokerage scores are combined: mean, median or weighted")
+	from := flag.String("from", "", "First rebalance date, YYYY-MM-DD")
+	to := flag.String("to", "", "Last possible rebalance date, YYYY-MM-DD")
+	rebalance := flag.Int("rebalance", 0, "Days between rebalances (default 7)")
+	horizon := flag.Int("horizon", 0, "Days over which returns are measured (default: the rebalance interval)")
+	picks := flag.Int("picks", 0, "Recommendations held at each rebalance (default 10)")
+	verbose := flag.Bool("v", false, "Print the picks of every rebalance")
+	flag.Var(params, "param", "Strategy parameter override as name=value; repeatable")
+	flag.Var(scale, "rating", "Rating value override on the 1-9 scale as label=value; repeatable")
+	flag.Parse()
+
+	start, err := time.Parse(time.DateOnly, *from)
+	if err != nil {
+		log.Fatalf("Invalid -from: %v", err)
+	}
+	end, err := time.Parse(time.DateOnly, *to)
+	if err != nil {
+		log.Fatalf("Invalid -to: %v", err)
+	}
+
+	cfg := shared.LoadConfig()
+	if err := database.Init(cfg.Database); err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close()
+
+	db := database.DB()
+	// Brokerages weigh alike: today's credibility was learned from the prices being replayed
+	ranker := recommendation.NewUseCase(db, cfg, nil)
+	useCase := backtest.NewUseCase(context.Background(), db, ranker, priceInfra.NewPriceRepository(db))
+
+	run, err := useCase.Execute(context.Background(), backtestDomain.Config{
+		Strategy:      *strategy,
+		Aggregation:   *aggregation,
+		Parameters:    params,
+		RatingScale:   scale,
+		From:          start,
+		To:            end,
+		RebalanceDays: *rebalance,
+		HorizonDays:   *horizon,
+		Picks:         *picks,
+	})
+	if err != nil {
+		if run != nil {
+			log.Printf("Backtest run %d failed", run.ID)
+		}
+		database.Close()
+		log.Fatalf("Backtest failed: %v", err)
+	}
+
+	printRun(run, *verbose)
+}
+
+func printRun(run *backtestDomain.Run, verbose bool) {
+	m := run.Metrics
+	fmt.Printf("Backtest run %d: %s from %s to %s\n", run.ID, run.Config.Strategy, run.Config.From.Format(time.DateOnly), run.Config.To.Format(time.DateOnly))
+	fmt.Printf("  rebalances     %d every %d days, %d picks, %d day horizon\n", m.Rebalances, run.Config.RebalanceDays, run.Config.Picks, run.Config.HorizonDays)
+	fmt.Printf("  evaluated      %d picks\n", m.Evaluated)
+	fmt.Printf("  hit rate       %.1f%%\n", m.HitRate*100)
+	fmt.Printf("  avg return     %.2f%%\n", m.AvgReturnPercent)
+	fmt.Printf("  total return   %.2f%%\n", m.TotalReturnPercent)
+	fmt.Printf("  max drawdown   %.2f%%\n", m.MaxDrawdownPercent)
+	fmt.Printf("  turnover       %.1f%%\n", m.Turnover*100)
+
+	if !verbose {
+		return
+	}
+	for _, period := range run.Periods {
+		ret, held := "n/a", "n/a"
+		if period.ReturnPercent != nil {
+			ret = fmt.Sprintf("%.2f%%", *period.ReturnPercent)
+		}
+		if period.HoldingReturnPercent != nil {
+			held = fmt.Sprintf("%.2f%%", *period.HoldingReturnPercent)
+		}
+		tickers := make([]string, 0, len(period.Picks))
+		for _, pick := range period.Picks {
+			tickers = append(tickers, pick.Ticker)
+		}
+		fmt.Printf("%s  return %-8s held %-8s turnover %3.0f%%  %s\n", period.Date.Format(time.DateOnly), ret, held, period.Turnover*100, strings.Join(tickers, " "))
+	}
+}
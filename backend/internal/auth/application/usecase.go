@@ -0,0 +1,142 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/auth/domain"
+	userApp "github.com/bryanriosb/stock-info/internal/user/application"
+	userDomain "github.com/bryanriosb/stock-info/internal/user/domain"
+	"github.com/bryanriosb/stock-info/shared"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenExpired = errors.New("refresh token expired or revoked")
+)
+
+// Tokens is the result of a login or refresh
+type Tokens struct {
+	AccessToken      string  `json:"access_token"`
+	RefreshToken     string  `json:"refresh_token"`
+	ExpiresIn        float64 `json:"expires_in"`
+	RefreshExpiresIn float64 `json:"refresh_expires_in"`
+}
+
+// AuthUseCase implements the token flow shared by the REST and gRPC APIs
+type AuthUseCase interface {
+	Login(ctx context.Context, username, password string) (*Tokens, error)
+	// Refresh rotates a refresh token: the old one is revoked and a new pair is issued
+	Refresh(ctx context.Context, refreshToken string) (*Tokens, error)
+	Logout(ctx context.Context, refreshToken string) error
+}
+
+type authUseCase struct {
+	repo        domain.RefreshTokenRepository
+	userUseCase userApp.UserUseCase
+	jwtConfig   shared.JWTConfig
+}
+
+func NewAuthUseCase(repo domain.RefreshTokenRepository, userUseCase userApp.UserUseCase, jwtConfig shared.JWTConfig) AuthUseCase {
+	return &authUseCase{
+		repo:        repo,
+		userUseCase: userUseCase,
+		jwtConfig:   jwtConfig,
+	}
+}
+
+func (uc *authUseCase) Login(ctx context.Context, username, password string) (*Tokens, error) {
+	user, err := uc.userUseCase.Authenticate(ctx, username, password)
+	if err != nil {
+		return nil, err
+	}
+	return uc.issueTokens(ctx, user)
+}
+
+func (uc *authUseCase) Refresh(ctx context.Context, refreshToken string) (*Tokens, error) {
+	stored, err := uc.repo.FindByToken(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	if !stored.IsValid() {
+		return nil, ErrRefreshTokenExpired
+	}
+
+	user, err := uc.userUseCase.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, userApp.ErrUserNotFound
+	}
+
+	if err := uc.repo.Revoke(ctx, refreshToken); err != nil {
+		return nil, err
+	}
+
+	return uc.issueTokens(ctx, user)
+}
+
+func (uc *authUseCase) Logout(ctx context.Context, refreshToken string) error {
+	if refreshToken == "" {
+		return nil
+	}
+	return uc.repo.Revoke(ctx, refreshToken)
+}
+
+func (uc *authUseCase) issueTokens(ctx context.Context, user *userDomain.User) (*Tokens, error) {
+	accessToken, err := uc.generateAccessToken(user.Username, user.Email, string(user.Role))
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := uc.createRefreshToken(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tokens{
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		ExpiresIn:        uc.jwtConfig.Expiration.Seconds(),
+		RefreshExpiresIn: uc.jwtConfig.RefreshExpiration.Seconds(),
+	}, nil
+}
+
+func (uc *authUseCase) generateAccessToken(username, email, role string) (string, error) {
+	claims := jwt.MapClaims{
+		"sub":   username,
+		"email": email,
+		"role":  role,
+		"exp":   time.Now().Add(uc.jwtConfig.Expiration).Unix(),
+		"iat":   time.Now().Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(uc.jwtConfig.Secret))
+}
+
+func (uc *authUseCase) createRefreshToken(ctx context.Context, userID int64) (string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+	tokenString := base64.URLEncoding.EncodeToString(tokenBytes)
+
+	refreshToken := domain.RefreshToken{
+		UserID:    userID,
+		Token:     tokenString,
+		ExpiresAt: time.Now().Add(uc.jwtConfig.RefreshExpiration),
+		Revoked:   false,
+	}
+
+	if err := uc.repo.Create(ctx, &refreshToken); err != nil {
+		return "", err
+	}
+
+	return tokenString, nil
+}
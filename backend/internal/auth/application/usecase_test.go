@@ -0,0 +1,195 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/auth/domain"
+	userApp "github.com/bryanriosb/stock-info/internal/user/application"
+	userDomain "github.com/bryanriosb/stock-info/internal/user/domain"
+	"github.com/bryanriosb/stock-info/shared"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock RefreshTokenRepository
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	return m.Called(ctx, token).Error(0)
+}
+
+func (m *MockRefreshTokenRepository) FindByToken(ctx context.Context, token string) (*domain.RefreshToken, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) Revoke(ctx context.Context, token string) error {
+	return m.Called(ctx, token).Error(0)
+}
+
+// Mock UserUseCase
+type MockUserUseCase struct {
+	mock.Mock
+}
+
+func (m *MockUserUseCase) Create(ctx context.Context, req userApp.CreateUserRequest) (*userDomain.User, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) GetByID(ctx context.Context, id int64) (*userDomain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) GetAll(ctx context.Context) ([]*userDomain.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*userDomain.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) GetPage(ctx context.Context, page, limit int) ([]*userDomain.User, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*userDomain.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) Update(ctx context.Context, id int64, req userApp.UpdateUserRequest) (*userDomain.User, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) Delete(ctx context.Context, id int64) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockUserUseCase) Authenticate(ctx context.Context, username, password string) (*userDomain.User, error) {
+	args := m.Called(ctx, username, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+var testJWT = shared.JWTConfig{Secret: "test-secret", Expiration: 15 * time.Minute, RefreshExpiration: 24 * time.Hour}
+
+func TestLogin_Success(t *testing.T) {
+	mockRepo := new(MockRefreshTokenRepository)
+	mockUsers := new(MockUserUseCase)
+
+	user := &userDomain.User{ID: 1, Username: "admin", Email: "admin@test.com", Role: userDomain.RoleAdmin}
+	mockUsers.On("Authenticate", mock.Anything, "admin", "secret").Return(user, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(token *domain.RefreshToken) bool {
+		return token.UserID == 1 && token.Token != "" && !token.Revoked
+	})).Return(nil)
+
+	uc := NewAuthUseCase(mockRepo, mockUsers, testJWT)
+	tokens, err := uc.Login(context.Background(), "admin", "secret")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.Equal(t, float64(900), tokens.ExpiresIn)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(tokens.AccessToken, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(testJWT.Secret), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", claims["sub"])
+	assert.Equal(t, "admin", claims["role"])
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLogin_InvalidCredentials(t *testing.T) {
+	mockRepo := new(MockRefreshTokenRepository)
+	mockUsers := new(MockUserUseCase)
+
+	mockUsers.On("Authenticate", mock.Anything, "admin", "wrong").Return(nil, userApp.ErrInvalidCredentials)
+
+	uc := NewAuthUseCase(mockRepo, mockUsers, testJWT)
+	_, err := uc.Login(context.Background(), "admin", "wrong")
+
+	assert.ErrorIs(t, err, userApp.ErrInvalidCredentials)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRefresh_RotatesToken(t *testing.T) {
+	mockRepo := new(MockRefreshTokenRepository)
+	mockUsers := new(MockUserUseCase)
+
+	stored := &domain.RefreshToken{UserID: 1, Token: "old", ExpiresAt: time.Now().Add(time.Hour)}
+	mockRepo.On("FindByToken", mock.Anything, "old").Return(stored, nil)
+	mockUsers.On("GetByID", mock.Anything, int64(1)).Return(&userDomain.User{ID: 1, Username: "john"}, nil)
+	mockRepo.On("Revoke", mock.Anything, "old").Return(nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	uc := NewAuthUseCase(mockRepo, mockUsers, testJWT)
+	tokens, err := uc.Refresh(context.Background(), "old")
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, "old", tokens.RefreshToken)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRefresh_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored *domain.RefreshToken
+		err    error
+		want   error
+	}{
+		{"unknown token", nil, nil, ErrInvalidRefreshToken},
+		{"revoked token", &domain.RefreshToken{Revoked: true, ExpiresAt: time.Now().Add(time.Hour)}, nil, ErrRefreshTokenExpired},
+		{"expired token", &domain.RefreshToken{ExpiresAt: time.Now().Add(-time.Hour)}, nil, ErrRefreshTokenExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRefreshTokenRepository)
+			if tt.stored == nil {
+				mockRepo.On("FindByToken", mock.Anything, "token").Return(nil, tt.err)
+			} else {
+				mockRepo.On("FindByToken", mock.Anything, "token").Return(tt.stored, tt.err)
+			}
+
+			uc := NewAuthUseCase(mockRepo, new(MockUserUseCase), testJWT)
+			_, err := uc.Refresh(context.Background(), "token")
+
+			assert.ErrorIs(t, err, tt.want)
+			mockRepo.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	mockRepo := new(MockRefreshTokenRepository)
+	mockRepo.On("Revoke", mock.Anything, "token").Return(errors.New("database error"))
+
+	uc := NewAuthUseCase(mockRepo, new(MockUserUseCase), testJWT)
+
+	assert.NoError(t, uc.Logout(context.Background(), ""))
+	assert.Error(t, uc.Logout(context.Background(), "token"))
+	mockRepo.AssertNumberOfCalls(t, "Revoke", 1)
+}
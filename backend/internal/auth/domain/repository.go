@@ -0,0 +1,10 @@
+package domain
+
+import "context"
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	// FindByToken returns nil when the token does not exist
+	FindByToken(ctx context.Context, token string) (*RefreshToken, error)
+	Revoke(ctx context.Context, token string) error
+}
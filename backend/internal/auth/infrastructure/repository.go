@@ -0,0 +1,37 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bryanriosb/stock-info/internal/auth/domain"
+	"gorm.io/gorm"
+)
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) domain.RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *refreshTokenRepository) FindByToken(ctx context.Context, token string) (*domain.RefreshToken, error) {
+	var stored domain.RefreshToken
+	err := r.db.WithContext(ctx).Where("token = ?", token).First(&stored).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+func (r *refreshTokenRepository) Revoke(ctx context.Context, token string) error {
+	return r.db.WithContext(ctx).Model(&domain.RefreshToken{}).Where("token = ?", token).Update("revoked", true).Error
+}
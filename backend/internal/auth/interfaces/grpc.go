@@ -0,0 +1,74 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bryanriosb/stock-info/internal/auth/application"
+	userApp "github.com/bryanriosb/stock-info/internal/user/application"
+	stockinfov1 "github.com/bryanriosb/stock-info/proto/stockinfo/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GRPCServer struct {
+	stockinfov1.UnimplementedAuthServiceServer
+	useCase application.AuthUseCase
+}
+
+func NewGRPCServer(useCase application.AuthUseCase) *GRPCServer {
+	return &GRPCServer{useCase: useCase}
+}
+
+func (s *GRPCServer) Login(ctx context.Context, req *stockinfov1.LoginRequest) (*stockinfov1.TokenResponse, error) {
+	if req.GetUsername() == "" || req.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Username and password are required")
+	}
+
+	tokens, err := s.useCase.Login(ctx, req.GetUsername(), req.GetPassword())
+	if err != nil {
+		if errors.Is(err, userApp.ErrInvalidCredentials) {
+			return nil, status.Error(codes.Unauthenticated, "Invalid credentials")
+		}
+		return nil, status.Error(codes.Internal, "Authentication failed")
+	}
+
+	return toTokenResponse(tokens), nil
+}
+
+func (s *GRPCServer) Refresh(ctx context.Context, req *stockinfov1.RefreshRequest) (*stockinfov1.TokenResponse, error) {
+	if req.GetRefreshToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Refresh token is required")
+	}
+
+	tokens, err := s.useCase.Refresh(ctx, req.GetRefreshToken())
+	if err != nil {
+		switch {
+		case errors.Is(err, application.ErrInvalidRefreshToken):
+			return nil, status.Error(codes.Unauthenticated, "Invalid refresh token")
+		case errors.Is(err, application.ErrRefreshTokenExpired):
+			return nil, status.Error(codes.Unauthenticated, "Refresh token expired or revoked")
+		case errors.Is(err, userApp.ErrUserNotFound):
+			return nil, status.Error(codes.Unauthenticated, "User not found")
+		}
+		return nil, status.Error(codes.Internal, "Failed to refresh token")
+	}
+
+	return toTokenResponse(tokens), nil
+}
+
+func (s *GRPCServer) Logout(ctx context.Context, req *stockinfov1.LogoutRequest) (*stockinfov1.LogoutResponse, error) {
+	if err := s.useCase.Logout(ctx, req.GetRefreshToken()); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to revoke refresh token")
+	}
+	return &stockinfov1.LogoutResponse{}, nil
+}
+
+func toTokenResponse(tokens *application.Tokens) *stockinfov1.TokenResponse {
+	return &stockinfov1.TokenResponse{
+		AccessToken:      tokens.AccessToken,
+		RefreshToken:     tokens.RefreshToken,
+		ExpiresIn:        tokens.ExpiresIn,
+		RefreshExpiresIn: tokens.RefreshExpiresIn,
+	}
+}
@@ -1,32 +1,21 @@
 package interfaces
 
 import (
-	"crypto/rand"
-	"encoding/base64"
 	"errors"
-	"time"
+	"log"
 
-	"github.com/bryanriosb/stock-info/internal/auth/domain"
-	"github.com/bryanriosb/stock-info/internal/user/application"
-	"github.com/bryanriosb/stock-info/shared"
+	"github.com/bryanriosb/stock-info/internal/auth/application"
+	userApp "github.com/bryanriosb/stock-info/internal/user/application"
 	"github.com/bryanriosb/stock-info/shared/response"
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v5"
-	"gorm.io/gorm"
 )
 
 type Handler struct {
-	db          *gorm.DB
-	jwtConfig   shared.JWTConfig
-	userUseCase application.UserUseCase
+	useCase application.AuthUseCase
 }
 
-func NewHandler(db *gorm.DB, jwtConfig shared.JWTConfig, userUseCase application.UserUseCase) *Handler {
-	return &Handler{
-		db:          db,
-		jwtConfig:   jwtConfig,
-		userUseCase: userUseCase,
-	}
+func NewHandler(useCase application.AuthUseCase) *Handler {
+	return &Handler{useCase: useCase}
 }
 
 type LoginRequest struct {
@@ -48,30 +37,15 @@ func (h *Handler) Login(c *fiber.Ctx) error {
 		return response.BadRequest(c, "Username and password are required")
 	}
 
-	user, err := h.userUseCase.Authenticate(c.Context(), req.Username, req.Password)
+	tokens, err := h.useCase.Login(c.Context(), req.Username, req.Password)
 	if err != nil {
-		if errors.Is(err, application.ErrInvalidCredentials) {
+		if errors.Is(err, userApp.ErrInvalidCredentials) {
 			return response.Unauthorized(c, "Invalid credentials")
 		}
 		return response.InternalError(c, "Authentication failed")
 	}
 
-	accessToken, err := h.generateAccessToken(user.Username, user.Email, string(user.Role))
-	if err != nil {
-		return response.InternalError(c, "Failed to generate access token")
-	}
-
-	refreshToken, err := h.createRefreshToken(user.ID)
-	if err != nil {
-		return response.InternalError(c, "Failed to generate refresh token")
-	}
-
-	return response.Success(c, fiber.Map{
-		"access_token":       accessToken,
-		"refresh_token":      refreshToken,
-		"expires_in":         h.jwtConfig.Expiration.Seconds(),
-		"refresh_expires_in": h.jwtConfig.RefreshExpiration.Seconds(),
-	})
+	return response.Success(c, tokens)
 }
 
 func (h *Handler) Refresh(c *fiber.Ctx) error {
@@ -84,42 +58,20 @@ func (h *Handler) Refresh(c *fiber.Ctx) error {
 		return response.BadRequest(c, "Refresh token is required")
 	}
 
-	var storedToken domain.RefreshToken
-	if err := h.db.Where("token = ?", req.RefreshToken).First(&storedToken).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	tokens, err := h.useCase.Refresh(c.Context(), req.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, application.ErrInvalidRefreshToken):
 			return response.Unauthorized(c, "Invalid refresh token")
+		case errors.Is(err, application.ErrRefreshTokenExpired):
+			return response.Unauthorized(c, "Refresh token expired or revoked")
+		case errors.Is(err, userApp.ErrUserNotFound):
+			return response.Unauthorized(c, "User not found")
 		}
-		return response.InternalError(c, "Failed to validate refresh token")
-	}
-
-	if !storedToken.IsValid() {
-		return response.Unauthorized(c, "Refresh token expired or revoked")
-	}
-
-	user, err := h.userUseCase.GetByID(c.Context(), storedToken.UserID)
-	if err != nil {
-		return response.Unauthorized(c, "User not found")
-	}
-
-	// Revoke old refresh token
-	h.db.Model(&storedToken).Update("revoked", true)
-
-	accessToken, err := h.generateAccessToken(user.Username, user.Email, string(user.Role))
-	if err != nil {
-		return response.InternalError(c, "Failed to generate access token")
+		return response.InternalError(c, "Failed to refresh token")
 	}
 
-	newRefreshToken, err := h.createRefreshToken(user.ID)
-	if err != nil {
-		return response.InternalError(c, "Failed to generate refresh token")
-	}
-
-	return response.Success(c, fiber.Map{
-		"access_token":       accessToken,
-		"refresh_token":      newRefreshToken,
-		"expires_in":         h.jwtConfig.Expiration.Seconds(),
-		"refresh_expires_in": h.jwtConfig.RefreshExpiration.Seconds(),
-	})
+	return response.Success(c, tokens)
 }
 
 func (h *Handler) Logout(c *fiber.Ctx) error {
@@ -128,45 +80,12 @@ func (h *Handler) Logout(c *fiber.Ctx) error {
 		return response.BadRequest(c, "Invalid request body")
 	}
 
-	if req.RefreshToken != "" {
-		h.db.Model(&domain.RefreshToken{}).Where("token = ?", req.RefreshToken).Update("revoked", true)
+	// Logging out always succeeds for the client; a failed revoke only leaves the token to expire
+	if err := h.useCase.Logout(c.Context(), req.RefreshToken); err != nil {
+		log.Printf("Warning: Failed to revoke refresh token: %v", err)
 	}
 
 	return response.Success(c, fiber.Map{
 		"message": "Logged out successfully",
 	})
 }
-
-func (h *Handler) generateAccessToken(username, email, role string) (string, error) {
-	claims := jwt.MapClaims{
-		"sub":   username,
-		"email": email,
-		"role":  role,
-		"exp":   time.Now().Add(h.jwtConfig.Expiration).Unix(),
-		"iat":   time.Now().Unix(),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(h.jwtConfig.Secret))
-}
-
-func (h *Handler) createRefreshToken(userID int64) (string, error) {
-	tokenBytes := make([]byte, 32)
-	if _, err := rand.Read(tokenBytes); err != nil {
-		return "", err
-	}
-	tokenString := base64.URLEncoding.EncodeToString(tokenBytes)
-
-	refreshToken := domain.RefreshToken{
-		UserID:    userID,
-		Token:     tokenString,
-		ExpiresAt: time.Now().Add(h.jwtConfig.RefreshExpiration),
-		Revoked:   false,
-	}
-
-	if err := h.db.Create(&refreshToken).Error; err != nil {
-		return "", err
-	}
-
-	return tokenString, nil
-}
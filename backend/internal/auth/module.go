@@ -1,18 +1,30 @@
 package auth
 
 import (
+	authApp "github.com/bryanriosb/stock-info/internal/auth/application"
+	"github.com/bryanriosb/stock-info/internal/auth/infrastructure"
 	"github.com/bryanriosb/stock-info/internal/auth/interfaces"
 	"github.com/bryanriosb/stock-info/internal/user/application"
+	stockinfov1 "github.com/bryanriosb/stock-info/proto/stockinfo/v1"
 	"github.com/bryanriosb/stock-info/shared"
 	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
-func Register(api fiber.Router, db *gorm.DB, cfg *shared.Config, userUseCase application.UserUseCase) {
-	handler := interfaces.NewHandler(db, cfg.JWT, userUseCase)
+func Register(api fiber.Router, db *gorm.DB, cfg *shared.Config, userUseCase application.UserUseCase) authApp.AuthUseCase {
+	repo := infrastructure.NewRefreshTokenRepository(db)
+	useCase := authApp.NewAuthUseCase(repo, userUseCase, cfg.JWT)
+	handler := interfaces.NewHandler(useCase)
 
 	group := api.Group("/auth")
 	group.Post("/login", handler.Login)
 	group.Post("/refresh", handler.Refresh)
 	group.Post("/logout", handler.Logout)
+
+	return useCase
+}
+
+func RegisterGRPC(server grpc.ServiceRegistrar, useCase authApp.AuthUseCase) {
+	stockinfov1.RegisterAuthServiceServer(server, interfaces.NewGRPCServer(useCase))
 }
@@ -0,0 +1,252 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/backtest/domain"
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
+	recommendationDomain "github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+)
+
+const (
+	// scanBatchSize is the number of actions read per query while streaming the history
+	scanBatchSize = 500
+	// rankBatchSize is the number of tickers held in memory and ranked together at every rebalance
+	rankBatchSize = 500
+	// priceTolerance is how far back a close may stand in for a day without
+	// one, covering weekends and holidays
+	priceTolerance = 7 * 24 * time.Hour
+)
+
+// engine replays the recorded analyst actions: at every rebalance date it ranks
+// the actions known by then, as recommendations would have been ranked that
+// day, and measures the returns of the top picks over the horizon and until
+// the next rebalance
+type engine struct {
+	history stockDomain.ActionHistoryRepository
+	ranker  domain.Ranker
+	prices  priceDomain.HistoryProvider
+}
+
+func (e *engine) run(ctx context.Context, cfg domain.Config) (domain.Metrics, []domain.Period, error) {
+	dates := cfg.RebalanceDates()
+	picks, err := e.pick(ctx, cfg, dates)
+	if err != nil {
+		return domain.Metrics{}, nil, err
+	}
+
+	horizon := time.Duration(cfg.HorizonDays) * 24 * time.Hour
+	interval := time.Duration(cfg.RebalanceDays) * 24 * time.Hour
+	histories := make(map[string][]priceDomain.PricePoint)
+
+	periods := make([]domain.Period, 0, len(dates))
+	var held map[string]bool
+	for i, date := range dates {
+		period := domain.Period{Date: date, Picks: make([]domain.Pick, 0, len(picks[i]))}
+		picked := make(map[string]bool, len(picks[i]))
+		var total, holding float64
+		var evaluated, holdingEvaluated int
+		for _, recommendation := range picks[i] {
+			pick := domain.Pick{Ticker: recommendation.Ticker, Score: recommendation.Score}
+			history, ok := histories[pick.Ticker]
+			if !ok {
+				if history, err = e.prices.AdjustedHistory(ctx, pick.Ticker, cfg.From.Add(-priceTolerance), cfg.To.Add(max(horizon, interval))); err != nil {
+					return domain.Metrics{}, nil, err
+				}
+				histories[pick.Ticker] = history
+			}
+			if ret, ok := forwardReturn(history, date, horizon); ok {
+				pick.ReturnPercent = &ret
+				total += ret
+				evaluated++
+			}
+			if ret, ok := forwardReturn(history, date, interval); ok {
+				holding += ret
+				holdingEvaluated++
+			}
+			period.Picks = append(period.Picks, pick)
+			picked[pick.Ticker] = true
+		}
+
+		if evaluated > 0 {
+			mean := total / float64(evaluated)
+			period.ReturnPercent = &mean
+		}
+		if holdingEvaluated > 0 {
+			mean := holding / float64(holdingEvaluated)
+			period.HoldingReturnPercent = &mean
+		}
+		if held != nil && len(picked) > 0 {
+			var entered int
+			for ticker := range picked {
+				if !held[ticker] {
+					entered++
+				}
+			}
+			period.Turnover = float64(entered) / float64(len(picked))
+		}
+		held = picked
+		periods = append(periods, period)
+	}
+
+	return summarize(periods), periods, nil
+}
+
+// pick streams the action history one ticker at a time and returns the top
+// picks at every rebalance date. Tickers are ranked rankBatchSize at a time,
+// and each batch's best are merged into the picks kept so far, so memory holds
+// one batch of tickers rather than the whole history.
+func (e *engine) pick(ctx context.Context, cfg domain.Config, dates []time.Time) ([][]*recommendationDomain.StockRecommendation, error) {
+	query := recommendationDomain.RecommendationQuery{
+		Limit:       cfg.Picks,
+		Strategy:    cfg.Strategy,
+		Aggregation: cfg.Aggregation,
+		Tuning:      recommendationDomain.Tuning{Parameters: cfg.Parameters, RatingScale: cfg.RatingScale},
+	}
+	picks := make([][]*recommendationDomain.StockRecommendation, len(dates))
+
+	var batch [][]*stockDomain.Stock
+	rank := func() error {
+		for i, date := range dates {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			recommendations, err := e.ranker.RankActions(ctx, query, known(batch, date), date)
+			if err != nil {
+				return err
+			}
+			picks[i] = best(append(picks[i], recommendations...), cfg.Picks)
+		}
+		batch = nil
+		return nil
+	}
+
+	err := e.history.ScanTickers(ctx, scanBatchSize, func(actions []*stockDomain.Stock) error {
+		batch = append(batch, actions)
+		if len(batch) < rankBatchSize {
+			return nil
+		}
+		return rank()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := rank(); err != nil {
+		return nil, err
+	}
+	return picks, nil
+}
+
+// best keeps the n best recommendations, ordered as the ranker orders them:
+// by score, ties broken by the lower stock id
+func best(recommendations []*recommendationDomain.StockRecommendation, n int) []*recommendationDomain.StockRecommendation {
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Stock.ID < b.Stock.ID
+	})
+	if len(recommendations) > n {
+		recommendations = recommendations[:n]
+	}
+	return recommendations
+}
+
+// known keeps each brokerage's latest action made on or before date, as the
+// stocks table held it that day. The history lists each brokerage's actions
+// oldest first.
+func known(tickers [][]*stockDomain.Stock, date time.Time) [][]*stockDomain.Stock {
+	result := make([][]*stockDomain.Stock, 0, len(tickers))
+	for _, actions := range tickers {
+		var kept []*stockDomain.Stock
+		for _, stock := range actions {
+			if stock.Time.IsZero() || stock.Time.After(date) {
+				continue
+			}
+			if n := len(kept); n > 0 && kept[n-1].Brokerage == stock.Brokerage {
+				kept[n-1] = stock
+				continue
+			}
+			kept = append(kept, stock)
+		}
+		if len(kept) > 0 {
+			result = append(result, kept)
+		}
+	}
+	return result
+}
+
+// forwardReturn is the change in percent from the close at date to the close a
+// horizon later; the closes are split-adjusted, so a split is no loss
+func forwardReturn(history []priceDomain.PricePoint, date time.Time, horizon time.Duration) (float64, bool) {
+	start, ok := closeAt(history, date)
+	if !ok || start <= 0 {
+		return 0, false
+	}
+	end, ok := closeAt(history, date.Add(horizon))
+	if !ok {
+		return 0, false
+	}
+	return (end/start - 1) * 100, true
+}
+
+// closeAt returns the last close on or before at, within priceTolerance
+func closeAt(history []priceDomain.PricePoint, at time.Time) (float64, bool) {
+	i := sort.Search(len(history), func(i int) bool { return history[i].Date.After(at) })
+	if i == 0 {
+		return 0, false
+	}
+	point := history[i-1]
+	if at.Sub(point.Date) > priceTolerance {
+		return 0, false
+	}
+	return point.Close, true
+}
+
+// summarize computes the run metrics. Hit rate and average return are over the
+// horizon; turnover averages the rebalances after the first. The equity curve
+// compounds the holding returns, from each rebalance to the next, so
+// overlapping horizons are not counted twice, and its deepest fall from a
+// peak is the drawdown.
+func summarize(periods []domain.Period) domain.Metrics {
+	metrics := domain.Metrics{Rebalances: len(periods)}
+
+	var hits, turnovers int
+	var returns, turnover float64
+	equity, peak := 1.0, 1.0
+	for i, period := range periods {
+		for _, pick := range period.Picks {
+			if pick.ReturnPercent == nil {
+				continue
+			}
+			metrics.Evaluated++
+			returns += *pick.ReturnPercent
+			if *pick.ReturnPercent > 0 {
+				hits++
+			}
+		}
+		if i > 0 {
+			turnover += period.Turnover
+			turnovers++
+		}
+		if period.HoldingReturnPercent != nil {
+			equity *= 1 + *period.HoldingReturnPercent/100
+			peak = max(peak, equity)
+			metrics.MaxDrawdownPercent = max(metrics.MaxDrawdownPercent, (1-equity/peak)*100)
+		}
+	}
+
+	if metrics.Evaluated > 0 {
+		metrics.HitRate = float64(hits) / float64(metrics.Evaluated)
+		metrics.AvgReturnPercent = returns / float64(metrics.Evaluated)
+	}
+	if turnovers > 0 {
+		metrics.Turnover = turnover / float64(turnovers)
+	}
+	metrics.TotalReturnPercent = (equity - 1) * 100
+	return metrics
+}
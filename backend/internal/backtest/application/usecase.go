@@ -0,0 +1,135 @@
+package application
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/backtest/domain"
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
+	recommendationDomain "github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+)
+
+// recordTimeout bounds recording a failed run once its context is done
+const recordTimeout = 5 * time.Second
+
+type BacktestUseCase interface {
+	// Start records a pending run and executes it in the background
+	Start(ctx context.Context, cfg domain.Config) (*domain.Run, error)
+	// Execute records a run and executes it before returning
+	Execute(ctx context.Context, cfg domain.Config) (*domain.Run, error)
+	// GetRun returns a run with its periods, or nil when it does not exist
+	GetRun(ctx context.Context, id uint) (*domain.Run, error)
+	// ListRuns returns the runs newest first, optionally of one strategy only
+	ListRuns(ctx context.Context, strategy string) ([]*domain.Run, error)
+}
+
+type backtestUseCase struct {
+	repo   domain.RunRepository
+	engine *engine
+	now    func() time.Time
+	// background bounds the runs started in the background
+	background context.Context
+
+	// mu runs one backtest at a time; started runs wait their turn as pending
+	mu sync.Mutex
+}
+
+// NewBacktestUseCase replays the action history through ranker. Runs started
+// in the background stop once ctx is done.
+func NewBacktestUseCase(ctx context.Context, repo domain.RunRepository, history stockDomain.ActionHistoryRepository, ranker domain.Ranker, prices priceDomain.HistoryProvider) BacktestUseCase {
+	return &backtestUseCase{
+		repo:       repo,
+		engine:     &engine{history: history, ranker: ranker, prices: prices},
+		now:        time.Now,
+		background: ctx,
+	}
+}
+
+func (uc *backtestUseCase) Start(ctx context.Context, cfg domain.Config) (*domain.Run, error) {
+	run, err := uc.create(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// The run outlives the request that started it, but not the application
+	pending := *run
+	go func() {
+		if err := uc.execute(uc.background, &pending); err != nil {
+			log.Printf("Backtest run %d failed: %v", pending.ID, err)
+		}
+	}()
+	return run, nil
+}
+
+func (uc *backtestUseCase) Execute(ctx context.Context, cfg domain.Config) (*domain.Run, error) {
+	run, err := uc.create(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.execute(ctx, run); err != nil {
+		return run, err
+	}
+	return run, nil
+}
+
+func (uc *backtestUseCase) GetRun(ctx context.Context, id uint) (*domain.Run, error) {
+	return uc.repo.FindByID(ctx, id)
+}
+
+func (uc *backtestUseCase) ListRuns(ctx context.Context, strategy string) ([]*domain.Run, error) {
+	return uc.repo.FindAll(ctx, strategy)
+}
+
+// create validates the configuration and records it as a pending run. Ranking
+// no tickers rejects an unknown strategy, aggregation or parameter up front.
+func (uc *backtestUseCase) create(ctx context.Context, cfg domain.Config) (*domain.Run, error) {
+	cfg = cfg.Normalized()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	query := recommendationDomain.RecommendationQuery{
+		Strategy:    cfg.Strategy,
+		Aggregation: cfg.Aggregation,
+		Tuning:      recommendationDomain.Tuning{Parameters: cfg.Parameters, RatingScale: cfg.RatingScale},
+	}
+	if _, err := uc.engine.ranker.RankActions(ctx, query, nil, cfg.From); err != nil {
+		return nil, err
+	}
+
+	run := &domain.Run{Status: domain.RunPending, Strategy: cfg.Strategy, Config: cfg, CreatedAt: uc.now()}
+	if err := uc.repo.Create(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// execute replays a recorded run and stores its outcome, failed runs included
+func (uc *backtestUseCase) execute(ctx context.Context, run *domain.Run) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	run.Status = domain.RunRunning
+	if err := uc.repo.Update(ctx, run); err != nil {
+		return err
+	}
+
+	metrics, periods, err := uc.engine.run(ctx, run.Config)
+	completed := uc.now()
+	run.CompletedAt = &completed
+	if err != nil {
+		run.Status, run.Error = domain.RunFailed, err.Error()
+		// A run cancelled at shutdown is still recorded as failed, within a deadline
+		recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+		defer cancel()
+		if updateErr := uc.repo.Update(recordCtx, run); updateErr != nil {
+			log.Printf("Failed to record backtest run %d failure: %v", run.ID, updateErr)
+		}
+		return err
+	}
+
+	run.Status, run.Metrics, run.Periods = domain.RunCompleted, metrics, periods
+	return uc.repo.Update(ctx, run)
+}
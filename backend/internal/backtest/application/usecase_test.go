@@ -0,0 +1,337 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/backtest/domain"
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
+	recommendationApp "github.com/bryanriosb/stock-info/internal/recommendation/application"
+	recommendationDomain "github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock RunRepository
+type MockRunRepository struct {
+	mock.Mock
+	statuses []domain.RunStatus
+}
+
+func (m *MockRunRepository) Create(ctx context.Context, run *domain.Run) error {
+	args := m.Called(ctx, run)
+	run.ID = 1
+	return args.Error(0)
+}
+
+func (m *MockRunRepository) Update(ctx context.Context, run *domain.Run) error {
+	m.statuses = append(m.statuses, run.Status)
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+func (m *MockRunRepository) FindByID(ctx context.Context, id uint) (*domain.Run, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Run), args.Error(1)
+}
+
+func (m *MockRunRepository) FindAll(ctx context.Context, strategy string) ([]*domain.Run, error) {
+	args := m.Called(ctx, strategy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Run), args.Error(1)
+}
+
+// Mock ActionHistoryRepository
+type MockActionHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockActionHistoryRepository) ScanTickers(ctx context.Context, batchSize int, fn func(actions []*stockDomain.Stock) error) error {
+	args := m.Called(ctx, batchSize, fn)
+	return args.Error(0)
+}
+
+func (m *MockActionHistoryRepository) FindByTicker(ctx context.Context, ticker string, rng stockDomain.TimeRange) ([]*stockDomain.Stock, error) {
+	args := m.Called(ctx, ticker, rng)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*stockDomain.Stock), args.Error(1)
+}
+
+// Mock HistoryProvider
+type MockHistoryProvider struct {
+	mock.Mock
+}
+
+func (m *MockHistoryProvider) History(ctx context.Context, ticker string, from, to time.Time) ([]priceDomain.PricePoint, error) {
+	args := m.Called(ctx, ticker, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]priceDomain.PricePoint), args.Error(1)
+}
+
+func (m *MockHistoryProvider) AdjustedHistory(ctx context.Context, ticker string, from, to time.Time) ([]priceDomain.PricePoint, error) {
+	args := m.Called(ctx, ticker, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]priceDomain.PricePoint), args.Error(1)
+}
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// newTestUseCase ranks with the real recommendation engine, undecayed and unweighted
+func newTestUseCase(repo *MockRunRepository, history *MockActionHistoryRepository, prices *MockHistoryProvider) BacktestUseCase {
+	ranker := recommendationApp.NewRecommendationUseCase(nil, recommendationApp.NewDefaultStrategyRegistry(), recommendationDomain.Decay{}, recommendationDomain.DefaultAggregation, nil, nil)
+	return NewBacktestUseCase(context.Background(), repo, history, ranker, prices)
+}
+
+func expectScan(history *MockActionHistoryRepository, tickers ...[]*stockDomain.Stock) {
+	history.On("ScanTickers", mock.Anything, scanBatchSize, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func([]*stockDomain.Stock) error)
+		for _, actions := range tickers {
+			if err := fn(actions); err != nil {
+				return
+			}
+		}
+	}).Return(nil)
+}
+
+func TestExecute_ReplaysRebalances(t *testing.T) {
+	repo := new(MockRunRepository)
+	history := new(MockActionHistoryRepository)
+	prices := new(MockHistoryProvider)
+
+	// AAA is the only ticker known on Jan 1; BBB, raised further on Jan 5, leads from Jan 8
+	expectScan(history,
+		[]*stockDomain.Stock{{Ticker: "AAA", Brokerage: "X", TargetFrom: 100, TargetTo: 150, Time: time.Date(2023, 12, 20, 0, 0, 0, 0, time.UTC)}},
+		[]*stockDomain.Stock{{Ticker: "BBB", Brokerage: "Y", TargetFrom: 100, TargetTo: 180, Time: day(1, 5)}},
+	)
+	prices.On("AdjustedHistory", mock.Anything, "AAA", mock.Anything, mock.Anything).Return([]priceDomain.PricePoint{
+		{Ticker: "AAA", Date: day(1, 1), Close: 100},
+		{Ticker: "AAA", Date: day(1, 8), Close: 110},
+	}, nil).Once()
+	prices.On("AdjustedHistory", mock.Anything, "BBB", mock.Anything, mock.Anything).Return([]priceDomain.PricePoint{
+		{Ticker: "BBB", Date: day(1, 8), Close: 50},
+		{Ticker: "BBB", Date: day(1, 15), Close: 45},
+		{Ticker: "BBB", Date: day(1, 22), Close: 54},
+	}, nil).Once()
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	uc := newTestUseCase(repo, history, prices)
+	run, err := uc.Execute(context.Background(), domain.Config{Strategy: recommendationApp.StrategyTargetUpside, From: day(1, 1), To: day(1, 15), Picks: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.RunCompleted, run.Status)
+	assert.Equal(t, []domain.RunStatus{domain.RunRunning, domain.RunCompleted}, repo.statuses)
+	assert.NotNil(t, run.CompletedAt)
+
+	assert.Len(t, run.Periods, 3)
+	var picked []string
+	for _, period := range run.Periods {
+		picked = append(picked, period.Picks[0].Ticker)
+	}
+	assert.Equal(t, []string{"AAA", "BBB", "BBB"}, picked)
+	assert.InDelta(t, 10, *run.Periods[0].ReturnPercent, 1e-9)
+	assert.InDelta(t, -10, *run.Periods[1].ReturnPercent, 1e-9)
+	assert.InDelta(t, 20, *run.Periods[2].ReturnPercent, 1e-9)
+
+	m := run.Metrics
+	assert.Equal(t, 3, m.Rebalances)
+	assert.Equal(t, 3, m.Evaluated)
+	assert.InDelta(t, 2.0/3, m.HitRate, 1e-9)
+	assert.InDelta(t, 20.0/3, m.AvgReturnPercent, 1e-9)
+	assert.InDelta(t, 18.8, m.TotalReturnPercent, 1e-9)
+	assert.InDelta(t, 10, m.MaxDrawdownPercent, 1e-9)
+	assert.InDelta(t, 0.5, m.Turnover, 1e-9)
+	prices.AssertExpectations(t)
+}
+
+func TestExecute_ReplaysEachBrokeragesCallAsOfTheDate(t *testing.T) {
+	repo := new(MockRunRepository)
+	history := new(MockActionHistoryRepository)
+	prices := new(MockHistoryProvider)
+
+	// X raised AAA on Jan 1 and cut it on Jan 10, after which BBB leads. The
+	// stocks table only keeps the cut, which would hide AAA's lead on Jan 8.
+	expectScan(history,
+		[]*stockDomain.Stock{
+			{ID: 1, Ticker: "AAA", Brokerage: "X", TargetFrom: 100, TargetTo: 200, Time: day(1, 1)},
+			{ID: 3, Ticker: "AAA", Brokerage: "X", TargetFrom: 200, TargetTo: 100, Time: day(1, 10)},
+		},
+		[]*stockDomain.Stock{{ID: 2, Ticker: "BBB", Brokerage: "Y", TargetFrom: 100, TargetTo: 120, Time: day(1, 1)}},
+	)
+	prices.On("AdjustedHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]priceDomain.PricePoint{}, nil)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	uc := newTestUseCase(repo, history, prices)
+	run, err := uc.Execute(context.Background(), domain.Config{Strategy: recommendationApp.StrategyTargetUpside, From: day(1, 8), To: day(1, 15), Picks: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "AAA", run.Periods[0].Picks[0].Ticker)
+	assert.Equal(t, "BBB", run.Periods[1].Picks[0].Ticker)
+}
+
+func TestExecute_RanksTickersInBatches(t *testing.T) {
+	repo := new(MockRunRepository)
+	history := new(MockActionHistoryRepository)
+	prices := new(MockHistoryProvider)
+
+	// The best ticker comes after the first batch
+	tickers := make([][]*stockDomain.Stock, 0, rankBatchSize+1)
+	for i := 0; i < rankBatchSize+1; i++ {
+		tickers = append(tickers, []*stockDomain.Stock{{ID: int64(i + 1), Ticker: fmt.Sprintf("T%03d", i), Brokerage: "X", TargetFrom: 100, TargetTo: 100 + float64(i+1)/100, Time: day(1, 1)}})
+	}
+	expectScan(history, tickers...)
+	prices.On("AdjustedHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]priceDomain.PricePoint{}, nil)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	uc := newTestUseCase(repo, history, prices)
+	run, err := uc.Execute(context.Background(), domain.Config{Strategy: recommendationApp.StrategyTargetUpside, From: day(1, 1), To: day(1, 1), Picks: 2})
+
+	assert.NoError(t, err)
+	picks := run.Periods[0].Picks
+	assert.Equal(t, []string{fmt.Sprintf("T%03d", rankBatchSize), fmt.Sprintf("T%03d", rankBatchSize-1)}, []string{picks[0].Ticker, picks[1].Ticker})
+}
+
+func TestExecute_HorizonLongerThanRebalance(t *testing.T) {
+	repo := new(MockRunRepository)
+	history := new(MockActionHistoryRepository)
+	prices := new(MockHistoryProvider)
+
+	expectScan(history, []*stockDomain.Stock{{Ticker: "AAA", Brokerage: "X", TargetFrom: 100, TargetTo: 150, Time: day(1, 1)}})
+	prices.On("AdjustedHistory", mock.Anything, "AAA", day(1, 1).Add(-priceTolerance), day(1, 8).AddDate(0, 0, 14)).Return([]priceDomain.PricePoint{
+		{Ticker: "AAA", Date: day(1, 1), Close: 100},
+		{Ticker: "AAA", Date: day(1, 8), Close: 110},
+		{Ticker: "AAA", Date: day(1, 15), Close: 121},
+		{Ticker: "AAA", Date: day(1, 22), Close: 121},
+	}, nil)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	uc := newTestUseCase(repo, history, prices)
+	run, err := uc.Execute(context.Background(), domain.Config{From: day(1, 1), To: day(1, 8), RebalanceDays: 7, HorizonDays: 14, Picks: 1})
+
+	assert.NoError(t, err)
+	// Two week returns overlap, so the equity curve compounds the weekly ones
+	assert.InDelta(t, 21, *run.Periods[0].ReturnPercent, 1e-9)
+	assert.InDelta(t, 10, *run.Periods[0].HoldingReturnPercent, 1e-9)
+	assert.InDelta(t, 10, *run.Periods[1].ReturnPercent, 1e-9)
+	assert.InDelta(t, 10, *run.Periods[1].HoldingReturnPercent, 1e-9)
+	assert.InDelta(t, 15.5, run.Metrics.AvgReturnPercent, 1e-9)
+	assert.InDelta(t, 21, run.Metrics.TotalReturnPercent, 1e-9)
+	prices.AssertExpectations(t)
+}
+
+func TestExecute_UnevaluatedPicks(t *testing.T) {
+	repo := new(MockRunRepository)
+	history := new(MockActionHistoryRepository)
+	prices := new(MockHistoryProvider)
+
+	expectScan(history, []*stockDomain.Stock{{Ticker: "AAA", Brokerage: "X", TargetFrom: 100, TargetTo: 150, Time: day(1, 1)}})
+	prices.On("AdjustedHistory", mock.Anything, "AAA", mock.Anything, mock.Anything).Return([]priceDomain.PricePoint{}, nil)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	uc := newTestUseCase(repo, history, prices)
+	run, err := uc.Execute(context.Background(), domain.Config{From: day(1, 1), To: day(1, 1)})
+
+	assert.NoError(t, err)
+	assert.Len(t, run.Periods, 1)
+	assert.Nil(t, run.Periods[0].ReturnPercent)
+	assert.Nil(t, run.Periods[0].Picks[0].ReturnPercent)
+	assert.Equal(t, 0, run.Metrics.Evaluated)
+	assert.Equal(t, 0.0, run.Metrics.TotalReturnPercent)
+}
+
+func TestExecute_RejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  domain.Config
+		err  error
+	}{
+		{"missing dates", domain.Config{}, domain.ErrInvalidConfig},
+		{"reversed dates", domain.Config{From: day(2, 1), To: day(1, 1)}, domain.ErrInvalidConfig},
+		{"too many picks", domain.Config{From: day(1, 1), To: day(2, 1), Picks: domain.MaxPicks + 1}, domain.ErrInvalidConfig},
+		{"too many rebalances", domain.Config{From: day(1, 1), To: day(1, 1).AddDate(3, 0, 0), RebalanceDays: 1}, domain.ErrInvalidConfig},
+		{"unknown strategy", domain.Config{Strategy: "astrology", From: day(1, 1), To: day(2, 1)}, recommendationDomain.ErrUnknownStrategy},
+		{"unknown parameter", domain.Config{Strategy: recommendationApp.StrategyMomentum, Parameters: map[string]float64{"luck": 1}, From: day(1, 1), To: day(2, 1)}, recommendationDomain.ErrInvalidParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockRunRepository)
+			uc := newTestUseCase(repo, new(MockActionHistoryRepository), new(MockHistoryProvider))
+
+			run, err := uc.Execute(context.Background(), tt.cfg)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Nil(t, run)
+			repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestExecute_RecordsFailure(t *testing.T) {
+	repo := new(MockRunRepository)
+	history := new(MockActionHistoryRepository)
+
+	history.On("ScanTickers", mock.Anything, scanBatchSize, mock.Anything).Return(errors.New("database error"))
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	uc := newTestUseCase(repo, history, new(MockHistoryProvider))
+	run, err := uc.Execute(context.Background(), domain.Config{From: day(1, 1), To: day(2, 1)})
+
+	assert.Error(t, err)
+	assert.Equal(t, domain.RunFailed, run.Status)
+	assert.Equal(t, "database error", run.Error)
+	assert.Equal(t, []domain.RunStatus{domain.RunRunning, domain.RunFailed}, repo.statuses)
+}
+
+func TestExecute_RecordsCancelledRun(t *testing.T) {
+	repo := new(MockRunRepository)
+	history := new(MockActionHistoryRepository)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Shutdown cancels the run halfway
+	history.On("ScanTickers", mock.Anything, scanBatchSize, mock.Anything).Run(func(mock.Arguments) { cancel() }).Return(context.Canceled)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	var recordErr error
+	repo.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		recordErr = args.Get(0).(context.Context).Err()
+	}).Return(nil)
+
+	uc := newTestUseCase(repo, history, new(MockHistoryProvider))
+	run, err := uc.Execute(ctx, domain.Config{From: day(1, 1), To: day(2, 1)})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []domain.RunStatus{domain.RunRunning, domain.RunFailed}, repo.statuses)
+	assert.Equal(t, domain.RunFailed, run.Status)
+	assert.NoError(t, recordErr)
+}
+
+func TestConfig_Normalized(t *testing.T) {
+	cfg := domain.Config{RebalanceDays: 30}.Normalized()
+
+	assert.Equal(t, 30, cfg.RebalanceDays)
+	assert.Equal(t, 30, cfg.HorizonDays)
+	assert.Equal(t, 10, cfg.Picks)
+	assert.Equal(t, 7, domain.Config{}.Normalized().RebalanceDays)
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// MaxPicks bounds the recommendations held at each rebalance, as a page of recommendations does
+	MaxPicks = 50
+	// MaxRebalances bounds the rebalance dates a run replays
+	MaxRebalances = 1000
+	// maxDays bounds the rebalance interval and the return horizon
+	maxDays = 365
+)
+
+var ErrInvalidConfig = errors.New("invalid backtest")
+
+// Config selects the strategy a backtest replays and how its picks are evaluated.
+// The strategy, aggregation, parameters and rating scale are those of a
+// recommendation query.
+type Config struct {
+	Strategy    string             `json:"strategy"`
+	Aggregation string             `json:"aggregation,omitempty"`
+	Parameters  map[string]float64 `json:"parameters,omitempty"`
+	RatingScale map[string]int     `json:"rating_scale,omitempty"`
+	From        time.Time          `json:"from"`
+	To          time.Time          `json:"to"`
+	// RebalanceDays is the interval between rebalance dates
+	RebalanceDays int `json:"rebalance_days"`
+	// HorizonDays is how long after a rebalance the picks' returns are measured;
+	// it may exceed RebalanceDays, as the equity curve compounds the returns
+	// held until the next rebalance instead
+	HorizonDays int `json:"horizon_days"`
+	// Picks is the number of top recommendations held at each rebalance
+	Picks int `json:"picks"`
+}
+
+// Normalized defaults to weekly rebalancing, a horizon equal to the rebalance
+// interval and 10 picks
+func (c Config) Normalized() Config {
+	if c.RebalanceDays == 0 {
+		c.RebalanceDays = 7
+	}
+	if c.HorizonDays == 0 {
+		c.HorizonDays = c.RebalanceDays
+	}
+	if c.Picks == 0 {
+		c.Picks = 10
+	}
+	return c
+}
+
+// Validate rejects periods and sizes a run cannot replay
+func (c Config) Validate() error {
+	switch {
+	case c.From.IsZero() || c.To.IsZero():
+		return fmt.Errorf("%w: from and to are required", ErrInvalidConfig)
+	case c.To.Before(c.From):
+		return fmt.Errorf("%w: from must not be after to", ErrInvalidConfig)
+	case c.RebalanceDays < 1 || c.RebalanceDays > maxDays:
+		return fmt.Errorf("%w: rebalance_days must be between 1 and %d", ErrInvalidConfig, maxDays)
+	case c.HorizonDays < 1 || c.HorizonDays > maxDays:
+		return fmt.Errorf("%w: horizon_days must be between 1 and %d", ErrInvalidConfig, maxDays)
+	case c.Picks < 1 || c.Picks > MaxPicks:
+		return fmt.Errorf("%w: picks must be between 1 and %d", ErrInvalidConfig, MaxPicks)
+	case len(c.RebalanceDates()) > MaxRebalances:
+		return fmt.Errorf("%w: more than %d rebalance dates, use a shorter period or longer interval", ErrInvalidConfig, MaxRebalances)
+	}
+	return nil
+}
+
+// RebalanceDates lists the dates from From to To, RebalanceDays apart
+func (c Config) RebalanceDates() []time.Time {
+	if c.RebalanceDays < 1 {
+		return nil
+	}
+	var dates []time.Time
+	for date := c.From; !date.After(c.To) && len(dates) <= MaxRebalances; date = date.AddDate(0, 0, c.RebalanceDays) {
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+type RunStatus string
+
+const (
+	RunPending   RunStatus = "pending"
+	RunRunning   RunStatus = "running"
+	RunCompleted RunStatus = "completed"
+	RunFailed    RunStatus = "failed"
+)
+
+// Run is a persisted backtest: its configuration, summary metrics and, once
+// completed, the picks of every rebalance
+type Run struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	Status      RunStatus  `json:"status" gorm:"size:20;not null"`
+	Strategy    string     `json:"strategy" gorm:"size:100;not null;index"`
+	Config      Config     `json:"config" gorm:"type:jsonb;serializer:json;not null"`
+	Metrics     Metrics    `json:"metrics" gorm:"embedded"`
+	Periods     []Period   `json:"periods,omitempty" gorm:"type:jsonb;serializer:json"`
+	Error       string     `json:"error,omitempty" gorm:"type:text"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+func (Run) TableName() string {
+	return "backtest_runs"
+}
+
+// Metrics summarise a run. The hit rate and average return are in percent over
+// the horizon; the total return and drawdown compound the holding returns, so
+// they read as a portfolio rebalanced every interval whatever the horizon.
+type Metrics struct {
+	Rebalances         int     `json:"rebalances"`
+	Evaluated          int     `json:"evaluated"`
+	HitRate            float64 `json:"hit_rate"`
+	AvgReturnPercent   float64 `json:"avg_return_percent"`
+	TotalReturnPercent float64 `json:"total_return_percent"`
+	MaxDrawdownPercent float64 `json:"max_drawdown_percent"`
+	Turnover           float64 `json:"turnover"`
+}
+
+// Period is one rebalance: the picks held and how they performed
+type Period struct {
+	Date time.Time `json:"date"`
+	// ReturnPercent is the mean return of the evaluated picks over the horizon,
+	// nil when none could be evaluated
+	ReturnPercent *float64 `json:"return_percent,omitempty"`
+	// HoldingReturnPercent is the mean return of the picks until the next
+	// rebalance, which the equity curve compounds
+	HoldingReturnPercent *float64 `json:"holding_return_percent,omitempty"`
+	// Turnover is the share of picks that were not held at the previous rebalance
+	Turnover float64 `json:"turnover"`
+	Picks    []Pick  `json:"picks"`
+}
+
+type Pick struct {
+	Ticker string  `json:"ticker"`
+	Score  float64 `json:"score"`
+	// ReturnPercent is nil without closes at both ends of the horizon
+	ReturnPercent *float64 `json:"return_percent,omitempty"`
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	recommendationDomain "github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+)
+
+type RunRepository interface {
+	Create(ctx context.Context, run *Run) error
+	Update(ctx context.Context, run *Run) error
+	// FindByID returns nil when the run does not exist
+	FindByID(ctx context.Context, id uint) (*Run, error)
+	// FindAll lists runs newest first without their periods, optionally of one strategy only
+	FindAll(ctx context.Context, strategy string) ([]*Run, error)
+}
+
+// Ranker ranks tickers from their actions as of a date, the way recommendations are ranked
+type Ranker interface {
+	RankActions(ctx context.Context, query recommendationDomain.RecommendationQuery, tickers [][]*stockDomain.Stock, now time.Time) ([]*recommendationDomain.StockRecommendation, error)
+}
@@ -0,0 +1,46 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/bryanriosb/stock-info/internal/backtest/domain"
+	"gorm.io/gorm"
+)
+
+type runRepository struct {
+	db *gorm.DB
+}
+
+func NewRunRepository(db *gorm.DB) domain.RunRepository {
+	return &runRepository{db: db}
+}
+
+func (r *runRepository) Create(ctx context.Context, run *domain.Run) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+func (r *runRepository) Update(ctx context.Context, run *domain.Run) error {
+	return r.db.WithContext(ctx).Save(run).Error
+}
+
+func (r *runRepository) FindByID(ctx context.Context, id uint) (*domain.Run, error) {
+	var run domain.Run
+	err := r.db.WithContext(ctx).First(&run, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *runRepository) FindAll(ctx context.Context, strategy string) ([]*domain.Run, error) {
+	query := r.db.WithContext(ctx).Omit("periods")
+	if strategy != "" {
+		query = query.Where("strategy = ?", strategy)
+	}
+	var runs []*domain.Run
+	err := query.Order("created_at DESC, id DESC").Find(&runs).Error
+	return runs, err
+}
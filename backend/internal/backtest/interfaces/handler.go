@@ -0,0 +1,96 @@
+package interfaces
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/backtest/application"
+	"github.com/bryanriosb/stock-info/internal/backtest/domain"
+	recommendationDomain "github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	"github.com/bryanriosb/stock-info/shared/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RunRequest configures a backtest; dates are YYYY-MM-DD
+type RunRequest struct {
+	Strategy      string             `json:"strategy"`
+	Aggregation   string             `json:"aggregation,omitempty"`
+	Parameters    map[string]float64 `json:"parameters,omitempty"`
+	RatingScale   map[string]int     `json:"rating_scale,omitempty"`
+	From          string             `json:"from"`
+	To            string             `json:"to"`
+	RebalanceDays int                `json:"rebalance_days,omitempty"`
+	HorizonDays   int                `json:"horizon_days,omitempty"`
+	Picks         int                `json:"picks,omitempty"`
+}
+
+type Handler struct {
+	useCase application.BacktestUseCase
+}
+
+func NewHandler(useCase application.BacktestUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// StartRun records a backtest and runs it in the background; poll GetRun for the outcome
+func (h *Handler) StartRun(c *fiber.Ctx) error {
+	var req RunRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body")
+	}
+	from, fromErr := time.Parse(time.DateOnly, req.From)
+	to, toErr := time.Parse(time.DateOnly, req.To)
+	if fromErr != nil || toErr != nil {
+		return response.BadRequest(c, "from and to must be YYYY-MM-DD dates")
+	}
+
+	run, err := h.useCase.Start(c.Context(), domain.Config{
+		Strategy:      req.Strategy,
+		Aggregation:   req.Aggregation,
+		Parameters:    req.Parameters,
+		RatingScale:   req.RatingScale,
+		From:          from,
+		To:            to,
+		RebalanceDays: req.RebalanceDays,
+		HorizonDays:   req.HorizonDays,
+		Picks:         req.Picks,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidConfig) || errors.Is(err, recommendationDomain.ErrUnknownStrategy) ||
+			errors.Is(err, recommendationDomain.ErrUnknownAggregation) || errors.Is(err, recommendationDomain.ErrInvalidParameter) ||
+			errors.Is(err, recommendationDomain.ErrInvalidRatingScale) {
+			return response.BadRequest(c, err.Error())
+		}
+		return response.InternalError(c, "Failed to start backtest")
+	}
+
+	return response.Created(c, run)
+}
+
+// ListRuns lists the runs for comparison, newest first
+func (h *Handler) ListRuns(c *fiber.Ctx) error {
+	runs, err := h.useCase.ListRuns(c.Context(), c.Query("strategy"))
+	if err != nil {
+		return response.InternalError(c, "Failed to fetch backtest runs")
+	}
+	return response.Success(c, runs)
+}
+
+// GetRun returns a run with the picks of every rebalance
+func (h *Handler) GetRun(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.BadRequest(c, "Invalid backtest run ID")
+	}
+
+	run, err := h.useCase.GetRun(c.Context(), uint(id))
+	if err != nil {
+		return response.InternalError(c, "Failed to fetch backtest run")
+	}
+	if run == nil {
+		return response.NotFound(c, "Backtest run not found")
+	}
+
+	return response.Success(c, run)
+}
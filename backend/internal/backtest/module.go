@@ -0,0 +1,34 @@
+package backtest
+
+import (
+	"context"
+
+	"github.com/bryanriosb/stock-info/internal/backtest/application"
+	"github.com/bryanriosb/stock-info/internal/backtest/domain"
+	"github.com/bryanriosb/stock-info/internal/backtest/infrastructure"
+	"github.com/bryanriosb/stock-info/internal/backtest/interfaces"
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
+	stockInfra "github.com/bryanriosb/stock-info/internal/stock/infrastructure"
+	"github.com/bryanriosb/stock-info/shared/middleware"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Register mounts the admin backtest API, replaying history through the given
+// ranker. Started runs stop once ctx is done.
+func Register(ctx context.Context, app fiber.Router, db *gorm.DB, ranker domain.Ranker, prices priceDomain.HistoryProvider) application.BacktestUseCase {
+	useCase := NewUseCase(ctx, db, ranker, prices)
+	handler := interfaces.NewHandler(useCase)
+
+	group := app.Group("/backtests", middleware.RequireAdmin())
+	group.Post("", handler.StartRun)
+	group.Get("", handler.ListRuns)
+	group.Get("/:id<int>", handler.GetRun)
+
+	return useCase
+}
+
+// NewUseCase builds the backtest use case over db, for the API and the CLI
+func NewUseCase(ctx context.Context, db *gorm.DB, ranker domain.Ranker, prices priceDomain.HistoryProvider) application.BacktestUseCase {
+	return application.NewBacktestUseCase(ctx, infrastructure.NewRunRepository(db), stockInfra.NewActionHistoryRepository(db), ranker, prices)
+}
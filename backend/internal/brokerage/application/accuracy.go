@@ -0,0 +1,283 @@
+package application
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/bryanriosb/stock-info/shared/jobs"
+)
+
+type AccuracyUseCase interface {
+	// ListAccuracy returns a page of scored actions, newest first, with their total
+	ListAccuracy(ctx context.Context, params domain.ActionAccuracyParams) ([]*domain.ActionAccuracy, int64, error)
+	// Summarize aggregates the scored actions per brokerage or per ticker,
+	// best hit rate at the params' horizon first
+	Summarize(ctx context.Context, groupBy string, params domain.AccuracyParams) ([]*domain.AccuracySummary, error)
+	// Refresh scores every action now
+	Refresh(ctx context.Context) (*domain.AccuracyRun, error)
+}
+
+// AccuracyJob scores the target of every recorded analyst action, not just the
+// latest call stocks keep per ticker and brokerage, against the price history:
+// whether the price reached it within each of AccuracyHorizons and how far the
+// price went for and against it within the longest horizon. Prices after a
+// split are scaled back to the shares the target was set on.
+type AccuracyJob struct {
+	history stockDomain.ActionHistoryRepository
+	prices  priceDomain.HistoryProvider
+	repo    domain.AccuracyRepository
+	bus     *events.Bus
+	now     func() time.Time
+
+	mu     sync.Mutex
+	runner *jobs.Runner
+}
+
+func NewAccuracyJob(history stockDomain.ActionHistoryRepository, prices priceDomain.HistoryProvider, repo domain.AccuracyRepository, bus *events.Bus) *AccuracyJob {
+	j := &AccuracyJob{history: history, prices: prices, repo: repo, bus: bus, now: time.Now}
+	j.runner = jobs.NewRunner("Target accuracy refresh", func(ctx context.Context) error {
+		_, err := j.Refresh(ctx)
+		return err
+	})
+	return j
+}
+
+func (j *AccuracyJob) ListAccuracy(ctx context.Context, params domain.ActionAccuracyParams) ([]*domain.ActionAccuracy, int64, error) {
+	params.Brokerage = strings.TrimSpace(params.Brokerage)
+	params.Ticker = strings.ToUpper(strings.TrimSpace(params.Ticker))
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 20
+	}
+	return j.repo.FindAccuracy(ctx, params)
+}
+
+func (j *AccuracyJob) Summarize(ctx context.Context, groupBy string, params domain.AccuracyParams) ([]*domain.AccuracySummary, error) {
+	if params.Horizon == 0 {
+		params.Horizon = longestHorizon()
+	}
+	if !domain.ValidHorizon(params.Horizon) {
+		return nil, domain.ErrInvalidHorizon
+	}
+	params.Brokerage = strings.TrimSpace(params.Brokerage)
+	params.Ticker = strings.ToUpper(strings.TrimSpace(params.Ticker))
+	if params.Limit < 1 || params.Limit > 500 {
+		params.Limit = 50
+	}
+
+	summaries, err := j.repo.Summarize(ctx, groupBy, params)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := summaries[:0]
+	for _, summary := range summaries {
+		if summary.Actions >= params.MinActions {
+			ranked = append(ranked, summary)
+		}
+	}
+	sort.Slice(ranked, func(a, b int) bool {
+		evaluatedA, rateA := ranked[a].Outcome(params.Horizon)
+		evaluatedB, rateB := ranked[b].Outcome(params.Horizon)
+		if (evaluatedA > 0) != (evaluatedB > 0) {
+			return evaluatedA > 0
+		}
+		if rateA != rateB {
+			return rateA > rateB
+		}
+		if evaluatedA != evaluatedB {
+			return evaluatedA > evaluatedB
+		}
+		return ranked[a].Brokerage+ranked[a].Ticker < ranked[b].Brokerage+ranked[b].Ticker
+	})
+	if len(ranked) > params.Limit {
+		ranked = ranked[:params.Limit]
+	}
+	return ranked, nil
+}
+
+// Start runs triggered scoring with ctx and, when interval is positive,
+// scores the actions now and every interval, until ctx is done
+func (j *AccuracyJob) Start(ctx context.Context, interval time.Duration) {
+	j.runner.Start(ctx)
+	if interval <= 0 {
+		return
+	}
+	j.Trigger()
+	go jobs.Every(ctx, interval, j.Trigger)
+}
+
+// Trigger scores the actions in the background, or once more after a run that is already going
+func (j *AccuracyJob) Trigger() {
+	j.runner.Trigger()
+}
+
+// Refresh saves the scores as each batch fills, then drops the scores of
+// actions this run no longer scored, such as actions removed upstream
+func (j *AccuracyJob) Refresh(ctx context.Context) (*domain.AccuracyRun, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	// Stored timestamps keep microseconds, so the scores saved now are not pruned below
+	now := j.now().Truncate(time.Microsecond)
+	run := &domain.AccuracyRun{EvaluatedAt: now}
+	var batch []*domain.ActionAccuracy
+	err := j.history.ScanTickers(ctx, scanBatchSize, func(actions []*stockDomain.Stock) error {
+		scored, err := j.score(ctx, actions, now, run)
+		if err != nil {
+			return err
+		}
+		batch = append(batch, scored...)
+		if len(batch) < scanBatchSize {
+			return nil
+		}
+		err = j.repo.SaveAccuracy(ctx, batch)
+		batch = nil
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := j.repo.SaveAccuracy(ctx, batch); err != nil {
+		return nil, err
+	}
+	if err := j.repo.DeleteEvaluatedBefore(ctx, now); err != nil {
+		return nil, err
+	}
+
+	j.bus.Publish(events.AccuracyUpdated, run.Actions)
+	return run, nil
+}
+
+// score assesses the past actions with a target on one ticker
+func (j *AccuracyJob) score(ctx context.Context, actions []*stockDomain.Stock, now time.Time, run *domain.AccuracyRun) ([]*domain.ActionAccuracy, error) {
+	months := longestHorizon()
+	var due []*stockDomain.Stock
+	var from, to time.Time
+	for _, stock := range actions {
+		if stock.Brokerage == "" || stock.TargetTo <= 0 || stock.Time.IsZero() || stock.Time.After(now) {
+			continue
+		}
+		if len(due) == 0 || stock.Time.Before(from) {
+			from = stock.Time
+		}
+		if end := stock.Time.AddDate(0, months, 0); end.After(to) {
+			to = end
+		}
+		due = append(due, stock)
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+	if to.After(now) {
+		to = now
+	}
+
+	history, err := j.prices.History(ctx, due[0].Ticker, from.Add(-priceTolerance), to)
+	if err != nil {
+		return nil, err
+	}
+
+	scored := make([]*domain.ActionAccuracy, 0, len(due))
+	for _, stock := range due {
+		accuracy, ok := assess(stock, history, now)
+		if !ok {
+			run.Skipped++
+			continue
+		}
+		scored = append(scored, accuracy)
+	}
+	run.Actions += len(scored)
+	return scored, nil
+}
+
+// assess follows the price from the day after the action to the end of the
+// longest horizon. A day reaches an upward target when its high does and a
+// downward target when its low does; days without a high or low use the close.
+func assess(stock *stockDomain.Stock, history []priceDomain.PricePoint, now time.Time) (*domain.ActionAccuracy, bool) {
+	point, ok := pointAt(history, stock.Time)
+	if !ok || point.Close <= 0 {
+		return nil, false
+	}
+	base := point.Close
+
+	accuracy := &domain.ActionAccuracy{
+		ActionID:      stock.ID,
+		Ticker:        stock.Ticker,
+		Brokerage:     stock.Brokerage,
+		Time:          stock.Time,
+		BaseClose:     base,
+		PricedThrough: point.Date,
+		TargetTo:      stock.TargetTo,
+		Direction:     domain.DirectionUp,
+		EvaluatedAt:   now,
+	}
+	up := stock.TargetTo >= base
+	if !up {
+		accuracy.Direction = domain.DirectionDown
+	}
+
+	end := stock.Time.AddDate(0, longestHorizon(), 0)
+	high, low, factor := base, base, 1.0
+	i := sort.Search(len(history), func(i int) bool { return history[i].Date.After(stock.Time) })
+	for ; i < len(history) && !history[i].Date.After(end); i++ {
+		bar := history[i]
+		if bar.SplitFactor > 0 {
+			factor *= bar.SplitFactor
+		}
+		barHigh, barLow := bar.Close, bar.Close
+		if bar.High > 0 {
+			barHigh = bar.High
+		}
+		if bar.Low > 0 {
+			barLow = bar.Low
+		}
+		barHigh, barLow = barHigh*factor, barLow*factor
+		high, low = math.Max(high, barHigh), math.Min(low, barLow)
+
+		reached := (up && barHigh >= stock.TargetTo) || (!up && barLow <= stock.TargetTo)
+		if reached && accuracy.HitAt == nil {
+			hitAt := bar.Date
+			days := int(hitAt.Sub(stock.Time.Truncate(24*time.Hour)).Hours() / 24)
+			accuracy.HitAt = &hitAt
+			accuracy.DaysToTarget = &days
+		}
+		accuracy.PricedThrough = bar.Date
+	}
+
+	rise, fall := (high/base-1)*100, (1-low/base)*100
+	if up {
+		accuracy.MaxFavorablePercent, accuracy.MaxAdversePercent = rise, fall
+	} else {
+		accuracy.MaxFavorablePercent, accuracy.MaxAdversePercent = fall, rise
+	}
+
+	for _, months := range domain.AccuracyHorizons {
+		deadline := stock.Time.AddDate(0, months, 0)
+		switch {
+		case accuracy.HitAt != nil && !accuracy.HitAt.After(deadline):
+			accuracy.SetHit(months, boolPtr(true))
+		case !deadline.After(now) && deadline.Sub(accuracy.PricedThrough) <= priceTolerance:
+			accuracy.SetHit(months, boolPtr(false))
+		}
+	}
+	return accuracy, true
+}
+
+func longestHorizon() int {
+	return domain.AccuracyHorizons[len(domain.AccuracyHorizons)-1]
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,206 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock AccuracyRepository
+type MockAccuracyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAccuracyRepository) SaveAccuracy(ctx context.Context, accuracy []*domain.ActionAccuracy) error {
+	args := m.Called(ctx, accuracy)
+	return args.Error(0)
+}
+
+func (m *MockAccuracyRepository) DeleteEvaluatedBefore(ctx context.Context, at time.Time) error {
+	args := m.Called(ctx, at)
+	return args.Error(0)
+}
+
+func (m *MockAccuracyRepository) FindAccuracy(ctx context.Context, params domain.ActionAccuracyParams) ([]*domain.ActionAccuracy, int64, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.ActionAccuracy), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAccuracyRepository) Summarize(ctx context.Context, groupBy string, params domain.AccuracyParams) ([]*domain.AccuracySummary, error) {
+	args := m.Called(ctx, groupBy, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AccuracySummary), args.Error(1)
+}
+
+func TestAccuracyRefresh(t *testing.T) {
+	history := new(MockActionHistoryRepository)
+	prices := new(MockHistoryProvider)
+	repo := new(MockAccuracyRepository)
+	bus := events.NewBus()
+
+	aapl := []*stockDomain.Stock{
+		// Upward target reached by an intraday high after 4 months
+		{ID: 1, Ticker: "AAPL", Brokerage: "Goldman Sachs", TargetTo: 120, Time: day("2025-01-02").Add(14 * time.Hour)},
+		// Downward target, only the 3 month horizon has passed
+		{ID: 2, Ticker: "AAPL", Brokerage: "Morgan Stanley", TargetTo: 90, Time: day("2025-10-01").Add(14 * time.Hour)},
+		// No close around the action
+		{ID: 4, Ticker: "AAPL", Brokerage: "Citigroup", TargetTo: 130, Time: day("2024-01-02")},
+		// No target
+		{ID: 5, Ticker: "AAPL", Brokerage: "UBS", RatingFrom: "Hold", RatingTo: "Buy", Time: day("2025-01-02")},
+	}
+	tsla := []*stockDomain.Stock{
+		// Reached only once the 3-for-1 split is accounted for
+		{ID: 3, Ticker: "TSLA", Brokerage: "UBS", TargetTo: 300, Time: day("2025-03-03").Add(14 * time.Hour)},
+	}
+	history.On("ScanTickers", mock.Anything, scanBatchSize, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func([]*stockDomain.Stock) error)
+		fn(aapl)
+		fn(tsla)
+	}).Return(nil)
+	prices.On("History", mock.Anything, "AAPL", day("2023-12-26"), day("2026-01-15")).Return([]priceDomain.PricePoint{
+		{Ticker: "AAPL", Date: day("2025-01-02"), Close: 100, SplitFactor: 1},
+		{Ticker: "AAPL", Date: day("2025-02-03"), High: 110, Low: 95, Close: 105, SplitFactor: 1},
+		{Ticker: "AAPL", Date: day("2025-05-01"), High: 121, Low: 110, Close: 118, SplitFactor: 1},
+		{Ticker: "AAPL", Date: day("2025-09-30"), Close: 110, SplitFactor: 1},
+		{Ticker: "AAPL", Date: day("2025-12-31"), Close: 115, SplitFactor: 1},
+		{Ticker: "AAPL", Date: day("2026-01-02"), Close: 112, SplitFactor: 1},
+	}, nil)
+	prices.On("History", mock.Anything, "TSLA", mock.Anything, mock.Anything).Return([]priceDomain.PricePoint{
+		{Ticker: "TSLA", Date: day("2025-03-03"), Close: 250, SplitFactor: 1},
+		{Ticker: "TSLA", Date: day("2025-06-02"), Close: 105, SplitFactor: 3},
+	}, nil)
+
+	var saved []*domain.ActionAccuracy
+	repo.On("SaveAccuracy", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(1).([]*domain.ActionAccuracy)...)
+	}).Return(nil)
+	now := day("2026-01-15")
+	repo.On("DeleteEvaluatedBefore", mock.Anything, now).Return(nil)
+
+	var published interface{}
+	bus.Subscribe(events.AccuracyUpdated, func(e events.Event) { published = e.Payload })
+
+	job := NewAccuracyJob(history, prices, repo, bus)
+	job.now = func() time.Time { return now }
+
+	run, err := job.Refresh(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, run.Actions)
+	assert.Equal(t, 1, run.Skipped)
+	assert.Equal(t, 3, published)
+	assert.Len(t, saved, 3)
+
+	goldman := saved[0]
+	assert.Equal(t, int64(1), goldman.ActionID)
+	assert.Equal(t, domain.DirectionUp, goldman.Direction)
+	assert.Equal(t, 100.0, goldman.BaseClose)
+	assert.False(t, *goldman.Hit3M)
+	assert.True(t, *goldman.Hit6M)
+	assert.True(t, *goldman.Hit12M)
+	assert.Equal(t, day("2025-05-01"), *goldman.HitAt)
+	assert.Equal(t, 119, *goldman.DaysToTarget)
+	assert.InDelta(t, 21.0, goldman.MaxFavorablePercent, 1e-9)
+	assert.InDelta(t, 5.0, goldman.MaxAdversePercent, 1e-9)
+	assert.Equal(t, day("2026-01-02"), goldman.PricedThrough)
+	assert.Equal(t, now, goldman.EvaluatedAt)
+
+	morgan := saved[1]
+	assert.Equal(t, domain.DirectionDown, morgan.Direction)
+	assert.Equal(t, 110.0, morgan.BaseClose)
+	assert.False(t, *morgan.Hit3M)
+	assert.Nil(t, morgan.Hit6M)
+	assert.Nil(t, morgan.Hit12M)
+	assert.Nil(t, morgan.HitAt)
+	assert.Equal(t, 0.0, morgan.MaxFavorablePercent)
+	assert.InDelta(t, (115.0/110-1)*100, morgan.MaxAdversePercent, 1e-9)
+
+	ubs := saved[2]
+	assert.Equal(t, "TSLA", ubs.Ticker)
+	assert.True(t, *ubs.Hit3M)
+	assert.True(t, *ubs.Hit12M)
+	assert.Equal(t, 91, *ubs.DaysToTarget)
+	assert.InDelta(t, 26.0, ubs.MaxFavorablePercent, 1e-9)
+	repo.AssertExpectations(t)
+	prices.AssertExpectations(t)
+}
+
+func TestAccuracySummarize(t *testing.T) {
+	repo := new(MockAccuracyRepository)
+	job := NewAccuracyJob(nil, nil, repo, nil)
+
+	repo.On("Summarize", mock.Anything, domain.GroupByBrokerage, domain.AccuracyParams{Ticker: "AAPL", Horizon: 6, MinActions: 2, Limit: 50}).
+		Return([]*domain.AccuracySummary{
+			{Brokerage: "Pending", Actions: 4},
+			{Brokerage: "Morgan Stanley", Actions: 5, Evaluated6M: 4, HitRate6M: 0.5},
+			{Brokerage: "Goldman Sachs", Actions: 3, Evaluated6M: 2, HitRate6M: 1},
+			{Brokerage: "New", Actions: 1, Evaluated6M: 1, HitRate6M: 1},
+		}, nil)
+
+	summaries, err := job.Summarize(context.Background(), domain.GroupByBrokerage, domain.AccuracyParams{Ticker: " aapl ", Horizon: 6, MinActions: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 3)
+	assert.Equal(t, "Goldman Sachs", summaries[0].Brokerage)
+	assert.Equal(t, "Morgan Stanley", summaries[1].Brokerage)
+	assert.Equal(t, "Pending", summaries[2].Brokerage)
+	repo.AssertExpectations(t)
+}
+
+func TestAccuracySummarize_InvalidHorizon(t *testing.T) {
+	repo := new(MockAccuracyRepository)
+	job := NewAccuracyJob(nil, nil, repo, nil)
+
+	_, err := job.Summarize(context.Background(), domain.GroupByTicker, domain.AccuracyParams{Horizon: 9})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidHorizon)
+	repo.AssertNotCalled(t, "Summarize", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAccuracyRefresh_ScoresEveryRecordedCall(t *testing.T) {
+	history := new(MockActionHistoryRepository)
+	prices := new(MockHistoryProvider)
+	repo := new(MockAccuracyRepository)
+
+	// A later sync replaced UBS's first call in stocks; the history keeps both
+	history.On("ScanTickers", mock.Anything, scanBatchSize, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(2).(func([]*stockDomain.Stock) error)([]*stockDomain.Stock{
+			{ID: 10, Ticker: "MSFT", Brokerage: "UBS", TargetTo: 110, Time: day("2025-01-02")},
+			{ID: 11, Ticker: "MSFT", Brokerage: "UBS", TargetTo: 130, Time: day("2025-03-03")},
+		})
+	}).Return(nil)
+	prices.On("History", mock.Anything, "MSFT", mock.Anything, mock.Anything).Return([]priceDomain.PricePoint{
+		{Ticker: "MSFT", Date: day("2025-01-02"), Close: 100, SplitFactor: 1},
+		{Ticker: "MSFT", Date: day("2025-03-03"), Close: 112, SplitFactor: 1},
+	}, nil)
+	var saved []*domain.ActionAccuracy
+	repo.On("SaveAccuracy", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(1).([]*domain.ActionAccuracy)...)
+	}).Return(nil)
+	repo.On("DeleteEvaluatedBefore", mock.Anything, mock.Anything).Return(nil)
+
+	job := NewAccuracyJob(history, prices, repo, events.NewBus())
+	job.now = func() time.Time { return day("2025-04-01") }
+
+	run, err := job.Refresh(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, run.Actions)
+	assert.Len(t, saved, 2)
+	assert.Equal(t, int64(10), saved[0].ActionID)
+	assert.NotNil(t, saved[0].HitAt)
+	assert.Equal(t, int64(11), saved[1].ActionID)
+	assert.Nil(t, saved[1].HitAt)
+}
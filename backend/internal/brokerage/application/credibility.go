@@ -0,0 +1,255 @@
+package application
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/bryanriosb/stock-info/shared/jobs"
+)
+
+const (
+	// credibilityPrior is the number of neutral calls blended into every score,
+	// so a brokerage needs a track record before its weight moves far from 1
+	credibilityPrior = 10
+	// priceTolerance is how far back a close may stand in for a day without
+	// one, covering weekends and holidays
+	priceTolerance = 7 * 24 * time.Hour
+	// scanBatchSize is the number of actions read per query while evaluating
+	scanBatchSize = 500
+)
+
+type CredibilityUseCase interface {
+	// GetCredibility returns the last computed credibility, most credible first
+	GetCredibility(ctx context.Context) ([]*domain.Credibility, error)
+	// Refresh recomputes credibility now and returns it
+	Refresh(ctx context.Context) ([]*domain.Credibility, error)
+}
+
+// CredibilityJob learns brokerage credibility by replaying every recorded
+// action against the price history: an action counts once the evaluation
+// horizon has passed and closes exist on both the action day and the horizon
+// day. Closes after a split are scaled back to the shares the call was made on.
+type CredibilityJob struct {
+	history stockDomain.ActionHistoryRepository
+	prices  priceDomain.HistoryProvider
+	repo    domain.CredibilityRepository
+	bus     *events.Bus
+	horizon time.Duration
+	now     func() time.Time
+
+	mu     sync.Mutex
+	runner *jobs.Runner
+}
+
+func NewCredibilityJob(history stockDomain.ActionHistoryRepository, prices priceDomain.HistoryProvider, repo domain.CredibilityRepository, bus *events.Bus, horizon time.Duration) *CredibilityJob {
+	j := &CredibilityJob{history: history, prices: prices, repo: repo, bus: bus, horizon: horizon, now: time.Now}
+	j.runner = jobs.NewRunner("Brokerage credibility refresh", func(ctx context.Context) error {
+		_, err := j.Refresh(ctx)
+		return err
+	})
+	return j
+}
+
+func (j *CredibilityJob) GetCredibility(ctx context.Context) ([]*domain.Credibility, error) {
+	return j.repo.FindAll(ctx)
+}
+
+// Start runs triggered refreshes with ctx and, when interval is positive,
+// refreshes credibility now and every interval, until ctx is done
+func (j *CredibilityJob) Start(ctx context.Context, interval time.Duration) {
+	j.runner.Start(ctx)
+	if interval <= 0 {
+		return
+	}
+	j.Trigger()
+	go jobs.Every(ctx, interval, j.Trigger)
+}
+
+// Trigger refreshes credibility in the background, or once more after a refresh that is already running
+func (j *CredibilityJob) Trigger() {
+	j.runner.Trigger()
+}
+
+func (j *CredibilityJob) Refresh(ctx context.Context) ([]*domain.Credibility, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := j.now()
+	tallies := make(map[string]*tally)
+	err := j.history.ScanTickers(ctx, scanBatchSize, func(actions []*stockDomain.Stock) error {
+		return j.evaluate(ctx, actions, now, tallies)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	credibility := make([]*domain.Credibility, 0, len(tallies))
+	for brokerage, t := range tallies {
+		credibility = append(credibility, t.credibility(brokerage, now))
+	}
+	sort.Slice(credibility, func(a, b int) bool {
+		if credibility[a].Score != credibility[b].Score {
+			return credibility[a].Score > credibility[b].Score
+		}
+		return credibility[a].Brokerage < credibility[b].Brokerage
+	})
+
+	if err := j.repo.ReplaceAll(ctx, credibility); err != nil {
+		return nil, err
+	}
+	j.bus.Publish(events.CredibilityUpdated, len(credibility))
+	return credibility, nil
+}
+
+// evaluate scores the actions on one ticker whose horizon has passed
+func (j *CredibilityJob) evaluate(ctx context.Context, actions []*stockDomain.Stock, now time.Time, tallies map[string]*tally) error {
+	var due []*stockDomain.Stock
+	var from, to time.Time
+	for _, stock := range actions {
+		if stock.Brokerage == "" || stock.Time.IsZero() || stock.Time.Add(j.horizon).After(now) {
+			continue
+		}
+		if len(due) == 0 || stock.Time.Before(from) {
+			from = stock.Time
+		}
+		if end := stock.Time.Add(j.horizon); end.After(to) {
+			to = end
+		}
+		due = append(due, stock)
+	}
+	if len(due) == 0 {
+		return nil
+	}
+
+	history, err := j.prices.History(ctx, due[0].Ticker, from.Add(-priceTolerance), to)
+	if err != nil {
+		return err
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
+	for _, stock := range due {
+		start, ok := closeAt(history, stock.Time)
+		if !ok {
+			continue
+		}
+		end, ok := closeAt(history, stock.Time.Add(j.horizon))
+		if !ok {
+			continue
+		}
+		end *= splitsBetween(history, stock.Time, stock.Time.Add(j.horizon))
+
+		t := tallies[stock.Brokerage]
+		if t == nil {
+			t = &tally{}
+			tallies[stock.Brokerage] = t
+		}
+		t.add(stock, start, end)
+	}
+	return nil
+}
+
+// tally accumulates the evaluated calls of a brokerage
+type tally struct {
+	calls, hits, targets int
+	returns, accuracy    float64
+}
+
+func (t *tally) add(stock *stockDomain.Stock, start, end float64) {
+	change := end/start - 1
+	if called := direction(stock); called != 0 {
+		t.calls++
+		if sign(change) == called {
+			t.hits++
+		}
+		t.returns += change * float64(called)
+	}
+	if stock.TargetTo > 0 {
+		t.targets++
+		t.accuracy += math.Max(0, 1-math.Abs(end-stock.TargetTo)/stock.TargetTo)
+	}
+}
+
+// credibility blends the hit rate and target accuracy, then shrinks the result
+// towards neutral by credibilityPrior calls
+func (t *tally) credibility(brokerage string, now time.Time) *domain.Credibility {
+	c := &domain.Credibility{Brokerage: brokerage, Calls: t.calls, Targets: t.targets, ComputedAt: now}
+
+	var raw float64
+	var parts int
+	if t.calls > 0 {
+		c.HitRate = float64(t.hits) / float64(t.calls)
+		c.AvgReturnPercent = t.returns / float64(t.calls) * 100
+		raw += c.HitRate
+		parts++
+	}
+	if t.targets > 0 {
+		c.TargetAccuracy = t.accuracy / float64(t.targets)
+		raw += c.TargetAccuracy
+		parts++
+	}
+
+	samples := float64(max(t.calls, t.targets))
+	c.Score = domain.NeutralCredibility
+	if parts > 0 {
+		c.Score = (samples*raw/float64(parts) + credibilityPrior*domain.NeutralCredibility) / (samples + credibilityPrior)
+	}
+	c.Weight = 0.5 + c.Score
+	return c
+}
+
+// direction is 1 for upgrades and target raises, -1 for downgrades and target
+// cuts and 0 for calls that moved neither
+func direction(stock *stockDomain.Stock) int {
+	from, to := stockDomain.RatingValue(stock.RatingFrom), stockDomain.RatingValue(stock.RatingTo)
+	if from != 0 && to != 0 && from != to {
+		return sign(float64(to - from))
+	}
+	return sign(stock.TargetTo - stock.TargetFrom)
+}
+
+// closeAt returns the last close on or before at, within priceTolerance
+func closeAt(history []priceDomain.PricePoint, at time.Time) (float64, bool) {
+	point, ok := pointAt(history, at)
+	return point.Close, ok
+}
+
+// splitsBetween multiplies the split factors taking effect after one time
+// through another, restating later closes in the shares of the first
+func splitsBetween(history []priceDomain.PricePoint, after, through time.Time) float64 {
+	factor := 1.0
+	for _, point := range history {
+		if point.Date.After(after) && !point.Date.After(through) && point.SplitFactor > 0 {
+			factor *= point.SplitFactor
+		}
+	}
+	return factor
+}
+
+// pointAt returns the last bar on or before at, within priceTolerance
+func pointAt(history []priceDomain.PricePoint, at time.Time) (priceDomain.PricePoint, bool) {
+	i := sort.Search(len(history), func(i int) bool { return history[i].Date.After(at) })
+	if i == 0 || at.Sub(history[i-1].Date) > priceTolerance {
+		return priceDomain.PricePoint{}, false
+	}
+	return history[i-1], true
+}
+
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,249 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock ActionHistoryRepository
+type MockActionHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockActionHistoryRepository) ScanTickers(ctx context.Context, batchSize int, fn func(actions []*stockDomain.Stock) error) error {
+	args := m.Called(ctx, batchSize, fn)
+	return args.Error(0)
+}
+
+func (m *MockActionHistoryRepository) FindByTicker(ctx context.Context, ticker string, rng stockDomain.TimeRange) ([]*stockDomain.Stock, error) {
+	args := m.Called(ctx, ticker, rng)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*stockDomain.Stock), args.Error(1)
+}
+
+// Mock HistoryProvider
+type MockHistoryProvider struct {
+	mock.Mock
+}
+
+func (m *MockHistoryProvider) History(ctx context.Context, ticker string, from, to time.Time) ([]priceDomain.PricePoint, error) {
+	args := m.Called(ctx, ticker, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]priceDomain.PricePoint), args.Error(1)
+}
+
+func (m *MockHistoryProvider) AdjustedHistory(ctx context.Context, ticker string, from, to time.Time) ([]priceDomain.PricePoint, error) {
+	args := m.Called(ctx, ticker, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]priceDomain.PricePoint), args.Error(1)
+}
+
+// Mock CredibilityRepository
+type MockCredibilityRepository struct {
+	mock.Mock
+}
+
+func (m *MockCredibilityRepository) ReplaceAll(ctx context.Context, credibility []*domain.Credibility) error {
+	args := m.Called(ctx, credibility)
+	return args.Error(0)
+}
+
+func (m *MockCredibilityRepository) FindAll(ctx context.Context) ([]*domain.Credibility, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Credibility), args.Error(1)
+}
+
+func (m *MockCredibilityRepository) Weights(ctx context.Context) (map[string]float64, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]float64), args.Error(1)
+}
+
+func day(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+func TestCredibilityRefresh(t *testing.T) {
+	history := new(MockActionHistoryRepository)
+	prices := new(MockHistoryProvider)
+	repo := new(MockCredibilityRepository)
+	bus := events.NewBus()
+
+	actions := []*stockDomain.Stock{
+		// Upgrade the price followed, target nearly met
+		{Ticker: "AAPL", Brokerage: "Goldman Sachs", RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 110, TargetTo: 120, Time: day("2025-01-02").Add(14 * time.Hour)},
+		// Downgrade the price ignored
+		{Ticker: "AAPL", Brokerage: "Morgan Stanley", RatingFrom: "Buy", RatingTo: "Hold", TargetFrom: 100, TargetTo: 90, Time: day("2025-01-02").Add(14 * time.Hour)},
+		// Horizon not over yet
+		{Ticker: "AAPL", Brokerage: "UBS", RatingFrom: "Hold", RatingTo: "Buy", TargetTo: 130, Time: day("2025-05-01")},
+		// No price around the action
+		{Ticker: "AAPL", Brokerage: "Citigroup", RatingFrom: "Hold", RatingTo: "Buy", TargetTo: 130, Time: day("2024-01-02")},
+	}
+	history.On("ScanTickers", mock.Anything, scanBatchSize, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(2).(func([]*stockDomain.Stock) error)(actions)
+	}).Return(nil)
+	prices.On("History", mock.Anything, "AAPL", mock.Anything, mock.Anything).Return([]priceDomain.PricePoint{
+		{Ticker: "AAPL", Date: day("2025-01-02"), Close: 100},
+		{Ticker: "AAPL", Date: day("2025-03-28"), Close: 114},
+		{Ticker: "AAPL", Date: day("2025-03-31"), Close: 115},
+	}, nil)
+	repo.On("ReplaceAll", mock.Anything, mock.Anything).Return(nil)
+
+	var published interface{}
+	bus.Subscribe(events.CredibilityUpdated, func(e events.Event) { published = e.Payload })
+
+	job := NewCredibilityJob(history, prices, repo, bus, 90*24*time.Hour)
+	job.now = func() time.Time { return day("2025-06-01") }
+
+	credibility, err := job.Refresh(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, credibility, 2)
+
+	// 2025-04-02, the horizon day, has no close; the last one within a week stands in
+	goldman := credibility[0]
+	assert.Equal(t, "Goldman Sachs", goldman.Brokerage)
+	assert.Equal(t, 1, goldman.Calls)
+	assert.Equal(t, 1.0, goldman.HitRate)
+	assert.InDelta(t, 1-5.0/120, goldman.TargetAccuracy, 1e-9)
+	assert.InDelta(t, 15.0, goldman.AvgReturnPercent, 1e-9)
+	assert.InDelta(t, ((1+goldman.TargetAccuracy)/2+10*0.5)/11, goldman.Score, 1e-9)
+	assert.InDelta(t, 0.5+goldman.Score, goldman.Weight, 1e-9)
+
+	morgan := credibility[1]
+	assert.Equal(t, "Morgan Stanley", morgan.Brokerage)
+	assert.Equal(t, 0.0, morgan.HitRate)
+	assert.InDelta(t, -15.0, morgan.AvgReturnPercent, 1e-9)
+	assert.Less(t, morgan.Score, domain.NeutralCredibility)
+
+	repo.AssertCalled(t, "ReplaceAll", mock.Anything, credibility)
+	assert.Equal(t, 2, published)
+}
+
+func TestCredibilityRefresh_SkipsTickersWithoutDueActions(t *testing.T) {
+	history := new(MockActionHistoryRepository)
+	prices := new(MockHistoryProvider)
+	repo := new(MockCredibilityRepository)
+
+	history.On("ScanTickers", mock.Anything, scanBatchSize, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(2).(func([]*stockDomain.Stock) error)([]*stockDomain.Stock{
+			{Ticker: "MSFT", Brokerage: "UBS", RatingFrom: "Hold", RatingTo: "Buy", Time: day("2025-05-20")},
+		})
+	}).Return(nil)
+	repo.On("ReplaceAll", mock.Anything, []*domain.Credibility{}).Return(nil)
+
+	job := NewCredibilityJob(history, prices, repo, events.NewBus(), 90*24*time.Hour)
+	job.now = func() time.Time { return day("2025-06-01") }
+
+	credibility, err := job.Refresh(context.Background())
+
+	assert.NoError(t, err)
+	assert.Empty(t, credibility)
+	prices.AssertNotCalled(t, "History")
+	repo.AssertExpectations(t)
+}
+
+func TestCredibilityRefresh_ReplaysEveryRecordedCall(t *testing.T) {
+	history := new(MockActionHistoryRepository)
+	prices := new(MockHistoryProvider)
+	repo := new(MockCredibilityRepository)
+
+	// The stocks table only keeps UBS's second call; the history has both
+	history.On("ScanTickers", mock.Anything, scanBatchSize, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(2).(func([]*stockDomain.Stock) error)([]*stockDomain.Stock{
+			{ID: 1, Ticker: "AAPL", Brokerage: "UBS", TargetFrom: 100, TargetTo: 110, Time: day("2025-01-02")},
+			{ID: 2, Ticker: "AAPL", Brokerage: "UBS", TargetFrom: 110, TargetTo: 120, Time: day("2025-02-03")},
+		})
+	}).Return(nil)
+	prices.On("History", mock.Anything, "AAPL", mock.Anything, mock.Anything).Return([]priceDomain.PricePoint{
+		{Ticker: "AAPL", Date: day("2025-01-02"), Close: 100},
+		{Ticker: "AAPL", Date: day("2025-02-03"), Close: 100},
+		{Ticker: "AAPL", Date: day("2025-04-02"), Close: 110},
+		{Ticker: "AAPL", Date: day("2025-05-02"), Close: 120},
+	}, nil)
+	repo.On("ReplaceAll", mock.Anything, mock.Anything).Return(nil)
+
+	job := NewCredibilityJob(history, prices, repo, events.NewBus(), 90*24*time.Hour)
+	job.now = func() time.Time { return day("2025-06-01") }
+
+	credibility, err := job.Refresh(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, credibility, 1)
+	assert.Equal(t, 2, credibility[0].Calls)
+	assert.Equal(t, 2, credibility[0].Targets)
+	assert.Equal(t, 1.0, credibility[0].HitRate)
+}
+
+func TestCredibilityRefresh_ScalesClosesAfterASplit(t *testing.T) {
+	history := new(MockActionHistoryRepository)
+	prices := new(MockHistoryProvider)
+	repo := new(MockCredibilityRepository)
+
+	history.On("ScanTickers", mock.Anything, scanBatchSize, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(2).(func([]*stockDomain.Stock) error)([]*stockDomain.Stock{
+			{Ticker: "NVDA", Brokerage: "UBS", TargetFrom: 100, TargetTo: 120, Time: day("2025-01-02")},
+		})
+	}).Return(nil)
+	// A 4-for-1 split halfway: 30 after it is 120 in the shares the target was set on
+	prices.On("History", mock.Anything, "NVDA", mock.Anything, mock.Anything).Return([]priceDomain.PricePoint{
+		{Ticker: "NVDA", Date: day("2025-01-02"), Close: 100, SplitFactor: 1},
+		{Ticker: "NVDA", Date: day("2025-02-14"), Close: 27, SplitFactor: 4},
+		{Ticker: "NVDA", Date: day("2025-04-02"), Close: 30, SplitFactor: 1},
+	}, nil)
+	repo.On("ReplaceAll", mock.Anything, mock.Anything).Return(nil)
+
+	job := NewCredibilityJob(history, prices, repo, events.NewBus(), 90*24*time.Hour)
+	job.now = func() time.Time { return day("2025-06-01") }
+
+	credibility, err := job.Refresh(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, credibility, 1)
+	assert.Equal(t, 1.0, credibility[0].HitRate)
+	assert.InDelta(t, 20.0, credibility[0].AvgReturnPercent, 1e-9)
+	assert.InDelta(t, 1.0, credibility[0].TargetAccuracy, 1e-9)
+}
+
+func TestCloseAt(t *testing.T) {
+	history := []priceDomain.PricePoint{
+		{Date: day("2025-01-02"), Close: 100},
+		{Date: day("2025-01-03"), Close: 101},
+		{Date: day("2025-01-06"), Close: 102},
+	}
+
+	price, ok := closeAt(history, day("2025-01-03").Add(15*time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, 101.0, price)
+
+	// Weekend falls back to Friday
+	price, ok = closeAt(history, day("2025-01-05"))
+	assert.True(t, ok)
+	assert.Equal(t, 101.0, price)
+
+	_, ok = closeAt(history, day("2025-01-01"))
+	assert.False(t, ok)
+
+	_, ok = closeAt(history, day("2025-01-20"))
+	assert.False(t, ok)
+}
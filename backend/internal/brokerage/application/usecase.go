@@ -0,0 +1,163 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+)
+
+var (
+	ErrBrokerageNotFound = errors.New("brokerage not found")
+	ErrInvalidSort       = errors.New("invalid sort field")
+)
+
+var leaderboardSorts = map[string]func(e *domain.LeaderboardEntry) float64{
+	"actions":        func(e *domain.LeaderboardEntry) float64 { return float64(e.TotalActions) },
+	"coverage":       func(e *domain.LeaderboardEntry) float64 { return float64(e.TickersCovered) },
+	"bullishness":    func(e *domain.LeaderboardEntry) float64 { return e.Bullishness },
+	"upgrade_share":  func(e *domain.LeaderboardEntry) float64 { return e.UpgradeShare },
+	"revision":       func(e *domain.LeaderboardEntry) float64 { return e.AvgTargetRevisionPercent },
+	"deviation_rate": func(e *domain.LeaderboardEntry) float64 { return e.Consensus.DeviationRate },
+}
+
+type BrokerageUseCase interface {
+	GetStats(ctx context.Context, brokerage string, params domain.StatsParams) (*domain.Stats, error)
+	GetLeaderboard(ctx context.Context, params domain.LeaderboardParams) ([]*domain.LeaderboardEntry, error)
+}
+
+type brokerageUseCase struct {
+	repo domain.BrokerageRepository
+}
+
+func NewBrokerageUseCase(repo domain.BrokerageRepository) BrokerageUseCase {
+	return &brokerageUseCase{repo: repo}
+}
+
+func (uc *brokerageUseCase) GetStats(ctx context.Context, brokerage string, params domain.StatsParams) (*domain.Stats, error) {
+	if params.Bucket == "" {
+		params.Bucket = stockDomain.BucketMonth
+	}
+	if params.TopTickers <= 0 || params.TopTickers > 50 {
+		params.TopTickers = 10
+	}
+
+	summary, err := uc.repo.FindSummary(ctx, brokerage, params.Range)
+	if err != nil {
+		return nil, err
+	}
+	if summary == nil {
+		return nil, ErrBrokerageNotFound
+	}
+
+	actionCounts, err := uc.repo.CountActions(ctx, brokerage, params.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := uc.repo.FindActivity(ctx, brokerage, params.Range, params.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	topTickers, err := uc.repo.FindTopTickers(ctx, brokerage, params.Range, params.TopTickers)
+	if err != nil {
+		return nil, err
+	}
+
+	deviations, err := uc.repo.FindConsensusDeviations(ctx, brokerage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Stats{
+		Summary:      *summary,
+		Sentiment:    sentiment(summary),
+		Bucket:       params.Bucket,
+		ActionCounts: actionCounts,
+		Activity:     activity,
+		TopTickers:   topTickers,
+		Consensus:    withRate(deviations[brokerage]),
+	}, nil
+}
+
+func (uc *brokerageUseCase) GetLeaderboard(ctx context.Context, params domain.LeaderboardParams) ([]*domain.LeaderboardEntry, error) {
+	if params.SortBy == "" {
+		params.SortBy = "actions"
+	}
+	key, ok := leaderboardSorts[params.SortBy]
+	if !ok {
+		return nil, ErrInvalidSort
+	}
+	if params.Limit <= 0 || params.Limit > 100 {
+		params.Limit = 20
+	}
+	if params.MinActions < 1 {
+		params.MinActions = 1
+	}
+
+	summaries, err := uc.repo.FindSummaries(ctx, params.Range, params.MinActions)
+	if err != nil {
+		return nil, err
+	}
+
+	deviations, err := uc.repo.FindConsensusDeviations(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.LeaderboardEntry, 0, len(summaries))
+	for _, summary := range summaries {
+		entries = append(entries, &domain.LeaderboardEntry{
+			Summary:   *summary,
+			Sentiment: sentiment(summary),
+			Consensus: withRate(deviations[summary.Brokerage]),
+		})
+	}
+
+	ascending := strings.ToUpper(params.SortDir) == "ASC"
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := key(entries[i]), key(entries[j])
+		if a == b {
+			return entries[i].Brokerage < entries[j].Brokerage
+		}
+		if ascending {
+			return a < b
+		}
+		return a > b
+	})
+
+	if len(entries) > params.Limit {
+		entries = entries[:params.Limit]
+	}
+	for i, entry := range entries {
+		entry.Rank = i + 1
+	}
+
+	return entries, nil
+}
+
+func sentiment(s *domain.Summary) domain.Sentiment {
+	var result domain.Sentiment
+
+	if rated := s.Upgrades + s.Downgrades; rated > 0 {
+		result.UpgradeShare = float64(s.Upgrades) / float64(rated)
+		result.DowngradeShare = float64(s.Downgrades) / float64(rated)
+	}
+	if s.TotalActions > 0 {
+		bullish := s.Upgrades + s.TargetRaises - s.Downgrades - s.TargetLowers
+		result.Bullishness = float64(bullish) / float64(s.TotalActions)
+	}
+
+	return result
+}
+
+func withRate(d domain.ConsensusDeviation) domain.ConsensusDeviation {
+	if d.Compared > 0 {
+		d.DeviationRate = float64(d.Deviations) / float64(d.Compared)
+	}
+	return d
+}
@@ -0,0 +1,157 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock BrokerageRepository
+type MockBrokerageRepository struct {
+	mock.Mock
+}
+
+func (m *MockBrokerageRepository) FindSummary(ctx context.Context, brokerage string, rng stockDomain.TimeRange) (*domain.Summary, error) {
+	args := m.Called(ctx, brokerage, rng)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Summary), args.Error(1)
+}
+
+func (m *MockBrokerageRepository) FindSummaries(ctx context.Context, rng stockDomain.TimeRange, minActions int) ([]*domain.Summary, error) {
+	args := m.Called(ctx, rng, minActions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Summary), args.Error(1)
+}
+
+func (m *MockBrokerageRepository) CountActions(ctx context.Context, brokerage string, rng stockDomain.TimeRange) ([]domain.ActionCount, error) {
+	args := m.Called(ctx, brokerage, rng)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ActionCount), args.Error(1)
+}
+
+func (m *MockBrokerageRepository) FindActivity(ctx context.Context, brokerage string, rng stockDomain.TimeRange, bucket stockDomain.Bucket) ([]domain.ActivityPoint, error) {
+	args := m.Called(ctx, brokerage, rng, bucket)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ActivityPoint), args.Error(1)
+}
+
+func (m *MockBrokerageRepository) FindTopTickers(ctx context.Context, brokerage string, rng stockDomain.TimeRange, limit int) ([]domain.TickerCoverage, error) {
+	args := m.Called(ctx, brokerage, rng, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TickerCoverage), args.Error(1)
+}
+
+func (m *MockBrokerageRepository) FindConsensusDeviations(ctx context.Context, brokerage string) (map[string]domain.ConsensusDeviation, error) {
+	args := m.Called(ctx, brokerage)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]domain.ConsensusDeviation), args.Error(1)
+}
+
+func TestGetStats_Success(t *testing.T) {
+	mockRepo := new(MockBrokerageRepository)
+
+	summary := &domain.Summary{
+		Brokerage:    "Goldman Sachs",
+		TotalActions: 10,
+		Upgrades:     3,
+		Downgrades:   1,
+		TargetRaises: 4,
+		TargetLowers: 2,
+	}
+
+	mockRepo.On("FindSummary", mock.Anything, "Goldman Sachs", mock.Anything).Return(summary, nil)
+	mockRepo.On("CountActions", mock.Anything, "Goldman Sachs", mock.Anything).Return([]domain.ActionCount{{Action: "upgraded by", Count: 3}}, nil)
+	mockRepo.On("FindActivity", mock.Anything, "Goldman Sachs", mock.Anything, stockDomain.BucketMonth).Return([]domain.ActivityPoint{}, nil)
+	mockRepo.On("FindTopTickers", mock.Anything, "Goldman Sachs", mock.Anything, 10).Return([]domain.TickerCoverage{{Ticker: "AAPL", Actions: 2}}, nil)
+	mockRepo.On("FindConsensusDeviations", mock.Anything, "Goldman Sachs").Return(map[string]domain.ConsensusDeviation{
+		"Goldman Sachs": {Compared: 8, Deviations: 2},
+	}, nil)
+
+	uc := NewBrokerageUseCase(mockRepo)
+	stats, err := uc.GetStats(context.Background(), "Goldman Sachs", domain.StatsParams{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, stockDomain.BucketMonth, stats.Bucket)
+	assert.Equal(t, 0.75, stats.UpgradeShare)
+	assert.Equal(t, 0.25, stats.DowngradeShare)
+	assert.InDelta(t, 0.4, stats.Bullishness, 1e-9)
+	assert.Equal(t, 0.25, stats.Consensus.DeviationRate)
+	assert.Len(t, stats.TopTickers, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetStats_NotFound(t *testing.T) {
+	mockRepo := new(MockBrokerageRepository)
+
+	mockRepo.On("FindSummary", mock.Anything, "Unknown", mock.Anything).Return(nil, nil)
+
+	uc := NewBrokerageUseCase(mockRepo)
+	stats, err := uc.GetStats(context.Background(), "Unknown", domain.StatsParams{})
+
+	assert.ErrorIs(t, err, ErrBrokerageNotFound)
+	assert.Nil(t, stats)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetStats_RepoError(t *testing.T) {
+	mockRepo := new(MockBrokerageRepository)
+
+	mockRepo.On("FindSummary", mock.Anything, "Goldman Sachs", mock.Anything).Return(nil, errors.New("database error"))
+
+	uc := NewBrokerageUseCase(mockRepo)
+	_, err := uc.GetStats(context.Background(), "Goldman Sachs", domain.StatsParams{})
+
+	assert.Error(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetLeaderboard_SortAndRank(t *testing.T) {
+	mockRepo := new(MockBrokerageRepository)
+
+	summaries := []*domain.Summary{
+		{Brokerage: "A", TotalActions: 5, Upgrades: 0, Downgrades: 5},
+		{Brokerage: "B", TotalActions: 3, Upgrades: 3},
+		{Brokerage: "C", TotalActions: 9, TargetRaises: 3, TargetLowers: 3},
+	}
+
+	mockRepo.On("FindSummaries", mock.Anything, mock.Anything, 1).Return(summaries, nil)
+	mockRepo.On("FindConsensusDeviations", mock.Anything, "").Return(map[string]domain.ConsensusDeviation{}, nil)
+
+	uc := NewBrokerageUseCase(mockRepo)
+	entries, err := uc.GetLeaderboard(context.Background(), domain.LeaderboardParams{SortBy: "bullishness", Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "B", entries[0].Brokerage)
+	assert.Equal(t, 1, entries[0].Rank)
+	assert.Equal(t, "C", entries[1].Brokerage)
+	assert.Equal(t, 2, entries[1].Rank)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetLeaderboard_InvalidSort(t *testing.T) {
+	mockRepo := new(MockBrokerageRepository)
+
+	uc := NewBrokerageUseCase(mockRepo)
+	_, err := uc.GetLeaderboard(context.Background(), domain.LeaderboardParams{SortBy: "name"})
+
+	assert.ErrorIs(t, err, ErrInvalidSort)
+	mockRepo.AssertNotCalled(t, "FindSummaries")
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Directions of an analyst target relative to the close on the action day
+const (
+	DirectionUp   = "up"
+	DirectionDown = "down"
+)
+
+// AccuracyHorizons are the months after an action within which the price is
+// checked for reaching the target; the last one also bounds the excursions
+var AccuracyHorizons = []int{3, 6, 12}
+
+// Groupings of accuracy summaries
+const (
+	GroupByBrokerage = "brokerage"
+	GroupByTicker    = "ticker"
+)
+
+var ErrInvalidHorizon = errors.New("invalid horizon: use 3, 6 or 12")
+
+// ActionAccuracy records whether the price reached the target of one analyst
+// action, keyed by its action_history row. Hits are nil while the horizon has
+// not passed, or has no prices, and the target has not been reached yet.
+type ActionAccuracy struct {
+	ActionID  int64     `json:"action_id" gorm:"primaryKey;autoIncrement:false"`
+	Ticker    string    `json:"ticker" gorm:"size:10;not null;index"`
+	Brokerage string    `json:"brokerage" gorm:"size:255;not null;index"`
+	Time      time.Time `json:"time" gorm:"type:timestamp;not null"`
+	// BaseClose is the last close on or before the action day
+	BaseClose float64 `json:"base_close" gorm:"not null"`
+	TargetTo  float64 `json:"target_to" gorm:"not null"`
+	// Direction is up when the target is at or above the base close, down otherwise
+	Direction string `json:"direction" gorm:"size:4;not null"`
+	Hit3M     *bool  `json:"hit_3m" gorm:"column:hit_3m"`
+	Hit6M     *bool  `json:"hit_6m" gorm:"column:hit_6m"`
+	Hit12M    *bool  `json:"hit_12m" gorm:"column:hit_12m"`
+	// HitAt is the first day within 12 months the price reached the target
+	HitAt        *time.Time `json:"hit_at" gorm:"type:date"`
+	DaysToTarget *int       `json:"days_to_target"`
+	// MaxFavorablePercent and MaxAdversePercent are the largest moves towards
+	// and away from the target within 12 months, as non-negative percentages
+	// of the base close
+	MaxFavorablePercent float64 `json:"max_favorable_percent" gorm:"not null"`
+	MaxAdversePercent   float64 `json:"max_adverse_percent" gorm:"not null"`
+	// PricedThrough is the last day with a price within the 12 months
+	PricedThrough time.Time `json:"priced_through" gorm:"type:date;not null"`
+	EvaluatedAt   time.Time `json:"evaluated_at" gorm:"type:timestamp;not null"`
+}
+
+func (ActionAccuracy) TableName() string {
+	return "action_accuracy"
+}
+
+// SetHit sets the outcome at a horizon of AccuracyHorizons
+func (a *ActionAccuracy) SetHit(months int, hit *bool) {
+	switch months {
+	case 3:
+		a.Hit3M = hit
+	case 6:
+		a.Hit6M = hit
+	default:
+		a.Hit12M = hit
+	}
+}
+
+// AccuracySummary aggregates the action accuracy of a brokerage or a ticker.
+// Hit rates count only the actions whose outcome at the horizon is known.
+type AccuracySummary struct {
+	Brokerage    string  `json:"brokerage,omitempty"`
+	Ticker       string  `json:"ticker,omitempty"`
+	Actions      int     `json:"actions"`
+	Evaluated3M  int     `json:"evaluated_3m"`
+	Hits3M       int     `json:"hits_3m"`
+	HitRate3M    float64 `json:"hit_rate_3m"`
+	Evaluated6M  int     `json:"evaluated_6m"`
+	Hits6M       int     `json:"hits_6m"`
+	HitRate6M    float64 `json:"hit_rate_6m"`
+	Evaluated12M int     `json:"evaluated_12m"`
+	Hits12M      int     `json:"hits_12m"`
+	HitRate12M   float64 `json:"hit_rate_12m"`
+	// AvgDaysToTarget is the mean time to the target of the actions that reached it
+	AvgDaysToTarget        *float64 `json:"avg_days_to_target"`
+	AvgMaxFavorablePercent float64  `json:"avg_max_favorable_percent"`
+	AvgMaxAdversePercent   float64  `json:"avg_max_adverse_percent"`
+}
+
+// Outcome returns the evaluated actions and hit rate at a horizon of AccuracyHorizons
+func (s *AccuracySummary) Outcome(months int) (int, float64) {
+	switch months {
+	case 3:
+		return s.Evaluated3M, s.HitRate3M
+	case 6:
+		return s.Evaluated6M, s.HitRate6M
+	default:
+		return s.Evaluated12M, s.HitRate12M
+	}
+}
+
+// AccuracyRun summarises a pass of the accuracy job
+type AccuracyRun struct {
+	// Actions counts the actions scored, Skipped those with a target but no
+	// close around the action day
+	Actions     int       `json:"actions"`
+	Skipped     int       `json:"skipped"`
+	EvaluatedAt time.Time `json:"evaluated_at"`
+}
+
+// AccuracyParams filter and rank accuracy summaries by their hit rate at Horizon months
+type AccuracyParams struct {
+	Brokerage  string
+	Ticker     string
+	Horizon    int
+	MinActions int
+	Limit      int
+}
+
+// ActionAccuracyParams pages through scored actions, optionally of one brokerage and ticker
+type ActionAccuracyParams struct {
+	Brokerage string
+	Ticker    string
+	Page      int
+	Limit     int
+}
+
+// ValidHorizon reports whether months is one of AccuracyHorizons
+func ValidHorizon(months int) bool {
+	for _, h := range AccuracyHorizons {
+		if h == months {
+			return true
+		}
+	}
+	return false
+}
+
+type AccuracyRepository interface {
+	// SaveAccuracy inserts or replaces the accuracy of actions
+	SaveAccuracy(ctx context.Context, accuracy []*ActionAccuracy) error
+	// DeleteEvaluatedBefore removes the accuracy of actions a later run no longer scored
+	DeleteEvaluatedBefore(ctx context.Context, at time.Time) error
+	// FindAccuracy returns a page of scored actions, newest first, with their total
+	FindAccuracy(ctx context.Context, params ActionAccuracyParams) ([]*ActionAccuracy, int64, error)
+	// Summarize aggregates the scored actions per brokerage or per ticker, as
+	// groupBy (GroupByBrokerage or GroupByTicker) says, filtered by the params' brokerage and ticker
+	Summarize(ctx context.Context, groupBy string, params AccuracyParams) ([]*AccuracySummary, error)
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// NeutralCredibility is the score of a brokerage without an evaluated track record
+const NeutralCredibility = 0.5
+
+// Credibility measures how often and by how much a brokerage's past calls
+// were borne out by prices over the evaluation horizon
+type Credibility struct {
+	Brokerage string `json:"brokerage" gorm:"primaryKey;size:255"`
+	// Score ranges from 0 to 1 and is shrunk towards 0.5 while the track record is short
+	Score float64 `json:"score" gorm:"not null"`
+	// Weight scales the brokerage's signals in recommendations: 0.5 + score, so 1 is neutral
+	Weight float64 `json:"weight" gorm:"not null"`
+	// HitRate is the share of upgrades, downgrades and target moves the price followed
+	HitRate float64 `json:"hit_rate"`
+	// TargetAccuracy is 1 minus the mean relative distance between target and price, floored at 0
+	TargetAccuracy float64 `json:"target_accuracy"`
+	// AvgReturnPercent is the mean price return in the direction called
+	AvgReturnPercent float64   `json:"avg_return_percent"`
+	Calls            int       `json:"calls"`
+	Targets          int       `json:"targets"`
+	ComputedAt       time.Time `json:"computed_at" gorm:"type:timestamp;not null"`
+}
+
+func (Credibility) TableName() string {
+	return "brokerage_credibility"
+}
+
+type CredibilityRepository interface {
+	// ReplaceAll swaps the stored credibility for a new computation
+	ReplaceAll(ctx context.Context, credibility []*Credibility) error
+	// FindAll returns the stored credibility, most credible first
+	FindAll(ctx context.Context) ([]*Credibility, error)
+	// Weights maps each evaluated brokerage to its weight
+	Weights(ctx context.Context) (map[string]float64, error)
+}
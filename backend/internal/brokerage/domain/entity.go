@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"time"
+
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+)
+
+// Summary holds the raw activity counters of a brokerage over a time range
+type Summary struct {
+	Brokerage                string     `json:"brokerage"`
+	TotalActions             int64      `json:"total_actions"`
+	Upgrades                 int64      `json:"upgrades"`
+	Downgrades               int64      `json:"downgrades"`
+	TargetRaises             int64      `json:"target_raises"`
+	TargetLowers             int64      `json:"target_lowers"`
+	AvgTargetRevisionPercent float64    `json:"avg_target_revision_percent"`
+	TickersCovered           int64      `json:"tickers_covered"`
+	FirstActionAt            *time.Time `json:"first_action_at,omitempty"`
+	LastActionAt             *time.Time `json:"last_action_at,omitempty"`
+}
+
+// Sentiment holds the ratios derived from a Summary
+type Sentiment struct {
+	UpgradeShare   float64 `json:"upgrade_share"`
+	DowngradeShare float64 `json:"downgrade_share"`
+	// Bullishness ranges from -1 (only downgrades and target cuts) to 1 (only upgrades and raises)
+	Bullishness float64 `json:"bullishness"`
+}
+
+// ConsensusDeviation measures how often a brokerage's RatingTo departs from
+// the average rating the other brokerages give the same ticker
+type ConsensusDeviation struct {
+	Compared      int64   `json:"compared"`
+	Deviations    int64   `json:"deviations"`
+	DeviationRate float64 `json:"deviation_rate"`
+	// AvgDeviation is the signed mean distance on the rating scale; positive means more bullish than consensus
+	AvgDeviation float64 `json:"avg_deviation"`
+}
+
+type ActionCount struct {
+	Action string `json:"action"`
+	Count  int64  `json:"count"`
+}
+
+type ActivityPoint struct {
+	Period time.Time `json:"period"`
+	Action string    `json:"action"`
+	Count  int64     `json:"count"`
+}
+
+type TickerCoverage struct {
+	Ticker       string    `json:"ticker"`
+	Company      string    `json:"company"`
+	Actions      int64     `json:"actions"`
+	LastActionAt time.Time `json:"last_action_at"`
+}
+
+type Stats struct {
+	Summary
+	Sentiment
+	Bucket       stockDomain.Bucket `json:"bucket"`
+	ActionCounts []ActionCount      `json:"action_counts"`
+	Activity     []ActivityPoint    `json:"activity"`
+	TopTickers   []TickerCoverage   `json:"top_tickers"`
+	Consensus    ConsensusDeviation `json:"consensus"`
+}
+
+type LeaderboardEntry struct {
+	Rank int `json:"rank"`
+	Summary
+	Sentiment
+	Consensus ConsensusDeviation `json:"consensus"`
+}
+
+type StatsParams struct {
+	Range      stockDomain.TimeRange
+	Bucket     stockDomain.Bucket
+	TopTickers int
+}
+
+type LeaderboardParams struct {
+	Range      stockDomain.TimeRange
+	SortBy     string
+	SortDir    string
+	Limit      int
+	MinActions int
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"context"
+
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+)
+
+type BrokerageRepository interface {
+	// FindSummary returns nil when the brokerage has no actions in the range
+	FindSummary(ctx context.Context, brokerage string, rng stockDomain.TimeRange) (*Summary, error)
+	FindSummaries(ctx context.Context, rng stockDomain.TimeRange, minActions int) ([]*Summary, error)
+	CountActions(ctx context.Context, brokerage string, rng stockDomain.TimeRange) ([]ActionCount, error)
+	FindActivity(ctx context.Context, brokerage string, rng stockDomain.TimeRange, bucket stockDomain.Bucket) ([]ActivityPoint, error)
+	FindTopTickers(ctx context.Context, brokerage string, rng stockDomain.TimeRange, limit int) ([]TickerCoverage, error)
+	// FindConsensusDeviations compares current ratings; an empty brokerage returns every brokerage
+	FindConsensusDeviations(ctx context.Context, brokerage string) (map[string]ConsensusDeviation, error)
+}
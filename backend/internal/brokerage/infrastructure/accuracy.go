@@ -0,0 +1,134 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type accuracyRepository struct {
+	db *gorm.DB
+}
+
+func NewAccuracyRepository(db *gorm.DB) domain.AccuracyRepository {
+	return &accuracyRepository{db: db}
+}
+
+func (r *accuracyRepository) SaveAccuracy(ctx context.Context, accuracy []*domain.ActionAccuracy) error {
+	if len(accuracy) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "action_id"}},
+			UpdateAll: true,
+		}).
+		CreateInBatches(accuracy, 100).Error
+}
+
+func (r *accuracyRepository) DeleteEvaluatedBefore(ctx context.Context, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Where("evaluated_at < ?", at).
+		Delete(&domain.ActionAccuracy{}).Error
+}
+
+func (r *accuracyRepository) FindAccuracy(ctx context.Context, params domain.ActionAccuracyParams) ([]*domain.ActionAccuracy, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.ActionAccuracy{})
+	if params.Brokerage != "" {
+		query = query.Where("brokerage = ?", params.Brokerage)
+	}
+	if params.Ticker != "" {
+		query = query.Where("ticker = ?", params.Ticker)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var accuracy []*domain.ActionAccuracy
+	err := query.
+		Order("time DESC, action_id DESC").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).
+		Find(&accuracy).Error
+	return accuracy, total, err
+}
+
+func (r *accuracyRepository) Summarize(ctx context.Context, groupBy string, params domain.AccuracyParams) ([]*domain.AccuracySummary, error) {
+	column := "brokerage"
+	if groupBy == domain.GroupByTicker {
+		column = "ticker"
+	}
+
+	query := r.db.WithContext(ctx).
+		Model(&domain.ActionAccuracy{}).
+		Select(column + ` AS name,
+	COUNT(*) AS actions,
+	COUNT(hit_3m) AS evaluated_3m, SUM(CASE WHEN hit_3m THEN 1 ELSE 0 END) AS hits_3m,
+	COUNT(hit_6m) AS evaluated_6m, SUM(CASE WHEN hit_6m THEN 1 ELSE 0 END) AS hits_6m,
+	COUNT(hit_12m) AS evaluated_12m, SUM(CASE WHEN hit_12m THEN 1 ELSE 0 END) AS hits_12m,
+	AVG(days_to_target)::FLOAT AS avg_days_to_target,
+	AVG(max_favorable_percent)::FLOAT AS avg_max_favorable_percent,
+	AVG(max_adverse_percent)::FLOAT AS avg_max_adverse_percent`).
+		Group(column)
+	if params.Brokerage != "" {
+		query = query.Where("brokerage = ?", params.Brokerage)
+	}
+	if params.Ticker != "" {
+		query = query.Where("ticker = ?", params.Ticker)
+	}
+
+	var rows []struct {
+		Name                   string
+		Actions                int
+		Evaluated3M            int `gorm:"column:evaluated_3m"`
+		Hits3M                 int `gorm:"column:hits_3m"`
+		Evaluated6M            int `gorm:"column:evaluated_6m"`
+		Hits6M                 int `gorm:"column:hits_6m"`
+		Evaluated12M           int `gorm:"column:evaluated_12m"`
+		Hits12M                int `gorm:"column:hits_12m"`
+		AvgDaysToTarget        *float64
+		AvgMaxFavorablePercent float64
+		AvgMaxAdversePercent   float64
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*domain.AccuracySummary, 0, len(rows))
+	for _, row := range rows {
+		summary := &domain.AccuracySummary{
+			Actions:                row.Actions,
+			Evaluated3M:            row.Evaluated3M,
+			Hits3M:                 row.Hits3M,
+			HitRate3M:              rate(row.Hits3M, row.Evaluated3M),
+			Evaluated6M:            row.Evaluated6M,
+			Hits6M:                 row.Hits6M,
+			HitRate6M:              rate(row.Hits6M, row.Evaluated6M),
+			Evaluated12M:           row.Evaluated12M,
+			Hits12M:                row.Hits12M,
+			HitRate12M:             rate(row.Hits12M, row.Evaluated12M),
+			AvgDaysToTarget:        row.AvgDaysToTarget,
+			AvgMaxFavorablePercent: row.AvgMaxFavorablePercent,
+			AvgMaxAdversePercent:   row.AvgMaxAdversePercent,
+		}
+		if column == "ticker" {
+			summary.Ticker = row.Name
+		} else {
+			summary.Brokerage = row.Name
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+func rate(hits, evaluated int) float64 {
+	if evaluated == 0 {
+		return 0
+	}
+	return float64(hits) / float64(evaluated)
+}
@@ -0,0 +1,56 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	"gorm.io/gorm"
+)
+
+type credibilityRepository struct {
+	db *gorm.DB
+}
+
+func NewCredibilityRepository(db *gorm.DB) domain.CredibilityRepository {
+	return &credibilityRepository{db: db}
+}
+
+func (r *credibilityRepository) ReplaceAll(ctx context.Context, credibility []*domain.Credibility) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&domain.Credibility{}).Error; err != nil {
+			return err
+		}
+		if len(credibility) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(credibility, 100).Error
+	})
+}
+
+func (r *credibilityRepository) FindAll(ctx context.Context) ([]*domain.Credibility, error) {
+	var credibility []*domain.Credibility
+	err := r.db.WithContext(ctx).
+		Order("score DESC, brokerage ASC").
+		Find(&credibility).Error
+	return credibility, err
+}
+
+func (r *credibilityRepository) Weights(ctx context.Context) (map[string]float64, error) {
+	var rows []struct {
+		Brokerage string
+		Weight    float64
+	}
+	err := r.db.WithContext(ctx).
+		Model(&domain.Credibility{}).
+		Select("brokerage, weight").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		weights[row.Brokerage] = row.Weight
+	}
+	return weights, nil
+}
@@ -0,0 +1,157 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	stockInfra "github.com/bryanriosb/stock-info/internal/stock/infrastructure"
+	"gorm.io/gorm"
+)
+
+// deviationThreshold is the distance on the 1-9 rating scale (e.g. Buy vs Hold)
+// from which a rating counts as deviating from consensus
+const deviationThreshold = 2.0
+
+const summarySelect = `brokerage,
+	COUNT(*) AS total_actions,
+	SUM(CASE WHEN action ILIKE '%upgrade%' THEN 1 ELSE 0 END) AS upgrades,
+	SUM(CASE WHEN action ILIKE '%downgrade%' THEN 1 ELSE 0 END) AS downgrades,
+	SUM(CASE WHEN action ILIKE '%raise%' THEN 1 ELSE 0 END) AS target_raises,
+	SUM(CASE WHEN action ILIKE '%lower%' THEN 1 ELSE 0 END) AS target_lowers,
+	COALESCE(AVG(CASE WHEN target_from > 0 THEN (target_to - target_from) / target_from * 100 END), 0)::FLOAT AS avg_target_revision_percent,
+	COUNT(DISTINCT ticker) AS tickers_covered,
+	MIN(time) AS first_action_at,
+	MAX(time) AS last_action_at`
+
+type brokerageRepository struct {
+	db *gorm.DB
+}
+
+func NewBrokerageRepository(db *gorm.DB) domain.BrokerageRepository {
+	return &brokerageRepository{db: db}
+}
+
+func (r *brokerageRepository) FindSummary(ctx context.Context, brokerage string, rng stockDomain.TimeRange) (*domain.Summary, error) {
+	var summaries []*domain.Summary
+	err := r.actions(ctx, brokerage, rng).
+		Select(summarySelect).
+		Group("brokerage").
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 {
+		return nil, nil
+	}
+	return summaries[0], nil
+}
+
+func (r *brokerageRepository) FindSummaries(ctx context.Context, rng stockDomain.TimeRange, minActions int) ([]*domain.Summary, error) {
+	var summaries []*domain.Summary
+	err := r.actions(ctx, "", rng).
+		Where("brokerage <> ''").
+		Select(summarySelect).
+		Group("brokerage").
+		Having("COUNT(*) >= ?", minActions).
+		Scan(&summaries).Error
+	return summaries, err
+}
+
+func (r *brokerageRepository) CountActions(ctx context.Context, brokerage string, rng stockDomain.TimeRange) ([]domain.ActionCount, error) {
+	var counts []domain.ActionCount
+	err := r.actions(ctx, brokerage, rng).
+		Select("action, COUNT(*) AS count").
+		Group("action").
+		Order("count DESC, action ASC").
+		Scan(&counts).Error
+	return counts, err
+}
+
+func (r *brokerageRepository) FindActivity(ctx context.Context, brokerage string, rng stockDomain.TimeRange, bucket stockDomain.Bucket) ([]domain.ActivityPoint, error) {
+	var points []domain.ActivityPoint
+	err := r.actions(ctx, brokerage, rng).
+		Select(fmt.Sprintf("date_trunc('%s', time) AS period, action, COUNT(*) AS count", bucketUnit(bucket))).
+		Group("1, 2").
+		Order("1 ASC, 2 ASC").
+		Scan(&points).Error
+	return points, err
+}
+
+func (r *brokerageRepository) FindTopTickers(ctx context.Context, brokerage string, rng stockDomain.TimeRange, limit int) ([]domain.TickerCoverage, error) {
+	var tickers []domain.TickerCoverage
+	err := r.actions(ctx, brokerage, rng).
+		Select("ticker, MAX(company) AS company, COUNT(*) AS actions, MAX(time) AS last_action_at").
+		Group("ticker").
+		Order("actions DESC, last_action_at DESC").
+		Limit(limit).
+		Scan(&tickers).Error
+	return tickers, err
+}
+
+func (r *brokerageRepository) FindConsensusDeviations(ctx context.Context, brokerage string) (map[string]domain.ConsensusDeviation, error) {
+	// Leave-one-out consensus: each rating is compared with the mean of the
+	// other brokerages covering the same ticker
+	query := `
+WITH scored AS (
+	SELECT ticker, brokerage, ` + stockInfra.RatingValueSQL("rating_to") + ` AS rating_value
+	FROM stocks
+), consensus AS (
+	SELECT ticker, SUM(rating_value) AS total, COUNT(rating_value) AS rated
+	FROM scored
+	GROUP BY ticker
+)
+SELECT s.brokerage,
+	COUNT(*) AS compared,
+	SUM(CASE WHEN abs(s.rating_value - (c.total - s.rating_value)::FLOAT / (c.rated - 1)) >= ? THEN 1 ELSE 0 END) AS deviations,
+	AVG(s.rating_value - (c.total - s.rating_value)::FLOAT / (c.rated - 1))::FLOAT AS avg_deviation
+FROM scored s
+JOIN consensus c ON c.ticker = s.ticker
+WHERE s.rating_value IS NOT NULL AND c.rated > 1 AND (? = '' OR s.brokerage = ?)
+GROUP BY s.brokerage`
+
+	var rows []struct {
+		Brokerage    string
+		Compared     int64
+		Deviations   int64
+		AvgDeviation float64
+	}
+	if err := r.db.WithContext(ctx).Raw(query, deviationThreshold, brokerage, brokerage).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	deviations := make(map[string]domain.ConsensusDeviation, len(rows))
+	for _, row := range rows {
+		deviations[row.Brokerage] = domain.ConsensusDeviation{
+			Compared:     row.Compared,
+			Deviations:   row.Deviations,
+			AvgDeviation: row.AvgDeviation,
+		}
+	}
+	return deviations, nil
+}
+
+// actions scopes a query to the stocks of a brokerage (all when empty) within a time range
+func (r *brokerageRepository) actions(ctx context.Context, brokerage string, rng stockDomain.TimeRange) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&stockDomain.Stock{})
+	if brokerage != "" {
+		query = query.Where("brokerage = ?", brokerage)
+	}
+	if !rng.From.IsZero() {
+		query = query.Where("time >= ?", rng.From)
+	}
+	if !rng.To.IsZero() {
+		query = query.Where("time < ?", rng.To)
+	}
+	return query
+}
+
+func bucketUnit(bucket stockDomain.Bucket) string {
+	switch bucket {
+	case stockDomain.BucketDay, stockDomain.BucketWeek:
+		return string(bucket)
+	default:
+		return string(stockDomain.BucketMonth)
+	}
+}
@@ -0,0 +1,86 @@
+package interfaces
+
+import (
+	"errors"
+
+	"github.com/bryanriosb/stock-info/internal/brokerage/application"
+	"github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	"github.com/bryanriosb/stock-info/shared/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AccuracyHandler struct {
+	accuracy application.AccuracyUseCase
+}
+
+func NewAccuracyHandler(accuracy application.AccuracyUseCase) *AccuracyHandler {
+	return &AccuracyHandler{accuracy: accuracy}
+}
+
+// GetBrokerageAccuracy ranks brokerages by how often their targets were reached
+func (h *AccuracyHandler) GetBrokerageAccuracy(c *fiber.Ctx) error {
+	return h.summarize(c, domain.GroupByBrokerage)
+}
+
+// GetTickerAccuracy ranks tickers by how often the targets set on them were reached
+func (h *AccuracyHandler) GetTickerAccuracy(c *fiber.Ctx) error {
+	return h.summarize(c, domain.GroupByTicker)
+}
+
+func (h *AccuracyHandler) summarize(c *fiber.Ctx, groupBy string) error {
+	summaries, err := h.accuracy.Summarize(c.Context(), groupBy, domain.AccuracyParams{
+		Brokerage:  c.Query("brokerage"),
+		Ticker:     c.Query("ticker"),
+		Horizon:    c.QueryInt("horizon", 12),
+		MinActions: c.QueryInt("min_actions", 1),
+		Limit:      c.QueryInt("limit", 50),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidHorizon) {
+			return response.BadRequest(c, err.Error())
+		}
+		return response.InternalError(c, "Failed to fetch target accuracy")
+	}
+	return response.Success(c, summaries)
+}
+
+// ListActionAccuracy pages through the scored actions, optionally of one brokerage and ticker
+func (h *AccuracyHandler) ListActionAccuracy(c *fiber.Ctx) error {
+	params := domain.ActionAccuracyParams{
+		Brokerage: c.Query("brokerage"),
+		Ticker:    c.Query("ticker"),
+		Page:      c.QueryInt("page", 1),
+		Limit:     c.QueryInt("limit", 20),
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 20
+	}
+
+	accuracy, total, err := h.accuracy.ListAccuracy(c.Context(), params)
+	if err != nil {
+		return response.InternalError(c, "Failed to fetch action accuracy")
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+	return response.SuccessWithMeta(c, accuracy, &response.Meta{
+		Page:       params.Page,
+		Limit:      params.Limit,
+		Total:      total,
+		TotalPages: totalPages,
+	})
+}
+
+// RefreshAccuracy scores every action now without waiting for the background job
+func (h *AccuracyHandler) RefreshAccuracy(c *fiber.Ctx) error {
+	run, err := h.accuracy.Refresh(c.Context())
+	if err != nil {
+		return response.InternalError(c, "Failed to refresh target accuracy")
+	}
+	return response.Success(c, run)
+}
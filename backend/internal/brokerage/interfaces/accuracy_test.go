@@ -0,0 +1,130 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	"github.com/bryanriosb/stock-info/shared/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock AccuracyUseCase
+type MockAccuracyUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAccuracyUseCase) ListAccuracy(ctx context.Context, params domain.ActionAccuracyParams) ([]*domain.ActionAccuracy, int64, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.ActionAccuracy), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAccuracyUseCase) Summarize(ctx context.Context, groupBy string, params domain.AccuracyParams) ([]*domain.AccuracySummary, error) {
+	args := m.Called(ctx, groupBy, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AccuracySummary), args.Error(1)
+}
+
+func (m *MockAccuracyUseCase) Refresh(ctx context.Context) (*domain.AccuracyRun, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AccuracyRun), args.Error(1)
+}
+
+func setupAccuracyApp(handler *AccuracyHandler) *fiber.App {
+	app := fiber.New()
+	app.Get("/brokerages/accuracy", handler.GetBrokerageAccuracy)
+	app.Get("/brokerages/accuracy/tickers", handler.GetTickerAccuracy)
+	app.Get("/brokerages/accuracy/actions", handler.ListActionAccuracy)
+	app.Post("/brokerages/accuracy/refresh", handler.RefreshAccuracy)
+	return app
+}
+
+func TestGetBrokerageAccuracy_Success(t *testing.T) {
+	mockUC := new(MockAccuracyUseCase)
+	app := setupAccuracyApp(NewAccuracyHandler(mockUC))
+
+	mockUC.On("Summarize", mock.Anything, domain.GroupByBrokerage, domain.AccuracyParams{Ticker: "AAPL", Horizon: 6, MinActions: 3, Limit: 10}).
+		Return([]*domain.AccuracySummary{{Brokerage: "Goldman Sachs", Actions: 4, Evaluated6M: 4, Hits6M: 3, HitRate6M: 0.75}}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/brokerages/accuracy?ticker=AAPL&horizon=6&min_actions=3&limit=10", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result response.Response
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	assert.True(t, result.Success)
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetTickerAccuracy_InvalidHorizon(t *testing.T) {
+	mockUC := new(MockAccuracyUseCase)
+	app := setupAccuracyApp(NewAccuracyHandler(mockUC))
+
+	mockUC.On("Summarize", mock.Anything, domain.GroupByTicker, mock.Anything).Return(nil, domain.ErrInvalidHorizon)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/brokerages/accuracy/tickers?horizon=9", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestListActionAccuracy_Success(t *testing.T) {
+	mockUC := new(MockAccuracyUseCase)
+	app := setupAccuracyApp(NewAccuracyHandler(mockUC))
+
+	mockUC.On("ListAccuracy", mock.Anything, domain.ActionAccuracyParams{Brokerage: "UBS", Page: 2, Limit: 5}).
+		Return([]*domain.ActionAccuracy{{ActionID: 7, Ticker: "AAPL", Brokerage: "UBS"}}, int64(6), nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/brokerages/accuracy/actions?brokerage=UBS&page=2&limit=5", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result response.Response
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	assert.True(t, result.Success)
+	assert.Equal(t, int64(6), result.Meta.Total)
+	assert.Equal(t, 2, result.Meta.TotalPages)
+	mockUC.AssertExpectations(t)
+}
+
+func TestRefreshAccuracy(t *testing.T) {
+	mockUC := new(MockAccuracyUseCase)
+	app := setupAccuracyApp(NewAccuracyHandler(mockUC))
+
+	mockUC.On("Refresh", mock.Anything).Return(&domain.AccuracyRun{Actions: 3}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/brokerages/accuracy/refresh", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestRefreshAccuracy_Error(t *testing.T) {
+	mockUC := new(MockAccuracyUseCase)
+	app := setupAccuracyApp(NewAccuracyHandler(mockUC))
+
+	mockUC.On("Refresh", mock.Anything).Return(nil, errors.New("db down"))
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/brokerages/accuracy/refresh", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
@@ -0,0 +1,93 @@
+package interfaces
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/bryanriosb/stock-info/internal/brokerage/application"
+	"github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/shared/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	useCase     application.BrokerageUseCase
+	credibility application.CredibilityUseCase
+}
+
+func NewHandler(useCase application.BrokerageUseCase, credibility application.CredibilityUseCase) *Handler {
+	return &Handler{useCase: useCase, credibility: credibility}
+}
+
+func (h *Handler) GetStats(c *fiber.Ctx) error {
+	name, err := url.PathUnescape(c.Params("name"))
+	if err != nil || name == "" {
+		return response.BadRequest(c, "Invalid brokerage name")
+	}
+
+	rng, err := stockDomain.ParseTimeRange(c.Query("from"), c.Query("to"))
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	bucket, err := stockDomain.ParseBucket(c.Query("bucket"), stockDomain.BucketMonth)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	stats, err := h.useCase.GetStats(c.Context(), name, domain.StatsParams{
+		Range:      rng,
+		Bucket:     bucket,
+		TopTickers: c.QueryInt("top", 10),
+	})
+	if err != nil {
+		if errors.Is(err, application.ErrBrokerageNotFound) {
+			return response.NotFound(c, "Brokerage not found")
+		}
+		return response.InternalError(c, "Failed to fetch brokerage stats")
+	}
+
+	return response.Success(c, stats)
+}
+
+func (h *Handler) GetLeaderboard(c *fiber.Ctx) error {
+	rng, err := stockDomain.ParseTimeRange(c.Query("from"), c.Query("to"))
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	entries, err := h.useCase.GetLeaderboard(c.Context(), domain.LeaderboardParams{
+		Range:      rng,
+		SortBy:     c.Query("sort_by", "actions"),
+		SortDir:    c.Query("sort_dir", "desc"),
+		Limit:      c.QueryInt("limit", 20),
+		MinActions: c.QueryInt("min_actions", 1),
+	})
+	if err != nil {
+		if errors.Is(err, application.ErrInvalidSort) {
+			return response.BadRequest(c, "Invalid sort_by: use actions, coverage, bullishness, upgrade_share, revision or deviation_rate")
+		}
+		return response.InternalError(c, "Failed to fetch brokerage leaderboard")
+	}
+
+	return response.Success(c, entries)
+}
+
+// GetCredibility lists the credibility learned by the last refresh
+func (h *Handler) GetCredibility(c *fiber.Ctx) error {
+	credibility, err := h.credibility.GetCredibility(c.Context())
+	if err != nil {
+		return response.InternalError(c, "Failed to fetch brokerage credibility")
+	}
+	return response.Success(c, credibility)
+}
+
+// RefreshCredibility recomputes credibility without waiting for the background job
+func (h *Handler) RefreshCredibility(c *fiber.Ctx) error {
+	credibility, err := h.credibility.Refresh(c.Context())
+	if err != nil {
+		return response.InternalError(c, "Failed to refresh brokerage credibility")
+	}
+	return response.Success(c, credibility)
+}
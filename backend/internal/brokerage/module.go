@@ -0,0 +1,52 @@
+package brokerage
+
+import (
+	"context"
+
+	"github.com/bryanriosb/stock-info/internal/brokerage/application"
+	"github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	"github.com/bryanriosb/stock-info/internal/brokerage/infrastructure"
+	"github.com/bryanriosb/stock-info/internal/brokerage/interfaces"
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
+	stockInfra "github.com/bryanriosb/stock-info/internal/stock/infrastructure"
+	"github.com/bryanriosb/stock-info/shared"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/bryanriosb/stock-info/shared/middleware"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Register mounts the brokerage analytics and starts the credibility and
+// target accuracy jobs, which also run after every sync and price import. It
+// returns the learned credibility for the recommendation module. The jobs stop
+// once ctx is done.
+func Register(ctx context.Context, app fiber.Router, db *gorm.DB, cfg *shared.Config, bus *events.Bus, prices priceDomain.HistoryProvider) domain.CredibilityRepository {
+	repo := infrastructure.NewBrokerageRepository(db)
+	useCase := application.NewBrokerageUseCase(repo)
+
+	credibilityRepo := infrastructure.NewCredibilityRepository(db)
+	job := application.NewCredibilityJob(stockInfra.NewActionHistoryRepository(db), prices, credibilityRepo, bus, cfg.Credibility.Horizon)
+	bus.Subscribe(events.SyncCompleted, func(events.Event) { job.Trigger() })
+	bus.Subscribe(events.PricesImported, func(events.Event) { job.Trigger() })
+	job.Start(ctx, cfg.Credibility.Interval)
+
+	accuracy := application.NewAccuracyJob(stockInfra.NewActionHistoryRepository(db), prices, infrastructure.NewAccuracyRepository(db), bus)
+	bus.Subscribe(events.SyncCompleted, func(events.Event) { accuracy.Trigger() })
+	bus.Subscribe(events.PricesImported, func(events.Event) { accuracy.Trigger() })
+	accuracy.Start(ctx, cfg.Accuracy.Interval)
+
+	handler := interfaces.NewHandler(useCase, job)
+	accuracyHandler := interfaces.NewAccuracyHandler(accuracy)
+
+	group := app.Group("/brokerages")
+	group.Get("/leaderboard", handler.GetLeaderboard)
+	group.Get("/credibility", handler.GetCredibility)
+	group.Post("/credibility/refresh", middleware.RequireAdmin(), handler.RefreshCredibility)
+	group.Get("/accuracy", accuracyHandler.GetBrokerageAccuracy)
+	group.Get("/accuracy/tickers", accuracyHandler.GetTickerAccuracy)
+	group.Get("/accuracy/actions", accuracyHandler.ListActionAccuracy)
+	group.Post("/accuracy/refresh", middleware.RequireAdmin(), accuracyHandler.RefreshAccuracy)
+	group.Get("/:name/stats", handler.GetStats)
+
+	return credibilityRepo
+}
@@ -0,0 +1,95 @@
+package interfaces
+
+import (
+	"errors"
+	"strings"
+
+	ratingDomain "github.com/bryanriosb/stock-info/internal/rating/domain"
+	recommendationApp "github.com/bryanriosb/stock-info/internal/recommendation/application"
+	stockApp "github.com/bryanriosb/stock-info/internal/stock/application"
+	userApp "github.com/bryanriosb/stock-info/internal/user/application"
+	"github.com/bryanriosb/stock-info/shared/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+type Handler struct {
+	schema       graphql.Schema
+	limits       Limits
+	stockUseCase stockApp.StockUseCase
+}
+
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func NewHandler(
+	stockUseCase stockApp.StockUseCase,
+	ratingRepo ratingDomain.RatingOptionRepository,
+	recommendationUseCase recommendationApp.RecommendationUseCase,
+	userUseCase userApp.UserUseCase,
+	limits Limits,
+) (*Handler, error) {
+	schema, err := newSchema(&resolver{
+		stockUseCase:          stockUseCase,
+		ratingRepo:            ratingRepo,
+		recommendationUseCase: recommendationUseCase,
+		userUseCase:           userUseCase,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{schema: schema, limits: limits, stockUseCase: stockUseCase}, nil
+}
+
+// Execute runs a GraphQL query. Requests that cannot be parsed, are invalid or
+// exceed the depth/complexity limits are rejected with 400 before any resolver runs;
+// executed queries answer 200 with partial data and errors per the GraphQL spec.
+func (h *Handler) Execute(c *fiber.Ctx) error {
+	var req Request
+	if err := c.BodyParser(&req); err != nil {
+		return requestError(c, "Invalid request body")
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		return requestError(c, "Query is required")
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})})
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(&graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+	}
+
+	depth, complexity, err := analyze(doc, req.OperationName, req.Variables)
+	if err == nil {
+		err = h.limits.check(depth, complexity)
+	}
+	if err != nil {
+		return requestError(c, err.Error())
+	}
+
+	if validation := graphql.ValidateDocument(&h.schema, doc, nil); !validation.IsValid {
+		return c.Status(fiber.StatusBadRequest).JSON(&graphql.Result{Errors: validation.Errors})
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withRequest(c.UserContext(), middleware.GetUserFromToken(c), middleware.GetRoleFromToken(c), h.stockUseCase),
+	})
+
+	return c.JSON(result)
+}
+
+func requestError(c *fiber.Ctx, message string) error {
+	return c.Status(fiber.StatusBadRequest).JSON(&graphql.Result{
+		Errors: gqlerrors.FormatErrors(errors.New(message)),
+	})
+}
@@ -0,0 +1,451 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ratingDomain "github.com/bryanriosb/stock-info/internal/rating/domain"
+	recommendationDomain "github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockApp "github.com/bryanriosb/stock-info/internal/stock/application"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/internal/stock/infrastructure"
+	userApp "github.com/bryanriosb/stock-info/internal/user/application"
+	userDomain "github.com/bryanriosb/stock-info/internal/user/domain"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock StockUseCase
+type MockStockUseCase struct {
+	mock.Mock
+}
+
+func (m *MockStockUseCase) SyncStocks(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStockUseCase) SyncStocksWithProgress(ctx context.Context, onProgress infrastructure.ProgressCallback) (int, error) {
+	args := m.Called(ctx, onProgress)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStockUseCase) GetStocks(ctx context.Context, params stockDomain.QueryParams) ([]*stockDomain.Stock, int64, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*stockDomain.Stock), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockStockUseCase) GetFacets(ctx context.Context, params stockDomain.QueryParams, facets stockDomain.FacetParams) (stockDomain.Facets, error) {
+	args := m.Called(ctx, params, facets)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(stockDomain.Facets), args.Error(1)
+}
+
+func (m *MockStockUseCase) GetIncludes(ctx context.Context, stocks []*stockDomain.Stock, includes []string) (*stockDomain.Includes, error) {
+	args := m.Called(ctx, stocks, includes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*stockDomain.Includes), args.Error(1)
+}
+
+func (m *MockStockUseCase) GetStockByID(ctx context.Context, id int64) (*stockDomain.Stock, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*stockDomain.Stock), args.Error(1)
+}
+
+func (m *MockStockUseCase) GetTimeline(ctx context.Context, ticker string, params stockDomain.TimelineParams) (*stockDomain.Timeline, int64, error) {
+	args := m.Called(ctx, ticker, params)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).(*stockDomain.Timeline), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockStockUseCase) LookupStocks(ctx context.Context, params stockDomain.LookupParams) (*stockDomain.LookupResult, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*stockDomain.LookupResult), args.Error(1)
+}
+
+// Mock RatingOptionRepository
+type MockRatingOptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRatingOptionRepository) FindByLabel(ctx context.Context, label string) (*ratingDomain.RatingOption, error) {
+	args := m.Called(ctx, label)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ratingDomain.RatingOption), args.Error(1)
+}
+
+func (m *MockRatingOptionRepository) FindAll(ctx context.Context) ([]*ratingDomain.RatingOption, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*ratingDomain.RatingOption), args.Error(1)
+}
+
+func (m *MockRatingOptionRepository) Create(ctx context.Context, option *ratingDomain.RatingOption) error {
+	return m.Called(ctx, option).Error(0)
+}
+
+func (m *MockRatingOptionRepository) Upsert(ctx context.Context, option *ratingDomain.RatingOption) error {
+	return m.Called(ctx, option).Error(0)
+}
+
+// Mock RecommendationUseCase
+type MockRecommendationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRecommendationUseCase) GetRecommendations(ctx context.Context, query recommendationDomain.RecommendationQuery) ([]*recommendationDomain.StockRecommendation, int64, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*recommendationDomain.StockRecommendation), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRecommendationUseCase) ExplainTicker(ctx context.Context, ticker string, query recommendationDomain.RecommendationQuery) (*recommendationDomain.Explanation, error) {
+	args := m.Called(ctx, ticker, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recommendationDomain.Explanation), args.Error(1)
+}
+
+func (m *MockRecommendationUseCase) TopRecommendations(ctx context.Context, query recommendationDomain.RecommendationQuery, n int) ([]*recommendationDomain.StockRecommendation, error) {
+	args := m.Called(ctx, query, n)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*recommendationDomain.StockRecommendation), args.Error(1)
+}
+
+func (m *MockRecommendationUseCase) RankActions(ctx context.Context, query recommendationDomain.RecommendationQuery, tickers [][]*stockDomain.Stock, now time.Time) ([]*recommendationDomain.StockRecommendation, error) {
+	args := m.Called(ctx, query, tickers, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*recommendationDomain.StockRecommendation), args.Error(1)
+}
+
+func (m *MockRecommendationUseCase) GetStrategies() []recommendationDomain.StrategyInfo {
+	args := m.Called()
+	return args.Get(0).([]recommendationDomain.StrategyInfo)
+}
+
+// Mock UserUseCase
+type MockUserUseCase struct {
+	mock.Mock
+}
+
+func (m *MockUserUseCase) Create(ctx context.Context, req userApp.CreateUserRequest) (*userDomain.User, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) GetByID(ctx context.Context, id int64) (*userDomain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) GetAll(ctx context.Context) ([]*userDomain.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*userDomain.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) GetPage(ctx context.Context, page, limit int) ([]*userDomain.User, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*userDomain.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) Update(ctx context.Context, id int64, req userApp.UpdateUserRequest) (*userDomain.User, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) Delete(ctx context.Context, id int64) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockUserUseCase) Authenticate(ctx context.Context, username, password string) (*userDomain.User, error) {
+	args := m.Called(ctx, username, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+type testMocks struct {
+	stocks          *MockStockUseCase
+	ratings         *MockRatingOptionRepository
+	recommendations *MockRecommendationUseCase
+	users           *MockUserUseCase
+}
+
+func setupTestApp(t *testing.T, role string, limits Limits) (*fiber.App, testMocks) {
+	mocks := testMocks{
+		stocks:          new(MockStockUseCase),
+		ratings:         new(MockRatingOptionRepository),
+		recommendations: new(MockRecommendationUseCase),
+		users:           new(MockUserUseCase),
+	}
+
+	handler, err := NewHandler(mocks.stocks, mocks.ratings, mocks.recommendations, mocks.users, limits)
+	assert.NoError(t, err)
+
+	app := fiber.New()
+	// Stand-in for middleware.JWTProtected
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{"sub": "1", "role": role}})
+		return c.Next()
+	})
+	app.Post("/graphql", handler.Execute)
+
+	return app, mocks
+}
+
+type graphResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func query(t *testing.T, app *fiber.App, body string) (int, graphResponse) {
+	req := httptest.NewRequest("POST", "/graphql", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+
+	var result graphResponse
+	json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
+
+func TestExecute_StocksWithConsensus(t *testing.T) {
+	app, mocks := setupTestApp(t, "user", DefaultLimits)
+
+	stocks := []*stockDomain.Stock{
+		{ID: 1, Ticker: "AAPL", Company: "Apple Inc."},
+		{ID: 2, Ticker: "MSFT", Company: "Microsoft"},
+	}
+	mocks.stocks.On("GetStocks", mock.Anything, stockDomain.QueryParams{
+		Page: 2, Limit: 2, SortBy: "time", SortDir: "desc", Search: "a",
+	}).Return(stocks, int64(5), nil)
+	// Consensus for the whole page is loaded once
+	mocks.stocks.On("GetIncludes", mock.Anything, stocks, []string{stockDomain.IncludeConsensus}).Return(&stockDomain.Includes{
+		Consensus: map[string]*stockDomain.Consensus{"AAPL": {Ticker: "AAPL", Rating: "Buy"}},
+	}, nil).Once()
+
+	status, result := query(t, app, `{"query":"{ stocks(page: 2, limit: 2, search: \"a\") { total total_pages items { id ticker consensus { rating } } } }"}`)
+
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Empty(t, result.Errors)
+	page := result.Data["stocks"].(map[string]interface{})
+	assert.Equal(t, float64(5), page["total"])
+	assert.Equal(t, float64(3), page["total_pages"])
+	items := page["items"].([]interface{})
+	assert.Len(t, items, 2)
+	assert.Equal(t, "1", items[0].(map[string]interface{})["id"])
+	assert.Equal(t, "Buy", items[0].(map[string]interface{})["consensus"].(map[string]interface{})["rating"])
+	assert.Nil(t, items[1].(map[string]interface{})["consensus"])
+	mocks.stocks.AssertExpectations(t)
+}
+
+func TestExecute_TickerWithRatingsAndRecommendation(t *testing.T) {
+	app, mocks := setupTestApp(t, "user", DefaultLimits)
+
+	mocks.stocks.On("GetTimeline", mock.Anything, "aapl", stockDomain.TimelineParams{Page: 1, Limit: 1}).
+		Return(&stockDomain.Timeline{Ticker: "AAPL", Company: "Apple Inc."}, int64(2), nil)
+	mocks.stocks.On("GetTimeline", mock.Anything, "AAPL", stockDomain.TimelineParams{Page: 1, Limit: 20}).
+		Return(&stockDomain.Timeline{Ticker: "AAPL", Events: []stockDomain.TimelineEvent{{ID: 7, Brokerage: "Goldman Sachs", RatingTo: "Buy"}}}, int64(1), nil)
+	mocks.recommendations.On("ExplainTicker", mock.Anything, "AAPL", recommendationDomain.RecommendationQuery{Username: "1"}).Return(&recommendationDomain.Explanation{
+		StockRecommendation: &recommendationDomain.StockRecommendation{
+			Ticker:      "AAPL",
+			Stock:       &stockDomain.Stock{Ticker: "AAPL"},
+			Score:       0.5,
+			Aggregation: recommendationDomain.AggregationWeighted,
+			Brokerages:  []recommendationDomain.BrokerageSignal{{Brokerage: "Goldman Sachs", Score: 0.5}},
+		},
+	}, nil)
+
+	status, result := query(t, app, `{"query":"{ ticker(symbol: \"aapl\") { symbol company ratings { brokerage rating_to } recommendation { score aggregation brokerages { brokerage } } } }"}`)
+
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Empty(t, result.Errors)
+	ticker := result.Data["ticker"].(map[string]interface{})
+	assert.Equal(t, "AAPL", ticker["symbol"])
+	assert.Equal(t, "Goldman Sachs", ticker["ratings"].([]interface{})[0].(map[string]interface{})["brokerage"])
+	recommendation := ticker["recommendation"].(map[string]interface{})
+	assert.Equal(t, 0.5, recommendation["score"])
+	assert.Equal(t, "weighted", recommendation["aggregation"])
+	assert.Equal(t, "Goldman Sachs", recommendation["brokerages"].([]interface{})[0].(map[string]interface{})["brokerage"])
+	mocks.stocks.AssertExpectations(t)
+	mocks.recommendations.AssertExpectations(t)
+}
+
+func TestExecute_TickerNotRecommended(t *testing.T) {
+	app, mocks := setupTestApp(t, "user", DefaultLimits)
+
+	mocks.stocks.On("GetTimeline", mock.Anything, "AAPL", stockDomain.TimelineParams{Page: 1, Limit: 1}).
+		Return(&stockDomain.Timeline{Ticker: "AAPL"}, int64(1), nil)
+	mocks.recommendations.On("ExplainTicker", mock.Anything, "AAPL", recommendationDomain.RecommendationQuery{Strategy: "momentum", Username: "1"}).
+		Return(nil, recommendationDomain.ErrTickerNotRecommended)
+
+	status, result := query(t, app, `{"query":"{ ticker(symbol: \"AAPL\") { symbol recommendation(strategy: \"momentum\") { score } } }"}`)
+
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Empty(t, result.Errors)
+	assert.Nil(t, result.Data["ticker"].(map[string]interface{})["recommendation"])
+	mocks.recommendations.AssertExpectations(t)
+}
+
+func TestExecute_RecommendationsAsCaller(t *testing.T) {
+	app, mocks := setupTestApp(t, "user", DefaultLimits)
+
+	// The caller is passed on so the use case applies their scoring profile
+	mocks.recommendations.On("GetRecommendations", mock.Anything, recommendationDomain.RecommendationQuery{Page: 1, Limit: 5, Username: "1"}).
+		Return([]*recommendationDomain.StockRecommendation{{Ticker: "AAPL", Score: 0.5}}, int64(1), nil)
+
+	status, result := query(t, app, `{"query":"{ recommendations(limit: 5) { score } }"}`)
+
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Empty(t, result.Errors)
+	assert.Len(t, result.Data["recommendations"], 1)
+	mocks.recommendations.AssertExpectations(t)
+}
+
+func TestExecute_TickerNotFound(t *testing.T) {
+	app, mocks := setupTestApp(t, "user", DefaultLimits)
+
+	mocks.stocks.On("GetTimeline", mock.Anything, "NOPE", mock.Anything).Return(nil, int64(0), stockApp.ErrTickerNotFound)
+
+	status, result := query(t, app, `{"query":"{ ticker(symbol: \"NOPE\") { symbol } }"}`)
+
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Empty(t, result.Errors)
+	assert.Nil(t, result.Data["ticker"])
+}
+
+func TestExecute_UsersRequireAdmin(t *testing.T) {
+	app, mocks := setupTestApp(t, "user", DefaultLimits)
+
+	status, result := query(t, app, `{"query":"{ users { username } }"}`)
+
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, result.Errors, 1)
+	assert.Equal(t, ErrAdminRequired.Error(), result.Errors[0].Message)
+	mocks.users.AssertNotCalled(t, "GetPage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExecute_UsersAsAdmin(t *testing.T) {
+	app, mocks := setupTestApp(t, "admin", DefaultLimits)
+
+	mocks.users.On("GetPage", mock.Anything, 1, 20).Return([]*userDomain.User{{ID: 1, Username: "admin", Role: userDomain.RoleAdmin}}, nil)
+
+	status, result := query(t, app, `{"query":"{ users { username role } }"}`)
+
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, "admin", result.Data["users"].([]interface{})[0].(map[string]interface{})["role"])
+}
+
+func TestExecute_UsersPage(t *testing.T) {
+	app, mocks := setupTestApp(t, "admin", DefaultLimits)
+
+	mocks.users.On("GetPage", mock.Anything, 3, 5).Return([]*userDomain.User{{ID: 11, Username: "analyst"}}, nil)
+
+	status, result := query(t, app, `{"query":"{ users(page: 3, limit: 5) { username } }"}`)
+
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Empty(t, result.Errors)
+	assert.Len(t, result.Data["users"], 1)
+	mocks.users.AssertExpectations(t)
+}
+
+func TestExecute_HidesInternalErrors(t *testing.T) {
+	app, mocks := setupTestApp(t, "user", DefaultLimits)
+
+	mocks.ratings.On("FindAll", mock.Anything).Return(nil, errors.New("pq: connection refused"))
+
+	status, result := query(t, app, `{"query":"{ rating_options { label } }"}`)
+
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, result.Errors, 1)
+	assert.Equal(t, "Failed to fetch rating options", result.Errors[0].Message)
+}
+
+func TestExecute_RejectsInvalidQueries(t *testing.T) {
+	app, _ := setupTestApp(t, "user", DefaultLimits)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid body", `not json`},
+		{"missing query", `{"query":""}`},
+		{"syntax error", `{"query":"{ stocks { "}`},
+		{"unknown field", `{"query":"{ stocks { password } }"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, result := query(t, app, tt.body)
+			assert.Equal(t, fiber.StatusBadRequest, status)
+			assert.NotEmpty(t, result.Errors)
+		})
+	}
+}
+
+func TestExecute_EnforcesLimits(t *testing.T) {
+	app, mocks := setupTestApp(t, "user", Limits{MaxDepth: 3, MaxComplexity: 100})
+
+	status, result := query(t, app, `{"query":"{ ticker(symbol: \"AAPL\") { recommendation { stock { consensus { rating } } } } }"}`)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Contains(t, result.Errors[0].Message, "depth 5 exceeds 3")
+
+	status, result = query(t, app, `{"query":"query Q($n: Int) { stocks(limit: $n) { items { id ticker } } }","variables":{"n":50}}`)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Contains(t, result.Errors[0].Message, "complexity")
+
+	mocks.stocks.AssertNotCalled(t, "GetStocks", mock.Anything, mock.Anything)
+	mocks.stocks.AssertNotCalled(t, "GetTimeline", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,146 @@
+package interfaces
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+var ErrQueryTooComplex = errors.New("query too complex")
+
+// Limits bound the cost of a query before it is executed
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+var DefaultLimits = Limits{MaxDepth: 7, MaxComplexity: 5000}
+
+// listSizes are the sizes assumed for list fields without a limit argument
+var listSizes = map[string]int{
+	"stocks":          20,
+	"ratings":         20,
+	"recommendations": 10,
+	"rating_options":  20,
+	"users":           20,
+}
+
+// analyze returns the depth and complexity of the selected operation. Every
+// field costs one and the cost of a field's selections is multiplied by its
+// limit argument. Introspection fields are not counted.
+func analyze(doc *ast.Document, operationName string, variables map[string]interface{}) (depth, complexity int, err error) {
+	fragments := map[string]*ast.FragmentDefinition{}
+	var operation *ast.OperationDefinition
+
+	for _, definition := range doc.Definitions {
+		switch def := definition.(type) {
+		case *ast.FragmentDefinition:
+			fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				if operation == nil {
+					operation = def
+				}
+			}
+		}
+	}
+	if operation == nil {
+		return 0, 0, fmt.Errorf("unknown operation %q", operationName)
+	}
+
+	a := &analyzer{fragments: fragments, variables: variables, visiting: map[string]bool{}}
+	depth, complexity = a.selectionSet(operation.SelectionSet, 0)
+	return depth, complexity, nil
+}
+
+type analyzer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	visiting  map[string]bool
+}
+
+func (a *analyzer) selectionSet(set *ast.SelectionSet, level int) (depth, complexity int) {
+	if set == nil {
+		return level, 0
+	}
+
+	depth = level
+	for _, selection := range set.Selections {
+		var d, c int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+			d, c = a.selectionSet(s.SelectionSet, level+1)
+			c = 1 + c*a.multiplier(s)
+		case *ast.InlineFragment:
+			d, c = a.selectionSet(s.SelectionSet, level)
+		case *ast.FragmentSpread:
+			fragment, ok := a.fragments[s.Name.Value]
+			// Cyclic spreads are rejected by validation; skip them here
+			if !ok || a.visiting[s.Name.Value] {
+				continue
+			}
+			a.visiting[s.Name.Value] = true
+			d, c = a.selectionSet(fragment.SelectionSet, level)
+			delete(a.visiting, s.Name.Value)
+		}
+
+		if d > depth {
+			depth = d
+		}
+		complexity += c
+	}
+
+	return depth, complexity
+}
+
+// multiplier is the limit argument of a field, or the assumed size of list
+// fields without one
+func (a *analyzer) multiplier(field *ast.Field) int {
+	size, ok := listSizes[field.Name.Value]
+	if !ok {
+		size = 1
+	}
+
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "limit" {
+			continue
+		}
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			if n, ok := toInt(a.variables[value.Name.Value]); ok && n > 0 {
+				return n
+			}
+		}
+	}
+	return size
+}
+
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+func (l Limits) check(depth, complexity int) error {
+	if l.MaxDepth > 0 && depth > l.MaxDepth {
+		return fmt.Errorf("%w: depth %d exceeds %d", ErrQueryTooComplex, depth, l.MaxDepth)
+	}
+	if l.MaxComplexity > 0 && complexity > l.MaxComplexity {
+		return fmt.Errorf("%w: complexity %d exceeds %d", ErrQueryTooComplex, complexity, l.MaxComplexity)
+	}
+	return nil
+}
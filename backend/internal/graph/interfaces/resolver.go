@@ -0,0 +1,370 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"strconv"
+	"sync"
+
+	ratingDomain "github.com/bryanriosb/stock-info/internal/rating/domain"
+	recommendationApp "github.com/bryanriosb/stock-info/internal/recommendation/application"
+	recommendationDomain "github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockApp "github.com/bryanriosb/stock-info/internal/stock/application"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	userApp "github.com/bryanriosb/stock-info/internal/user/application"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+var (
+	ErrAdminRequired = errors.New("admin access required")
+	ErrInvalidID     = errors.New("invalid id")
+)
+
+// maxPageLimit mirrors the REST listing limit
+const maxPageLimit = 100
+
+type contextKey string
+
+const (
+	roleKey   contextKey = "role"
+	userKey   contextKey = "user"
+	loaderKey contextKey = "loader"
+)
+
+// resolver adapts the module use cases to GraphQL resolve functions
+type resolver struct {
+	stockUseCase          stockApp.StockUseCase
+	ratingRepo            ratingDomain.RatingOptionRepository
+	recommendationUseCase recommendationApp.RecommendationUseCase
+	userUseCase           userApp.UserUseCase
+}
+
+// stockPage is the source of a StockConnection
+type stockPage struct {
+	Items      []*stockDomain.Stock `json:"items"`
+	Total      int64                `json:"total"`
+	Page       int                  `json:"page"`
+	Limit      int                  `json:"limit"`
+	TotalPages int                  `json:"total_pages"`
+}
+
+// tickerNode is the source of a Ticker
+type tickerNode struct {
+	Symbol  string `json:"symbol"`
+	Company string `json:"company"`
+}
+
+// consensusLoader caches consensus per ticker for one request, so a page of
+// stocks resolves its consensus with a single query
+type consensusLoader struct {
+	useCase  stockApp.StockUseCase
+	mu       sync.Mutex
+	byTicker map[string]*stockDomain.Consensus
+}
+
+func (l *consensusLoader) prime(ctx context.Context, stocks []*stockDomain.Stock) error {
+	includes, err := l.useCase.GetIncludes(ctx, stocks, []string{stockDomain.IncludeConsensus})
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, stock := range stocks {
+		l.byTicker[stock.Ticker] = includes.Consensus[stock.Ticker]
+	}
+	return nil
+}
+
+func (l *consensusLoader) load(ctx context.Context, ticker string) (*stockDomain.Consensus, error) {
+	l.mu.Lock()
+	consensus, ok := l.byTicker[ticker]
+	l.mu.Unlock()
+	if ok {
+		return consensus, nil
+	}
+
+	if err := l.prime(ctx, []*stockDomain.Stock{{Ticker: ticker}}); err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.byTicker[ticker], nil
+}
+
+func withRequest(ctx context.Context, username, role string, useCase stockApp.StockUseCase) context.Context {
+	ctx = context.WithValue(ctx, userKey, username)
+	ctx = context.WithValue(ctx, roleKey, role)
+	return context.WithValue(ctx, loaderKey, &consensusLoader{useCase: useCase, byTicker: map[string]*stockDomain.Consensus{}})
+}
+
+// userFrom returns the caller, whose scoring profile and rules recommendations use
+func userFrom(ctx context.Context) string {
+	username, _ := ctx.Value(userKey).(string)
+	return username
+}
+
+func loaderFrom(ctx context.Context) *consensusLoader {
+	return ctx.Value(loaderKey).(*consensusLoader)
+}
+
+func requireAdmin(ctx context.Context) error {
+	if role, _ := ctx.Value(roleKey).(string); role != "admin" {
+		return ErrAdminRequired
+	}
+	return nil
+}
+
+func (r *resolver) stocks(p graphql.ResolveParams) (interface{}, error) {
+	params := stockDomain.QueryParams{
+		Page:       intArg(p, "page"),
+		Limit:      intArg(p, "limit"),
+		SortBy:     stringArg(p, "sort_by"),
+		SortDir:    stringArg(p, "sort_dir"),
+		Search:     stringArg(p, "search"),
+		RatingFrom: stringArg(p, "rating_from"),
+		RatingTo:   stringArg(p, "rating_to"),
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.Limit < 1 || params.Limit > maxPageLimit {
+		params.Limit = 20
+	}
+
+	stocks, total, err := r.stockUseCase.GetStocks(p.Context, params)
+	if err != nil {
+		return nil, internalError("Failed to fetch stocks", err)
+	}
+
+	if selects(p.Info.FieldASTs, "items", "consensus") && len(stocks) > 0 {
+		if err := loaderFrom(p.Context).prime(p.Context, stocks); err != nil {
+			return nil, internalError("Failed to fetch consensus", err)
+		}
+	}
+
+	return &stockPage{
+		Items:      stocks,
+		Total:      total,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(params.Limit))),
+	}, nil
+}
+
+func (r *resolver) stock(p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := r.stockUseCase.GetStockByID(p.Context, id)
+	if err != nil {
+		return nil, internalError("Failed to fetch stock", err)
+	}
+	if stock == nil {
+		return nil, nil
+	}
+	return stock, nil
+}
+
+func resolveStockConsensus(p graphql.ResolveParams) (interface{}, error) {
+	stock, ok := p.Source.(*stockDomain.Stock)
+	if !ok {
+		return nil, nil
+	}
+
+	consensus, err := loaderFrom(p.Context).load(p.Context, stock.Ticker)
+	if err != nil {
+		return nil, internalError("Failed to fetch consensus", err)
+	}
+	if consensus == nil {
+		return nil, nil
+	}
+	return consensus, nil
+}
+
+func (r *resolver) ticker(p graphql.ResolveParams) (interface{}, error) {
+	timeline, _, err := r.stockUseCase.GetTimeline(p.Context, stringArg(p, "symbol"), stockDomain.TimelineParams{Page: 1, Limit: 1})
+	if errors.Is(err, stockApp.ErrTickerNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, internalError("Failed to fetch ticker", err)
+	}
+	return &tickerNode{Symbol: timeline.Ticker, Company: timeline.Company}, nil
+}
+
+func (r *resolver) tickerConsensus(p graphql.ResolveParams) (interface{}, error) {
+	consensus, err := loaderFrom(p.Context).load(p.Context, p.Source.(*tickerNode).Symbol)
+	if err != nil {
+		return nil, internalError("Failed to fetch consensus", err)
+	}
+	if consensus == nil {
+		return nil, nil
+	}
+	return consensus, nil
+}
+
+func (r *resolver) tickerRatings(p graphql.ResolveParams) (interface{}, error) {
+	rng, err := stockDomain.ParseTimeRange(stringArg(p, "from"), stringArg(p, "to"))
+	if err != nil {
+		return nil, err
+	}
+
+	limit := intArg(p, "limit")
+	if limit < 1 || limit > maxPageLimit {
+		limit = 20
+	}
+
+	timeline, _, err := r.stockUseCase.GetTimeline(p.Context, p.Source.(*tickerNode).Symbol, stockDomain.TimelineParams{
+		Range: rng,
+		Page:  intArg(p, "page"),
+		Limit: limit,
+	})
+	if errors.Is(err, stockApp.ErrTickerNotFound) {
+		return []stockDomain.TimelineEvent{}, nil
+	}
+	if err != nil {
+		return nil, internalError("Failed to fetch ratings", err)
+	}
+	return timeline.Events, nil
+}
+
+// tickerRecommendation scores the ticker alone, as its explanation does, so
+// it resolves wherever the ticker ranks and without ranking every other ticker
+func (r *resolver) tickerRecommendation(p graphql.ResolveParams) (interface{}, error) {
+	explanation, err := r.recommendationUseCase.ExplainTicker(p.Context, p.Source.(*tickerNode).Symbol, recommendationDomain.RecommendationQuery{
+		Strategy:    stringArg(p, "strategy"),
+		Aggregation: stringArg(p, "aggregation"),
+		Username:    userFrom(p.Context),
+	})
+	if errors.Is(err, recommendationDomain.ErrTickerNotRecommended) {
+		return nil, nil
+	}
+	if errors.Is(err, recommendationDomain.ErrUnknownStrategy) || errors.Is(err, recommendationDomain.ErrUnknownAggregation) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, internalError("Failed to fetch recommendation", err)
+	}
+	return explanation.StockRecommendation, nil
+}
+
+func (r *resolver) ratingOptions(p graphql.ResolveParams) (interface{}, error) {
+	options, err := r.ratingRepo.FindAll(p.Context)
+	if err != nil {
+		return nil, internalError("Failed to fetch rating options", err)
+	}
+	return options, nil
+}
+
+func (r *resolver) recommendations(p graphql.ResolveParams) (interface{}, error) {
+	recommendations, _, err := r.recommendationUseCase.GetRecommendations(p.Context, recommendationDomain.RecommendationQuery{
+		Page:        intArg(p, "page"),
+		Limit:       intArg(p, "limit"),
+		Strategy:    stringArg(p, "strategy"),
+		Aggregation: stringArg(p, "aggregation"),
+		Username:    userFrom(p.Context),
+	})
+	if errors.Is(err, recommendationDomain.ErrUnknownStrategy) || errors.Is(err, recommendationDomain.ErrUnknownAggregation) || errors.Is(err, recommendationDomain.ErrPageOutOfRange) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, internalError("Failed to fetch recommendations", err)
+	}
+	return recommendations, nil
+}
+
+func (r *resolver) recommendationStrategies(p graphql.ResolveParams) (interface{}, error) {
+	return r.recommendationUseCase.GetStrategies(), nil
+}
+
+func (r *resolver) users(p graphql.ResolveParams) (interface{}, error) {
+	if err := requireAdmin(p.Context); err != nil {
+		return nil, err
+	}
+
+	page, limit := intArg(p, "page"), intArg(p, "limit")
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > maxPageLimit {
+		limit = 20
+	}
+
+	users, err := r.userUseCase.GetPage(p.Context, page, limit)
+	if err != nil {
+		return nil, internalError("Failed to fetch users", err)
+	}
+	return users, nil
+}
+
+func (r *resolver) user(p graphql.ResolveParams) (interface{}, error) {
+	if err := requireAdmin(p.Context); err != nil {
+		return nil, err
+	}
+
+	id, err := idArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.userUseCase.GetByID(p.Context, id)
+	if errors.Is(err, userApp.ErrUserNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, internalError("Failed to fetch user", err)
+	}
+	return user, nil
+}
+
+// internalError logs the cause and returns a message safe to show clients
+func internalError(message string, err error) error {
+	log.Printf("GraphQL: %s: %v", message, err)
+	return errors.New(message)
+}
+
+func intArg(p graphql.ResolveParams, name string) int {
+	value, _ := p.Args[name].(int)
+	return value
+}
+
+func stringArg(p graphql.ResolveParams, name string) string {
+	value, _ := p.Args[name].(string)
+	return value
+}
+
+func idArg(p graphql.ResolveParams) (int64, error) {
+	id, err := strconv.ParseInt(stringArg(p, "id"), 10, 64)
+	if err != nil {
+		return 0, ErrInvalidID
+	}
+	return id, nil
+}
+
+// selects reports whether the field selections contain the given path of
+// field names; selections inside fragments are not inspected
+func selects(fields []*ast.Field, path ...string) bool {
+	if len(path) == 0 {
+		return true
+	}
+	for _, field := range fields {
+		if field.SelectionSet == nil {
+			continue
+		}
+		for _, selection := range field.SelectionSet.Selections {
+			if child, ok := selection.(*ast.Field); ok && child.Name.Value == path[0] {
+				if selects([]*ast.Field{child}, path[1:]...) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
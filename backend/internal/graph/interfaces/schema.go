@@ -0,0 +1,244 @@
+package interfaces
+
+import (
+	"github.com/graphql-go/graphql"
+)
+
+// Field names follow the JSON names of the REST API so both share one vocabulary
+
+var consensusType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Consensus",
+	Description: "Aggregated latest rating and target of every brokerage covering a ticker",
+	Fields: graphql.Fields{
+		"ticker":           &graphql.Field{Type: graphql.String},
+		"brokerages":       &graphql.Field{Type: graphql.Int},
+		"rated_brokerages": &graphql.Field{Type: graphql.Int},
+		"mean_rating":      &graphql.Field{Type: graphql.Float},
+		"rating":           &graphql.Field{Type: graphql.String},
+		"avg_target":       &graphql.Field{Type: graphql.Float},
+		"high_target":      &graphql.Field{Type: graphql.Float},
+		"low_target":       &graphql.Field{Type: graphql.Float},
+	},
+})
+
+var stockType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Stock",
+	Description: "An analyst action on a ticker",
+	Fields: graphql.Fields{
+		"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"ticker":      &graphql.Field{Type: graphql.String},
+		"company":     &graphql.Field{Type: graphql.String},
+		"brokerage":   &graphql.Field{Type: graphql.String},
+		"action":      &graphql.Field{Type: graphql.String},
+		"rating_from": &graphql.Field{Type: graphql.String},
+		"rating_to":   &graphql.Field{Type: graphql.String},
+		"target_from": &graphql.Field{Type: graphql.Float},
+		"target_to":   &graphql.Field{Type: graphql.Float},
+		"time":        &graphql.Field{Type: graphql.DateTime},
+		"consensus":   &graphql.Field{Type: consensusType, Resolve: resolveStockConsensus},
+	},
+})
+
+var stockConnectionType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "StockConnection",
+	Fields: graphql.Fields{
+		"items":       &graphql.Field{Type: graphql.NewList(stockType)},
+		"total":       &graphql.Field{Type: graphql.Int},
+		"page":        &graphql.Field{Type: graphql.Int},
+		"limit":       &graphql.Field{Type: graphql.Int},
+		"total_pages": &graphql.Field{Type: graphql.Int},
+	},
+})
+
+var ratingOptionType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RatingOption",
+	Fields: graphql.Fields{
+		"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"label":     &graphql.Field{Type: graphql.String},
+		"value":     &graphql.Field{Type: graphql.String},
+		"is_active": &graphql.Field{Type: graphql.Boolean},
+	},
+})
+
+var brokerageSignalType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "BrokerageSignal",
+	Fields: graphql.Fields{
+		"brokerage":    &graphql.Field{Type: graphql.String},
+		"action":       &graphql.Field{Type: graphql.String},
+		"rating_to":    &graphql.Field{Type: graphql.String},
+		"target_to":    &graphql.Field{Type: graphql.Float},
+		"time":         &graphql.Field{Type: graphql.DateTime},
+		"score":        &graphql.Field{Type: graphql.Float},
+		"decay_weight": &graphql.Field{Type: graphql.Float},
+		"credibility":  &graphql.Field{Type: graphql.Float},
+	},
+})
+
+var recommendationType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "StockRecommendation",
+	Fields: graphql.Fields{
+		"ticker":                 &graphql.Field{Type: graphql.String},
+		"stock":                  &graphql.Field{Type: stockType},
+		"score":                  &graphql.Field{Type: graphql.Float},
+		"reason":                 &graphql.Field{Type: graphql.String},
+		"potential_gain_percent": &graphql.Field{Type: graphql.Float},
+		"strategy":               &graphql.Field{Type: graphql.String},
+		"decay_weight":           &graphql.Field{Type: graphql.Float},
+		"aggregation":            &graphql.Field{Type: graphql.String},
+		"agreement":              &graphql.Field{Type: graphql.Float},
+		"conviction":             &graphql.Field{Type: graphql.Float},
+		"brokerages":             &graphql.Field{Type: graphql.NewList(brokerageSignalType)},
+	},
+})
+
+var strategyParameterType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "StrategyParameter",
+	Fields: graphql.Fields{
+		"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"description": &graphql.Field{Type: graphql.String},
+		"default":     &graphql.Field{Type: graphql.Float},
+		"min":         &graphql.Field{Type: graphql.Float},
+		"max":         &graphql.Field{Type: graphql.Float},
+	},
+})
+
+var strategyType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "RecommendationStrategy",
+	Description: "A way of scoring recommendations",
+	Fields: graphql.Fields{
+		"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"description": &graphql.Field{Type: graphql.String},
+		"parameters":  &graphql.Field{Type: graphql.NewList(strategyParameterType)},
+	},
+})
+
+var timelineEventType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Rating",
+	Description: "A brokerage rating change on a ticker",
+	Fields: graphql.Fields{
+		"id":                    &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"time":                  &graphql.Field{Type: graphql.DateTime},
+		"brokerage":             &graphql.Field{Type: graphql.String},
+		"action":                &graphql.Field{Type: graphql.String},
+		"rating_from":           &graphql.Field{Type: graphql.String},
+		"rating_to":             &graphql.Field{Type: graphql.String},
+		"rating_change":         &graphql.Field{Type: graphql.Int},
+		"target_from":           &graphql.Field{Type: graphql.Float},
+		"target_to":             &graphql.Field{Type: graphql.Float},
+		"target_change":         &graphql.Field{Type: graphql.Float},
+		"target_change_percent": &graphql.Field{Type: graphql.Float},
+	},
+})
+
+var userType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "User",
+	Fields: graphql.Fields{
+		"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"username":   &graphql.Field{Type: graphql.String},
+		"email":      &graphql.Field{Type: graphql.String},
+		"role":       &graphql.Field{Type: graphql.String},
+		"created_at": &graphql.Field{Type: graphql.DateTime},
+		"updated_at": &graphql.Field{Type: graphql.DateTime},
+	},
+})
+
+var pageArgs = graphql.FieldConfigArgument{
+	"page":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+	"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 20},
+}
+
+// newSchema builds the schema; resolvers reach the use cases through r
+func newSchema(r *resolver) (graphql.Schema, error) {
+	tickerType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Ticker",
+		Description: "A ticker with its brokerage ratings, consensus and recommendation",
+		Fields: graphql.Fields{
+			"symbol":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"company":   &graphql.Field{Type: graphql.String},
+			"consensus": &graphql.Field{Type: consensusType, Resolve: r.tickerConsensus},
+			"ratings": &graphql.Field{
+				Type: graphql.NewList(timelineEventType),
+				Args: graphql.FieldConfigArgument{
+					"from":  &graphql.ArgumentConfig{Type: graphql.String},
+					"to":    &graphql.ArgumentConfig{Type: graphql.String},
+					"page":  pageArgs["page"],
+					"limit": pageArgs["limit"],
+				},
+				Resolve: r.tickerRatings,
+			},
+			"recommendation": &graphql.Field{
+				Type:        recommendationType,
+				Description: "The ticker's recommendation, scored as GET /recommendations would score it, null when it has no recent actions",
+				Args: graphql.FieldConfigArgument{
+					"strategy":    &graphql.ArgumentConfig{Type: graphql.String},
+					"aggregation": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.tickerRecommendation,
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"stocks": &graphql.Field{
+				Type: stockConnectionType,
+				Args: graphql.FieldConfigArgument{
+					"page":        pageArgs["page"],
+					"limit":       pageArgs["limit"],
+					"sort_by":     &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "time"},
+					"sort_dir":    &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "desc"},
+					"search":      &graphql.ArgumentConfig{Type: graphql.String},
+					"rating_from": &graphql.ArgumentConfig{Type: graphql.String},
+					"rating_to":   &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.stocks,
+			},
+			"stock": &graphql.Field{
+				Type:    stockType,
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: r.stock,
+			},
+			"ticker": &graphql.Field{
+				Type:    tickerType,
+				Args:    graphql.FieldConfigArgument{"symbol": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: r.ticker,
+			},
+			"rating_options": &graphql.Field{
+				Type:    graphql.NewList(ratingOptionType),
+				Resolve: r.ratingOptions,
+			},
+			"recommendations": &graphql.Field{
+				Type: graphql.NewList(recommendationType),
+				Args: graphql.FieldConfigArgument{
+					"page":        &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+					"limit":       &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+					"strategy":    &graphql.ArgumentConfig{Type: graphql.String, Description: "Scoring strategy, balanced when omitted"},
+					"aggregation": &graphql.ArgumentConfig{Type: graphql.String, Description: "How brokerage signals are combined per ticker: mean, median or weighted"},
+				},
+				Resolve: r.recommendations,
+			},
+			"recommendation_strategies": &graphql.Field{
+				Type:    graphql.NewList(strategyType),
+				Resolve: r.recommendationStrategies,
+			},
+			"users": &graphql.Field{
+				Type:        graphql.NewList(userType),
+				Description: "Admin only",
+				Args: graphql.FieldConfigArgument{
+					"page":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+					"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 20},
+				},
+				Resolve: r.users,
+			},
+			"user": &graphql.Field{
+				Type:        userType,
+				Description: "Admin only",
+				Args:        graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve:     r.user,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
@@ -0,0 +1,29 @@
+package graph
+
+import (
+	"log"
+
+	"github.com/bryanriosb/stock-info/internal/graph/interfaces"
+	ratingDomain "github.com/bryanriosb/stock-info/internal/rating/domain"
+	recommendationApp "github.com/bryanriosb/stock-info/internal/recommendation/application"
+	stockApp "github.com/bryanriosb/stock-info/internal/stock/application"
+	userApp "github.com/bryanriosb/stock-info/internal/user/application"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Register mounts the GraphQL endpoint. It resolves through the use cases of
+// the other modules instead of querying the database itself.
+func Register(
+	app fiber.Router,
+	stockUseCase stockApp.StockUseCase,
+	ratingRepo ratingDomain.RatingOptionRepository,
+	recommendationUseCase recommendationApp.RecommendationUseCase,
+	userUseCase userApp.UserUseCase,
+) {
+	handler, err := interfaces.NewHandler(stockUseCase, ratingRepo, recommendationUseCase, userUseCase, interfaces.DefaultLimits)
+	if err != nil {
+		log.Fatalf("Failed to build GraphQL schema: %v", err)
+	}
+
+	app.Post("/graphql", handler.Execute)
+}
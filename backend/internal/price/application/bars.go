@@ -0,0 +1,77 @@
+package application
+
+import (
+	"math"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+)
+
+// maxHolidayGap is the number of missing weekdays a market holiday explains,
+// so only longer runs are reported as gaps
+const maxHolidayGap = 1
+
+// findGaps reports the runs of more than maxHolidayGap weekdays without a bar
+// between a ticker's bars, oldest first. A non-zero previous day counts as a
+// bar before the first one.
+func findGaps(ticker string, previous time.Time, bars []domain.PricePoint) []domain.Gap {
+	var gaps []domain.Gap
+	for _, bar := range bars {
+		if !previous.IsZero() {
+			if missing := weekdaysBetween(previous, bar.Date); missing > maxHolidayGap {
+				gaps = append(gaps, domain.Gap{Ticker: ticker, After: previous, Before: bar.Date, Missing: missing})
+			}
+		}
+		previous = bar.Date
+	}
+	return gaps
+}
+
+// weekdaysBetween counts the weekdays strictly between two days
+func weekdaysBetween(after, before time.Time) int {
+	count := 0
+	for day := after.AddDate(0, 0, 1); day.Before(before); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			count++
+		}
+	}
+	return count
+}
+
+// downsample combines daily bars into one bar per interval, dated at the
+// interval's start: the first open, highest high, lowest low, last close and
+// adjusted close, total volume and the product of the split factors. Prices
+// the source did not give are left out of the high and low.
+func downsample(bars []domain.PricePoint, interval domain.Interval) []domain.PricePoint {
+	if interval == domain.IntervalDay {
+		if bars == nil {
+			return []domain.PricePoint{}
+		}
+		return bars
+	}
+
+	combined := []domain.PricePoint{}
+	for _, bar := range bars {
+		start := interval.Truncate(bar.Date)
+		last := len(combined) - 1
+		if last < 0 || !combined[last].Date.Equal(start) {
+			bar.Date = start
+			combined = append(combined, bar)
+			continue
+		}
+
+		current := &combined[last]
+		if current.Open == 0 {
+			current.Open = bar.Open
+		}
+		current.High = math.Max(current.High, bar.High)
+		if bar.Low > 0 && (current.Low == 0 || bar.Low < current.Low) {
+			current.Low = bar.Low
+		}
+		current.Close = bar.Close
+		current.AdjClose = bar.AdjClose
+		current.Volume += bar.Volume
+		current.SplitFactor *= bar.SplitFactor
+	}
+	return combined
+}
@@ -0,0 +1,135 @@
+package application
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+)
+
+var ErrInvalidCSV = errors.New("invalid price CSV")
+
+// csvColumns are the header names a price CSV must contain, in any order
+var csvColumns = []string{"ticker", "date", "close"}
+
+// ParseCSV reads daily bars from a CSV with a ticker,date,close header and
+// optional open, high, low, volume, adj_close and split_factor columns. Dates
+// use YYYY-MM-DD, other columns are ignored and tickers are upper-cased.
+func ParseCSV(r io.Reader) ([]domain.PricePoint, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: empty file", ErrInvalidCSV)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, column := range csvColumns {
+		if _, ok := index[column]; !ok {
+			return nil, fmt.Errorf("%w: missing %s column", ErrInvalidCSV, column)
+		}
+	}
+
+	var points []domain.PricePoint
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return points, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+		}
+
+		point, err := parseRecord(record, index)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidCSV, line, err)
+		}
+		points = append(points, point)
+	}
+}
+
+func parseRecord(record []string, index map[string]int) (domain.PricePoint, error) {
+	field := func(column string) string {
+		if i, ok := index[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	number := func(column string) (float64, error) {
+		value := field(column)
+		if value == "" {
+			return 0, nil
+		}
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s %q is not a number", column, value)
+		}
+		return n, nil
+	}
+
+	date, err := time.Parse("2006-01-02", field("date"))
+	if err != nil {
+		return domain.PricePoint{}, fmt.Errorf("date %q is not YYYY-MM-DD", field("date"))
+	}
+	point := domain.PricePoint{Ticker: field("ticker"), Date: date}
+	if point.Close, err = strconv.ParseFloat(field("close"), 64); err != nil || point.Close <= 0 {
+		return domain.PricePoint{}, fmt.Errorf("close %q is not a positive number", field("close"))
+	}
+	optional := []struct {
+		column string
+		value  *float64
+	}{
+		{"open", &point.Open},
+		{"high", &point.High},
+		{"low", &point.Low},
+		{"adj_close", &point.AdjClose},
+		{"split_factor", &point.SplitFactor},
+	}
+	for _, o := range optional {
+		if *o.value, err = number(o.column); err != nil {
+			return domain.PricePoint{}, err
+		}
+	}
+	if value := field("volume"); value != "" {
+		if point.Volume, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return domain.PricePoint{}, fmt.Errorf("volume %q is not a whole number", value)
+		}
+	}
+
+	return normalizePoint(point)
+}
+
+// normalizePoint upper-cases the ticker, defaults the split factor to 1 and
+// checks the bar is consistent
+func normalizePoint(point domain.PricePoint) (domain.PricePoint, error) {
+	point.Ticker = strings.ToUpper(strings.TrimSpace(point.Ticker))
+	switch {
+	case point.Ticker == "":
+		return point, errors.New("empty ticker")
+	case len(point.Ticker) > domain.MaxTickerLength:
+		return point, fmt.Errorf("ticker %q is longer than %d characters", point.Ticker, domain.MaxTickerLength)
+	case point.Close <= 0:
+		return point, fmt.Errorf("close %v is not a positive number", point.Close)
+	case point.Open < 0 || point.High < 0 || point.Low < 0 || point.AdjClose < 0 || point.Volume < 0 || point.SplitFactor < 0:
+		return point, errors.New("prices, volume and split factor cannot be negative")
+	case point.High > 0 && point.Low > point.High:
+		return point, fmt.Errorf("low %v is above high %v", point.Low, point.High)
+	}
+	if point.SplitFactor == 0 {
+		point.SplitFactor = 1
+	}
+	return point, nil
+}
@@ -0,0 +1,127 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/bryanriosb/stock-info/shared/jobs"
+)
+
+type LastPriceUseCase interface {
+	// GetLastPrices returns the latest price of the given tickers, or of every
+	// ticker when none are given, ordered by ticker
+	GetLastPrices(ctx context.Context, tickers []string) ([]domain.LastPrice, error)
+	// Refresh reads the price feed now and stores its prices
+	Refresh(ctx context.Context) (*domain.ImportResult, error)
+}
+
+// PriceFeedJob stores the prices quoted by a feed as the tickers' latest
+// prices. Without a feed there are only the prices stored before.
+type PriceFeedJob struct {
+	provider domain.PriceProvider
+	repo     domain.LastPriceRepository
+	bus      *events.Bus
+	now      func() time.Time
+
+	mu     sync.Mutex
+	runner *jobs.Runner
+}
+
+func NewPriceFeedJob(provider domain.PriceProvider, repo domain.LastPriceRepository, bus *events.Bus) *PriceFeedJob {
+	j := &PriceFeedJob{provider: provider, repo: repo, bus: bus, now: time.Now}
+	j.runner = jobs.NewRunner("Price feed refresh", func(ctx context.Context) error {
+		_, err := j.Refresh(ctx)
+		return err
+	})
+	return j
+}
+
+func (j *PriceFeedJob) GetLastPrices(ctx context.Context, tickers []string) ([]domain.LastPrice, error) {
+	normalized := make([]string, 0, len(tickers))
+	for _, ticker := range tickers {
+		if ticker = strings.ToUpper(strings.TrimSpace(ticker)); ticker != "" {
+			normalized = append(normalized, ticker)
+		}
+	}
+
+	byTicker, err := j.repo.LastPrices(ctx, normalized)
+	if err != nil {
+		return nil, err
+	}
+	prices := make([]domain.LastPrice, 0, len(byTicker))
+	for _, price := range byTicker {
+		prices = append(prices, price)
+	}
+	sort.Slice(prices, func(a, b int) bool { return prices[a].Ticker < prices[b].Ticker })
+	return prices, nil
+}
+
+// Start runs triggered reads with ctx and, when interval is positive, reads
+// the feed now and every interval, until ctx is done
+func (j *PriceFeedJob) Start(ctx context.Context, interval time.Duration) {
+	j.runner.Start(ctx)
+	if interval <= 0 {
+		return
+	}
+	j.Trigger()
+	go jobs.Every(ctx, interval, j.Trigger)
+}
+
+// Trigger reads the feed in the background, or once more after a read that is already running
+func (j *PriceFeedJob) Trigger() {
+	j.runner.Trigger()
+}
+
+// Refresh stores the feed's prices, quoted now when the feed gives no time. A
+// ticker quoted more than once keeps its latest price.
+func (j *PriceFeedJob) Refresh(ctx context.Context) (*domain.ImportResult, error) {
+	if j.provider == nil {
+		return nil, domain.ErrNoPriceFeed
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	quoted, err := j.provider.LatestPrices(ctx)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidFeed) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", domain.ErrFeedUnavailable, err)
+	}
+
+	now := j.now()
+	latest := make(map[string]domain.LastPrice, len(quoted))
+	for _, price := range quoted {
+		if price.At.IsZero() {
+			price.At = now
+		}
+		if stored, ok := latest[price.Ticker]; !ok || !price.At.Before(stored.At) {
+			latest[price.Ticker] = price
+		}
+	}
+
+	result := &domain.ImportResult{Imported: len(latest), Tickers: make([]string, 0, len(latest))}
+	prices := make([]domain.LastPrice, 0, len(latest))
+	for ticker, price := range latest {
+		result.Tickers = append(result.Tickers, ticker)
+		prices = append(prices, price)
+	}
+	sort.Strings(result.Tickers)
+	sort.Slice(prices, func(a, b int) bool { return prices[a].Ticker < prices[b].Ticker })
+
+	if err := j.repo.SaveLastPrices(ctx, prices); err != nil {
+		return nil, err
+	}
+	if len(prices) > 0 {
+		j.bus.Publish(events.LastPricesUpdated, len(prices))
+	}
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock LastPriceRepository
+type MockLastPriceRepository struct {
+	mock.Mock
+}
+
+func (m *MockLastPriceRepository) SaveLastPrices(ctx context.Context, prices []domain.LastPrice) error {
+	args := m.Called(ctx, prices)
+	return args.Error(0)
+}
+
+func (m *MockLastPriceRepository) LastPrices(ctx context.Context, tickers []string) (map[string]domain.LastPrice, error) {
+	args := m.Called(ctx, tickers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]domain.LastPrice), args.Error(1)
+}
+
+// Mock PriceProvider
+type MockPriceProvider struct {
+	mock.Mock
+}
+
+func (m *MockPriceProvider) LatestPrices(ctx context.Context) ([]domain.LastPrice, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.LastPrice), args.Error(1)
+}
+
+func TestRefresh(t *testing.T) {
+	provider := new(MockPriceProvider)
+	repo := new(MockLastPriceRepository)
+	bus := events.NewBus()
+	var published interface{}
+	bus.Subscribe(events.LastPricesUpdated, func(e events.Event) { published = e.Payload })
+
+	now := time.Date(2025, 6, 2, 16, 0, 0, 0, time.UTC)
+	provider.On("LatestPrices", mock.Anything).Return([]domain.LastPrice{
+		{Ticker: "MSFT", Price: 410},
+		{Ticker: "AAPL", Price: 191, At: now.Add(-time.Minute)},
+		{Ticker: "AAPL", Price: 190, At: now.Add(-time.Hour)},
+	}, nil)
+	// AAPL keeps its latest quote and MSFT is quoted now
+	repo.On("SaveLastPrices", mock.Anything, []domain.LastPrice{
+		{Ticker: "AAPL", Price: 191, At: now.Add(-time.Minute)},
+		{Ticker: "MSFT", Price: 410, At: now},
+	}).Return(nil)
+
+	job := NewPriceFeedJob(provider, repo, bus)
+	job.now = func() time.Time { return now }
+	result, err := job.Refresh(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, []string{"AAPL", "MSFT"}, result.Tickers)
+	assert.Equal(t, 2, published)
+	repo.AssertExpectations(t)
+}
+
+func TestRefresh_FeedErrors(t *testing.T) {
+	repo := new(MockLastPriceRepository)
+
+	_, err := NewPriceFeedJob(nil, repo, nil).Refresh(context.Background())
+	assert.ErrorIs(t, err, domain.ErrNoPriceFeed)
+
+	provider := new(MockPriceProvider)
+	provider.On("LatestPrices", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+	_, err = NewPriceFeedJob(provider, repo, nil).Refresh(context.Background())
+	assert.ErrorIs(t, err, domain.ErrFeedUnavailable)
+	assert.Contains(t, err.Error(), "connection refused")
+
+	provider.On("LatestPrices", mock.Anything).Return(nil, domain.ErrInvalidFeed).Once()
+	_, err = NewPriceFeedJob(provider, repo, nil).Refresh(context.Background())
+	assert.ErrorIs(t, err, domain.ErrInvalidFeed)
+	assert.NotErrorIs(t, err, domain.ErrFeedUnavailable)
+
+	repo.AssertNotCalled(t, "SaveLastPrices")
+}
+
+func TestGetLastPrices(t *testing.T) {
+	repo := new(MockLastPriceRepository)
+	at := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	repo.On("LastPrices", mock.Anything, []string{"MSFT", "AAPL"}).Return(map[string]domain.LastPrice{
+		"MSFT": {Ticker: "MSFT", Price: 410, At: at},
+		"AAPL": {Ticker: "AAPL", Price: 190, At: at},
+	}, nil)
+
+	prices, err := NewPriceFeedJob(nil, repo, nil).GetLastPrices(context.Background(), []string{" msft", "", "AAPL"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.LastPrice{{Ticker: "AAPL", Price: 190, At: at}, {Ticker: "MSFT", Price: 410, At: at}}, prices)
+}
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+)
+
+var ErrInvalidNDJSON = errors.New("invalid price NDJSON")
+
+// maxNDJSONLine bounds the length of one NDJSON bar
+const maxNDJSONLine = 64 * 1024
+
+// ndjsonBar is one line of a price NDJSON; the date is a YYYY-MM-DD string
+type ndjsonBar struct {
+	Ticker      string  `json:"ticker"`
+	Date        string  `json:"date"`
+	Open        float64 `json:"open"`
+	High        float64 `json:"high"`
+	Low         float64 `json:"low"`
+	Close       float64 `json:"close"`
+	Volume      int64   `json:"volume"`
+	AdjClose    float64 `json:"adj_close"`
+	SplitFactor float64 `json:"split_factor"`
+}
+
+// ParseNDJSON reads daily bars from one JSON object per line with the fields
+// of a CSV import. Blank lines are skipped and tickers are upper-cased.
+func ParseNDJSON(r io.Reader) ([]domain.PricePoint, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxNDJSONLine)
+
+	var points []domain.PricePoint
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var bar ndjsonBar
+		if err := json.Unmarshal(text, &bar); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidNDJSON, line, err)
+		}
+		date, err := time.Parse("2006-01-02", bar.Date)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: date %q is not YYYY-MM-DD", ErrInvalidNDJSON, line, bar.Date)
+		}
+		point, err := normalizePoint(domain.PricePoint{
+			Ticker:      bar.Ticker,
+			Date:        date,
+			Open:        bar.Open,
+			High:        bar.High,
+			Low:         bar.Low,
+			Close:       bar.Close,
+			Volume:      bar.Volume,
+			AdjClose:    bar.AdjClose,
+			SplitFactor: bar.SplitFactor,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidNDJSON, line, err)
+		}
+		points = append(points, point)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidNDJSON, err)
+	}
+	return points, nil
+}
@@ -0,0 +1,169 @@
+package application
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+)
+
+type PriceUseCase interface {
+	// Import stores the bars of a CSV or NDJSON import; bulk imports replace the
+	// days already imported, incremental ones only add later days
+	Import(ctx context.Context, r io.Reader, options domain.ImportOptions) (*domain.ImportResult, error)
+	// GetPrices returns a ticker's bars over a range, downsampled and split-adjusted as the query asks
+	GetPrices(ctx context.Context, ticker string, query domain.BarQuery) (*domain.PriceSeries, error)
+}
+
+type priceUseCase struct {
+	repo domain.PriceRepository
+	bus  *events.Bus
+	now  func() time.Time
+}
+
+func NewPriceUseCase(repo domain.PriceRepository, bus *events.Bus) PriceUseCase {
+	return &priceUseCase{repo: repo, bus: bus, now: time.Now}
+}
+
+func (uc *priceUseCase) Import(ctx context.Context, r io.Reader, options domain.ImportOptions) (*domain.ImportResult, error) {
+	if options.Mode == "" {
+		options.Mode = domain.ImportBulk
+	}
+	if options.Mode != domain.ImportBulk && options.Mode != domain.ImportIncremental {
+		return nil, domain.ErrInvalidImportMode
+	}
+
+	var points []domain.PricePoint
+	var err error
+	switch options.Format {
+	case "", domain.FormatCSV:
+		points, err = ParseCSV(r)
+	case domain.FormatNDJSON:
+		points, err = ParseNDJSON(r)
+	default:
+		return nil, domain.ErrInvalidFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// A day listed twice keeps its last bar, as a statement cannot upsert a row twice
+	byDay := make(map[string]int, len(points))
+	unique := points[:0]
+	for _, point := range points {
+		key := point.Ticker + point.Date.Format("2006-01-02")
+		if i, ok := byDay[key]; ok {
+			unique[i] = point
+			continue
+		}
+		byDay[key] = len(unique)
+		unique = append(unique, point)
+	}
+	points = unique
+	sort.SliceStable(points, func(a, b int) bool {
+		if points[a].Ticker != points[b].Ticker {
+			return points[a].Ticker < points[b].Ticker
+		}
+		return points[a].Date.Before(points[b].Date)
+	})
+
+	result := &domain.ImportResult{Tickers: []string{}}
+	var latest map[string]time.Time
+	if options.Mode == domain.ImportIncremental {
+		if latest, err = uc.repo.LatestDates(ctx, tickersOf(points)); err != nil {
+			return nil, err
+		}
+		kept := points[:0]
+		for _, point := range points {
+			if stored, ok := latest[point.Ticker]; ok && !point.Date.After(stored) {
+				result.Skipped++
+				continue
+			}
+			kept = append(kept, point)
+		}
+		points = kept
+	}
+
+	if err := uc.repo.SaveBatch(ctx, points); err != nil {
+		return nil, err
+	}
+
+	result.Imported = len(points)
+	result.Tickers = tickersOf(points)
+	for start := 0; start < len(points); {
+		end := start
+		for end < len(points) && points[end].Ticker == points[start].Ticker {
+			end++
+		}
+		// An incremental import continues from the latest day stored before
+		result.Gaps = append(result.Gaps, findGaps(points[start].Ticker, latest[points[start].Ticker], points[start:end])...)
+		start = end
+	}
+
+	if len(points) > 0 {
+		uc.bus.Publish(events.PricesImported, len(points))
+	}
+	return result, nil
+}
+
+// GetPrices defaults the range to the year up to today
+func (uc *priceUseCase) GetPrices(ctx context.Context, ticker string, query domain.BarQuery) (*domain.PriceSeries, error) {
+	if query.Interval == "" {
+		query.Interval = domain.IntervalDay
+	}
+	if query.To.IsZero() {
+		query.To = domain.IntervalDay.Truncate(uc.now().UTC())
+	}
+	if query.From.IsZero() {
+		query.From = query.To.AddDate(-1, 0, 0)
+	}
+	if query.From.After(query.To) {
+		return nil, domain.ErrInvalidPriceRange
+	}
+
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	bars, err := uc.repo.History(ctx, ticker, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	series := &domain.PriceSeries{
+		Ticker:   ticker,
+		From:     query.From,
+		To:       query.To,
+		Interval: query.Interval,
+		Adjusted: query.Adjusted,
+		Gaps:     findGaps(ticker, time.Time{}, bars),
+	}
+	if series.Gaps == nil {
+		series.Gaps = []domain.Gap{}
+	}
+
+	if query.Adjusted && len(bars) > 0 {
+		splits, err := uc.repo.Splits(ctx, ticker, bars[0].Date)
+		if err != nil {
+			return nil, err
+		}
+		domain.AdjustForSplits(bars, splits)
+	}
+
+	series.Bars = downsample(bars, query.Interval)
+	return series, nil
+}
+
+func tickersOf(points []domain.PricePoint) []string {
+	seen := make(map[string]bool)
+	tickers := []string{}
+	for _, point := range points {
+		if !seen[point.Ticker] {
+			seen[point.Ticker] = true
+			tickers = append(tickers, point.Ticker)
+		}
+	}
+	sort.Strings(tickers)
+	return tickers
+}
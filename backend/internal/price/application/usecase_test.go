@@ -0,0 +1,233 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock PriceRepository
+type MockPriceRepository struct {
+	mock.Mock
+}
+
+func (m *MockPriceRepository) SaveBatch(ctx context.Context, points []domain.PricePoint) error {
+	args := m.Called(ctx, points)
+	return args.Error(0)
+}
+
+func (m *MockPriceRepository) History(ctx context.Context, ticker string, from, to time.Time) ([]domain.PricePoint, error) {
+	args := m.Called(ctx, ticker, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PricePoint), args.Error(1)
+}
+
+func (m *MockPriceRepository) AdjustedHistory(ctx context.Context, ticker string, from, to time.Time) ([]domain.PricePoint, error) {
+	args := m.Called(ctx, ticker, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PricePoint), args.Error(1)
+}
+
+func (m *MockPriceRepository) LatestDates(ctx context.Context, tickers []string) (map[string]time.Time, error) {
+	args := m.Called(ctx, tickers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]time.Time), args.Error(1)
+}
+
+func (m *MockPriceRepository) Splits(ctx context.Context, ticker string, after time.Time) ([]domain.PricePoint, error) {
+	args := m.Called(ctx, ticker, after)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PricePoint), args.Error(1)
+}
+
+func day(value string) time.Time {
+	t, _ := time.Parse("2006-01-02", value)
+	return t
+}
+
+func TestParseCSV(t *testing.T) {
+	points, err := ParseCSV(strings.NewReader("Date,Ticker,Open,Close\n2025-01-02, aapl ,99,100.5\n2025-01-03,AAPL,100,101\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.PricePoint{
+		{Ticker: "AAPL", Date: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), Open: 99, Close: 100.5, SplitFactor: 1},
+		{Ticker: "AAPL", Date: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), Open: 100, Close: 101, SplitFactor: 1},
+	}, points)
+}
+
+func TestParseCSV_Invalid(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"", "empty file"},
+		{"ticker,date\nAAPL,2025-01-02\n", "missing close column"},
+		{"symbol,date,close\nAAPL,2025-01-02,100\n", "missing ticker column"},
+		{"ticker,date,close\nAAPL,01/02/2025,100\n", "is not YYYY-MM-DD"},
+		{"ticker,date,close\nAAPL,2025-01-02,-1\n", "is not a positive number"},
+		{"ticker,date,close\nAAPL,2025-01-02,\n", "is not a positive number"},
+		{"ticker,date,close\n,2025-01-02,100\n", "empty ticker"},
+		{"ticker,date,close\nAAPL,2025-01-02,100\nAAPL,2025-01-03,abc\n", "line 3"},
+		{"ticker,date,close\nABCDEFGHIJK,2025-01-02,100\n", "longer than 10 characters"},
+		{"ticker,date,close,high,low\nAAPL,2025-01-02,100,99,101\n", "low 101 is above high 99"},
+		{"ticker,date,close,volume\nAAPL,2025-01-02,100,1.5\n", "is not a whole number"},
+		{"ticker,date,close,open\nAAPL,2025-01-02,100,-1\n", "cannot be negative"},
+	}
+	for _, tc := range cases {
+		_, err := ParseCSV(strings.NewReader(tc.input))
+		assert.ErrorIs(t, err, ErrInvalidCSV, tc.input)
+		assert.Contains(t, err.Error(), tc.want, tc.input)
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	mockRepo := new(MockPriceRepository)
+	bus := events.NewBus()
+	var published interface{}
+	bus.Subscribe(events.PricesImported, func(e events.Event) { published = e.Payload })
+
+	mockRepo.On("SaveBatch", mock.Anything, mock.MatchedBy(func(points []domain.PricePoint) bool { return len(points) == 3 })).Return(nil)
+
+	uc := NewPriceUseCase(mockRepo, bus)
+	result, err := uc.Import(context.Background(), strings.NewReader("ticker,date,close\nMSFT,2025-01-02,400\nAAPL,2025-01-02,100\nMSFT,2025-01-03,401\n"), domain.ImportOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Imported)
+	assert.Equal(t, []string{"AAPL", "MSFT"}, result.Tickers)
+	assert.Equal(t, 3, published)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportCSV_InvalidDoesNotSave(t *testing.T) {
+	mockRepo := new(MockPriceRepository)
+
+	uc := NewPriceUseCase(mockRepo, events.NewBus())
+	_, err := uc.Import(context.Background(), strings.NewReader("ticker,close\nAAPL,100\n"), domain.ImportOptions{})
+
+	assert.ErrorIs(t, err, ErrInvalidCSV)
+	mockRepo.AssertNotCalled(t, "SaveBatch")
+}
+
+func TestImportCSV_RepoError(t *testing.T) {
+	mockRepo := new(MockPriceRepository)
+	mockRepo.On("SaveBatch", mock.Anything, mock.Anything).Return(errors.New("database error"))
+
+	uc := NewPriceUseCase(mockRepo, events.NewBus())
+	_, err := uc.Import(context.Background(), strings.NewReader("ticker,date,close\nAAPL,2025-01-02,100\n"), domain.ImportOptions{})
+
+	assert.EqualError(t, err, "database error")
+}
+
+func TestParseCSV_Bars(t *testing.T) {
+	points, err := ParseCSV(strings.NewReader("ticker,date,open,high,low,close,volume,adj_close,split_factor\nNVDA,2024-06-10,1205,1210,1180,121.8,46100000,121.7,10\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.PricePoint{{
+		Ticker: "NVDA", Date: day("2024-06-10"), Open: 1205, High: 1210, Low: 1180, Close: 121.8,
+		Volume: 46100000, AdjClose: 121.7, SplitFactor: 10,
+	}}, points)
+}
+
+func TestParseNDJSON(t *testing.T) {
+	points, err := ParseNDJSON(strings.NewReader(`{"ticker":"aapl","date":"2025-01-02","open":99,"high":101,"low":98,"close":100.5,"volume":1200}
+
+{"ticker":"AAPL","date":"2025-01-03","close":101}
+`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.PricePoint{
+		{Ticker: "AAPL", Date: day("2025-01-02"), Open: 99, High: 101, Low: 98, Close: 100.5, Volume: 1200, SplitFactor: 1},
+		{Ticker: "AAPL", Date: day("2025-01-03"), Close: 101, SplitFactor: 1},
+	}, points)
+
+	_, err = ParseNDJSON(strings.NewReader("{\"ticker\":\"AAPL\",\"date\":\"2025-01-02\",\"close\":100}\n{\"ticker\":\"AAPL\",\"date\":\"01/03/2025\",\"close\":101}\n"))
+	assert.ErrorIs(t, err, ErrInvalidNDJSON)
+	assert.Contains(t, err.Error(), "line 2")
+
+	_, err = ParseNDJSON(strings.NewReader("ticker,date,close\n"))
+	assert.ErrorIs(t, err, ErrInvalidNDJSON)
+}
+
+func TestImport_Incremental(t *testing.T) {
+	mockRepo := new(MockPriceRepository)
+
+	// AAPL has bars up to Friday Jan 3, MSFT has none
+	mockRepo.On("LatestDates", mock.Anything, []string{"AAPL", "MSFT"}).Return(map[string]time.Time{"AAPL": day("2025-01-03")}, nil)
+	mockRepo.On("SaveBatch", mock.Anything, []domain.PricePoint{
+		{Ticker: "AAPL", Date: day("2025-01-09"), Close: 103, SplitFactor: 1},
+		{Ticker: "MSFT", Date: day("2025-01-02"), Close: 400, SplitFactor: 1},
+		{Ticker: "MSFT", Date: day("2025-01-03"), Close: 402, SplitFactor: 1},
+	}).Return(nil)
+
+	uc := NewPriceUseCase(mockRepo, events.NewBus())
+	result, err := uc.Import(context.Background(), strings.NewReader(`{"ticker":"AAPL","date":"2025-01-02","close":99}
+{"ticker":"AAPL","date":"2025-01-03","close":100}
+{"ticker":"AAPL","date":"2025-01-09","close":103}
+{"ticker":"MSFT","date":"2025-01-02","close":401}
+{"ticker":"MSFT","date":"2025-01-03","close":402}
+{"ticker":"MSFT","date":"2025-01-02","close":400}
+`), domain.ImportOptions{Format: domain.FormatNDJSON, Mode: domain.ImportIncremental})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Imported)
+	assert.Equal(t, 2, result.Skipped)
+	assert.Equal(t, []string{"AAPL", "MSFT"}, result.Tickers)
+	// Monday to Wednesday are missing between the stored Friday and the imported Thursday
+	assert.Equal(t, []domain.Gap{{Ticker: "AAPL", After: day("2025-01-03"), Before: day("2025-01-09"), Missing: 3}}, result.Gaps)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImport_InvalidOptions(t *testing.T) {
+	uc := NewPriceUseCase(new(MockPriceRepository), events.NewBus())
+
+	_, err := uc.Import(context.Background(), strings.NewReader(""), domain.ImportOptions{Format: "xml"})
+	assert.ErrorIs(t, err, domain.ErrInvalidFormat)
+
+	_, err = uc.Import(context.Background(), strings.NewReader(""), domain.ImportOptions{Mode: "append"})
+	assert.ErrorIs(t, err, domain.ErrInvalidImportMode)
+}
+
+func TestGetPrices(t *testing.T) {
+	mockRepo := new(MockPriceRepository)
+	bars := []domain.PricePoint{
+		{Ticker: "NVDA", Date: day("2024-06-03"), Open: 1000, High: 1100, Low: 990, Close: 1090, Volume: 100, SplitFactor: 1},
+		{Ticker: "NVDA", Date: day("2024-06-07"), Open: 1200, High: 1210, Low: 1180, Close: 1200, Volume: 200, SplitFactor: 1},
+		{Ticker: "NVDA", Date: day("2024-06-10"), Open: 120, High: 125, Low: 118, Close: 121, Volume: 3000, SplitFactor: 10},
+		{Ticker: "NVDA", Date: day("2024-06-11"), Open: 121, High: 123, Low: 120, Close: 122, Volume: 1000, SplitFactor: 1},
+	}
+	mockRepo.On("History", mock.Anything, "NVDA", day("2024-06-01"), day("2024-06-30")).Return(bars, nil)
+	mockRepo.On("Splits", mock.Anything, "NVDA", day("2024-06-03")).Return([]domain.PricePoint{bars[2]}, nil)
+
+	uc := NewPriceUseCase(mockRepo, nil)
+	series, err := uc.GetPrices(context.Background(), "nvda", domain.BarQuery{
+		From: day("2024-06-01"), To: day("2024-06-30"), Interval: domain.IntervalWeek, Adjusted: true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "NVDA", series.Ticker)
+	// Tuesday to Thursday are missing in the first week
+	assert.Equal(t, []domain.Gap{{Ticker: "NVDA", After: day("2024-06-03"), Before: day("2024-06-07"), Missing: 3}}, series.Gaps)
+	// The bars before the 10-for-1 split are restated in post-split shares
+	assert.Equal(t, []domain.PricePoint{
+		{Ticker: "NVDA", Date: day("2024-06-03"), Open: 100, High: 121, Low: 99, Close: 120, Volume: 3000, SplitFactor: 1},
+		{Ticker: "NVDA", Date: day("2024-06-10"), Open: 120, High: 125, Low: 118, Close: 122, Volume: 4000, SplitFactor: 10},
+	}, series.Bars)
+
+	_, err = uc.GetPrices(context.Background(), "NVDA", domain.BarQuery{From: day("2024-07-01"), To: day("2024-06-01")})
+	assert.ErrorIs(t, err, domain.ErrInvalidPriceRange)
+}
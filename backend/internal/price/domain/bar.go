@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidInterval   = errors.New("invalid interval: use day, week or month")
+	ErrInvalidImportMode = errors.New("invalid import mode: use bulk or incremental")
+	ErrInvalidFormat     = errors.New("invalid price format: use csv or ndjson")
+	ErrInvalidPriceRange = errors.New("invalid range: use YYYY-MM-DD dates with from not after to")
+)
+
+// Interval is the period a bar of a price series covers
+type Interval string
+
+const (
+	IntervalDay   Interval = "day"
+	IntervalWeek  Interval = "week"
+	IntervalMonth Interval = "month"
+)
+
+// ParseInterval validates an interval name, returning day when value is empty
+func ParseInterval(value string) (Interval, error) {
+	if value == "" {
+		return IntervalDay, nil
+	}
+	switch interval := Interval(strings.ToLower(value)); interval {
+	case IntervalDay, IntervalWeek, IntervalMonth:
+		return interval, nil
+	}
+	return "", ErrInvalidInterval
+}
+
+// Truncate returns the start of the interval containing t (weeks start on Monday)
+func (i Interval) Truncate(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch i {
+	case IntervalWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case IntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// ImportFormat is the encoding of a price import
+type ImportFormat string
+
+const (
+	// FormatCSV has a header naming the columns
+	FormatCSV ImportFormat = "csv"
+	// FormatNDJSON has one JSON bar per line
+	FormatNDJSON ImportFormat = "ndjson"
+)
+
+// ImportMode decides what happens to the days a ticker already has
+type ImportMode string
+
+const (
+	// ImportBulk replaces the days already imported
+	ImportBulk ImportMode = "bulk"
+	// ImportIncremental only adds the days after a ticker's latest stored day
+	ImportIncremental ImportMode = "incremental"
+)
+
+// ImportOptions select how an import is read and stored; empty options import a CSV in bulk
+type ImportOptions struct {
+	Format ImportFormat
+	Mode   ImportMode
+}
+
+// BarQuery selects a ticker's bars between two days, inclusive. Adjusted bars
+// divide prices and multiply volume by the splits that took effect later.
+type BarQuery struct {
+	From     time.Time
+	To       time.Time
+	Interval Interval
+	Adjusted bool
+}
+
+// Gap is a run of weekdays without a bar between two bars of a ticker
+type Gap struct {
+	Ticker string    `json:"ticker"`
+	After  time.Time `json:"after"`
+	Before time.Time `json:"before"`
+	// Missing is the number of weekdays between the bars
+	Missing int `json:"missing"`
+}
+
+// PriceSeries is a ticker's bars over a range, downsampled to an interval. Gaps
+// are found between the daily bars.
+type PriceSeries struct {
+	Ticker   string       `json:"ticker"`
+	From     time.Time    `json:"from"`
+	To       time.Time    `json:"to"`
+	Interval Interval     `json:"interval"`
+	Adjusted bool         `json:"adjusted"`
+	Bars     []PricePoint `json:"bars"`
+	Gaps     []Gap        `json:"gaps"`
+}
+
+// AdjustForSplits restates bars in the shares after every split that took
+// effect after them: prices are divided by the splits and volume multiplied.
+// splits are the bars with a split factor from the first bar on, oldest first.
+func AdjustForSplits(bars, splits []PricePoint) {
+	for i := range bars {
+		factor := 1.0
+		for _, split := range splits {
+			if split.Date.After(bars[i].Date) && split.SplitFactor > 0 {
+				factor *= split.SplitFactor
+			}
+		}
+		if factor == 1 {
+			continue
+		}
+		bars[i].Open /= factor
+		bars[i].High /= factor
+		bars[i].Low /= factor
+		bars[i].Close /= factor
+		bars[i].Volume = int64(math.Round(float64(bars[i].Volume) * factor))
+	}
+}
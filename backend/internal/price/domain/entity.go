@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// MaxTickerLength matches the ticker column of the stocks, so bars join the analyst actions
+const MaxTickerLength = 10
+
+// PricePoint is the daily bar of a ticker. Open, high, low, volume and the
+// adjusted close are 0 when the source only gave the close.
+type PricePoint struct {
+	Ticker string    `json:"ticker" gorm:"primaryKey;size:10"`
+	Date   time.Time `json:"date" gorm:"primaryKey;type:date"`
+	Open   float64   `json:"open,omitempty" gorm:"type:decimal(12,4);not null;default:0"`
+	High   float64   `json:"high,omitempty" gorm:"type:decimal(12,4);not null;default:0"`
+	Low    float64   `json:"low,omitempty" gorm:"type:decimal(12,4);not null;default:0"`
+	Close  float64   `json:"close" gorm:"type:decimal(12,4);not null"`
+	Volume int64     `json:"volume,omitempty" gorm:"not null;default:0"`
+	// AdjClose is the close adjusted for splits and dividends as given by the source
+	AdjClose float64 `json:"adj_close,omitempty" gorm:"type:decimal(12,4);not null;default:0"`
+	// SplitFactor is the split taking effect on the day, e.g. 4 for a 4-for-1
+	// split; 1 when there is none
+	SplitFactor float64 `json:"split_factor" gorm:"type:decimal(12,6);not null;default:1"`
+}
+
+func (PricePoint) TableName() string {
+	return "price_history"
+}
+
+// ImportResult reports the outcome of a price import
+type ImportResult struct {
+	Imported int      `json:"imported"`
+	Tickers  []string `json:"tickers"`
+	// Skipped counts the days an incremental import already had
+	Skipped int `json:"skipped,omitempty"`
+	// Gaps lists the weekdays missing between the imported days
+	Gaps []Gap `json:"gaps,omitempty"`
+}
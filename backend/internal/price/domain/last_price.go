@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidFeed     = errors.New("invalid price feed")
+	ErrFeedUnavailable = errors.New("price feed is unavailable")
+	ErrNoPriceFeed     = errors.New("no price feed is configured")
+)
+
+// LastPrice is the latest known price of a ticker
+type LastPrice struct {
+	Ticker string  `json:"ticker" gorm:"primaryKey;size:10"`
+	Price  float64 `json:"price" gorm:"type:decimal(12,4);not null"`
+	// At is when the feed quoted the price
+	At        time.Time `json:"at" gorm:"type:timestamp;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"type:timestamp;autoUpdateTime"`
+}
+
+func (LastPrice) TableName() string {
+	return "last_prices"
+}
+
+// Upside is how far a target is above a price, in percent; a target below the
+// price is a downside and negative
+func Upside(target, price float64) float64 {
+	return (target/price - 1) * 100
+}
+
+// PriceProvider is a feed of current prices, such as a file or an HTTP endpoint
+type PriceProvider interface {
+	// LatestPrices returns the prices the feed currently quotes
+	LatestPrices(ctx context.Context) ([]LastPrice, error)
+}
+
+// LastPriceSource serves the latest prices to other modules
+type LastPriceSource interface {
+	// LastPrices maps the given tickers to their latest price, or every ticker
+	// when none are given; tickers without a price are missing from the map
+	LastPrices(ctx context.Context, tickers []string) (map[string]LastPrice, error)
+}
+
+type LastPriceRepository interface {
+	LastPriceSource
+	// SaveLastPrices stores prices, keeping the stored price of a ticker when it was quoted later
+	SaveLastPrices(ctx context.Context, prices []LastPrice) error
+}
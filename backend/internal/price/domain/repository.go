@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// HistoryProvider serves daily closing prices to other modules
+type HistoryProvider interface {
+	// History returns the bars of a ticker between from and to inclusive, oldest first
+	History(ctx context.Context, ticker string, from, to time.Time) ([]PricePoint, error)
+	// AdjustedHistory returns the same bars restated in the shares after every
+	// later split, so returns measured across a split are not distorted
+	AdjustedHistory(ctx context.Context, ticker string, from, to time.Time) ([]PricePoint, error)
+}
+
+type PriceRepository interface {
+	HistoryProvider
+	// SaveBatch inserts points, replacing the bars of days already stored
+	SaveBatch(ctx context.Context, points []PricePoint) error
+	// LatestDates maps the given tickers to their latest stored day; tickers without bars are missing
+	LatestDates(ctx context.Context, tickers []string) (map[string]time.Time, error)
+	// Splits returns the bars of a ticker after a day whose split factor is not 1, oldest first
+	Splits(ctx context.Context, ticker string, after time.Time) ([]PricePoint, error)
+}
@@ -0,0 +1,191 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+)
+
+// feedTimeout bounds a request to an HTTP price feed
+const feedTimeout = 10 * time.Second
+
+// NewPriceProvider reads current prices from an http(s) URL or a file path, or
+// returns nil when feed is empty
+func NewPriceProvider(feed string) domain.PriceProvider {
+	switch {
+	case feed == "":
+		return nil
+	case strings.HasPrefix(feed, "http://"), strings.HasPrefix(feed, "https://"):
+		return &httpPriceProvider{url: feed, client: &http.Client{Timeout: feedTimeout}}
+	default:
+		return &filePriceProvider{path: feed}
+	}
+}
+
+// filePriceProvider reads a JSON file when its extension is .json and a CSV file otherwise
+type filePriceProvider struct {
+	path string
+}
+
+func (p *filePriceProvider) LatestPrices(ctx context.Context) ([]domain.LastPrice, error) {
+	file, err := os.Open(p.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseFeed(file, strings.EqualFold(filepath.Ext(p.path), ".json"))
+}
+
+// httpPriceProvider reads a JSON response when its content type says so and a CSV one otherwise,
+// such as a local stand-in serving a feed file
+type httpPriceProvider struct {
+	url    string
+	client *http.Client
+}
+
+func (p *httpPriceProvider) LatestPrices(ctx context.Context) ([]domain.LastPrice, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("price feed returned status %d", resp.StatusCode)
+	}
+	return ParseFeed(resp.Body, strings.Contains(resp.Header.Get("Content-Type"), "json"))
+}
+
+// ParseFeed reads current prices from a JSON array of {"ticker", "price", "at"}
+// objects, or from a CSV with a ticker,price header and an optional at column.
+// Times use RFC 3339 or YYYY-MM-DD and may be left out, tickers are upper-cased
+// and at most domain.MaxTickerLength characters long.
+func ParseFeed(r io.Reader, isJSON bool) ([]domain.LastPrice, error) {
+	var prices []domain.LastPrice
+	var err error
+	if isJSON {
+		prices, err = parseJSONFeed(r)
+	} else {
+		prices, err = parseCSVFeed(r)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range prices {
+		prices[i].Ticker = strings.ToUpper(strings.TrimSpace(prices[i].Ticker))
+		if prices[i].Ticker == "" {
+			return nil, fmt.Errorf("%w: price %d: empty ticker", domain.ErrInvalidFeed, i+1)
+		}
+		if len(prices[i].Ticker) > domain.MaxTickerLength {
+			return nil, fmt.Errorf("%w: price %d: ticker %q is longer than %d characters", domain.ErrInvalidFeed, i+1, prices[i].Ticker, domain.MaxTickerLength)
+		}
+		if prices[i].Price <= 0 {
+			return nil, fmt.Errorf("%w: price %d: %s price is not positive", domain.ErrInvalidFeed, i+1, prices[i].Ticker)
+		}
+	}
+	return prices, nil
+}
+
+type feedEntry struct {
+	Ticker string  `json:"ticker"`
+	Price  float64 `json:"price"`
+	At     string  `json:"at"`
+}
+
+func parseJSONFeed(r io.Reader) ([]domain.LastPrice, error) {
+	var entries []feedEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidFeed, err)
+	}
+
+	prices := make([]domain.LastPrice, 0, len(entries))
+	for i, entry := range entries {
+		at, err := parseFeedTime(entry.At)
+		if err != nil {
+			return nil, fmt.Errorf("%w: price %d: %v", domain.ErrInvalidFeed, i+1, err)
+		}
+		prices = append(prices, domain.LastPrice{Ticker: entry.Ticker, Price: entry.Price, At: at})
+	}
+	return prices, nil
+}
+
+func parseCSVFeed(r io.Reader) ([]domain.LastPrice, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: empty feed", domain.ErrInvalidFeed)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidFeed, err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, column := range []string{"ticker", "price"} {
+		if _, ok := index[column]; !ok {
+			return nil, fmt.Errorf("%w: missing %s column", domain.ErrInvalidFeed, column)
+		}
+	}
+
+	var prices []domain.LastPrice
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return prices, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidFeed, err)
+		}
+
+		field := func(column string) string {
+			if i, ok := index[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		price, err := strconv.ParseFloat(field("price"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: price %q is not a number", domain.ErrInvalidFeed, line, field("price"))
+		}
+		at, err := parseFeedTime(field("at"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidFeed, line, err)
+		}
+		prices = append(prices, domain.LastPrice{Ticker: field("ticker"), Price: price, At: at})
+	}
+}
+
+// parseFeedTime reads an RFC 3339 time or a YYYY-MM-DD date; an empty value is the zero time
+func parseFeedTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if at, err := time.Parse(time.RFC3339, value); err == nil {
+		return at, nil
+	}
+	if at, err := time.Parse("2006-01-02", value); err == nil {
+		return at, nil
+	}
+	return time.Time{}, fmt.Errorf("time %q is not RFC 3339 or YYYY-MM-DD", value)
+}
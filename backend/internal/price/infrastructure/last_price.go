@@ -0,0 +1,51 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type lastPriceRepository struct {
+	db *gorm.DB
+}
+
+func NewLastPriceRepository(db *gorm.DB) domain.LastPriceRepository {
+	return &lastPriceRepository{db: db}
+}
+
+// SaveLastPrices upserts prices; a feed replaying an older quote does not
+// overwrite a newer one
+func (r *lastPriceRepository) SaveLastPrices(ctx context.Context, prices []domain.LastPrice) error {
+	if len(prices) == 0 {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "ticker"}},
+			DoUpdates: clause.AssignmentColumns([]string{"price", "at", "updated_at"}),
+			Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "excluded.at >= last_prices.at"}}},
+		}).
+		CreateInBatches(prices, 500).Error
+}
+
+func (r *lastPriceRepository) LastPrices(ctx context.Context, tickers []string) (map[string]domain.LastPrice, error) {
+	query := r.db.WithContext(ctx)
+	if len(tickers) > 0 {
+		query = query.Where("ticker IN ?", tickers)
+	}
+
+	var prices []domain.LastPrice
+	if err := query.Find(&prices).Error; err != nil {
+		return nil, err
+	}
+
+	byTicker := make(map[string]domain.LastPrice, len(prices))
+	for _, price := range prices {
+		byTicker[price.Ticker] = price
+	}
+	return byTicker, nil
+}
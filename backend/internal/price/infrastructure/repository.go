@@ -0,0 +1,89 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type priceRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceRepository(db *gorm.DB) domain.PriceRepository {
+	return &priceRepository{db: db}
+}
+
+func (r *priceRepository) SaveBatch(ctx context.Context, points []domain.PricePoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "ticker"}, {Name: "date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"open", "high", "low", "close", "volume", "adj_close", "split_factor"}),
+		}).
+		CreateInBatches(points, 500).Error
+}
+
+func (r *priceRepository) History(ctx context.Context, ticker string, from, to time.Time) ([]domain.PricePoint, error) {
+	var points []domain.PricePoint
+	err := r.db.WithContext(ctx).
+		Where("ticker = ? AND date BETWEEN ? AND ?", ticker, from, to).
+		Order("date ASC").
+		Find(&points).Error
+	return points, err
+}
+
+func (r *priceRepository) AdjustedHistory(ctx context.Context, ticker string, from, to time.Time) ([]domain.PricePoint, error) {
+	points, err := r.History(ctx, ticker, from, to)
+	if err != nil || len(points) == 0 {
+		return points, err
+	}
+
+	splits, err := r.Splits(ctx, ticker, points[0].Date)
+	if err != nil {
+		return nil, err
+	}
+	domain.AdjustForSplits(points, splits)
+	return points, nil
+}
+
+func (r *priceRepository) LatestDates(ctx context.Context, tickers []string) (map[string]time.Time, error) {
+	if len(tickers) == 0 {
+		return map[string]time.Time{}, nil
+	}
+
+	var rows []struct {
+		Ticker string
+		Latest time.Time
+	}
+	err := r.db.WithContext(ctx).
+		Model(&domain.PricePoint{}).
+		Select("ticker, MAX(date) AS latest").
+		Where("ticker IN ?", tickers).
+		Group("ticker").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]time.Time, len(rows))
+	for _, row := range rows {
+		latest[row.Ticker] = row.Latest
+	}
+	return latest, nil
+}
+
+func (r *priceRepository) Splits(ctx context.Context, ticker string, after time.Time) ([]domain.PricePoint, error) {
+	var points []domain.PricePoint
+	err := r.db.WithContext(ctx).
+		Where("ticker = ? AND date > ? AND split_factor <> 1", ticker, after).
+		Order("date ASC").
+		Find(&points).Error
+	return points, err
+}
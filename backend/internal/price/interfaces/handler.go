@@ -0,0 +1,79 @@
+package interfaces
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/price/application"
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+	"github.com/bryanriosb/stock-info/shared/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	useCase application.PriceUseCase
+}
+
+func NewHandler(useCase application.PriceUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// ImportPrices stores the daily bars of a CSV or NDJSON request body. The
+// format follows ?format= or else the content type, the mode ?mode=.
+func (h *Handler) ImportPrices(c *fiber.Ctx) error {
+	options := domain.ImportOptions{
+		Format: domain.ImportFormat(strings.ToLower(c.Query("format"))),
+		Mode:   domain.ImportMode(strings.ToLower(c.Query("mode"))),
+	}
+	if options.Format == "" && strings.Contains(c.Get(fiber.HeaderContentType), "ndjson") {
+		options.Format = domain.FormatNDJSON
+	}
+
+	result, err := h.useCase.Import(c.Context(), bytes.NewReader(c.Body()), options)
+	if err != nil {
+		if errors.Is(err, application.ErrInvalidCSV) || errors.Is(err, application.ErrInvalidNDJSON) ||
+			errors.Is(err, domain.ErrInvalidFormat) || errors.Is(err, domain.ErrInvalidImportMode) {
+			return response.BadRequest(c, err.Error())
+		}
+		return response.InternalError(c, "Failed to import prices")
+	}
+
+	return response.Success(c, result)
+}
+
+// GetPrices returns a ticker's bars between ?from= and ?to= (YYYY-MM-DD,
+// inclusive), combined per ?interval= and split-adjusted with ?adjusted=true
+func (h *Handler) GetPrices(c *fiber.Ctx) error {
+	var query domain.BarQuery
+	for _, bound := range []struct {
+		name  string
+		value *time.Time
+	}{{"from", &query.From}, {"to", &query.To}} {
+		if value := c.Query(bound.name); value != "" {
+			day, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return response.BadRequest(c, domain.ErrInvalidPriceRange.Error())
+			}
+			*bound.value = day
+		}
+	}
+
+	interval, err := domain.ParseInterval(c.Query("interval"))
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	query.Interval = interval
+	query.Adjusted = c.QueryBool("adjusted")
+
+	series, err := h.useCase.GetPrices(c.Context(), c.Params("symbol"), query)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPriceRange) {
+			return response.BadRequest(c, err.Error())
+		}
+		return response.InternalError(c, "Failed to fetch prices")
+	}
+
+	return response.Success(c, series)
+}
@@ -0,0 +1,105 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/price/application"
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock PriceUseCase
+type MockPriceUseCase struct {
+	mock.Mock
+}
+
+func (m *MockPriceUseCase) Import(ctx context.Context, r io.Reader, options domain.ImportOptions) (*domain.ImportResult, error) {
+	args := m.Called(ctx, r, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportResult), args.Error(1)
+}
+
+func (m *MockPriceUseCase) GetPrices(ctx context.Context, ticker string, query domain.BarQuery) (*domain.PriceSeries, error) {
+	args := m.Called(ctx, ticker, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PriceSeries), args.Error(1)
+}
+
+func setupPriceApp(handler *Handler) *fiber.App {
+	app := fiber.New()
+	app.Post("/prices/import", handler.ImportPrices)
+	app.Get("/tickers/:symbol/prices", handler.GetPrices)
+	return app
+}
+
+func TestImportPrices_Options(t *testing.T) {
+	mockUC := new(MockPriceUseCase)
+	app := setupPriceApp(NewHandler(mockUC))
+
+	mockUC.On("Import", mock.Anything, mock.Anything, domain.ImportOptions{Format: domain.FormatNDJSON, Mode: domain.ImportIncremental}).
+		Return(&domain.ImportResult{Imported: 1, Tickers: []string{"AAPL"}}, nil)
+
+	req := httptest.NewRequest("POST", "/prices/import?mode=incremental", strings.NewReader(`{"ticker":"AAPL","date":"2025-01-02","close":100}`))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestImportPrices_Invalid(t *testing.T) {
+	for _, err := range []error{application.ErrInvalidNDJSON, domain.ErrInvalidImportMode} {
+		mockUC := new(MockPriceUseCase)
+		app := setupPriceApp(NewHandler(mockUC))
+		mockUC.On("Import", mock.Anything, mock.Anything, mock.Anything).Return(nil, err)
+
+		resp, testErr := app.Test(httptest.NewRequest("POST", "/prices/import", strings.NewReader("")))
+
+		assert.NoError(t, testErr)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, err.Error())
+	}
+}
+
+func TestGetPrices(t *testing.T) {
+	mockUC := new(MockPriceUseCase)
+	app := setupPriceApp(NewHandler(mockUC))
+
+	query := domain.BarQuery{
+		From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+		Interval: domain.IntervalMonth,
+		Adjusted: true,
+	}
+	mockUC.On("GetPrices", mock.Anything, "NVDA", query).Return(&domain.PriceSeries{Ticker: "NVDA", Interval: domain.IntervalMonth}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/tickers/NVDA/prices?from=2024-01-01&to=2024-06-30&interval=month&adjusted=true", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetPrices_InvalidQuery(t *testing.T) {
+	mockUC := new(MockPriceUseCase)
+	app := setupPriceApp(NewHandler(mockUC))
+
+	for _, url := range []string{"/tickers/NVDA/prices?interval=hour", "/tickers/NVDA/prices?from=2024-13-01"} {
+		resp, err := app.Test(httptest.NewRequest("GET", url, nil))
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, url)
+	}
+	mockUC.AssertNotCalled(t, "GetPrices")
+}
@@ -0,0 +1,48 @@
+package interfaces
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/bryanriosb/stock-info/internal/price/application"
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+	"github.com/bryanriosb/stock-info/shared/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+type LastPriceHandler struct {
+	lastPrices application.LastPriceUseCase
+}
+
+func NewLastPriceHandler(lastPrices application.LastPriceUseCase) *LastPriceHandler {
+	return &LastPriceHandler{lastPrices: lastPrices}
+}
+
+// GetLastPrices returns the latest prices of the comma-separated tickers, or of every ticker
+func (h *LastPriceHandler) GetLastPrices(c *fiber.Ctx) error {
+	var tickers []string
+	if value := c.Query("tickers"); value != "" {
+		tickers = strings.Split(value, ",")
+	}
+
+	prices, err := h.lastPrices.GetLastPrices(c.Context(), tickers)
+	if err != nil {
+		return response.InternalError(c, "Failed to fetch last prices")
+	}
+	return response.Success(c, prices)
+}
+
+// RefreshLastPrices reads the price feed now instead of waiting for its interval
+func (h *LastPriceHandler) RefreshLastPrices(c *fiber.Ctx) error {
+	result, err := h.lastPrices.Refresh(c.Context())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNoPriceFeed):
+			return response.Error(c, fiber.StatusServiceUnavailable, err.Error())
+		case errors.Is(err, domain.ErrInvalidFeed), errors.Is(err, domain.ErrFeedUnavailable):
+			return response.Error(c, fiber.StatusBadGateway, err.Error())
+		}
+		return response.InternalError(c, "Failed to refresh last prices")
+	}
+	return response.Success(c, result)
+}
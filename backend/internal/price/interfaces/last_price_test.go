@@ -0,0 +1,89 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+	"github.com/bryanriosb/stock-info/shared/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock LastPriceUseCase
+type MockLastPriceUseCase struct {
+	mock.Mock
+}
+
+func (m *MockLastPriceUseCase) GetLastPrices(ctx context.Context, tickers []string) ([]domain.LastPrice, error) {
+	args := m.Called(ctx, tickers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.LastPrice), args.Error(1)
+}
+
+func (m *MockLastPriceUseCase) Refresh(ctx context.Context) (*domain.ImportResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportResult), args.Error(1)
+}
+
+func setupLastPriceApp(handler *LastPriceHandler) *fiber.App {
+	app := fiber.New()
+	app.Get("/prices/last", handler.GetLastPrices)
+	app.Post("/prices/last/refresh", handler.RefreshLastPrices)
+	return app
+}
+
+func TestGetLastPrices(t *testing.T) {
+	mockUC := new(MockLastPriceUseCase)
+	app := setupLastPriceApp(NewLastPriceHandler(mockUC))
+
+	mockUC.On("GetLastPrices", mock.Anything, []string{"AAPL", "MSFT"}).
+		Return([]domain.LastPrice{{Ticker: "AAPL", Price: 190}}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/prices/last?tickers=AAPL,MSFT", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result response.Response
+	json.NewDecoder(resp.Body).Decode(&result)
+	assert.Len(t, result.Data, 1)
+	mockUC.AssertExpectations(t)
+}
+
+func TestRefreshLastPrices(t *testing.T) {
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{nil, fiber.StatusOK},
+		{domain.ErrNoPriceFeed, fiber.StatusServiceUnavailable},
+		{fmt.Errorf("%w: line 2: price \"abc\" is not a number", domain.ErrInvalidFeed), fiber.StatusBadGateway},
+		{fmt.Errorf("%w: connection refused", domain.ErrFeedUnavailable), fiber.StatusBadGateway},
+		{errors.New("database error"), fiber.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		mockUC := new(MockLastPriceUseCase)
+		app := setupLastPriceApp(NewLastPriceHandler(mockUC))
+		if tc.err != nil {
+			mockUC.On("Refresh", mock.Anything).Return(nil, tc.err)
+		} else {
+			mockUC.On("Refresh", mock.Anything).Return(&domain.ImportResult{Imported: 1, Tickers: []string{"AAPL"}}, nil)
+		}
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/prices/last/refresh", nil))
+
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, resp.StatusCode, fmt.Sprint(tc.err))
+	}
+}
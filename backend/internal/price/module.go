@@ -0,0 +1,39 @@
+package price
+
+import (
+	"context"
+
+	"github.com/bryanriosb/stock-info/internal/price/application"
+	"github.com/bryanriosb/stock-info/internal/price/domain"
+	"github.com/bryanriosb/stock-info/internal/price/infrastructure"
+	"github.com/bryanriosb/stock-info/internal/price/interfaces"
+	"github.com/bryanriosb/stock-info/shared"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/bryanriosb/stock-info/shared/middleware"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Register mounts the price import, the daily bars and the latest prices, starting the price
+// feed job when a feed is configured, until ctx is done. It returns the price history and the
+// latest prices for other modules.
+func Register(ctx context.Context, app fiber.Router, db *gorm.DB, cfg *shared.Config, bus *events.Bus) (domain.HistoryProvider, domain.LastPriceSource) {
+	repo := infrastructure.NewPriceRepository(db)
+	useCase := application.NewPriceUseCase(repo, bus)
+	handler := interfaces.NewHandler(useCase)
+
+	lastPrices := infrastructure.NewLastPriceRepository(db)
+	provider := infrastructure.NewPriceProvider(cfg.Price.Feed)
+	job := application.NewPriceFeedJob(provider, lastPrices, bus)
+	if provider != nil {
+		job.Start(ctx, cfg.Price.FeedInterval)
+	}
+	lastPriceHandler := interfaces.NewLastPriceHandler(job)
+
+	app.Post("/prices/import", middleware.RequireAdmin(), handler.ImportPrices)
+	app.Get("/tickers/:symbol/prices", handler.GetPrices)
+	app.Get("/prices/last", lastPriceHandler.GetLastPrices)
+	app.Post("/prices/last/refresh", middleware.RequireAdmin(), lastPriceHandler.RefreshLastPrices)
+
+	return repo, lastPrices
+}
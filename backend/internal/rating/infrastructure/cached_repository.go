@@ -0,0 +1,29 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/rating/domain"
+	"github.com/bryanriosb/stock-info/shared/cache"
+)
+
+// CacheNamespace prefixes every rating option cache key
+const CacheNamespace = "ratings:"
+
+// cachedRatingOptionRepository serves FindAll from the application cache
+type cachedRatingOptionRepository struct {
+	domain.RatingOptionRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+func NewCachedRatingOptionRepository(repo domain.RatingOptionRepository, c cache.Cache, ttl time.Duration) domain.RatingOptionRepository {
+	return &cachedRatingOptionRepository{RatingOptionRepository: repo, cache: c, ttl: ttl}
+}
+
+func (r *cachedRatingOptionRepository) FindAll(ctx context.Context) ([]*domain.RatingOption, error) {
+	return cache.GetOrLoad(ctx, r.cache, CacheNamespace+"findall", r.ttl, func() ([]*domain.RatingOption, error) {
+		return r.RatingOptionRepository.FindAll(ctx)
+	})
+}
@@ -18,7 +18,12 @@ func NewHandler(repo domain.RatingOptionRepository) *Handler {
 	return &Handler{repo: repo}
 }
 
+// GetAllRatingOptions lists every rating. It is deprecated in favour of the
+// rating_to facet of /stocks, which leaves out ratings without results.
 func (h *Handler) GetAllRatingOptions(c *fiber.Ctx) error {
+	c.Set("Deprecation", "true")
+	c.Set(fiber.HeaderLink, `</api/v1/stocks?facets=rating_to>; rel="successor-version"`)
+
 	ctx := context.Background()
 
 	options, err := h.repo.FindAll(ctx)
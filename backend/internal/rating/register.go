@@ -1,15 +1,20 @@
 package rating
 
 import (
+	"github.com/bryanriosb/stock-info/internal/rating/domain"
 	"github.com/bryanriosb/stock-info/internal/rating/infrastructure"
 	"github.com/bryanriosb/stock-info/internal/rating/interfaces"
+	"github.com/bryanriosb/stock-info/shared"
+	"github.com/bryanriosb/stock-info/shared/cache"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
-func Register(app fiber.Router, db *gorm.DB) {
-	repo := infrastructure.NewRatingOptionRepository(db)
+func Register(app fiber.Router, db *gorm.DB, cfg *shared.Config, appCache cache.Cache) domain.RatingOptionRepository {
+	repo := infrastructure.NewCachedRatingOptionRepository(infrastructure.NewRatingOptionRepository(db), appCache, cfg.Cache.TTL)
 	handler := interfaces.NewHandler(repo)
 
 	app.Get("/rating-options", handler.GetAllRatingOptions)
+
+	return repo
 }
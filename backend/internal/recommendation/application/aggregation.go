@@ -0,0 +1,81 @@
+package application
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+)
+
+// aggregate combines the brokerage scores on a ticker into one score
+func aggregate(aggregation domain.Aggregation, signals []domain.BrokerageSignal) float64 {
+	if len(signals) == 0 {
+		return 0
+	}
+
+	switch aggregation {
+	case domain.AggregationMedian:
+		scores := make([]float64, len(signals))
+		for i, signal := range signals {
+			scores[i] = signal.Score
+		}
+		sort.Float64s(scores)
+		middle := len(scores) / 2
+		if len(scores)%2 == 0 {
+			return (scores[middle-1] + scores[middle]) / 2
+		}
+		return scores[middle]
+	case domain.AggregationWeighted:
+		var sum, weights float64
+		for _, signal := range signals {
+			weight := signal.DecayWeight * signal.Credibility
+			sum += signal.Score * weight
+			weights += weight
+		}
+		if weights == 0 {
+			return 0
+		}
+		return sum / weights
+	default:
+		var sum float64
+		for _, signal := range signals {
+			sum += signal.Score
+		}
+		return sum / float64(len(signals))
+	}
+}
+
+// agreeing counts the brokerages whose score points the same way as the
+// combined score; a neutral score only agrees with neutral signals
+func agreeing(score float64, signals []domain.BrokerageSignal) int {
+	count := 0
+	for _, signal := range signals {
+		if sign(signal.Score) == sign(score) {
+			count++
+		}
+	}
+	return count
+}
+
+// conviction discounts agreement by coverage, so one brokerage agreeing with
+// itself scores 0.5 while four brokerages in agreement score 0.8
+func conviction(agreement float64, brokerages int) float64 {
+	n := float64(brokerages)
+	return agreement * n / (n + 1)
+}
+
+// agreementReason summarises how many brokerages back the score
+func agreementReason(agree, brokerages int) string {
+	return fmt.Sprintf("%d of %d brokerages agree", agree, brokerages)
+}
+
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregate(t *testing.T) {
+	signals := []domain.BrokerageSignal{
+		{Score: 0.9, DecayWeight: 1, Credibility: 1},
+		{Score: 0.1, DecayWeight: 0.25, Credibility: 1},
+		{Score: -0.4, DecayWeight: 0.25, Credibility: 1},
+		{Score: 0.2, DecayWeight: 0.5, Credibility: 1},
+	}
+
+	assert.InDelta(t, 0.2, aggregate(domain.AggregationMean, signals), 1e-9)
+	assert.InDelta(t, 0.15, aggregate(domain.AggregationMedian, signals), 1e-9)
+	assert.InDelta(t, (0.9+0.025-0.1+0.1)/2, aggregate(domain.AggregationWeighted, signals), 1e-9)
+	assert.InDelta(t, 0.1, aggregate(domain.AggregationMedian, signals[1:]), 1e-9)
+	assert.Equal(t, 0.0, aggregate(domain.AggregationMean, nil))
+
+	// A credible brokerage outweighs an unreliable one at the same age
+	credible := []domain.BrokerageSignal{
+		{Score: 1, DecayWeight: 1, Credibility: 1.5},
+		{Score: -1, DecayWeight: 1, Credibility: 0.5},
+	}
+	assert.InDelta(t, 0.5, aggregate(domain.AggregationWeighted, credible), 1e-9)
+	assert.InDelta(t, 0.0, aggregate(domain.AggregationMean, credible), 1e-9)
+}
+
+func TestAgreementAndConviction(t *testing.T) {
+	signals := []domain.BrokerageSignal{{Score: 0.5}, {Score: 0.2}, {Score: 0}, {Score: -0.3}}
+
+	assert.Equal(t, 2, agreeing(0.1, signals))
+	assert.Equal(t, 1, agreeing(-0.1, signals))
+	assert.Equal(t, 1, agreeing(0, signals))
+	assert.InDelta(t, 0.5, conviction(1, 1), 1e-9)
+	assert.InDelta(t, 0.8, conviction(1, 4), 1e-9)
+}
+
+func TestParseAggregation(t *testing.T) {
+	aggregation, err := domain.ParseAggregation("Median")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AggregationMedian, aggregation)
+
+	_, err = domain.ParseAggregation("mode")
+	assert.ErrorIs(t, err, domain.ErrUnknownAggregation)
+}
+
+func TestGetRecommendations_CombinesBrokeragesPerTicker(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+
+	expectScan(mockRepo, []*stockDomain.Stock{
+		{ID: 1, Ticker: "AAPL", Brokerage: "Goldman Sachs", RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 100, TargetTo: 120, Action: "upgraded by"},
+		{ID: 2, Ticker: "AAPL", Brokerage: "Morgan Stanley", RatingFrom: "Buy", RatingTo: "Buy", TargetFrom: 100, TargetTo: 110, Action: "target raised by"},
+		{ID: 3, Ticker: "AAPL", Brokerage: "UBS", RatingFrom: "Buy", RatingTo: "Hold", TargetFrom: 100, TargetTo: 90, Action: "downgraded by"},
+		{ID: 4, Ticker: "MSFT", Brokerage: "UBS", RatingFrom: "Hold", RatingTo: "Hold", TargetFrom: 100, TargetTo: 100, Action: "maintained"},
+	})
+
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.AggregationWeighted, nil, nil)
+	recommendations, total, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Aggregation: "mean"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, recommendations, 2)
+
+	aapl := recommendations[0]
+	assert.Equal(t, "AAPL", aapl.Ticker)
+	assert.Equal(t, domain.AggregationMean, aapl.Aggregation)
+	assert.Len(t, aapl.Brokerages, 3)
+	assert.Equal(t, []string{"Goldman Sachs", "Morgan Stanley", "UBS"}, []string{
+		aapl.Brokerages[0].Brokerage, aapl.Brokerages[1].Brokerage, aapl.Brokerages[2].Brokerage,
+	})
+	assert.InDelta(t, (aapl.Brokerages[0].Score+aapl.Brokerages[1].Score+aapl.Brokerages[2].Score)/3, aapl.Score, 1e-9)
+	assert.InDelta(t, 2.0/3, aapl.Agreement, 1e-9)
+	assert.InDelta(t, 2.0/3*3/4, aapl.Conviction, 1e-9)
+	assert.InDelta(t, 20.0/3, aapl.PotentialGain, 1e-9)
+	assert.Contains(t, aapl.Reason, "2 of 3 brokerages agree")
+
+	msft := recommendations[1]
+	assert.Equal(t, "MSFT", msft.Ticker)
+	assert.Len(t, msft.Brokerages, 1)
+	assert.NotContains(t, msft.Reason, "brokerages agree")
+}
+
+func TestGetRecommendations_UnknownAggregation(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	_, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Aggregation: "mode"})
+
+	assert.ErrorIs(t, err, domain.ErrUnknownAggregation)
+	mockRepo.AssertNotCalled(t, "ScanTickers")
+}
+
+// Stub CredibilitySource
+type stubCredibility map[string]float64
+
+func (s stubCredibility) Weights(ctx context.Context) (map[string]float64, error) {
+	return s, nil
+}
+
+func TestGetRecommendations_WeighsBrokeragesByCredibility(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+
+	expectScan(mockRepo, []*stockDomain.Stock{
+		{ID: 1, Ticker: "AAPL", Brokerage: "Goldman Sachs", RatingFrom: "Hold", RatingTo: "Buy", Action: "upgraded by"},
+		{ID: 2, Ticker: "AAPL", Brokerage: "Morgan Stanley", RatingFrom: "Buy", RatingTo: "Hold", Action: "downgraded by"},
+		{ID: 3, Ticker: "AAPL", Brokerage: "UBS", RatingFrom: "Buy", RatingTo: "Buy", Action: "reiterated by"},
+	})
+
+	credibility := stubCredibility{"Goldman Sachs": 1.5, "Morgan Stanley": 0.5}
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.AggregationWeighted, credibility, nil)
+	recommendations, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{})
+
+	assert.NoError(t, err)
+	assert.Len(t, recommendations, 1)
+	signals := recommendations[0].Brokerages
+	assert.Equal(t, 1.5, signals[0].Credibility)
+	assert.Equal(t, 0.5, signals[1].Credibility)
+	// Brokerages without a track record weigh 1
+	assert.Equal(t, 1.0, signals[2].Credibility)
+
+	want := (signals[0].Score*1.5 + signals[1].Score*0.5 + signals[2].Score) / 3
+	assert.InDelta(t, want, recommendations[0].Score, 1e-9)
+}
@@ -0,0 +1,39 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	"github.com/bryanriosb/stock-info/shared/cache"
+)
+
+// CacheNamespace prefixes every recommendation cache key
+const CacheNamespace = "recommendations:"
+
+type recommendationPage struct {
+	Recommendations []*domain.StockRecommendation `json:"recommendations"`
+	Total           int64                         `json:"total"`
+}
+
+// cachedRecommendationUseCase serves computed recommendations from the application cache
+type cachedRecommendationUseCase struct {
+	RecommendationUseCase
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+func NewCachedRecommendationUseCase(useCase RecommendationUseCase, c cache.Cache, ttl time.Duration) RecommendationUseCase {
+	return &cachedRecommendationUseCase{RecommendationUseCase: useCase, cache: c, ttl: ttl}
+}
+
+func (uc *cachedRecommendationUseCase) GetRecommendations(ctx context.Context, query domain.RecommendationQuery) ([]*domain.StockRecommendation, int64, error) {
+	page, err := cache.GetOrLoad(ctx, uc.cache, cache.Key(CacheNamespace, query.Normalized()), uc.ttl, func() (recommendationPage, error) {
+		recommendations, total, err := uc.RecommendationUseCase.GetRecommendations(ctx, query)
+		return recommendationPage{Recommendations: recommendations, Total: total}, err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return page.Recommendations, page.Total, nil
+}
@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+const day = 24 * time.Hour
+
+func TestDecay_Exponential(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	decay := domain.Decay{Mode: domain.DecayExponential, HalfLife: 30 * day}
+
+	weight, fresh := decay.Weight(now, now)
+	assert.True(t, fresh)
+	assert.Equal(t, 1.0, weight)
+
+	weight, _ = decay.Weight(now.Add(-30*day), now)
+	assert.InDelta(t, 0.5, weight, 1e-9)
+
+	weight, _ = decay.Weight(now.Add(-45*day), now)
+	assert.InDelta(t, 0.3536, weight, 1e-4)
+}
+
+func TestDecay_Step(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	decay := domain.Decay{Mode: domain.DecayStep, Window: 30 * day}
+
+	weight, _ := decay.Weight(now.Add(-29*day), now)
+	assert.Equal(t, 1.0, weight)
+
+	weight, _ = decay.Weight(now.Add(-30*day), now)
+	assert.Equal(t, 0.5, weight)
+
+	weight, _ = decay.Weight(now.Add(-75*day), now)
+	assert.Equal(t, 0.25, weight)
+}
+
+func TestDecay_MaxAge(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	decay := domain.Decay{Mode: domain.DecayNone, MaxAge: 90 * day}
+
+	weight, fresh := decay.Weight(now.Add(-90*day), now)
+	assert.True(t, fresh)
+	assert.Equal(t, 1.0, weight)
+
+	_, fresh = decay.Weight(now.Add(-91*day), now)
+	assert.False(t, fresh)
+}
+
+func TestDecay_UndatedAndFutureActionsKeepFullWeight(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	decay := domain.Decay{Mode: domain.DecayExponential, HalfLife: day, MaxAge: day}
+
+	weight, fresh := decay.Weight(time.Time{}, now)
+	assert.True(t, fresh)
+	assert.Equal(t, 1.0, weight)
+
+	weight, fresh = decay.Weight(now.Add(day), now)
+	assert.True(t, fresh)
+	assert.Equal(t, 1.0, weight)
+}
+
+func TestDecay_Validate(t *testing.T) {
+	assert.NoError(t, domain.Decay{}.Validate())
+	assert.NoError(t, domain.Decay{Mode: domain.DecayExponential, HalfLife: day}.Validate())
+	assert.Error(t, domain.Decay{Mode: domain.DecayExponential}.Validate())
+	assert.Error(t, domain.Decay{Mode: domain.DecayStep}.Validate())
+	assert.Error(t, domain.Decay{Mode: "linear"}.Validate())
+	assert.Error(t, domain.Decay{MaxAge: -day}.Validate())
+}
+
+func TestGetRecommendations_DecaysOlderActions(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo := new(MockStockRepository)
+
+	// The same upgrade on three tickers made today, 30 days ago and beyond the max age
+	upgrade := func(id int64, at time.Time) *stockDomain.Stock {
+		return &stockDomain.Stock{ID: id, Ticker: fmt.Sprintf("T%d", id), RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 100, TargetTo: 120, Action: "upgraded by", Time: at}
+	}
+	expectScan(mockRepo, []*stockDomain.Stock{
+		upgrade(1, now.Add(-30*day)),
+		upgrade(2, now),
+		upgrade(3, now.Add(-400*day)),
+	})
+
+	decay := domain.Decay{Mode: domain.DecayExponential, HalfLife: 30 * day, MaxAge: 365 * day}
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), decay, domain.DefaultAggregation, nil, nil).(*recommendationUseCase)
+	uc.now = func() time.Time { return now }
+
+	recommendations, total, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, recommendations, 2)
+
+	fresh, old := recommendations[0], recommendations[1]
+	assert.Equal(t, int64(2), fresh.Stock.ID)
+	assert.Equal(t, 1.0, fresh.DecayWeight)
+	assert.NotContains(t, fresh.Reason, "days old")
+
+	assert.Equal(t, int64(1), old.Stock.ID)
+	assert.InDelta(t, 0.5, old.DecayWeight, 1e-9)
+	assert.InDelta(t, fresh.Score/2, old.Score, 1e-9)
+	assert.Contains(t, old.Reason, "30 days old, weighted 0.50")
+}
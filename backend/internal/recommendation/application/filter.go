@@ -0,0 +1,109 @@
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+)
+
+// filter is a query's Filter prepared for scoring every ticker: brokerage
+// names are matched without case and tickers upper-cased
+type filter struct {
+	domain.Filter
+	brokerages        map[string]bool
+	excludeBrokerages map[string]bool
+	excludeTickers    map[string]bool
+	maxAge            time.Duration
+	bucket            string
+	anomalies         string
+}
+
+func newFilter(f domain.Filter) *filter {
+	return &filter{
+		Filter:            f,
+		brokerages:        setOf(f.Brokerages, brokerageKey),
+		excludeBrokerages: setOf(f.ExcludeBrokerages, brokerageKey),
+		excludeTickers:    setOf(f.ExcludeTickers, tickerKey),
+		maxAge:            time.Duration(f.MaxAgeDays) * 24 * time.Hour,
+		bucket:            strings.ToLower(f.RatingBucket),
+		anomalies:         strings.ToLower(f.Anomalies),
+	}
+}
+
+// excludesTicker reports whether the ticker is left out altogether
+func (f *filter) excludesTicker(ticker string) bool {
+	return f.excludeTickers[tickerKey(ticker)]
+}
+
+// allowsAction reports whether an action counts towards its ticker's
+// recommendation. Undated actions pass the age filter, as they keep full weight;
+// flagged actions are dropped when anomalies are excluded.
+func (f *filter) allowsAction(stock *stockDomain.Stock, now time.Time) bool {
+	brokerage := brokerageKey(stock.Brokerage)
+	if len(f.brokerages) > 0 && !f.brokerages[brokerage] {
+		return false
+	}
+	if f.excludeBrokerages[brokerage] {
+		return false
+	}
+	if f.maxAge > 0 && !stock.Time.IsZero() && now.Sub(stock.Time) > f.maxAge {
+		return false
+	}
+	if f.anomalies == domain.AnomaliesExclude && len(stock.Anomalies) > 0 {
+		return false
+	}
+	return true
+}
+
+// anomalyWeight scales the score of an action, down-weighting flagged ones when asked to
+func (f *filter) anomalyWeight(stock *stockDomain.Stock) float64 {
+	if f.anomalies == domain.AnomaliesDownweight && len(stock.Anomalies) > 0 {
+		return domain.AnomalyWeight
+	}
+	return 1
+}
+
+// keeps reports whether a recommendation passes the coverage, gain and rating
+// bucket filters, rating with the query's scale
+func (f *filter) keeps(recommendation *domain.StockRecommendation, ratings domain.RatingScale) bool {
+	if len(recommendation.Brokerages) < f.MinBrokerages {
+		return false
+	}
+	if f.MinGain != nil && recommendation.PotentialGain < *f.MinGain {
+		return false
+	}
+	if f.bucket != "" {
+		var sum, rated float64
+		for _, signal := range recommendation.Brokerages {
+			if value := ratings.Value(signal.RatingTo); value > 0 {
+				sum += float64(value)
+				rated++
+			}
+		}
+		if rated == 0 || domain.RatingBucketOf(sum/rated) != f.bucket {
+			return false
+		}
+	}
+	return true
+}
+
+func setOf(values []string, key func(string) string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[key(value)] = true
+	}
+	return set
+}
+
+func brokerageKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func tickerKey(ticker string) string {
+	return strings.ToUpper(strings.TrimSpace(ticker))
+}
@@ -0,0 +1,125 @@
+package application
+
+import (
+	"context"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+)
+
+type ProfileUseCase interface {
+	// GetProfile returns the user's scoring profile or ErrProfileNotFound
+	GetProfile(ctx context.Context, username string) (*domain.ScoringProfile, error)
+	// SaveProfile validates the profile against its strategy and stores it as
+	// the user's, replacing any previous one
+	SaveProfile(ctx context.Context, username string, profile *domain.ScoringProfile) (*domain.ScoringProfile, error)
+	// DeleteProfile removes the user's profile or returns ErrProfileNotFound
+	DeleteProfile(ctx context.Context, username string) error
+	// Apply fills what the query leaves unset from the user's profile, if any
+	Apply(ctx context.Context, username string, query domain.RecommendationQuery) (domain.RecommendationQuery, error)
+}
+
+type profileUseCase struct {
+	repo       domain.ScoringProfileRepository
+	strategies *StrategyRegistry
+	bus        *events.Bus
+}
+
+// NewProfileUseCase stores scoring profiles, validated against the strategies
+// recommendations are ranked with, and announces every change on bus
+func NewProfileUseCase(repo domain.ScoringProfileRepository, strategies *StrategyRegistry, bus *events.Bus) ProfileUseCase {
+	return &profileUseCase{repo: repo, strategies: strategies, bus: bus}
+}
+
+func (uc *profileUseCase) GetProfile(ctx context.Context, username string) (*domain.ScoringProfile, error) {
+	profile, err := uc.repo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, domain.ErrProfileNotFound
+	}
+	return profile, nil
+}
+
+func (uc *profileUseCase) SaveProfile(ctx context.Context, username string, profile *domain.ScoringProfile) (*domain.ScoringProfile, error) {
+	strategy, err := uc.strategies.Resolve(ctx, profile.Strategy, username, profile.Tuning())
+	if err != nil {
+		return nil, err
+	}
+	ratings, err := profile.RatingScale.Normalized()
+	if err != nil {
+		return nil, err
+	}
+
+	// The strategy is stored by name so the profile's parameters apply to
+	// queries naming it as well as to those relying on the default
+	saved := &domain.ScoringProfile{
+		Username:    username,
+		Strategy:    strategy.Info().Name,
+		Parameters:  profile.Parameters,
+		RatingScale: ratings,
+	}
+	if err := uc.repo.Save(ctx, saved); err != nil {
+		return nil, err
+	}
+	uc.bus.Publish(events.ScoringProfileChanged, username)
+	return saved, nil
+}
+
+func (uc *profileUseCase) DeleteProfile(ctx context.Context, username string) error {
+	deleted, err := uc.repo.Delete(ctx, username)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return domain.ErrProfileNotFound
+	}
+	uc.bus.Publish(events.ScoringProfileChanged, username)
+	return nil
+}
+
+func (uc *profileUseCase) Apply(ctx context.Context, username string, query domain.RecommendationQuery) (domain.RecommendationQuery, error) {
+	profile, err := uc.repo.FindByUsername(ctx, username)
+	if err != nil {
+		return query, err
+	}
+	return profile.Apply(query), nil
+}
+
+// profiledRecommendationUseCase tunes every query by its caller's scoring
+// profile, so all transports rank with the profile the caller saved
+type profiledRecommendationUseCase struct {
+	RecommendationUseCase
+	profiles ProfileUseCase
+}
+
+// NewProfiledRecommendationUseCase fills what a query leaves unset from the
+// profile of the query's Username before useCase ranks it. Queries without a
+// user pass through unchanged.
+func NewProfiledRecommendationUseCase(useCase RecommendationUseCase, profiles ProfileUseCase) RecommendationUseCase {
+	return &profiledRecommendationUseCase{RecommendationUseCase: useCase, profiles: profiles}
+}
+
+func (uc *profiledRecommendationUseCase) GetRecommendations(ctx context.Context, query domain.RecommendationQuery) ([]*domain.StockRecommendation, int64, error) {
+	query, err := uc.profile(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	return uc.RecommendationUseCase.GetRecommendations(ctx, query)
+}
+
+func (uc *profiledRecommendationUseCase) ExplainTicker(ctx context.Context, ticker string, query domain.RecommendationQuery) (*domain.Explanation, error) {
+	query, err := uc.profile(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return uc.RecommendationUseCase.ExplainTicker(ctx, ticker, query)
+}
+
+func (uc *profiledRecommendationUseCase) profile(ctx context.Context, query domain.RecommendationQuery) (domain.RecommendationQuery, error) {
+	if query.Username == "" {
+		return query, nil
+	}
+	return uc.profiles.Apply(ctx, query.Username, query)
+}
@@ -0,0 +1,195 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock ScoringProfileRepository
+type MockScoringProfileRepository struct {
+	mock.Mock
+}
+
+func (m *MockScoringProfileRepository) FindByUsername(ctx context.Context, username string) (*domain.ScoringProfile, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScoringProfile), args.Error(1)
+}
+
+func (m *MockScoringProfileRepository) Save(ctx context.Context, profile *domain.ScoringProfile) error {
+	args := m.Called(ctx, profile)
+	return args.Error(0)
+}
+
+func (m *MockScoringProfileRepository) Delete(ctx context.Context, username string) (bool, error) {
+	args := m.Called(ctx, username)
+	return args.Bool(0), args.Error(1)
+}
+
+// Mock RecommendationUseCase
+type MockRecommendationUseCase struct {
+	RecommendationUseCase
+	mock.Mock
+}
+
+func (m *MockRecommendationUseCase) GetRecommendations(ctx context.Context, query domain.RecommendationQuery) ([]*domain.StockRecommendation, int64, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.StockRecommendation), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRecommendationUseCase) ExplainTicker(ctx context.Context, ticker string, query domain.RecommendationQuery) (*domain.Explanation, error) {
+	args := m.Called(ctx, ticker, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Explanation), args.Error(1)
+}
+
+func TestProfileUseCase_SaveProfile(t *testing.T) {
+	mockRepo := new(MockScoringProfileRepository)
+	uc := NewProfileUseCase(mockRepo, NewDefaultStrategyRegistry(), nil)
+
+	// The default strategy is stored by name and rating labels are normalised
+	expected := &domain.ScoringProfile{
+		Username:    "alice",
+		Strategy:    StrategyBalanced,
+		Parameters:  map[string]float64{"rating_weight": 0.6},
+		RatingScale: domain.RatingScale{"hold": 4},
+	}
+	mockRepo.On("Save", mock.Anything, expected).Return(nil)
+
+	saved, err := uc.SaveProfile(context.Background(), "alice", &domain.ScoringProfile{
+		Parameters:  map[string]float64{"rating_weight": 0.6},
+		RatingScale: domain.RatingScale{" Hold ": 4},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, saved)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProfileUseCase_SaveProfile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile *domain.ScoringProfile
+		err     error
+	}{
+		{"unknown strategy", &domain.ScoringProfile{Strategy: "astrology"}, domain.ErrUnknownStrategy},
+		{"unknown parameter", &domain.ScoringProfile{Parameters: map[string]float64{"luck": 1}}, domain.ErrInvalidParameter},
+		{"out of bounds", &domain.ScoringProfile{Strategy: StrategyMomentum, Parameters: map[string]float64{"half_life_days": 1000}}, domain.ErrInvalidParameter},
+		{"rating off the scale", &domain.ScoringProfile{RatingScale: domain.RatingScale{"buy": 0}}, domain.ErrInvalidRatingScale},
+	}
+
+	for _, tt := range tests {
+		mockRepo := new(MockScoringProfileRepository)
+		uc := NewProfileUseCase(mockRepo, NewDefaultStrategyRegistry(), nil)
+
+		_, err := uc.SaveProfile(context.Background(), "alice", tt.profile)
+
+		assert.ErrorIs(t, err, tt.err, tt.name)
+		mockRepo.AssertNotCalled(t, "Save")
+	}
+}
+
+func TestProfileUseCase_DeleteProfile_NotFound(t *testing.T) {
+	mockRepo := new(MockScoringProfileRepository)
+	uc := NewProfileUseCase(mockRepo, NewDefaultStrategyRegistry(), nil)
+	mockRepo.On("Delete", mock.Anything, "alice").Return(false, nil)
+
+	err := uc.DeleteProfile(context.Background(), "alice")
+
+	assert.ErrorIs(t, err, domain.ErrProfileNotFound)
+}
+
+func TestProfileUseCase_Apply(t *testing.T) {
+	profile := &domain.ScoringProfile{
+		Username:    "alice",
+		Strategy:    StrategyMomentum,
+		Parameters:  map[string]float64{"half_life_days": 14},
+		RatingScale: domain.RatingScale{"hold": 4},
+	}
+	params := map[string]float64{"half_life_days": 60}
+
+	tests := []struct {
+		name     string
+		query    domain.RecommendationQuery
+		expected domain.RecommendationQuery
+	}{
+		{
+			"no overrides",
+			domain.RecommendationQuery{Page: 1},
+			domain.RecommendationQuery{Page: 1, Strategy: StrategyMomentum, Tuning: profile.Tuning()},
+		},
+		{
+			"own parameters",
+			domain.RecommendationQuery{Tuning: domain.Tuning{Parameters: params}},
+			domain.RecommendationQuery{Strategy: StrategyMomentum, Tuning: domain.Tuning{Parameters: params, RatingScale: profile.RatingScale}},
+		},
+		{
+			// The profile's parameters belong to its strategy
+			"other strategy",
+			domain.RecommendationQuery{Strategy: StrategyBalanced},
+			domain.RecommendationQuery{Strategy: StrategyBalanced, Tuning: domain.Tuning{RatingScale: profile.RatingScale}},
+		},
+		{
+			"own rating scale",
+			domain.RecommendationQuery{Tuning: domain.Tuning{RatingScale: domain.RatingScale{"buy": 6}}},
+			domain.RecommendationQuery{Strategy: StrategyMomentum, Tuning: domain.Tuning{Parameters: profile.Parameters, RatingScale: domain.RatingScale{"buy": 6}}},
+		},
+	}
+
+	for _, tt := range tests {
+		mockRepo := new(MockScoringProfileRepository)
+		uc := NewProfileUseCase(mockRepo, NewDefaultStrategyRegistry(), nil)
+		mockRepo.On("FindByUsername", mock.Anything, "alice").Return(profile, nil)
+
+		query, err := uc.Apply(context.Background(), "alice", tt.query)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, query, tt.name)
+	}
+
+	// Users without a profile get the query as it is
+	mockRepo := new(MockScoringProfileRepository)
+	uc := NewProfileUseCase(mockRepo, NewDefaultStrategyRegistry(), nil)
+	mockRepo.On("FindByUsername", mock.Anything, "bob").Return(nil, nil)
+
+	query, err := uc.Apply(context.Background(), "bob", domain.RecommendationQuery{Page: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.RecommendationQuery{Page: 2}, query)
+}
+
+func TestProfiledRecommendationUseCase(t *testing.T) {
+	mockRepo := new(MockScoringProfileRepository)
+	mockUC := new(MockRecommendationUseCase)
+	uc := NewProfiledRecommendationUseCase(mockUC, NewProfileUseCase(mockRepo, NewDefaultStrategyRegistry(), nil))
+
+	profile := &domain.ScoringProfile{Username: "alice", Strategy: StrategyMomentum, Parameters: map[string]float64{"half_life_days": 14}}
+	mockRepo.On("FindByUsername", mock.Anything, "alice").Return(profile, nil)
+	profiled := domain.RecommendationQuery{Page: 1, Strategy: StrategyMomentum, Tuning: profile.Tuning(), Username: "alice"}
+	mockUC.On("GetRecommendations", mock.Anything, profiled).Return([]*domain.StockRecommendation{}, int64(0), nil)
+	mockUC.On("ExplainTicker", mock.Anything, "AAPL", profiled).Return(&domain.Explanation{}, nil)
+
+	_, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Page: 1, Username: "alice"})
+	assert.NoError(t, err)
+	_, err = uc.ExplainTicker(context.Background(), "AAPL", domain.RecommendationQuery{Page: 1, Username: "alice"})
+	assert.NoError(t, err)
+
+	// Queries without a user, such as snapshots', use the defaults
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 1}).Return([]*domain.StockRecommendation{}, int64(0), nil)
+	_, _, err = uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Page: 1})
+	assert.NoError(t, err)
+
+	mockRepo.AssertNumberOfCalls(t, "FindByUsername", 2)
+	mockUC.AssertExpectations(t)
+}
@@ -0,0 +1,125 @@
+package application
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+)
+
+// topK keeps the k best recommendations offered to it. It is a min-heap whose
+// root is the worst kept recommendation, so each offer costs O(log k).
+type topK struct {
+	k     int
+	items []*domain.StockRecommendation
+}
+
+func newTopK(k int) *topK {
+	return &topK{k: k, items: make([]*domain.StockRecommendation, 0, k)}
+}
+
+func (h *topK) offer(r *domain.StockRecommendation) {
+	if h.k <= 0 {
+		return
+	}
+	if len(h.items) < h.k {
+		heap.Push(h, r)
+		return
+	}
+	if ranksBelow(h.items[0], r) {
+		h.items[0] = r
+		heap.Fix(h, 0)
+	}
+}
+
+// sorted returns the kept recommendations, best first
+func (h *topK) sorted() []*domain.StockRecommendation {
+	ranked := append([]*domain.StockRecommendation(nil), h.items...)
+	sort.Slice(ranked, func(i, j int) bool { return ranksBelow(ranked[j], ranked[i]) })
+	return ranked
+}
+
+// ranksBelow orders by score, breaking ties by stock id so pages are stable
+func ranksBelow(a, b *domain.StockRecommendation) bool {
+	if a.Score != b.Score {
+		return a.Score < b.Score
+	}
+	return a.Stock.ID > b.Stock.ID
+}
+
+// picker keeps the best k recommendations, of which at most perLead share a
+// lead brokerage when perLead is set. Walking down the ranking would pick
+// exactly each brokerage's best perLead recommendations, so every brokerage
+// keeps its own top perLead until they are merged.
+type picker struct {
+	k, perLead int
+	top        *topK
+	leads      map[string]*topK
+	counts     map[string]int64
+	offered    int64
+}
+
+func newPicker(k, perLead int) *picker {
+	p := &picker{k: k, perLead: perLead}
+	if perLead > 0 {
+		p.leads = make(map[string]*topK)
+		p.counts = make(map[string]int64)
+	} else {
+		p.top = newTopK(k)
+	}
+	return p
+}
+
+func (p *picker) offer(r *domain.StockRecommendation) {
+	if p.perLead <= 0 {
+		p.top.offer(r)
+		p.offered++
+		return
+	}
+	lead := r.LeadBrokerage()
+	if p.leads[lead] == nil {
+		p.leads[lead] = newTopK(p.perLead)
+	}
+	p.leads[lead].offer(r)
+	p.counts[lead]++
+}
+
+// total returns how many recommendations the constraint lets through
+func (p *picker) total() int64 {
+	if p.perLead <= 0 {
+		return p.offered
+	}
+	var total int64
+	for _, count := range p.counts {
+		total += min(count, int64(p.perLead))
+	}
+	return total
+}
+
+// sorted returns the kept recommendations, best first
+func (p *picker) sorted() []*domain.StockRecommendation {
+	if p.perLead <= 0 {
+		return p.top.sorted()
+	}
+	top := newTopK(p.k)
+	for _, lead := range p.leads {
+		for _, r := range lead.items {
+			top.offer(r)
+		}
+	}
+	return top.sorted()
+}
+
+func (h *topK) Len() int           { return len(h.items) }
+func (h *topK) Less(i, j int) bool { return ranksBelow(h.items[i], h.items[j]) }
+func (h *topK) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *topK) Push(x interface{}) {
+	h.items = append(h.items, x.(*domain.StockRecommendation))
+}
+
+func (h *topK) Pop() interface{} {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
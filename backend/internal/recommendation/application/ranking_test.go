@@ -0,0 +1,75 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func recommendation(id int64, score float64) *domain.StockRecommendation {
+	return &domain.StockRecommendation{Stock: &stockDomain.Stock{ID: id}, Score: score}
+}
+
+func TestTopK_KeepsBestInOrder(t *testing.T) {
+	top := newTopK(3)
+	for i, score := range []float64{0.1, 0.9, -0.4, 0.5, 0.3, 0.7} {
+		top.offer(recommendation(int64(i+1), score))
+	}
+
+	var ids []int64
+	for _, r := range top.sorted() {
+		ids = append(ids, r.Stock.ID)
+	}
+	assert.Equal(t, []int64{2, 6, 4}, ids)
+}
+
+func TestTopK_BreaksTiesByID(t *testing.T) {
+	top := newTopK(2)
+	top.offer(recommendation(3, 0.5))
+	top.offer(recommendation(1, 0.5))
+	top.offer(recommendation(2, 0.5))
+
+	ranked := top.sorted()
+	assert.Equal(t, int64(1), ranked[0].Stock.ID)
+	assert.Equal(t, int64(2), ranked[1].Stock.ID)
+}
+
+func TestTopK_Empty(t *testing.T) {
+	top := newTopK(0)
+	top.offer(recommendation(1, 1))
+	assert.Empty(t, top.sorted())
+}
+
+func led(id int64, score float64, brokerage string) *domain.StockRecommendation {
+	r := recommendation(id, score)
+	r.Brokerages = []domain.BrokerageSignal{{Brokerage: brokerage, Score: score}}
+	return r
+}
+
+func TestPicker_CapsPerLeadBrokerage(t *testing.T) {
+	pick := newPicker(3, 1)
+	pick.offer(led(1, 0.9, "Goldman Sachs"))
+	pick.offer(led(2, 0.8, "Goldman Sachs"))
+	pick.offer(led(3, 0.7, "Morgan Stanley"))
+	pick.offer(led(4, 0.6, "JPMorgan"))
+	pick.offer(led(5, 0.5, "Morgan Stanley"))
+
+	var ids []int64
+	for _, r := range pick.sorted() {
+		ids = append(ids, r.Stock.ID)
+	}
+	assert.Equal(t, []int64{1, 3, 4}, ids)
+	assert.Equal(t, int64(3), pick.total())
+}
+
+func TestPicker_Unconstrained(t *testing.T) {
+	pick := newPicker(2, 0)
+	pick.offer(led(1, 0.9, "Goldman Sachs"))
+	pick.offer(led(2, 0.8, "Goldman Sachs"))
+	pick.offer(led(3, 0.7, "Goldman Sachs"))
+
+	assert.Len(t, pick.sorted(), 2)
+	assert.Equal(t, int64(3), pick.total())
+}
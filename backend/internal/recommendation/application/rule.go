@@ -0,0 +1,331 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/bryanriosb/stock-info/shared/expr"
+)
+
+// Bounds of a scoring rule besides the expression limits
+const (
+	maxRuleName        = 100
+	maxRuleDescription = 500
+)
+
+// RuleLanguage describes what scoring rule expressions can use
+type RuleLanguage struct {
+	Variables []domain.RuleVariable `json:"variables"`
+	Functions []expr.Function       `json:"functions"`
+	Operators []string              `json:"operators"`
+	MaxLength int                   `json:"max_length"`
+	MaxDepth  int                   `json:"max_depth"`
+	MaxCost   int                   `json:"max_cost"`
+}
+
+type RuleUseCase interface {
+	// ListRules returns the user's own rules and the shared ones, newest first
+	ListRules(ctx context.Context, username string) ([]*domain.ScoringRule, error)
+	// GetRule returns a rule visible to the user or ErrRuleNotFound
+	GetRule(ctx context.Context, username string, id uint) (*domain.ScoringRule, error)
+	// CreateRule validates the rule's expression and stores it as the user's
+	CreateRule(ctx context.Context, username string, rule *domain.ScoringRule) (*domain.ScoringRule, error)
+	// UpdateRule replaces one of the user's rules; others' rules return ErrRuleNotOwned
+	UpdateRule(ctx context.Context, username string, id uint, rule *domain.ScoringRule) (*domain.ScoringRule, error)
+	// DeleteRule removes one of the user's rules
+	DeleteRule(ctx context.Context, username string, id uint) error
+	// Language describes the variables, functions and limits of expressions
+	Language() RuleLanguage
+}
+
+type ruleUseCase struct {
+	repo domain.ScoringRuleRepository
+	bus  *events.Bus
+}
+
+// NewRuleUseCase stores scoring rules, announcing changes to the rules
+// recommendations may have been ranked with on bus
+func NewRuleUseCase(repo domain.ScoringRuleRepository, bus *events.Bus) RuleUseCase {
+	return &ruleUseCase{repo: repo, bus: bus}
+}
+
+func (uc *ruleUseCase) ListRules(ctx context.Context, username string) ([]*domain.ScoringRule, error) {
+	rules, err := uc.repo.FindVisible(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		rule.Strategy = rule.StrategyName()
+	}
+	return rules, nil
+}
+
+func (uc *ruleUseCase) GetRule(ctx context.Context, username string, id uint) (*domain.ScoringRule, error) {
+	rule, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil || !rule.VisibleTo(username) {
+		return nil, domain.ErrRuleNotFound
+	}
+	rule.Strategy = rule.StrategyName()
+	return rule, nil
+}
+
+func (uc *ruleUseCase) CreateRule(ctx context.Context, username string, rule *domain.ScoringRule) (*domain.ScoringRule, error) {
+	created, err := validateRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	count, err := uc.repo.CountByOwner(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if count >= domain.MaxRulesPerUser {
+		return nil, fmt.Errorf("%w: a user can own at most %d rules", domain.ErrInvalidRule, domain.MaxRulesPerUser)
+	}
+
+	created.Owner = username
+	if err := uc.repo.Create(ctx, created); err != nil {
+		return nil, err
+	}
+	created.Strategy = created.StrategyName()
+	return created, nil
+}
+
+func (uc *ruleUseCase) UpdateRule(ctx context.Context, username string, id uint, rule *domain.ScoringRule) (*domain.ScoringRule, error) {
+	existing, err := uc.owned(ctx, username, id)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := validateRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	updated.ID, updated.Owner, updated.CreatedAt = existing.ID, existing.Owner, existing.CreatedAt
+	if err := uc.repo.Update(ctx, updated); err != nil {
+		return nil, err
+	}
+	updated.Strategy = updated.StrategyName()
+	uc.bus.Publish(events.ScoringRuleChanged, id)
+	return updated, nil
+}
+
+func (uc *ruleUseCase) DeleteRule(ctx context.Context, username string, id uint) error {
+	if _, err := uc.owned(ctx, username, id); err != nil {
+		return err
+	}
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	uc.bus.Publish(events.ScoringRuleChanged, id)
+	return nil
+}
+
+func (uc *ruleUseCase) Language() RuleLanguage {
+	return RuleLanguage{
+		Variables: domain.RuleVariables,
+		Functions: expr.Functions,
+		Operators: []string{"+", "-", "*", "/", "<", "<=", ">", ">=", "==", "!=", "&&", "||", "!"},
+		MaxLength: expr.DefaultLimits.MaxLength,
+		MaxDepth:  expr.DefaultLimits.MaxDepth,
+		MaxCost:   expr.DefaultLimits.MaxCost,
+	}
+}
+
+// owned returns the user's rule; rules the user cannot see are not found
+func (uc *ruleUseCase) owned(ctx context.Context, username string, id uint) (*domain.ScoringRule, error) {
+	rule, err := uc.GetRule(ctx, username, id)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Owner != username {
+		return nil, domain.ErrRuleNotOwned
+	}
+	return rule, nil
+}
+
+// validateRule trims the rule and compiles its expression
+func validateRule(rule *domain.ScoringRule) (*domain.ScoringRule, error) {
+	valid := &domain.ScoringRule{
+		Name:        strings.TrimSpace(rule.Name),
+		Description: strings.TrimSpace(rule.Description),
+		Expression:  strings.TrimSpace(rule.Expression),
+		Shared:      rule.Shared,
+	}
+	if valid.Name == "" || len(valid.Name) > maxRuleName {
+		return nil, fmt.Errorf("%w: name must be 1 to %d characters", domain.ErrInvalidRule, maxRuleName)
+	}
+	if len(valid.Description) > maxRuleDescription {
+		return nil, fmt.Errorf("%w: description must be at most %d characters", domain.ErrInvalidRule, maxRuleDescription)
+	}
+	if _, err := compileRule(valid.Expression); err != nil {
+		return nil, err
+	}
+	return valid, nil
+}
+
+// compileRule compiles an expression over the rule variables within the default limits
+func compileRule(expression string) (*expr.Program, error) {
+	names := make([]string, len(domain.RuleVariables))
+	for i, variable := range domain.RuleVariables {
+		names[i] = variable.Name
+	}
+	program, err := expr.Compile(expression, names, expr.DefaultLimits)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidRule, err)
+	}
+	return program, nil
+}
+
+// ruleStrategy scores every action with a rule's expression. The variables it
+// reads are listed as signals that contribute nothing, followed by the rule's
+// own signal, which makes the score.
+type ruleStrategy struct {
+	rule    *domain.ScoringRule
+	program *expr.Program
+	ratings domain.RatingScale
+}
+
+func newRuleStrategy(rule *domain.ScoringRule) (*ruleStrategy, error) {
+	program, err := compileRule(rule.Expression)
+	if err != nil {
+		return nil, err
+	}
+	return &ruleStrategy{rule: rule, program: program}, nil
+}
+
+func (s *ruleStrategy) Info() domain.StrategyInfo {
+	return domain.StrategyInfo{
+		Name:        s.rule.StrategyName(),
+		Description: fmt.Sprintf("%s: %s", s.rule.Name, s.rule.Expression),
+		Parameters:  []domain.StrategyParameter{},
+	}
+}
+
+// WithTuning accepts a rating scale only, as rules have no parameters
+func (s *ruleStrategy) WithTuning(tuning domain.Tuning) (domain.ScoringStrategy, error) {
+	for name := range tuning.Parameters {
+		return nil, fmt.Errorf("%w: %s has no parameter %q", domain.ErrInvalidParameter, s.rule.StrategyName(), name)
+	}
+	ratings, err := tuning.RatingScale.Normalized()
+	if err != nil {
+		return nil, err
+	}
+	return &ruleStrategy{rule: s.rule, program: s.program, ratings: ratings}, nil
+}
+
+func (s *ruleStrategy) Score(stock *stockDomain.Stock, now time.Time) domain.Evaluation {
+	return s.ScoreInConsensus(stock, nil, now)
+}
+
+func (s *ruleStrategy) ScoreInConsensus(stock *stockDomain.Stock, consensus *stockDomain.Consensus, now time.Time) domain.Evaluation {
+	values := ruleValues(stock, consensus, s.ratings, now)
+	used := s.program.Variables()
+	signals := make([]domain.Signal, 0, len(used)+1)
+	for _, name := range used {
+		for i, variable := range domain.RuleVariables {
+			if variable.Name == name {
+				signals = append(signals, domain.Signal{Name: name, Raw: values[i], Normalized: values[i]})
+				break
+			}
+		}
+	}
+
+	evaluation := domain.Evaluation{Reason: "No strong signals"}
+	score, err := s.program.Eval(values)
+	if err != nil {
+		evaluation.Reason = "Rule did not evaluate to a number"
+	} else if score > 0 {
+		evaluation.Reason = fmt.Sprintf("%s: %.2f", s.rule.Name, score)
+	}
+	evaluation.Score = score
+	evaluation.Signals = append(signals, weighted("rule", s.rule.Expression, score, score, 1))
+	return evaluation
+}
+
+// ruleValues measures an action in the order of domain.RuleVariables
+func ruleValues(stock *stockDomain.Stock, consensus *stockDomain.Consensus, ratings domain.RatingScale, now time.Time) []float64 {
+	from, to := ratings.Value(stock.RatingFrom), ratings.Value(stock.RatingTo)
+	delta := 0.0
+	if from > 0 && to > 0 {
+		delta = float64(to - from)
+	}
+	age := 0.0
+	if !stock.Time.IsZero() && stock.Time.Before(now) {
+		age = now.Sub(stock.Time).Hours() / 24
+	}
+	if consensus == nil {
+		consensus = &stockDomain.Consensus{}
+	}
+	gap := 0.0
+	if consensus.AvgTarget > 0 && stock.TargetTo > 0 {
+		gap = stock.TargetTo/consensus.AvgTarget - 1
+	}
+	anomalous := 0.0
+	if len(stock.Anomalies) > 0 {
+		anomalous = 1
+	}
+
+	return []float64{
+		stock.TargetFrom,
+		stock.TargetTo,
+		targetChange(stock),
+		float64(from),
+		float64(to),
+		delta,
+		getActionScore(stock.Action),
+		age,
+		float64(consensus.Brokerages),
+		consensus.MeanRating,
+		consensus.AvgTarget,
+		consensus.HighTarget,
+		consensus.LowTarget,
+		gap,
+		anomalous,
+	}
+}
+
+// tickerConsensus summarises the latest action of each brokerage covering a
+// ticker like StockRepository.FindConsensus, rating with the query's scale
+func tickerConsensus(actions []*stockDomain.Stock, ratings domain.RatingScale) *stockDomain.Consensus {
+	consensus := &stockDomain.Consensus{}
+	if len(actions) == 0 {
+		return consensus
+	}
+	consensus.Ticker = actions[0].Ticker
+
+	brokerages := make(map[string]bool, len(actions))
+	var ratingSum, targetSum float64
+	var targets int
+	for _, stock := range actions {
+		brokerages[stock.Brokerage] = true
+		if value := ratings.Value(stock.RatingTo); value > 0 {
+			ratingSum += float64(value)
+			consensus.RatedBrokerages++
+		}
+		if stock.TargetTo > 0 {
+			targetSum += stock.TargetTo
+			targets++
+			consensus.HighTarget = max(consensus.HighTarget, stock.TargetTo)
+			if consensus.LowTarget == 0 || stock.TargetTo < consensus.LowTarget {
+				consensus.LowTarget = stock.TargetTo
+			}
+		}
+	}
+	consensus.Brokerages = int64(len(brokerages))
+	if consensus.RatedBrokerages > 0 {
+		consensus.MeanRating = ratingSum / float64(consensus.RatedBrokerages)
+	}
+	if targets > 0 {
+		consensus.AvgTarget = targetSum / float64(targets)
+	}
+	consensus.Rating = stockDomain.ConsensusRating(consensus.MeanRating)
+	return consensus
+}
@@ -0,0 +1,213 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock ScoringRuleRepository
+type MockScoringRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockScoringRuleRepository) FindByID(ctx context.Context, id uint) (*domain.ScoringRule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScoringRule), args.Error(1)
+}
+
+func (m *MockScoringRuleRepository) FindVisible(ctx context.Context, username string) ([]*domain.ScoringRule, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ScoringRule), args.Error(1)
+}
+
+func (m *MockScoringRuleRepository) CountByOwner(ctx context.Context, username string) (int64, error) {
+	args := m.Called(ctx, username)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockScoringRuleRepository) Create(ctx context.Context, rule *domain.ScoringRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockScoringRuleRepository) Update(ctx context.Context, rule *domain.ScoringRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockScoringRuleRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestRuleUseCase_CreateRule(t *testing.T) {
+	mockRepo := new(MockScoringRuleRepository)
+	uc := NewRuleUseCase(mockRepo, nil)
+	mockRepo.On("CountByOwner", mock.Anything, "alice").Return(int64(0), nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.ScoringRule")).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.ScoringRule).ID = 7
+	}).Return(nil)
+
+	rule, err := uc.CreateRule(context.Background(), "alice", &domain.ScoringRule{
+		Name:       " Fresh upgrades ",
+		Expression: "score = 0.5*target_change + 0.5*rating_delta - 0.2*(age_days/30)",
+		Shared:     true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", rule.Owner)
+	assert.Equal(t, "Fresh upgrades", rule.Name)
+	assert.Equal(t, "rule:7", rule.Strategy)
+	assert.True(t, rule.Shared)
+}
+
+func TestRuleUseCase_CreateRule_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		rule *domain.ScoringRule
+	}{
+		{"no name", &domain.ScoringRule{Expression: "target_change"}},
+		{"syntax error", &domain.ScoringRule{Name: "r", Expression: "target_change +"}},
+		{"unknown field", &domain.ScoringRule{Name: "r", Expression: "password_hash"}},
+		{"unknown function", &domain.ScoringRule{Name: "r", Expression: "sleep(1000)"}},
+		{"too expensive", &domain.ScoringRule{Name: "r", Expression: "exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)+exp(1)"}},
+	}
+
+	for _, tt := range tests {
+		mockRepo := new(MockScoringRuleRepository)
+		uc := NewRuleUseCase(mockRepo, nil)
+
+		_, err := uc.CreateRule(context.Background(), "alice", tt.rule)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidRule, tt.name)
+		mockRepo.AssertNotCalled(t, "Create")
+	}
+
+	// Users own a bounded number of rules
+	mockRepo := new(MockScoringRuleRepository)
+	mockRepo.On("CountByOwner", mock.Anything, "alice").Return(int64(domain.MaxRulesPerUser), nil)
+	uc := NewRuleUseCase(mockRepo, nil)
+
+	_, err := uc.CreateRule(context.Background(), "alice", &domain.ScoringRule{Name: "r", Expression: "target_change"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidRule)
+	mockRepo.AssertNotCalled(t, "Create")
+}
+
+func TestRuleUseCase_UpdateRule(t *testing.T) {
+	mockRepo := new(MockScoringRuleRepository)
+	bus := events.NewBus()
+	var changed []interface{}
+	bus.Subscribe(events.ScoringRuleChanged, func(e events.Event) { changed = append(changed, e.Payload) })
+	uc := NewRuleUseCase(mockRepo, bus)
+
+	mockRepo.On("FindByID", mock.Anything, uint(1)).Return(&domain.ScoringRule{ID: 1, Owner: "alice", Name: "old", Expression: "1"}, nil)
+	mockRepo.On("FindByID", mock.Anything, uint(2)).Return(&domain.ScoringRule{ID: 2, Owner: "bob", Name: "shared", Expression: "1", Shared: true}, nil)
+	mockRepo.On("FindByID", mock.Anything, uint(3)).Return(&domain.ScoringRule{ID: 3, Owner: "bob", Name: "private", Expression: "1"}, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.ScoringRule")).Return(nil)
+
+	updated, err := uc.UpdateRule(context.Background(), "alice", 1, &domain.ScoringRule{Name: "new", Expression: "rating_delta"})
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", updated.Owner)
+	assert.Equal(t, "rating_delta", updated.Expression)
+	assert.Equal(t, []interface{}{uint(1)}, changed)
+
+	_, err = uc.UpdateRule(context.Background(), "alice", 2, &domain.ScoringRule{Name: "mine", Expression: "1"})
+	assert.ErrorIs(t, err, domain.ErrRuleNotOwned)
+
+	// Others' private rules are not disclosed
+	_, err = uc.UpdateRule(context.Background(), "alice", 3, &domain.ScoringRule{Name: "mine", Expression: "1"})
+	assert.ErrorIs(t, err, domain.ErrRuleNotFound)
+
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestStrategyRegistry_ResolveRule(t *testing.T) {
+	mockRepo := new(MockScoringRuleRepository)
+	mockRepo.On("FindByID", mock.Anything, uint(1)).Return(&domain.ScoringRule{ID: 1, Owner: "alice", Name: "private", Expression: "rating_delta"}, nil)
+	mockRepo.On("FindByID", mock.Anything, uint(2)).Return(&domain.ScoringRule{ID: 2, Owner: "bob", Name: "shared", Expression: "target_change", Shared: true}, nil)
+	mockRepo.On("FindByID", mock.Anything, uint(3)).Return(nil, nil)
+	registry := NewDefaultStrategyRegistry()
+	registry.UseRules(mockRepo)
+	ctx := context.Background()
+
+	strategy, err := registry.Resolve(ctx, "rule:1", "alice", domain.Tuning{})
+	assert.NoError(t, err)
+	assert.Equal(t, "rule:1", strategy.Info().Name)
+
+	_, err = registry.Resolve(ctx, "rule:2", "", domain.Tuning{})
+	assert.NoError(t, err)
+
+	_, err = registry.Resolve(ctx, "rule:1", "bob", domain.Tuning{})
+	assert.ErrorIs(t, err, domain.ErrUnknownStrategy)
+
+	_, err = registry.Resolve(ctx, "rule:3", "alice", domain.Tuning{})
+	assert.ErrorIs(t, err, domain.ErrUnknownStrategy)
+
+	// Rules take a rating scale but have no parameters
+	_, err = registry.Resolve(ctx, "rule:1", "alice", domain.Tuning{RatingScale: domain.RatingScale{"hold": 4}})
+	assert.NoError(t, err)
+	_, err = registry.Resolve(ctx, "rule:1", "alice", domain.Tuning{Parameters: map[string]float64{"rating_weight": 1}})
+	assert.ErrorIs(t, err, domain.ErrInvalidParameter)
+
+	// Built-in strategies resolve as before
+	strategy, err = registry.Resolve(ctx, "", "alice", domain.Tuning{})
+	assert.NoError(t, err)
+	assert.Equal(t, StrategyBalanced, strategy.Info().Name)
+}
+
+func TestGetRecommendations_RuleStrategy(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo := new(MockStockRepository)
+	expectScan(mockRepo, []*stockDomain.Stock{
+		{ID: 1, Ticker: "AAPL", Brokerage: "Goldman Sachs", RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 100, TargetTo: 110, Time: now},
+		{ID: 2, Ticker: "AAPL", Brokerage: "Morgan Stanley", RatingFrom: "Buy", RatingTo: "Buy", TargetFrom: 100, TargetTo: 130, Time: now},
+		{ID: 3, Ticker: "MSFT", Brokerage: "JPMorgan", RatingFrom: "Hold", RatingTo: "Strong Buy", TargetFrom: 100, TargetTo: 100, Time: now},
+	})
+	mockRules := new(MockScoringRuleRepository)
+	// Coverage favours AAPL even though MSFT was upgraded further
+	mockRules.On("FindByID", mock.Anything, uint(5)).Return(&domain.ScoringRule{ID: 5, Owner: "alice", Name: "coverage", Expression: "brokerages + target_vs_consensus"}, nil)
+	registry := NewDefaultStrategyRegistry()
+	registry.UseRules(mockRules)
+
+	uc := NewRecommendationUseCase(mockRepo, registry, domain.Decay{}, domain.AggregationMean, nil, nil).(*recommendationUseCase)
+	uc.now = func() time.Time { return now }
+	recommendations, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10, Strategy: "rule:5", Username: "alice"})
+
+	assert.NoError(t, err)
+	assert.Len(t, recommendations, 2)
+	assert.Equal(t, "AAPL", recommendations[0].Ticker)
+	// Each brokerage is measured against the consensus target of 120
+	assert.InDelta(t, 2, recommendations[0].Score, 1e-9)
+	assert.Equal(t, "rule:5", recommendations[0].Strategy)
+	signals := recommendations[0].Brokerages[0].Breakdown.Signals
+	assert.Equal(t, []string{"brokerages", "target_vs_consensus", "rule"}, []string{signals[0].Name, signals[1].Name, signals[2].Name})
+}
+
+func TestTickerConsensus(t *testing.T) {
+	consensus := tickerConsensus([]*stockDomain.Stock{
+		{Ticker: "AAPL", Brokerage: "Goldman Sachs", RatingTo: "Buy", TargetTo: 120},
+		{Ticker: "AAPL", Brokerage: "Morgan Stanley", RatingTo: "Hold", TargetTo: 100},
+		{Ticker: "AAPL", Brokerage: "JPMorgan", RatingTo: "Unrated"},
+	}, nil)
+
+	assert.Equal(t, int64(3), consensus.Brokerages)
+	assert.Equal(t, int64(2), consensus.RatedBrokerages)
+	assert.Equal(t, 110.0, consensus.AvgTarget)
+	assert.Equal(t, 120.0, consensus.HighTarget)
+	assert.Equal(t, 100.0, consensus.LowTarget)
+	assert.Equal(t, float64(stockDomain.RatingValue("Buy")+stockDomain.RatingValue("Hold"))/2, consensus.MeanRating)
+}
@@ -0,0 +1,166 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/shared/jobs"
+)
+
+// defaultSnapshotSize is the number of recommendations snapshotted when the size is out of range
+const defaultSnapshotSize = 50
+
+type SnapshotUseCase interface {
+	// Take snapshots the current recommendations now
+	Take(ctx context.Context, trigger string) (*domain.Snapshot, error)
+	// ListSnapshots returns a page of snapshots newest first, without entries
+	ListSnapshots(ctx context.Context, rng stockDomain.TimeRange, page, limit int) ([]*domain.Snapshot, int64, error)
+	// GetSnapshot returns a snapshot with its entries
+	GetSnapshot(ctx context.Context, id uint) (*domain.Snapshot, error)
+	// Diff compares two snapshots. A zero to compares with the latest snapshot;
+	// a zero from with the snapshot taken since days before to, or right before
+	// it when days is 0.
+	Diff(ctx context.Context, from, to uint, days int) (*domain.SnapshotDiff, error)
+}
+
+// SnapshotJob records the top of the default ranking after every sync and on a
+// schedule, so the list can be compared over time
+type SnapshotJob struct {
+	recommendations RecommendationUseCase
+	repo            domain.SnapshotRepository
+	aggregation     domain.Aggregation
+	size            int
+	now             func() time.Time
+
+	mu     sync.Mutex
+	runner *jobs.Runner
+	// trigger is what asked for the next triggered snapshot
+	trigger atomic.Value
+}
+
+// NewSnapshotJob snapshots the best size recommendations, which are ranked with
+// the default strategy and the given default aggregation
+func NewSnapshotJob(recommendations RecommendationUseCase, repo domain.SnapshotRepository, aggregation domain.Aggregation, size int) *SnapshotJob {
+	if size < 1 || size > domain.MaxRankDepth {
+		size = defaultSnapshotSize
+	}
+	j := &SnapshotJob{recommendations: recommendations, repo: repo, aggregation: aggregation, size: size, now: time.Now}
+	j.runner = jobs.NewRunner("Recommendation snapshot", func(ctx context.Context) error {
+		_, err := j.Take(ctx, j.trigger.Load().(string))
+		return err
+	})
+	return j
+}
+
+// Start runs triggered snapshots with ctx and, when interval is positive,
+// takes a scheduled snapshot every interval, until ctx is done
+func (j *SnapshotJob) Start(ctx context.Context, interval time.Duration) {
+	j.runner.Start(ctx)
+	if interval <= 0 {
+		return
+	}
+	go jobs.Every(ctx, interval, func() { j.Trigger(domain.SnapshotScheduled) })
+}
+
+// Trigger takes a snapshot in the background, or once more after a snapshot
+// that is already being taken, recorded with the latest trigger
+func (j *SnapshotJob) Trigger(trigger string) {
+	j.trigger.Store(trigger)
+	j.runner.Trigger()
+}
+
+func (j *SnapshotJob) Take(ctx context.Context, trigger string) (*domain.Snapshot, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	// One pass ranks as deep as the snapshot, rather than one per page
+	recommendations, err := j.recommendations.TopRecommendations(ctx, domain.RecommendationQuery{}, j.size)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]domain.SnapshotEntry, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		entries = append(entries, domain.SnapshotEntry{
+			Rank:          len(entries) + 1,
+			Ticker:        recommendation.Ticker,
+			Score:         recommendation.Score,
+			Reason:        recommendation.Reason,
+			PotentialGain: recommendation.PotentialGain,
+			Agreement:     recommendation.Agreement,
+			Conviction:    recommendation.Conviction,
+			Brokerages:    len(recommendation.Brokerages),
+		})
+	}
+
+	snapshot := &domain.Snapshot{
+		TakenAt:     j.now(),
+		Trigger:     trigger,
+		Strategy:    DefaultStrategy,
+		Aggregation: j.aggregation,
+		Size:        len(entries),
+		Entries:     entries,
+	}
+	if err := j.repo.Create(ctx, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (j *SnapshotJob) ListSnapshots(ctx context.Context, rng stockDomain.TimeRange, page, limit int) ([]*domain.Snapshot, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	return j.repo.FindAll(ctx, rng, page, limit)
+}
+
+func (j *SnapshotJob) GetSnapshot(ctx context.Context, id uint) (*domain.Snapshot, error) {
+	snapshot, err := j.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, domain.ErrSnapshotNotFound
+	}
+	return snapshot, nil
+}
+
+func (j *SnapshotJob) Diff(ctx context.Context, from, to uint, days int) (*domain.SnapshotDiff, error) {
+	var after *domain.Snapshot
+	var err error
+	if to == 0 {
+		after, err = j.repo.FindLatest(ctx, time.Time{})
+	} else {
+		after, err = j.repo.FindByID(ctx, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if after == nil {
+		return nil, domain.ErrSnapshotNotFound
+	}
+
+	var before *domain.Snapshot
+	switch {
+	case from != 0:
+		before, err = j.repo.FindByID(ctx, from)
+	case days > 0:
+		before, err = j.repo.FindLatest(ctx, after.TakenAt.AddDate(0, 0, -days))
+	default:
+		before, err = j.repo.FindLatest(ctx, after.TakenAt.Add(-time.Nanosecond))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if before == nil {
+		return nil, domain.ErrSnapshotNotFound
+	}
+
+	return domain.Diff(before, after), nil
+}
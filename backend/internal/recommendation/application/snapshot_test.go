@@ -0,0 +1,169 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock SnapshotRepository
+type MockSnapshotRepository struct {
+	mock.Mock
+}
+
+func (m *MockSnapshotRepository) Create(ctx context.Context, snapshot *domain.Snapshot) error {
+	args := m.Called(ctx, snapshot)
+	return args.Error(0)
+}
+
+func (m *MockSnapshotRepository) FindByID(ctx context.Context, id uint) (*domain.Snapshot, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Snapshot), args.Error(1)
+}
+
+func (m *MockSnapshotRepository) FindLatest(ctx context.Context, at time.Time) (*domain.Snapshot, error) {
+	args := m.Called(ctx, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Snapshot), args.Error(1)
+}
+
+func (m *MockSnapshotRepository) FindAll(ctx context.Context, rng stockDomain.TimeRange, page, limit int) ([]*domain.Snapshot, int64, error) {
+	args := m.Called(ctx, rng, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.Snapshot), args.Get(1).(int64), args.Error(2)
+}
+
+// snapshotOf lists tickers from rank 1 down
+func snapshotOf(id uint, takenAt time.Time, tickers ...string) *domain.Snapshot {
+	snapshot := &domain.Snapshot{ID: id, TakenAt: takenAt, Size: len(tickers)}
+	for i, ticker := range tickers {
+		snapshot.Entries = append(snapshot.Entries, domain.SnapshotEntry{Rank: i + 1, Ticker: ticker, Score: float64(len(tickers) - i)})
+	}
+	return snapshot
+}
+
+func tickersOf(changes []domain.RankChange) []string {
+	tickers := make([]string, 0, len(changes))
+	for _, change := range changes {
+		tickers = append(tickers, change.Ticker)
+	}
+	return tickers
+}
+
+func TestSnapshotJob_TakeRanksUpToSize(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+	stocks := make([]*stockDomain.Stock, 0, 60)
+	for i := 0; i < 60; i++ {
+		stocks = append(stocks, &stockDomain.Stock{ID: int64(i + 1), Ticker: fmt.Sprintf("T%02d", i), TargetFrom: 100, TargetTo: 100 + float64(i)})
+	}
+	expectScan(mockRepo, stocks)
+
+	snapshots := new(MockSnapshotRepository)
+	snapshots.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	ranking := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.AggregationMedian, nil, nil)
+	job := NewSnapshotJob(ranking, snapshots, domain.AggregationMedian, 55)
+	snapshot, err := job.Take(context.Background(), domain.SnapshotManual)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 55, snapshot.Size)
+	assert.Len(t, snapshot.Entries, 55)
+	assert.Equal(t, domain.SnapshotManual, snapshot.Trigger)
+	assert.Equal(t, DefaultStrategy, snapshot.Strategy)
+	assert.Equal(t, domain.AggregationMedian, snapshot.Aggregation)
+	assert.Equal(t, 1, snapshot.Entries[0].Rank)
+	assert.Equal(t, "T59", snapshot.Entries[0].Ticker)
+	assert.Equal(t, 55, snapshot.Entries[54].Rank)
+	assert.Equal(t, "T05", snapshot.Entries[54].Ticker)
+	// Deeper than a page, yet ranked in a single scan
+	mockRepo.AssertNumberOfCalls(t, "ScanTickers", 1)
+	snapshots.AssertExpectations(t)
+}
+
+func TestSnapshotJob_TakeStopsAtTotal(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+	expectScan(mockRepo, []*stockDomain.Stock{{ID: 1, Ticker: "AAPL"}, {ID: 2, Ticker: "MSFT"}})
+
+	snapshots := new(MockSnapshotRepository)
+	snapshots.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	ranking := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	snapshot, err := NewSnapshotJob(ranking, snapshots, domain.DefaultAggregation, 100).Take(context.Background(), domain.SnapshotAfterSync)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, snapshot.Size)
+	mockRepo.AssertNumberOfCalls(t, "ScanTickers", 1)
+}
+
+func TestSnapshotJob_Diff(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := snapshotOf(1, now.AddDate(0, 0, -7), "AAPL", "MSFT", "GOOGL", "TSLA", "NVDA")
+	after := snapshotOf(2, now, "GOOGL", "MSFT", "AMZN", "NVDA", "AAPL")
+
+	snapshots := new(MockSnapshotRepository)
+	snapshots.On("FindByID", mock.Anything, uint(1)).Return(before, nil)
+	snapshots.On("FindByID", mock.Anything, uint(2)).Return(after, nil)
+
+	job := NewSnapshotJob(nil, snapshots, domain.DefaultAggregation, 5)
+	diff, err := job.Diff(context.Background(), 1, 2, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"AMZN"}, tickersOf(diff.Entered))
+	assert.Equal(t, 3, *diff.Entered[0].ToRank)
+	assert.Nil(t, diff.Entered[0].FromRank)
+	assert.Equal(t, []string{"TSLA"}, tickersOf(diff.Exited))
+	assert.Equal(t, []string{"GOOGL", "NVDA"}, tickersOf(diff.MovedUp))
+	assert.Equal(t, 2, diff.MovedUp[0].Change)
+	assert.Equal(t, []string{"AAPL"}, tickersOf(diff.MovedDown))
+	assert.Equal(t, -4, diff.MovedDown[0].Change)
+	assert.Equal(t, 1, diff.Unchanged)
+	assert.Empty(t, diff.From.Entries)
+	assert.Len(t, after.Entries, 5, "the compared snapshots keep their entries")
+}
+
+func TestSnapshotJob_DiffDefaults(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	latest := snapshotOf(3, now, "AAPL")
+	weekAgo := snapshotOf(1, now.AddDate(0, 0, -7), "MSFT")
+	previous := snapshotOf(2, now.AddDate(0, 0, -1), "AAPL")
+
+	snapshots := new(MockSnapshotRepository)
+	snapshots.On("FindLatest", mock.Anything, time.Time{}).Return(latest, nil)
+	snapshots.On("FindLatest", mock.Anything, now.AddDate(0, 0, -7)).Return(weekAgo, nil)
+	snapshots.On("FindLatest", mock.Anything, now.Add(-time.Nanosecond)).Return(previous, nil)
+	job := NewSnapshotJob(nil, snapshots, domain.DefaultAggregation, 5)
+
+	diff, err := job.Diff(context.Background(), 0, 0, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), diff.From.ID)
+	assert.Equal(t, []string{"AAPL"}, tickersOf(diff.Entered))
+
+	diff, err = job.Diff(context.Background(), 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), diff.From.ID)
+	assert.Equal(t, 1, diff.Unchanged)
+}
+
+func TestSnapshotJob_DiffWithoutEarlierSnapshot(t *testing.T) {
+	snapshots := new(MockSnapshotRepository)
+	latest := snapshotOf(1, time.Now(), "AAPL")
+	snapshots.On("FindLatest", mock.Anything, time.Time{}).Return(latest, nil)
+	snapshots.On("FindLatest", mock.Anything, mock.Anything).Return(nil, nil)
+
+	_, err := NewSnapshotJob(nil, snapshots, domain.DefaultAggregation, 5).Diff(context.Background(), 0, 0, 0)
+
+	assert.ErrorIs(t, err, domain.ErrSnapshotNotFound)
+}
@@ -0,0 +1,391 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+)
+
+// Names of the built-in strategies
+const (
+	StrategyBalanced      = "balanced"
+	StrategyMomentum      = "momentum"
+	StrategyTargetUpside  = "target-upside"
+	StrategyRatingUpgrade = "rating-upgrade"
+	StrategyContrarian    = "contrarian"
+
+	DefaultStrategy = StrategyBalanced
+)
+
+// StrategyRegistry holds the strategies recommendations can be ranked with, by
+// name, and resolves the rule:<id> strategies of scoring rules when it has them
+type StrategyRegistry struct {
+	strategies map[string]domain.ScoringStrategy
+	names      []string
+	rules      domain.ScoringRuleRepository
+}
+
+func NewStrategyRegistry(strategies ...domain.ScoringStrategy) *StrategyRegistry {
+	r := &StrategyRegistry{strategies: make(map[string]domain.ScoringStrategy, len(strategies))}
+	for _, s := range strategies {
+		r.Register(s)
+	}
+	return r
+}
+
+// NewDefaultStrategyRegistry registers the built-in strategies
+func NewDefaultStrategyRegistry() *StrategyRegistry {
+	return NewStrategyRegistry(
+		newBalancedStrategy(),
+		newMomentumStrategy(),
+		newTargetUpsideStrategy(),
+		newRatingUpgradeStrategy(),
+		newContrarianStrategy(),
+	)
+}
+
+// Register adds a strategy, replacing any strategy with the same name
+func (r *StrategyRegistry) Register(s domain.ScoringStrategy) {
+	name := s.Info().Name
+	if _, ok := r.strategies[name]; !ok {
+		r.names = append(r.names, name)
+	}
+	r.strategies[name] = s
+}
+
+// Get returns the named strategy, or the default strategy when name is empty
+func (r *StrategyRegistry) Get(name string) (domain.ScoringStrategy, error) {
+	if name == "" {
+		name = DefaultStrategy
+	}
+	s, ok := r.strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q: use %s", domain.ErrUnknownStrategy, name, strings.Join(r.names, ", "))
+	}
+	return s, nil
+}
+
+// Configure returns the named strategy scoring with the tuning in place of its
+// defaults, or the strategy as registered when the tuning overrides nothing
+func (r *StrategyRegistry) Configure(name string, tuning domain.Tuning) (domain.ScoringStrategy, error) {
+	s, err := r.Get(name)
+	if err != nil || tuning.IsZero() {
+		return s, err
+	}
+	configurable, ok := s.(domain.ConfigurableStrategy)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s cannot be tuned", domain.ErrInvalidParameter, s.Info().Name)
+	}
+	return configurable.WithTuning(tuning)
+}
+
+// UseRules lets the registry resolve rule:<id> strategies from the repository
+func (r *StrategyRegistry) UseRules(rules domain.ScoringRuleRepository) {
+	r.rules = rules
+}
+
+// Resolve configures the named strategy like Configure, also resolving
+// rule:<id> to the scoring rule when the user may score with it
+func (r *StrategyRegistry) Resolve(ctx context.Context, name, username string, tuning domain.Tuning) (domain.ScoringStrategy, error) {
+	id, ok := domain.ParseRuleStrategy(name)
+	if !ok || r.rules == nil {
+		return r.Configure(name, tuning)
+	}
+	rule, err := r.rules.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil || !rule.VisibleTo(username) {
+		return nil, fmt.Errorf("%w %q: no such scoring rule", domain.ErrUnknownStrategy, name)
+	}
+	strategy, err := newRuleStrategy(rule)
+	if err != nil {
+		return nil, err
+	}
+	if tuning.IsZero() {
+		return strategy, nil
+	}
+	return strategy.WithTuning(tuning)
+}
+
+// List describes the registered strategies in registration order
+func (r *StrategyRegistry) List() []domain.StrategyInfo {
+	infos := make([]domain.StrategyInfo, 0, len(r.names))
+	for _, name := range r.names {
+		infos = append(infos, r.strategies[name].Info())
+	}
+	return infos
+}
+
+// strategy scores with named parameters initialised from their defaults and
+// the rating scale, the default one unless tuned. Its score function returns
+// weighted signals, whose contributions make the score, and the reasons to
+// show for them.
+type strategy struct {
+	info    domain.StrategyInfo
+	params  map[string]float64
+	ratings domain.RatingScale
+	score   scoreFunc
+}
+
+type scoreFunc func(p map[string]float64, ratings domain.RatingScale, stock *stockDomain.Stock, now time.Time) ([]domain.Signal, []string)
+
+func newStrategy(info domain.StrategyInfo, score scoreFunc) *strategy {
+	params := make(map[string]float64, len(info.Parameters))
+	for _, param := range info.Parameters {
+		params[param.Name] = param.Default
+	}
+	return &strategy{info: info, params: params, score: score}
+}
+
+func (s *strategy) Info() domain.StrategyInfo {
+	return s.info
+}
+
+func (s *strategy) WithTuning(tuning domain.Tuning) (domain.ScoringStrategy, error) {
+	configured := &strategy{info: s.info, params: make(map[string]float64, len(s.params)), ratings: s.ratings, score: s.score}
+	for name, value := range s.params {
+		configured.params[name] = value
+	}
+	for name, value := range tuning.Parameters {
+		parameter, ok := s.parameter(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s has no parameter %q", domain.ErrInvalidParameter, s.info.Name, name)
+		}
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, fmt.Errorf("%w: %s must be a finite number", domain.ErrInvalidParameter, name)
+		}
+		if value < parameter.Min || value > parameter.Max {
+			return nil, fmt.Errorf("%w: %s must be between %g and %g", domain.ErrInvalidParameter, name, parameter.Min, parameter.Max)
+		}
+		configured.params[name] = value
+	}
+	if len(tuning.RatingScale) > 0 {
+		ratings, err := tuning.RatingScale.Normalized()
+		if err != nil {
+			return nil, err
+		}
+		configured.ratings = ratings
+	}
+	return configured, nil
+}
+
+func (s *strategy) parameter(name string) (domain.StrategyParameter, bool) {
+	for _, parameter := range s.info.Parameters {
+		if parameter.Name == name {
+			return parameter, true
+		}
+	}
+	return domain.StrategyParameter{}, false
+}
+
+func (s *strategy) Score(stock *stockDomain.Stock, now time.Time) domain.Evaluation {
+	signals, reasons := s.score(s.params, s.ratings, stock, now)
+	evaluation := domain.Evaluation{Reason: "No strong signals", Signals: signals}
+	for _, signal := range signals {
+		evaluation.Score += signal.Contribution
+	}
+	if len(reasons) > 0 {
+		evaluation.Reason = strings.Join(reasons, ", ")
+	}
+	return evaluation
+}
+
+// newBalancedStrategy weighs the rating change, target change and action together
+func newBalancedStrategy() *strategy {
+	return newStrategy(domain.StrategyInfo{
+		Name:        StrategyBalanced,
+		Description: "Weighted blend of the rating change, target price change and action",
+		Parameters: []domain.StrategyParameter{
+			{Name: "rating_weight", Description: "Weight of the rating change, scaled to -1..1", Default: 0.3, Min: 0, Max: 1},
+			{Name: "target_weight", Description: "Weight of the relative target price change", Default: 0.4, Min: 0, Max: 1},
+			{Name: "action_weight", Description: "Weight of the action: raised/upgraded 1, maintained 0.5, lowered/downgraded -0.5", Default: 0.3, Min: 0, Max: 1},
+		},
+	}, func(p map[string]float64, ratings domain.RatingScale, stock *stockDomain.Stock, _ time.Time) ([]domain.Signal, []string) {
+		rating := ratingSignal(stock, ratings, p["rating_weight"])
+		target := targetSignal(stock, p["target_weight"])
+		action := actionSignal(stock, p["action_weight"])
+
+		var reasons []string
+		if rating.Normalized > 0 {
+			reasons = append(reasons, "Positive rating")
+		}
+		if target.Normalized > 0 {
+			reasons = append(reasons, "Target price increased")
+		}
+		if action.Normalized > 0 {
+			reasons = append(reasons, "Positive action")
+		}
+
+		return []domain.Signal{rating, target, action}, reasons
+	})
+}
+
+// newMomentumStrategy favours fresh positive revisions, halving a signal's weight every half life
+func newMomentumStrategy() *strategy {
+	return newStrategy(domain.StrategyInfo{
+		Name:        StrategyMomentum,
+		Description: "Recent upgrades and raised targets, decayed by the age of the action",
+		Parameters: []domain.StrategyParameter{
+			{Name: "rating_weight", Description: "Weight of the rating change, scaled to -1..1", Default: 0.5, Min: 0, Max: 1},
+			{Name: "action_weight", Description: "Weight of the action score", Default: 0.5, Min: 0, Max: 1},
+			{Name: "half_life_days", Description: "Days after which an action counts half", Default: 30, Min: 1, Max: 365},
+		},
+	}, func(p map[string]float64, ratings domain.RatingScale, stock *stockDomain.Stock, now time.Time) ([]domain.Signal, []string) {
+		// Recency scales the weights of the other signals and contributes nothing itself
+		recency := decay(stock.Time, now, p["half_life_days"])
+		age := 0.0
+		if !stock.Time.IsZero() && stock.Time.Before(now) {
+			age = now.Sub(stock.Time).Hours() / 24
+		}
+		rating := ratingSignal(stock, ratings, p["rating_weight"]*recency)
+		action := actionSignal(stock, p["action_weight"]*recency)
+		freshness := domain.Signal{
+			Name:       "recency",
+			Input:      fmt.Sprintf("%.0f day half-life, multiplies the weights above", p["half_life_days"]),
+			Raw:        age,
+			Normalized: recency,
+		}
+
+		var reasons []string
+		if rating.Normalized > 0 {
+			reasons = append(reasons, "Upgraded")
+		}
+		if action.Normalized > 0 {
+			reasons = append(reasons, "Positive action")
+		}
+		if len(reasons) > 0 && recency >= 0.5 {
+			reasons = append(reasons, "Recent")
+		}
+
+		return []domain.Signal{rating, action, freshness}, reasons
+	})
+}
+
+// newTargetUpsideStrategy ranks by the relative target price change alone
+func newTargetUpsideStrategy() *strategy {
+	return newStrategy(domain.StrategyInfo{
+		Name:        StrategyTargetUpside,
+		Description: "Largest relative target price increase",
+		Parameters: []domain.StrategyParameter{
+			{Name: "max_upside", Description: "Cap on the relative target change, so outliers do not dominate", Default: 1, Min: 0.01, Max: 10},
+		},
+	}, func(p map[string]float64, _ domain.RatingScale, stock *stockDomain.Stock, _ time.Time) ([]domain.Signal, []string) {
+		target := targetSignal(stock, 1)
+		target.Normalized = math.Min(target.Normalized, p["max_upside"])
+		target.Contribution = target.Normalized * target.Weight
+		if target.Normalized <= 0 {
+			return []domain.Signal{target}, nil
+		}
+		return []domain.Signal{target}, []string{fmt.Sprintf("Target price raised %.1f%%", target.Normalized*100)}
+	})
+}
+
+// newRatingUpgradeStrategy ranks by rating steps gained, rewarding moves into buy ratings
+func newRatingUpgradeStrategy() *strategy {
+	return newStrategy(domain.StrategyInfo{
+		Name:        StrategyRatingUpgrade,
+		Description: "Largest rating upgrades, with a bonus for moves into a buy rating",
+		Parameters: []domain.StrategyParameter{
+			{Name: "buy_bonus", Description: "Added when the rating moves from below buy to buy or better", Default: 0.25, Min: 0, Max: 1},
+		},
+	}, func(p map[string]float64, ratings domain.RatingScale, stock *stockDomain.Stock, _ time.Time) ([]domain.Signal, []string) {
+		rating := ratingSignal(stock, ratings, 1)
+		var reasons []string
+		if rating.Normalized > 0 {
+			reasons = append(reasons, "Upgraded")
+		}
+
+		movedToBuy := 0.0
+		from, to := ratings.Value(stock.RatingFrom), ratings.Value(stock.RatingTo)
+		if from > 0 && from < buyRating && to >= buyRating {
+			movedToBuy = 1
+			reasons = append(reasons, "Moved to a buy rating")
+		}
+		bonus := weighted("buy_bonus", ratingInput(stock), movedToBuy, movedToBuy, p["buy_bonus"])
+
+		return []domain.Signal{rating, bonus}, reasons
+	})
+}
+
+// newContrarianStrategy looks for downgrades that analysts still price above the previous target
+func newContrarianStrategy() *strategy {
+	return newStrategy(domain.StrategyInfo{
+		Name:        StrategyContrarian,
+		Description: "Downgraded stocks, preferring those whose target price held up",
+		Parameters: []domain.StrategyParameter{
+			{Name: "rating_weight", Description: "Weight of the rating cut, scaled to 0..1", Default: 0.6, Min: 0, Max: 1},
+			{Name: "target_weight", Description: "Weight of the relative target price change", Default: 0.4, Min: 0, Max: 1},
+		},
+	}, func(p map[string]float64, ratings domain.RatingScale, stock *stockDomain.Stock, _ time.Time) ([]domain.Signal, []string) {
+		change := ratingSignal(stock, ratings, p["rating_weight"])
+		cut := weighted("rating_cut", change.Input, -change.Raw, -change.Normalized, p["rating_weight"])
+		target := targetSignal(stock, p["target_weight"])
+
+		var reasons []string
+		if cut.Normalized > 0 {
+			reasons = append(reasons, "Downgraded")
+		}
+		if cut.Normalized > 0 && target.Normalized >= 0 {
+			reasons = append(reasons, "Target price held")
+		}
+
+		return []domain.Signal{cut, target}, reasons
+	})
+}
+
+// weighted builds a signal contributing normalized × weight
+func weighted(name, input string, raw, normalized, weight float64) domain.Signal {
+	return domain.Signal{Name: name, Input: input, Raw: raw, Normalized: normalized, Weight: weight, Contribution: normalized * weight}
+}
+
+// ratingSignal measures the rating change in steps on the 1-9 scale, normalised to -1..1
+func ratingSignal(stock *stockDomain.Stock, ratings domain.RatingScale, weight float64) domain.Signal {
+	steps := 0.0
+	from, to := ratings.Value(stock.RatingFrom), ratings.Value(stock.RatingTo)
+	if from > 0 && to > 0 {
+		steps = float64(to - from)
+	}
+	return weighted("rating_change", ratingInput(stock), steps, getRatingScore(ratings, stock.RatingFrom, stock.RatingTo), weight)
+}
+
+// targetSignal measures the target price change in percent, normalised to a ratio
+func targetSignal(stock *stockDomain.Stock, weight float64) domain.Signal {
+	change := targetChange(stock)
+	input := fmt.Sprintf("%.2f → %.2f", stock.TargetFrom, stock.TargetTo)
+	return weighted("target_change", input, change*100, change, weight)
+}
+
+// actionSignal maps the action wording to a score
+func actionSignal(stock *stockDomain.Stock, weight float64) domain.Signal {
+	score := getActionScore(stock.Action)
+	return weighted("action", stock.Action, score, score, weight)
+}
+
+func ratingInput(stock *stockDomain.Stock) string {
+	return fmt.Sprintf("%s → %s", stock.RatingFrom, stock.RatingTo)
+}
+
+// buyRating is the lowest rating value analysts count as a buy
+const buyRating = 7
+
+func targetChange(stock *stockDomain.Stock) float64 {
+	if stock.TargetFrom <= 0 {
+		return 0
+	}
+	return (stock.TargetTo - stock.TargetFrom) / stock.TargetFrom
+}
+
+// decay halves a weight every halfLifeDays, keeping full weight for future or unknown times
+func decay(at, now time.Time, halfLifeDays float64) float64 {
+	if at.IsZero() || halfLifeDays <= 0 || !at.Before(now) {
+		return 1
+	}
+	ageDays := now.Sub(at).Hours() / 24
+	return math.Pow(0.5, ageDays/halfLifeDays)
+}
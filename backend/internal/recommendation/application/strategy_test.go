@@ -0,0 +1,209 @@
+package application
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrategyRegistry_Get(t *testing.T) {
+	registry := NewDefaultStrategyRegistry()
+
+	strategy, err := registry.Get("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultStrategy, strategy.Info().Name)
+
+	strategy, err = registry.Get(StrategyMomentum)
+	assert.NoError(t, err)
+	assert.Equal(t, StrategyMomentum, strategy.Info().Name)
+
+	_, err = registry.Get("astrology")
+	assert.ErrorIs(t, err, domain.ErrUnknownStrategy)
+}
+
+func TestStrategyRegistry_ListKeepsRegistrationOrder(t *testing.T) {
+	registry := NewDefaultStrategyRegistry()
+	registry.Register(newBalancedStrategy())
+
+	var names []string
+	for _, info := range registry.List() {
+		names = append(names, info.Name)
+		assert.NotEmpty(t, info.Description)
+		assert.NotEmpty(t, info.Parameters)
+	}
+	assert.Equal(t, []string{StrategyBalanced, StrategyMomentum, StrategyTargetUpside, StrategyRatingUpgrade, StrategyContrarian}, names)
+}
+
+// scoreOf returns the score and reason of a strategy's evaluation
+func scoreOf(strategy domain.ScoringStrategy, stock *stockDomain.Stock, now time.Time) (float64, string) {
+	evaluation := strategy.Score(stock, now)
+	return evaluation.Score, evaluation.Reason
+}
+
+func TestStrategies_ContributionsAddUpToScore(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	stock := &stockDomain.Stock{RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 100, TargetTo: 130, Action: "upgraded by", Time: now.AddDate(0, 0, -10)}
+
+	for _, info := range NewDefaultStrategyRegistry().List() {
+		strategy, err := NewDefaultStrategyRegistry().Get(info.Name)
+		assert.NoError(t, err)
+
+		evaluation := strategy.Score(stock, now)
+		assert.NotEmpty(t, evaluation.Signals, info.Name)
+		var sum float64
+		for _, signal := range evaluation.Signals {
+			assert.InDelta(t, signal.Normalized*signal.Weight, signal.Contribution, 1e-9, info.Name+" "+signal.Name)
+			sum += signal.Contribution
+		}
+		assert.InDelta(t, evaluation.Score, sum, 1e-9, info.Name)
+	}
+}
+
+func TestBalancedStrategy_Signals(t *testing.T) {
+	stock := &stockDomain.Stock{RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 150, TargetTo: 180, Action: "upgraded by"}
+
+	evaluation := newBalancedStrategy().Score(stock, time.Now())
+
+	want := []domain.Signal{
+		{Name: "rating_change", Input: "Hold → Buy", Raw: 2, Normalized: 0.25, Weight: 0.3, Contribution: 0.075},
+		{Name: "target_change", Input: "150.00 → 180.00", Raw: 20, Normalized: 0.2, Weight: 0.4, Contribution: 0.08},
+		{Name: "action", Input: "upgraded by", Raw: 1, Normalized: 1, Weight: 0.3, Contribution: 0.3},
+	}
+	assert.Len(t, evaluation.Signals, len(want))
+	for i, signal := range evaluation.Signals {
+		assert.Equal(t, want[i].Name, signal.Name)
+		assert.Equal(t, want[i].Input, signal.Input)
+		assert.InDelta(t, want[i].Raw, signal.Raw, 1e-9, signal.Name)
+		assert.InDelta(t, want[i].Normalized, signal.Normalized, 1e-9, signal.Name)
+		assert.InDelta(t, want[i].Weight, signal.Weight, 1e-9, signal.Name)
+		assert.InDelta(t, want[i].Contribution, signal.Contribution, 1e-9, signal.Name)
+	}
+}
+
+func TestMomentumStrategy_DecaysWithAge(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	strategy := newMomentumStrategy()
+
+	fresh := &stockDomain.Stock{RatingFrom: "Hold", RatingTo: "Buy", Action: "upgraded by", Time: now}
+	stale := &stockDomain.Stock{RatingFrom: "Hold", RatingTo: "Buy", Action: "upgraded by", Time: now.AddDate(0, 0, -30)}
+
+	freshScore, reason := scoreOf(strategy, fresh, now)
+	staleScore, _ := scoreOf(strategy, stale, now)
+
+	assert.Greater(t, freshScore, 0.0)
+	assert.InDelta(t, freshScore/2, staleScore, 1e-9)
+	assert.Contains(t, reason, "Recent")
+}
+
+func TestTargetUpsideStrategy_CapsOutliers(t *testing.T) {
+	strategy := newTargetUpsideStrategy()
+
+	score, reason := scoreOf(strategy, &stockDomain.Stock{TargetFrom: 100, TargetTo: 120}, time.Now())
+	assert.InDelta(t, 0.2, score, 1e-9)
+	assert.Equal(t, "Target price raised 20.0%", reason)
+
+	score, _ = scoreOf(strategy, &stockDomain.Stock{TargetFrom: 10, TargetTo: 100}, time.Now())
+	assert.Equal(t, 1.0, score)
+}
+
+func TestRatingUpgradeStrategy_BuyBonus(t *testing.T) {
+	strategy := newRatingUpgradeStrategy()
+
+	toBuy, reason := scoreOf(strategy, &stockDomain.Stock{RatingFrom: "Hold", RatingTo: "Buy"}, time.Now())
+	withinBuy, _ := scoreOf(strategy, &stockDomain.Stock{RatingFrom: "Buy", RatingTo: "Strong Buy"}, time.Now())
+
+	assert.InDelta(t, 2.0/8+0.25, toBuy, 1e-9)
+	assert.InDelta(t, 2.0/8, withinBuy, 1e-9)
+	assert.Contains(t, reason, "Moved to a buy rating")
+}
+
+func TestContrarianStrategy_FavoursDowngrades(t *testing.T) {
+	strategy := newContrarianStrategy()
+
+	downgraded, reason := scoreOf(strategy, &stockDomain.Stock{RatingFrom: "Buy", RatingTo: "Hold", TargetFrom: 100, TargetTo: 100}, time.Now())
+	upgraded, _ := scoreOf(strategy, &stockDomain.Stock{RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 100, TargetTo: 100}, time.Now())
+
+	assert.Greater(t, downgraded, upgraded)
+	assert.Equal(t, "Downgraded, Target price held", reason)
+}
+
+func TestGetRecommendations_UsesRequestedStrategy(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+
+	stocks := []*stockDomain.Stock{
+		{ID: 1, Ticker: "UP", RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 100, TargetTo: 100},
+		{ID: 2, Ticker: "DOWN", RatingFrom: "Buy", RatingTo: "Hold", TargetFrom: 100, TargetTo: 100},
+	}
+	expectScan(mockRepo, stocks)
+
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	recommendations, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10, Strategy: StrategyContrarian})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "DOWN", recommendations[0].Stock.Ticker)
+	for _, recommendation := range recommendations {
+		assert.Equal(t, StrategyContrarian, recommendation.Strategy)
+	}
+}
+
+func TestGetRecommendations_UnknownStrategy(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	recommendations, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Strategy: "astrology"})
+
+	assert.ErrorIs(t, err, domain.ErrUnknownStrategy)
+	assert.Nil(t, recommendations)
+	mockRepo.AssertNotCalled(t, "ScanTickers")
+}
+
+func TestStrategyRegistry_Configure(t *testing.T) {
+	registry := NewDefaultStrategyRegistry()
+	stock := &stockDomain.Stock{TargetFrom: 100, TargetTo: 180}
+
+	capped, err := registry.Configure(StrategyTargetUpside, domain.Tuning{Parameters: map[string]float64{"max_upside": 0.5}})
+	assert.NoError(t, err)
+	score, _ := scoreOf(capped, stock, time.Now())
+	assert.InDelta(t, 0.5, score, 1e-9)
+
+	// The registered strategy keeps its defaults
+	registered, _ := registry.Get(StrategyTargetUpside)
+	score, _ = scoreOf(registered, stock, time.Now())
+	assert.InDelta(t, 0.8, score, 1e-9)
+
+	_, err = registry.Configure(StrategyTargetUpside, domain.Tuning{Parameters: map[string]float64{"luck": 1}})
+	assert.ErrorIs(t, err, domain.ErrInvalidParameter)
+	_, err = registry.Configure(StrategyTargetUpside, domain.Tuning{Parameters: map[string]float64{"max_upside": math.Inf(1)}})
+	assert.ErrorIs(t, err, domain.ErrInvalidParameter)
+	// Values must stay within the declared bounds
+	_, err = registry.Configure(StrategyBalanced, domain.Tuning{Parameters: map[string]float64{"rating_weight": 1.5}})
+	assert.ErrorIs(t, err, domain.ErrInvalidParameter)
+	_, err = registry.Configure(StrategyMomentum, domain.Tuning{Parameters: map[string]float64{"half_life_days": 0}})
+	assert.ErrorIs(t, err, domain.ErrInvalidParameter)
+}
+
+func TestStrategyRegistry_ConfigureRatingScale(t *testing.T) {
+	registry := NewDefaultStrategyRegistry()
+	// hold (5) to buy (7) is two steps into a buy rating on the default scale
+	stock := &stockDomain.Stock{RatingFrom: "Hold", RatingTo: "Buy"}
+
+	registered, _ := registry.Get(StrategyRatingUpgrade)
+	score, _ := scoreOf(registered, stock, time.Now())
+	assert.InDelta(t, 2.0/8+0.25, score, 1e-9)
+
+	// Counting buy as a plain hold leaves no upgrade and no move into a buy rating
+	tuned, err := registry.Configure(StrategyRatingUpgrade, domain.Tuning{RatingScale: domain.RatingScale{" BUY ": 5}})
+	assert.NoError(t, err)
+	score, _ = scoreOf(tuned, stock, time.Now())
+	assert.InDelta(t, 0, score, 1e-9)
+
+	_, err = registry.Configure(StrategyRatingUpgrade, domain.Tuning{RatingScale: domain.RatingScale{"hold": 10}})
+	assert.ErrorIs(t, err, domain.ErrInvalidRatingScale)
+	_, err = registry.Configure(StrategyRatingUpgrade, domain.Tuning{RatingScale: domain.RatingScale{" ": 5}})
+	assert.ErrorIs(t, err, domain.ErrInvalidRatingScale)
+}
@@ -2,120 +2,355 @@ package application
 
 import (
 	"context"
-	"sort"
 	"strings"
+	"time"
 
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
 	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
 	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
 )
 
+// scanBatchSize is the number of actions read per query while ranking
+const scanBatchSize = 500
+
 type RecommendationUseCase interface {
-	GetRecommendations(ctx context.Context, limit int) ([]*domain.StockRecommendation, error)
+	// GetRecommendations ranks every ticker and returns a page of the ranking with
+	// the number of recommendations that can be paged through
+	GetRecommendations(ctx context.Context, query domain.RecommendationQuery) ([]*domain.StockRecommendation, int64, error)
+	// ExplainTicker derives one ticker's recommendation signal by signal, with
+	// the strategy parameters and rating scale behind it
+	ExplainTicker(ctx context.Context, ticker string, query domain.RecommendationQuery) (*domain.Explanation, error)
+	// TopRecommendations ranks every ticker once and returns the best n, up to
+	// domain.MaxRankDepth, without paging through the ranking
+	TopRecommendations(ctx context.Context, query domain.RecommendationQuery, n int) ([]*domain.StockRecommendation, error)
+	// RankActions ranks the given tickers' actions as of now, returning the best
+	// query.Limit recommendations. Backtests replay history through it.
+	RankActions(ctx context.Context, query domain.RecommendationQuery, tickers [][]*stockDomain.Stock, now time.Time) ([]*domain.StockRecommendation, error)
+	GetStrategies() []domain.StrategyInfo
 }
 
 type recommendationUseCase struct {
-	repo stockDomain.StockRepository
+	repo        stockDomain.StockRepository
+	strategies  *StrategyRegistry
+	decay       domain.Decay
+	aggregation domain.Aggregation
+	credibility domain.CredibilitySource
+	prices      domain.PriceSource
+	now         func() time.Time
 }
 
-func NewRecommendationUseCase(repo stockDomain.StockRepository) RecommendationUseCase {
-	return &recommendationUseCase{repo: repo}
+// NewRecommendationUseCase ranks tickers with the registered strategies,
+// weighting every brokerage's score by the age of its action with decay and
+// combining them with aggregation unless a query picks another. The weighted
+// aggregation also weighs brokerages by credibility; a nil source weighs all alike.
+// Recommendations report the upside from the latest prices when prices is not nil.
+func NewRecommendationUseCase(repo stockDomain.StockRepository, strategies *StrategyRegistry, decay domain.Decay, aggregation domain.Aggregation, credibility domain.CredibilitySource, prices domain.PriceSource) RecommendationUseCase {
+	if aggregation == "" {
+		aggregation = domain.DefaultAggregation
+	}
+	return &recommendationUseCase{repo: repo, strategies: strategies, decay: decay, aggregation: aggregation, credibility: credibility, prices: prices, now: time.Now}
 }
 
-func (uc *recommendationUseCase) GetRecommendations(ctx context.Context, limit int) ([]*domain.StockRecommendation, error) {
-	if limit <= 0 || limit > 50 {
-		limit = 10
+func (uc *recommendationUseCase) GetRecommendations(ctx context.Context, query domain.RecommendationQuery) ([]*domain.StockRecommendation, int64, error) {
+	query = query.Normalized()
+	depth := query.Page * query.Limit
+	if depth > domain.MaxRankDepth {
+		return nil, 0, domain.ErrPageOutOfRange
 	}
 
-	stocks, _, err := uc.repo.FindAll(ctx, stockDomain.QueryParams{
-		Page:  1,
-		Limit: 100,
-	})
+	top, err := uc.rank(ctx, query, depth)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+
+	total := top.total()
+	if total > domain.MaxRankDepth {
+		total = domain.MaxRankDepth
 	}
 
-	recommendations := make([]*domain.StockRecommendation, 0, len(stocks))
-	for _, stock := range stocks {
-		score, reason := calculateScore(stock)
-		potentialGain := calculatePotentialGain(stock)
+	ranked := top.sorted()
+	start := (query.Page - 1) * query.Limit
+	if start >= len(ranked) {
+		return []*domain.StockRecommendation{}, total, nil
+	}
+	if err := uc.price(ctx, ranked[start:]); err != nil {
+		return nil, 0, err
+	}
+	return ranked[start:], total, nil
+}
 
-		recommendations = append(recommendations, &domain.StockRecommendation{
-			Stock:         stock,
-			Score:         score,
-			Reason:        reason,
-			PotentialGain: potentialGain,
-		})
+func (uc *recommendationUseCase) TopRecommendations(ctx context.Context, query domain.RecommendationQuery, n int) ([]*domain.StockRecommendation, error) {
+	if n < 1 || n > domain.MaxRankDepth {
+		return nil, domain.ErrPageOutOfRange
+	}
+	query = query.Normalized()
+
+	top, err := uc.rank(ctx, query, n)
+	if err != nil {
+		return nil, err
+	}
+	ranked := top.sorted()
+	if err := uc.price(ctx, ranked); err != nil {
+		return nil, err
+	}
+	return ranked, nil
+}
+
+// rank scores every ticker, keeping only the best depth recommendations;
+// tickers whose actions are all past the max age or filtered out do not
+// count towards the total
+func (uc *recommendationUseCase) rank(ctx context.Context, query domain.RecommendationQuery, depth int) (*picker, error) {
+	ranking, err := uc.scoring(ctx, query)
+	if err != nil {
+		return nil, err
 	}
 
-	sort.Slice(recommendations, func(i, j int) bool {
-		return recommendations[i].Score > recommendations[j].Score
+	now := uc.now()
+	top := newPicker(depth, query.Filter.MaxPerBrokerage)
+	err = uc.repo.ScanTickers(ctx, scanBatchSize, func(actions []*stockDomain.Stock) error {
+		if recommendation := uc.recommendTicker(actions, ranking, now); recommendation != nil {
+			top.offer(recommendation)
+		}
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return top, nil
+}
 
-	if len(recommendations) > limit {
-		recommendations = recommendations[:limit]
+func (uc *recommendationUseCase) ExplainTicker(ctx context.Context, ticker string, query domain.RecommendationQuery) (*domain.Explanation, error) {
+	ranking, err := uc.scoring(ctx, query)
+	if err != nil {
+		return nil, err
 	}
 
-	return recommendations, nil
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	actions, err := uc.repo.FindByTicker(ctx, ticker, stockDomain.TimeRange{})
+	if err != nil {
+		return nil, err
+	}
+	recommendation := uc.recommendTicker(actions, ranking, uc.now())
+	if recommendation == nil {
+		return nil, domain.ErrTickerNotRecommended
+	}
+	if err := uc.price(ctx, []*domain.StockRecommendation{recommendation}); err != nil {
+		return nil, err
+	}
+
+	info := ranking.strategy.Info()
+	parameters := make(map[string]float64, len(info.Parameters))
+	for _, parameter := range info.Parameters {
+		parameters[parameter.Name] = parameter.Default
+	}
+	for name, value := range query.Parameters {
+		parameters[name] = value
+	}
+	return &domain.Explanation{StockRecommendation: recommendation, Parameters: parameters, RatingScale: ranking.ratings.Merged()}, nil
 }
 
-func calculateScore(stock *stockDomain.Stock) (float64, string) {
-	score := 0.0
-	reasons := []string{}
+// RankActions leaves out the upside, as the latest prices were not known at the ranked time
+func (uc *recommendationUseCase) RankActions(ctx context.Context, query domain.RecommendationQuery, tickers [][]*stockDomain.Stock, now time.Time) ([]*domain.StockRecommendation, error) {
+	query = query.Normalized()
+	ranking, err := uc.scoring(ctx, query)
+	if err != nil {
+		return nil, err
+	}
 
-	ratingScore := getRatingScore(stock.RatingFrom, stock.RatingTo)
-	score += ratingScore * 0.3
-	if ratingScore > 0 {
-		reasons = append(reasons, "Positive rating")
+	top := newPicker(query.Limit, query.Filter.MaxPerBrokerage)
+	for _, actions := range tickers {
+		if recommendation := uc.recommendTicker(actions, ranking, now); recommendation != nil {
+			top.offer(recommendation)
+		}
 	}
+	return top.sorted(), nil
+}
+
+// ranking is what a query scores and filters tickers with
+type ranking struct {
+	strategy    domain.ScoringStrategy
+	aggregation domain.Aggregation
+	credibility map[string]float64
+	ratings     domain.RatingScale
+	filter      *filter
+}
 
-	if stock.TargetFrom > 0 {
-		targetChange := (stock.TargetTo - stock.TargetFrom) / stock.TargetFrom
-		score += targetChange * 0.4
-		if targetChange > 0 {
-			reasons = append(reasons, "Target price increased")
+// scoring resolves the tuned strategy, the aggregation and the filter a query asks for and loads the
+// brokerage credibility weights when there is a source
+func (uc *recommendationUseCase) scoring(ctx context.Context, query domain.RecommendationQuery) (ranking, error) {
+	if err := query.Filter.Validate(); err != nil {
+		return ranking{}, err
+	}
+	strategy, err := uc.strategies.Resolve(ctx, query.Strategy, query.Username, query.Tuning)
+	if err != nil {
+		return ranking{}, err
+	}
+	// Configure has validated the scale; it is kept to show the rating values scored with
+	ratings, _ := query.RatingScale.Normalized()
+	aggregation := uc.aggregation
+	if query.Aggregation != "" {
+		if aggregation, err = domain.ParseAggregation(query.Aggregation); err != nil {
+			return ranking{}, err
 		}
 	}
 
-	actionScore := getActionScore(stock.Action)
-	score += actionScore * 0.3
-	if actionScore > 0 {
-		reasons = append(reasons, "Positive action")
+	var credibility map[string]float64
+	if uc.credibility != nil {
+		if credibility, err = uc.credibility.Weights(ctx); err != nil {
+			return ranking{}, err
+		}
 	}
+	return ranking{strategy: strategy, aggregation: aggregation, credibility: credibility, ratings: ratings, filter: newFilter(query.Filter)}, nil
+}
 
-	reason := "No strong signals"
-	if len(reasons) > 0 {
-		reason = strings.Join(reasons, ", ")
+// price reports the upside of the recommendations from the latest prices of
+// their tickers when there is a price source. Prices are looked up once the
+// ranking is known, as they do not change it, and only for what is returned.
+func (uc *recommendationUseCase) price(ctx context.Context, recommendations []*domain.StockRecommendation) error {
+	if uc.prices == nil || len(recommendations) == 0 {
+		return nil
+	}
+	tickers := make([]string, len(recommendations))
+	for i, recommendation := range recommendations {
+		tickers[i] = recommendation.Ticker
+	}
+	prices, err := uc.prices.LastPrices(ctx, tickers)
+	if err != nil {
+		return err
 	}
 
-	return score, reason
+	for _, recommendation := range recommendations {
+		price, ok := prices[recommendation.Ticker]
+		if !ok {
+			continue
+		}
+		recommendation.LastPrice, recommendation.LastPriceAt = &price.Price, &price.At
+		var targets float64
+		var targeted int
+		for _, signal := range recommendation.Brokerages {
+			if signal.TargetTo > 0 {
+				targets += signal.TargetTo
+				targeted++
+			}
+		}
+		if targeted > 0 {
+			upside := priceDomain.Upside(targets/float64(targeted), price.Price)
+			recommendation.UpsidePercent = &upside
+		}
+	}
+	return nil
 }
 
-func getRatingScore(from, to string) float64 {
-	ratings := map[string]int{
-		"sell":                1,
-		"negative":            2,
-		"underperform":        3,
-		"sector underperform": 3,
-		"cautious":            4,
-		"market perform":      5,
-		"sector perform":      5,
-		"neutral":             5,
-		"hold":                5,
-		"equal weight":        5,
-		"in-line":             5,
-		"buy":                 7,
-		"positive":            7,
-		"overweight":          7,
-		"outperform":          8,
-		"outperformer":        8,
-		"market outperform":   8,
-		"sector outperform":   8,
-		"speculative buy":     8,
-		"strong-buy":          9,
+// recommendTicker scores the latest action of each brokerage covering a ticker
+// and combines them, or returns nil when every action is past the max age or
+// the filter leaves the ticker out
+func (uc *recommendationUseCase) recommendTicker(actions []*stockDomain.Stock, r ranking, now time.Time) *domain.StockRecommendation {
+	if len(actions) == 0 || r.filter.excludesTicker(actions[0].Ticker) {
+		return nil
+	}
+	// Strategies reading the consensus see every brokerage covering the ticker
+	consensusStrategy, withConsensus := r.strategy.(domain.ConsensusStrategy)
+	var consensus *stockDomain.Consensus
+	if withConsensus {
+		consensus = tickerConsensus(actions, r.ratings)
+	}
+
+	signals := make([]domain.BrokerageSignal, 0, len(actions))
+	var latest *stockDomain.Stock
+	var latestReason string
+	var gain, weights float64
+	for _, stock := range actions {
+		weight, fresh := uc.decay.Weight(stock.Time, now)
+		if !fresh || !r.filter.allowsAction(stock, now) {
+			continue
+		}
+		var evaluation domain.Evaluation
+		if withConsensus {
+			evaluation = consensusStrategy.ScoreInConsensus(stock, consensus, now)
+		} else {
+			evaluation = r.strategy.Score(stock, now)
+		}
+		reason := evaluation.Reason
+		if explanation := uc.decay.Explain(weight, stock.Time, now); explanation != "" {
+			reason += " (" + explanation + ")"
+		}
+		anomalyWeight := r.filter.anomalyWeight(stock)
+		if len(stock.Anomalies) > 0 {
+			reason += " (flagged: " + strings.Join(stock.Anomalies, ", ") + ")"
+		}
+
+		brokerageWeight, known := r.credibility[stock.Brokerage]
+		if !known {
+			brokerageWeight = 1
+		}
+		signals = append(signals, domain.BrokerageSignal{
+			Brokerage:     stock.Brokerage,
+			Action:        stock.Action,
+			RatingTo:      stock.RatingTo,
+			TargetTo:      stock.TargetTo,
+			Time:          stock.Time,
+			Score:         evaluation.Score * weight * anomalyWeight,
+			DecayWeight:   weight,
+			Credibility:   brokerageWeight,
+			Anomalies:     stock.Anomalies,
+			AnomalyWeight: anomalyWeight,
+			Breakdown: domain.Breakdown{
+				Signals:       evaluation.Signals,
+				StrategyScore: evaluation.Score,
+				Rating: domain.RatingMapping{
+					From:      stock.RatingFrom,
+					FromValue: r.ratings.Value(stock.RatingFrom),
+					To:        stock.RatingTo,
+					ToValue:   r.ratings.Value(stock.RatingTo),
+				},
+				Decay: uc.decay.Detail(stock.Time, now),
+			},
+		})
+		gain += calculatePotentialGain(stock)
+		weights += weight
+		if latest == nil || stock.Time.After(latest.Time) {
+			latest, latestReason = stock, reason
+		}
 	}
+	if len(signals) == 0 {
+		return nil
+	}
+
+	n := len(signals)
+	score := aggregate(r.aggregation, signals)
+	agree := agreeing(score, signals)
+	agreement := float64(agree) / float64(n)
+	reason := latestReason
+	if n > 1 {
+		reason += "; " + agreementReason(agree, n)
+	}
+
+	recommendation := &domain.StockRecommendation{
+		Ticker:        latest.Ticker,
+		Stock:         latest,
+		Score:         score,
+		Reason:        reason,
+		PotentialGain: gain / float64(n),
+		Strategy:      r.strategy.Info().Name,
+		DecayWeight:   weights / float64(n),
+		Aggregation:   r.aggregation,
+		Agreement:     agreement,
+		Conviction:    conviction(agreement, n),
+		Brokerages:    signals,
+	}
+	if !r.filter.keeps(recommendation, r.ratings) {
+		return nil
+	}
+	return recommendation
+}
+
+func (uc *recommendationUseCase) GetStrategies() []domain.StrategyInfo {
+	return uc.strategies.List()
+}
 
-	fromScore := ratings[strings.ToLower(from)]
-	toScore := ratings[strings.ToLower(to)]
+func getRatingScore(ratings domain.RatingScale, from, to string) float64 {
+	fromScore := ratings.Value(from)
+	toScore := ratings.Value(to)
 
 	if fromScore == 0 || toScore == 0 {
 		return 0
@@ -3,8 +3,12 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
 	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -33,14 +37,51 @@ func (m *MockStockRepository) FindAll(ctx context.Context, params stockDomain.Qu
 	return args.Get(0).([]*stockDomain.Stock), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockStockRepository) FindByTicker(ctx context.Context, ticker string) ([]*stockDomain.Stock, error) {
-	args := m.Called(ctx, ticker)
+func (m *MockStockRepository) ScanTickers(ctx context.Context, batchSize int, fn func(actions []*stockDomain.Stock) error) error {
+	args := m.Called(ctx, batchSize, fn)
+	return args.Error(0)
+}
+
+func (m *MockStockRepository) FindByTicker(ctx context.Context, ticker string, rng stockDomain.TimeRange) ([]*stockDomain.Stock, error) {
+	args := m.Called(ctx, ticker, rng)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*stockDomain.Stock), args.Error(1)
+}
+
+func (m *MockStockRepository) FindByTickers(ctx context.Context, tickers []string, brokerages []string) ([]*stockDomain.Stock, error) {
+	args := m.Called(ctx, tickers, brokerages)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*stockDomain.Stock), args.Error(1)
 }
 
+func (m *MockStockRepository) CountFacets(ctx context.Context, params stockDomain.QueryParams, facets stockDomain.FacetParams) (stockDomain.Facets, error) {
+	args := m.Called(ctx, params, facets)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(stockDomain.Facets), args.Error(1)
+}
+
+func (m *MockStockRepository) FindConsensus(ctx context.Context, tickers []string) (map[string]*stockDomain.Consensus, error) {
+	args := m.Called(ctx, tickers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*stockDomain.Consensus), args.Error(1)
+}
+
+func (m *MockStockRepository) FindCompanies(ctx context.Context, tickers []string) (map[string]*stockDomain.Company, error) {
+	args := m.Called(ctx, tickers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*stockDomain.Company), args.Error(1)
+}
+
 func (m *MockStockRepository) FindByID(ctx context.Context, id int64) (*stockDomain.Stock, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -49,6 +90,30 @@ func (m *MockStockRepository) FindByID(ctx context.Context, id int64) (*stockDom
 	return args.Get(0).(*stockDomain.Stock), args.Error(1)
 }
 
+// expectScan makes ScanTickers pass the given stocks to the use case, grouped
+// by ticker in order of first appearance
+func expectScan(mockRepo *MockStockRepository, batches ...[]*stockDomain.Stock) {
+	var tickers []string
+	groups := make(map[string][]*stockDomain.Stock)
+	for _, batch := range batches {
+		for _, stock := range batch {
+			if _, ok := groups[stock.Ticker]; !ok {
+				tickers = append(tickers, stock.Ticker)
+			}
+			groups[stock.Ticker] = append(groups[stock.Ticker], stock)
+		}
+	}
+
+	mockRepo.On("ScanTickers", mock.Anything, scanBatchSize, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func([]*stockDomain.Stock) error)
+		for _, ticker := range tickers {
+			if err := fn(groups[ticker]); err != nil {
+				return
+			}
+		}
+	}).Return(nil)
+}
+
 func TestGetRecommendations_Success(t *testing.T) {
 	mockRepo := new(MockStockRepository)
 
@@ -75,10 +140,10 @@ func TestGetRecommendations_Success(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("FindAll", mock.Anything, mock.Anything).Return(stocks, int64(2), nil)
+	expectScan(mockRepo, stocks)
 
-	uc := NewRecommendationUseCase(mockRepo)
-	recommendations, err := uc.GetRecommendations(context.Background(), 10)
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	recommendations, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10})
 
 	assert.NoError(t, err)
 	assert.Len(t, recommendations, 2)
@@ -97,10 +162,10 @@ func TestGetRecommendations_WithLimit(t *testing.T) {
 		{ID: 3, Ticker: "MSFT", RatingFrom: "Sell", RatingTo: "Hold", TargetFrom: 80, TargetTo: 100, Action: "raised"},
 	}
 
-	mockRepo.On("FindAll", mock.Anything, mock.Anything).Return(stocks, int64(3), nil)
+	expectScan(mockRepo, stocks)
 
-	uc := NewRecommendationUseCase(mockRepo)
-	recommendations, err := uc.GetRecommendations(context.Background(), 2)
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	recommendations, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 2})
 
 	assert.NoError(t, err)
 	assert.Len(t, recommendations, 2)
@@ -110,16 +175,16 @@ func TestGetRecommendations_WithLimit(t *testing.T) {
 func TestGetRecommendations_DefaultLimit(t *testing.T) {
 	mockRepo := new(MockStockRepository)
 
-	mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*stockDomain.Stock{}, int64(0), nil)
+	expectScan(mockRepo, []*stockDomain.Stock{})
 
-	uc := NewRecommendationUseCase(mockRepo)
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
 
 	// Test with invalid limit (0)
-	_, err := uc.GetRecommendations(context.Background(), 0)
+	_, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 0})
 	assert.NoError(t, err)
 
 	// Test with negative limit
-	_, err = uc.GetRecommendations(context.Background(), -5)
+	_, _, err = uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: -5})
 	assert.NoError(t, err)
 
 	mockRepo.AssertExpectations(t)
@@ -128,10 +193,10 @@ func TestGetRecommendations_DefaultLimit(t *testing.T) {
 func TestGetRecommendations_RepoError(t *testing.T) {
 	mockRepo := new(MockStockRepository)
 
-	mockRepo.On("FindAll", mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("database error"))
+	mockRepo.On("ScanTickers", mock.Anything, scanBatchSize, mock.Anything).Return(errors.New("database error"))
 
-	uc := NewRecommendationUseCase(mockRepo)
-	recommendations, err := uc.GetRecommendations(context.Background(), 10)
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	recommendations, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10})
 
 	assert.Error(t, err)
 	assert.Nil(t, recommendations)
@@ -141,17 +206,332 @@ func TestGetRecommendations_RepoError(t *testing.T) {
 func TestGetRecommendations_Empty(t *testing.T) {
 	mockRepo := new(MockStockRepository)
 
-	mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*stockDomain.Stock{}, int64(0), nil)
+	expectScan(mockRepo, []*stockDomain.Stock{})
 
-	uc := NewRecommendationUseCase(mockRepo)
-	recommendations, err := uc.GetRecommendations(context.Background(), 10)
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	recommendations, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10})
 
 	assert.NoError(t, err)
 	assert.Empty(t, recommendations)
 	mockRepo.AssertExpectations(t)
 }
 
-func TestCalculateScore_PositiveRating(t *testing.T) {
+func TestGetRecommendations_ScoresEveryBatch(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+
+	// Tickers beyond the first 100 must be ranked too
+	first := make([]*stockDomain.Stock, 0, scanBatchSize)
+	for i := 1; i <= scanBatchSize; i++ {
+		first = append(first, &stockDomain.Stock{ID: int64(i), Ticker: fmt.Sprintf("OLD%d", i), RatingFrom: "Hold", RatingTo: "Hold"})
+	}
+	last := []*stockDomain.Stock{
+		{ID: 501, Ticker: "NEW", RatingFrom: "Sell", RatingTo: "Strong Buy", TargetFrom: 100, TargetTo: 200, Action: "upgraded by"},
+	}
+	expectScan(mockRepo, first, last)
+
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	recommendations, total, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 5})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(501), total)
+	assert.Len(t, recommendations, 5)
+	assert.Equal(t, "NEW", recommendations[0].Stock.Ticker)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetRecommendations_Pages(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+
+	stocks := make([]*stockDomain.Stock, 0, 25)
+	for i := 1; i <= 25; i++ {
+		// Higher ids get larger target raises, so the ranking is 25, 24, ... 1
+		stocks = append(stocks, &stockDomain.Stock{ID: int64(i), Ticker: fmt.Sprintf("T%02d", i), TargetFrom: 100, TargetTo: 100 + float64(i)})
+	}
+	expectScan(mockRepo, stocks)
+
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+
+	page, total, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Page: 3, Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(25), total)
+	assert.Len(t, page, 5)
+	assert.Equal(t, int64(5), page[0].Stock.ID)
+
+	page, _, err = uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Page: 4, Limit: 10})
+	assert.NoError(t, err)
+	assert.Empty(t, page)
+}
+
+func TestGetRecommendations_PageBeyondRankDepth(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	_, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Page: domain.MaxRankDepth/10 + 1, Limit: 10})
+
+	assert.ErrorIs(t, err, domain.ErrPageOutOfRange)
+	mockRepo.AssertNotCalled(t, "ScanTickers")
+}
+
+func TestTopRecommendations_BeyondRankDepth(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	_, err := uc.TopRecommendations(context.Background(), domain.RecommendationQuery{}, domain.MaxRankDepth+1)
+
+	assert.ErrorIs(t, err, domain.ErrPageOutOfRange)
+	mockRepo.AssertNotCalled(t, "ScanTickers")
+}
+
+func TestGetRecommendations_Filters(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	stocks := []*stockDomain.Stock{
+		{ID: 1, Ticker: "AAPL", Brokerage: "Goldman Sachs", RatingTo: "Buy", TargetFrom: 100, TargetTo: 120, Time: now},
+		{ID: 2, Ticker: "AAPL", Brokerage: "Morgan Stanley", RatingTo: "Buy", TargetFrom: 100, TargetTo: 110, Time: now},
+		{ID: 3, Ticker: "MSFT", Brokerage: "Goldman Sachs", RatingTo: "Hold", TargetFrom: 100, TargetTo: 105, Time: now.AddDate(0, 0, -60)},
+		{ID: 4, Ticker: "TSLA", Brokerage: "JPMorgan", RatingTo: "Sell", TargetFrom: 100, TargetTo: 90, Time: now},
+		{ID: 5, Ticker: "NVDA", Brokerage: "Morgan Stanley", RatingTo: "Strong Buy", TargetFrom: 100, TargetTo: 150, Time: now},
+	}
+	minGain := 12.0
+
+	tests := []struct {
+		name     string
+		filter   domain.Filter
+		expected []string
+	}{
+		{"no filter", domain.Filter{}, []string{"AAPL", "MSFT", "TSLA", "NVDA"}},
+		{"brokerages", domain.Filter{Brokerages: []string{"goldman sachs"}}, []string{"AAPL", "MSFT"}},
+		{"excluded brokerages", domain.Filter{ExcludeBrokerages: []string{"Goldman Sachs"}}, []string{"AAPL", "TSLA", "NVDA"}},
+		{"min gain", domain.Filter{MinGain: &minGain}, []string{"AAPL", "NVDA"}},
+		{"min brokerages", domain.Filter{MinBrokerages: 2}, []string{"AAPL"}},
+		{"rating bucket", domain.Filter{RatingBucket: "HOLD"}, []string{"MSFT"}},
+		{"max age", domain.Filter{MaxAgeDays: 30}, []string{"AAPL", "TSLA", "NVDA"}},
+		{"excluded tickers", domain.Filter{ExcludeTickers: []string{"nvda", "TSLA"}}, []string{"AAPL", "MSFT"}},
+	}
+
+	for _, tt := range tests {
+		mockRepo := new(MockStockRepository)
+		expectScan(mockRepo, stocks)
+		uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil).(*recommendationUseCase)
+		uc.now = func() time.Time { return now }
+
+		recommendations, total, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10, Filter: tt.filter})
+
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, int64(len(tt.expected)), total, tt.name)
+		var tickers []string
+		for _, recommendation := range recommendations {
+			tickers = append(tickers, recommendation.Ticker)
+		}
+		assert.ElementsMatch(t, tt.expected, tickers, tt.name)
+	}
+}
+
+func TestGetRecommendations_Anomalies(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	stocks := []*stockDomain.Stock{
+		{ID: 1, Ticker: "AAPL", Brokerage: "Goldman Sachs", RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 100, TargetTo: 110, Time: now.AddDate(0, 0, -1)},
+		{ID: 2, Ticker: "AAPL", Brokerage: "UBS", RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 100, TargetTo: 400, Time: now,
+			Anomalies: []string{stockDomain.AnomalyTargetOutlier}},
+	}
+	recommend := func(anomalies string) (*domain.StockRecommendation, error) {
+		mockRepo := new(MockStockRepository)
+		expectScan(mockRepo, stocks)
+		uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil).(*recommendationUseCase)
+		uc.now = func() time.Time { return now }
+		recommendations, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10, Filter: domain.Filter{Anomalies: anomalies}})
+		if err != nil {
+			return nil, err
+		}
+		return recommendations[0], nil
+	}
+
+	kept, err := recommend("")
+	assert.NoError(t, err)
+	assert.Len(t, kept.Brokerages, 2)
+	assert.Equal(t, []string{stockDomain.AnomalyTargetOutlier}, kept.Brokerages[1].Anomalies)
+	assert.Equal(t, 1.0, kept.Brokerages[1].AnomalyWeight)
+	assert.Contains(t, kept.Reason, "flagged: target_outlier")
+
+	downweighted, err := recommend(domain.AnomaliesDownweight)
+	assert.NoError(t, err)
+	assert.Equal(t, kept.Brokerages[0].Score, downweighted.Brokerages[0].Score)
+	assert.InDelta(t, kept.Brokerages[1].Score*domain.AnomalyWeight, downweighted.Brokerages[1].Score, 1e-9)
+	assert.Less(t, downweighted.Score, kept.Score)
+
+	excluded, err := recommend(domain.AnomaliesExclude)
+	assert.NoError(t, err)
+	assert.Len(t, excluded.Brokerages, 1)
+	assert.Equal(t, "Goldman Sachs", excluded.Brokerages[0].Brokerage)
+
+	_, err = recommend("ignore")
+	assert.ErrorIs(t, err, domain.ErrInvalidFilter)
+}
+
+// Mock PriceSource
+type MockPriceSource struct {
+	mock.Mock
+}
+
+func (m *MockPriceSource) LastPrices(ctx context.Context, tickers []string) (map[string]priceDomain.LastPrice, error) {
+	args := m.Called(ctx, tickers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]priceDomain.LastPrice), args.Error(1)
+}
+
+func TestGetRecommendations_Upside(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	stocks := []*stockDomain.Stock{
+		{ID: 1, Ticker: "AAPL", Brokerage: "Goldman Sachs", RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 100, TargetTo: 110, Time: now},
+		{ID: 2, Ticker: "AAPL", Brokerage: "UBS", RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 100, TargetTo: 130, Time: now},
+		{ID: 3, Ticker: "MSFT", Brokerage: "UBS", RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 400, TargetTo: 440, Time: now},
+	}
+	mockRepo := new(MockStockRepository)
+	expectScan(mockRepo, stocks)
+	prices := new(MockPriceSource)
+	// Only the returned tickers are priced
+	prices.On("LastPrices", mock.Anything, []string{"AAPL", "MSFT"}).Return(map[string]priceDomain.LastPrice{
+		"AAPL": {Ticker: "AAPL", Price: 100, At: now},
+	}, nil)
+
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, prices).(*recommendationUseCase)
+	uc.now = func() time.Time { return now }
+	recommendations, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Len(t, recommendations, 2)
+	for _, recommendation := range recommendations {
+		switch recommendation.Ticker {
+		case "AAPL":
+			// The mean target of 120 is 20% above the price, while the targets were revised by 20% on average
+			assert.Equal(t, 100.0, *recommendation.LastPrice)
+			assert.InDelta(t, 20, *recommendation.UpsidePercent, 1e-9)
+			assert.InDelta(t, 20, recommendation.PotentialGain, 1e-9)
+		case "MSFT":
+			assert.Nil(t, recommendation.LastPrice)
+			assert.Nil(t, recommendation.UpsidePercent)
+		}
+	}
+
+	// Backtests rank the past, when today's prices were not known
+	ranked, err := uc.RankActions(context.Background(), domain.RecommendationQuery{Limit: 10}, [][]*stockDomain.Stock{stocks[:2]}, now)
+	assert.NoError(t, err)
+	assert.Nil(t, ranked[0].UpsidePercent)
+	prices.AssertNumberOfCalls(t, "LastPrices", 1)
+}
+
+func TestGetRecommendations_FilterDropsActions(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+	expectScan(mockRepo, []*stockDomain.Stock{
+		{ID: 1, Ticker: "AAPL", Brokerage: "Goldman Sachs", RatingTo: "Buy", TargetFrom: 100, TargetTo: 120},
+		{ID: 2, Ticker: "AAPL", Brokerage: "Morgan Stanley", RatingTo: "Buy", TargetFrom: 100, TargetTo: 110},
+	})
+
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	recommendations, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{
+		Limit:  10,
+		Filter: domain.Filter{ExcludeBrokerages: []string{"Goldman Sachs"}},
+	})
+
+	// The ticker is recommended on the actions that pass the filter alone
+	assert.NoError(t, err)
+	assert.Len(t, recommendations, 1)
+	assert.Len(t, recommendations[0].Brokerages, 1)
+	assert.Equal(t, "Morgan Stanley", recommendations[0].Brokerages[0].Brokerage)
+	assert.InDelta(t, 10, recommendations[0].PotentialGain, 1e-9)
+}
+
+func TestGetRecommendations_MaxPerBrokerage(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+	stocks := make([]*stockDomain.Stock, 0, 6)
+	for i := 1; i <= 6; i++ {
+		// Goldman Sachs leads the odd tickers and the best raises
+		brokerage := "Morgan Stanley"
+		if i%2 == 1 {
+			brokerage = "Goldman Sachs"
+		}
+		stocks = append(stocks, &stockDomain.Stock{ID: int64(i), Ticker: fmt.Sprintf("T%d", i), Brokerage: brokerage, TargetFrom: 100, TargetTo: 100 + float64(10*i)})
+	}
+	expectScan(mockRepo, stocks)
+
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	recommendations, total, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{
+		Limit:  10,
+		Filter: domain.Filter{MaxPerBrokerage: 2},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	var tickers []string
+	for _, recommendation := range recommendations {
+		tickers = append(tickers, recommendation.Ticker)
+	}
+	assert.Equal(t, []string{"T6", "T5", "T4", "T3"}, tickers)
+}
+
+func TestGetRecommendations_InvalidFilter(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	_, _, err := uc.GetRecommendations(context.Background(), domain.RecommendationQuery{
+		Limit:  10,
+		Filter: domain.Filter{RatingBucket: "moon"},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidFilter)
+	mockRepo.AssertNotCalled(t, "ScanTickers")
+}
+
+func TestExplainTicker_BreaksDownEachBrokerage(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo := new(MockStockRepository)
+	mockRepo.On("FindByTicker", mock.Anything, "AAPL", stockDomain.TimeRange{}).Return([]*stockDomain.Stock{
+		{Ticker: "AAPL", Brokerage: "Goldman Sachs", RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 150, TargetTo: 180, Action: "upgraded by", Time: now.AddDate(0, 0, -90)},
+		{Ticker: "AAPL", Brokerage: "Morgan Stanley", RatingFrom: "Buy", RatingTo: "Buy", TargetFrom: 170, TargetTo: 175, Action: "target raised by", Time: now},
+	}, nil)
+
+	decay := domain.Decay{Mode: domain.DecayExponential, HalfLife: 90 * 24 * time.Hour}
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), decay, domain.AggregationMean, nil, nil).(*recommendationUseCase)
+	uc.now = func() time.Time { return now }
+
+	explanation, err := uc.ExplainTicker(context.Background(), " aapl ", domain.RecommendationQuery{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "AAPL", explanation.Ticker)
+	assert.Equal(t, 0.3, explanation.Parameters["rating_weight"])
+	assert.Equal(t, 7, explanation.RatingScale["buy"])
+	assert.Len(t, explanation.Brokerages, 2)
+
+	goldman := explanation.Brokerages[0].Breakdown
+	assert.Equal(t, domain.RatingMapping{From: "Hold", FromValue: 5, To: "Buy", ToValue: 7}, goldman.Rating)
+	assert.Equal(t, domain.DecayExponential, goldman.Decay.Mode)
+	assert.Equal(t, 90, goldman.Decay.AgeDays)
+	assert.InDelta(t, 0.5, goldman.Decay.Weight, 1e-9)
+	assert.Equal(t, 90.0, goldman.Decay.HalfLifeDays)
+	assert.InDelta(t, goldman.StrategyScore*goldman.Decay.Weight, explanation.Brokerages[0].Score, 1e-9)
+	assert.Len(t, goldman.Signals, 3)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestExplainTicker_NotRecommended(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+	mockRepo.On("FindByTicker", mock.Anything, "ZZZ", stockDomain.TimeRange{}).Return([]*stockDomain.Stock{}, nil)
+
+	uc := NewRecommendationUseCase(mockRepo, NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	_, err := uc.ExplainTicker(context.Background(), "ZZZ", domain.RecommendationQuery{})
+
+	assert.ErrorIs(t, err, domain.ErrTickerNotRecommended)
+}
+
+func TestExplainTicker_UnknownStrategy(t *testing.T) {
+	uc := NewRecommendationUseCase(new(MockStockRepository), NewDefaultStrategyRegistry(), domain.Decay{}, domain.DefaultAggregation, nil, nil)
+	_, err := uc.ExplainTicker(context.Background(), "AAPL", domain.RecommendationQuery{Strategy: "astrology"})
+
+	assert.ErrorIs(t, err, domain.ErrUnknownStrategy)
+}
+
+func TestBalancedStrategy_PositiveRating(t *testing.T) {
 	stock := &stockDomain.Stock{
 		RatingFrom: "Hold",
 		RatingTo:   "Buy",
@@ -160,13 +540,13 @@ func TestCalculateScore_PositiveRating(t *testing.T) {
 		Action:     "",
 	}
 
-	score, reason := calculateScore(stock)
+	score, reason := scoreOf(newBalancedStrategy(), stock, time.Now())
 
 	assert.Greater(t, score, 0.0)
 	assert.Contains(t, reason, "Positive rating")
 }
 
-func TestCalculateScore_TargetIncrease(t *testing.T) {
+func TestBalancedStrategy_TargetIncrease(t *testing.T) {
 	stock := &stockDomain.Stock{
 		RatingFrom: "Hold",
 		RatingTo:   "Hold",
@@ -175,13 +555,13 @@ func TestCalculateScore_TargetIncrease(t *testing.T) {
 		Action:     "",
 	}
 
-	score, reason := calculateScore(stock)
+	score, reason := scoreOf(newBalancedStrategy(), stock, time.Now())
 
 	assert.Greater(t, score, 0.0)
 	assert.Contains(t, reason, "Target price increased")
 }
 
-func TestCalculateScore_PositiveAction(t *testing.T) {
+func TestBalancedStrategy_PositiveAction(t *testing.T) {
 	stock := &stockDomain.Stock{
 		RatingFrom: "Hold",
 		RatingTo:   "Hold",
@@ -190,13 +570,13 @@ func TestCalculateScore_PositiveAction(t *testing.T) {
 		Action:     "target raised by analyst",
 	}
 
-	score, reason := calculateScore(stock)
+	score, reason := scoreOf(newBalancedStrategy(), stock, time.Now())
 
 	assert.Greater(t, score, 0.0)
 	assert.Contains(t, reason, "Positive action")
 }
 
-func TestCalculateScore_NoSignals(t *testing.T) {
+func TestBalancedStrategy_NoSignals(t *testing.T) {
 	stock := &stockDomain.Stock{
 		RatingFrom: "",
 		RatingTo:   "",
@@ -205,7 +585,7 @@ func TestCalculateScore_NoSignals(t *testing.T) {
 		Action:     "",
 	}
 
-	score, reason := calculateScore(stock)
+	score, reason := scoreOf(newBalancedStrategy(), stock, time.Now())
 
 	assert.Equal(t, 0.0, score)
 	assert.Equal(t, "No strong signals", reason)
@@ -225,7 +605,7 @@ func TestGetRatingScore(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		score := getRatingScore(tt.from, tt.to)
+		score := getRatingScore(nil, tt.from, tt.to)
 		if tt.positive {
 			assert.Greater(t, score, 0.0, "expected positive score for %s -> %s", tt.from, tt.to)
 		} else {
@@ -234,6 +614,17 @@ func TestGetRatingScore(t *testing.T) {
 	}
 }
 
+func TestGetRatingScore_KeepsRecommendationScale(t *testing.T) {
+	// The brokerage analytics rate underweight and accumulate, recommendations do not
+	assert.Equal(t, 3, stockDomain.RatingValue("Underweight"))
+	assert.Equal(t, 0.0, getRatingScore(nil, "Underweight", "Buy"))
+	assert.Equal(t, 0.0, getRatingScore(nil, "Hold", "Accumulate"))
+	assert.InDelta(t, 2.0/8, getRatingScore(nil, "Hold", "Overweight"), 1e-9)
+
+	// Tuning can still add them
+	assert.InDelta(t, 4.0/8, getRatingScore(domain.RatingScale{"underweight": 3}, "Underweight", "Buy"), 1e-9)
+}
+
 func TestGetActionScore(t *testing.T) {
 	tests := []struct {
 		action   string
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Aggregation selects how the signals of the brokerages covering a ticker are
+// combined into its score
+type Aggregation string
+
+const (
+	// AggregationMean averages the brokerage scores
+	AggregationMean Aggregation = "mean"
+	// AggregationMedian takes the middle brokerage score, ignoring outliers
+	AggregationMedian Aggregation = "median"
+	// AggregationWeighted averages the brokerage scores weighted by their
+	// recency and credibility, so fresh actions from reliable brokerages
+	// outweigh old ones from unreliable brokerages
+	AggregationWeighted Aggregation = "weighted"
+)
+
+// DefaultAggregation is used when neither the request nor the configuration picks one
+const DefaultAggregation = AggregationWeighted
+
+var ErrUnknownAggregation = errors.New("unknown aggregation: use mean, median or weighted")
+
+// ParseAggregation resolves an aggregation name, ignoring case
+func ParseAggregation(name string) (Aggregation, error) {
+	switch aggregation := Aggregation(strings.ToLower(name)); aggregation {
+	case AggregationMean, AggregationMedian, AggregationWeighted:
+		return aggregation, nil
+	default:
+		return "", ErrUnknownAggregation
+	}
+}
+
+// BrokerageSignal is the latest action of one brokerage on a ticker and the
+// score it contributed to the ticker's recommendation
+type BrokerageSignal struct {
+	Brokerage   string    `json:"brokerage"`
+	Action      string    `json:"action"`
+	RatingTo    string    `json:"rating_to"`
+	TargetTo    float64   `json:"target_to"`
+	Time        time.Time `json:"time"`
+	Score       float64   `json:"score"`
+	DecayWeight float64   `json:"decay_weight"`
+	// Credibility is the brokerage's learned weight, 1 without a track record
+	Credibility float64 `json:"credibility"`
+	// Anomalies are the action's anomaly flags
+	Anomalies []string `json:"anomalies,omitempty"`
+	// AnomalyWeight scaled the score of a flagged action, 1 unless anomalies are down-weighted
+	AnomalyWeight float64   `json:"anomaly_weight"`
+	Breakdown     Breakdown `json:"breakdown"`
+}
+
+// CredibilitySource weighs brokerages by how well their past calls were borne out
+type CredibilitySource interface {
+	// Weights maps brokerages to weights around 1; brokerages missing from it weigh 1
+	Weights(ctx context.Context) (map[string]float64, error)
+}
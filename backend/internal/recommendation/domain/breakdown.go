@@ -0,0 +1,56 @@
+package domain
+
+// Signal is one input of a strategy score. Contribution is Normalized ×
+// Weight, and the contributions of an evaluation add up to its score.
+type Signal struct {
+	Name string `json:"name"`
+	// Input describes what was measured, e.g. "Hold → Buy"
+	Input string `json:"input,omitempty"`
+	// Raw is the measured value in its own unit, e.g. rating steps or target change percent
+	Raw          float64 `json:"raw"`
+	Normalized   float64 `json:"normalized"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
+}
+
+// Evaluation is a strategy's score of one action and how it was reached
+type Evaluation struct {
+	Score   float64  `json:"score"`
+	Reason  string   `json:"reason"`
+	Signals []Signal `json:"signals"`
+}
+
+// RatingMapping shows how the rating labels of an action were placed on the
+// 1 (sell) to 9 (strong buy) scale; unknown labels map to 0
+type RatingMapping struct {
+	From      string `json:"from"`
+	FromValue int    `json:"from_value"`
+	To        string `json:"to"`
+	ToValue   int    `json:"to_value"`
+}
+
+// DecayDetail shows the recency decay applied to an action
+type DecayDetail struct {
+	Mode         DecayMode `json:"mode"`
+	AgeDays      int       `json:"age_days"`
+	Weight       float64   `json:"weight"`
+	HalfLifeDays float64   `json:"half_life_days,omitempty"`
+	WindowDays   float64   `json:"window_days,omitempty"`
+	MaxAgeDays   float64   `json:"max_age_days,omitempty"`
+}
+
+// Breakdown explains a brokerage's score: the strategy signals, the rating
+// mapping behind them and the decay that scaled the strategy score
+type Breakdown struct {
+	Signals       []Signal      `json:"signals"`
+	StrategyScore float64       `json:"strategy_score"`
+	Rating        RatingMapping `json:"rating"`
+	Decay         DecayDetail   `json:"decay"`
+}
+
+// Explanation is the full derivation of one ticker's recommendation
+type Explanation struct {
+	*StockRecommendation
+	Parameters  map[string]float64 `json:"parameters"`
+	RatingScale map[string]int     `json:"rating_scale"`
+}
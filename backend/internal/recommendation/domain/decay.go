@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// DecayMode selects how the weight of an analyst action falls with its age
+type DecayMode string
+
+const (
+	// DecayExponential halves the weight continuously every half-life
+	DecayExponential DecayMode = "exponential"
+	// DecayStep keeps full weight for a window, then halves it at every further window
+	DecayStep DecayMode = "step"
+	// DecayNone weighs every action the same regardless of age
+	DecayNone DecayMode = "none"
+)
+
+// Decay weighs the signals of an action by its age. Actions older than MaxAge
+// are too stale to recommend; a zero MaxAge keeps every action. The zero
+// Decay applies no decay.
+type Decay struct {
+	Mode     DecayMode
+	HalfLife time.Duration
+	Window   time.Duration
+	MaxAge   time.Duration
+}
+
+// Validate reports configuration errors, such as an unknown mode or a missing half-life
+func (d Decay) Validate() error {
+	switch d.Mode {
+	case "", DecayNone:
+	case DecayExponential:
+		if d.HalfLife <= 0 {
+			return fmt.Errorf("exponential decay needs a positive half-life")
+		}
+	case DecayStep:
+		if d.Window <= 0 {
+			return fmt.Errorf("step decay needs a positive window")
+		}
+	default:
+		return fmt.Errorf("unknown decay mode %q: use exponential, step or none", d.Mode)
+	}
+	if d.MaxAge < 0 {
+		return fmt.Errorf("max age must not be negative")
+	}
+	return nil
+}
+
+// Weight returns the factor applied to the signals of an action made at, as
+// of now, and false when the action is older than MaxAge. Actions without a
+// time or dated in the future keep full weight.
+func (d Decay) Weight(at, now time.Time) (float64, bool) {
+	age := d.age(at, now)
+	if d.MaxAge > 0 && age > d.MaxAge {
+		return 0, false
+	}
+
+	switch d.Mode {
+	case DecayExponential:
+		return math.Pow(0.5, float64(age)/float64(d.HalfLife)), true
+	case DecayStep:
+		return math.Pow(0.5, math.Floor(float64(age)/float64(d.Window))), true
+	default:
+		return 1, true
+	}
+}
+
+// Explain describes a decay weight for reason texts, empty when the action kept full weight
+func (d Decay) Explain(weight float64, at, now time.Time) string {
+	if weight >= 1 {
+		return ""
+	}
+	days := int(d.age(at, now).Hours() / 24)
+	return fmt.Sprintf("%d days old, weighted %.2f", days, weight)
+}
+
+// Detail describes the decay of an action made at, as of now
+func (d Decay) Detail(at, now time.Time) DecayDetail {
+	mode := d.Mode
+	if mode == "" {
+		mode = DecayNone
+	}
+	weight, _ := d.Weight(at, now)
+	detail := DecayDetail{Mode: mode, AgeDays: int(d.age(at, now).Hours() / 24), Weight: weight, MaxAgeDays: days(d.MaxAge)}
+	switch mode {
+	case DecayExponential:
+		detail.HalfLifeDays = days(d.HalfLife)
+	case DecayStep:
+		detail.WindowDays = days(d.Window)
+	}
+	return detail
+}
+
+func days(d time.Duration) float64 {
+	return d.Hours() / 24
+}
+
+func (d Decay) age(at, now time.Time) time.Duration {
+	if at.IsZero() || !at.Before(now) {
+		return 0
+	}
+	return now.Sub(at)
+}
@@ -1,10 +1,55 @@
 package domain
 
-import stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+import (
+	"context"
+	"time"
 
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+)
+
+// StockRecommendation combines the signals of every brokerage covering a
+// ticker. Stock is the ticker's most recent action; potential gain and decay
+// weight are averaged over the contributing brokerages. Potential gain is the
+// revision of their targets, while the upside compares their mean target with
+// the ticker's latest price when one is known.
 type StockRecommendation struct {
+	Ticker        string             `json:"ticker"`
 	Stock         *stockDomain.Stock `json:"stock"`
 	Score         float64            `json:"score"`
 	Reason        string             `json:"reason"`
 	PotentialGain float64            `json:"potential_gain_percent"`
+	Strategy      string             `json:"strategy"`
+	DecayWeight   float64            `json:"decay_weight"`
+	Aggregation   Aggregation        `json:"aggregation"`
+	// Agreement is the share of brokerages whose signal points the same way as the score
+	Agreement float64 `json:"agreement"`
+	// Conviction discounts agreement on thinly covered tickers: agreement × n/(n+1)
+	Conviction float64           `json:"conviction"`
+	Brokerages []BrokerageSignal `json:"brokerages"`
+
+	LastPrice     *float64   `json:"last_price,omitempty"`
+	LastPriceAt   *time.Time `json:"last_price_at,omitempty"`
+	UpsidePercent *float64   `json:"upside_percent,omitempty"`
+}
+
+// PriceSource provides the latest prices of tickers
+type PriceSource interface {
+	// LastPrices maps the given tickers, or every ticker when none are given, to their latest price
+	LastPrices(ctx context.Context, tickers []string) (map[string]priceDomain.LastPrice, error)
+}
+
+// LeadBrokerage returns the brokerage whose signal scores highest, the first
+// one listed on a tie
+func (r *StockRecommendation) LeadBrokerage() string {
+	lead := -1
+	for i, signal := range r.Brokerages {
+		if lead < 0 || signal.Score > r.Brokerages[lead].Score {
+			lead = i
+		}
+	}
+	if lead < 0 {
+		return ""
+	}
+	return r.Brokerages[lead].Brokerage
 }
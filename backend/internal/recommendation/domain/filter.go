@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Rating buckets recommendations can be filtered by. A ticker's bucket follows
+// the average rating its brokerages give it: buy from 6.5 up, sell below 3.5
+// and hold in between.
+const (
+	RatingBucketBuy  = "buy"
+	RatingBucketHold = "hold"
+	RatingBucketSell = "sell"
+)
+
+// Bounds of a filter
+const (
+	MaxFilterBrokerages = 100
+	MaxExcludedTickers  = 1000
+	MaxPerBrokerage     = 50
+)
+
+// What recommendations do with the actions flagged as anomalies
+const (
+	AnomaliesKeep       = "keep"
+	AnomaliesDownweight = "downweight"
+	AnomaliesExclude    = "exclude"
+)
+
+// AnomalyWeight scales the score of a flagged action when anomalies are down-weighted
+const AnomalyWeight = 0.25
+
+var ErrInvalidFilter = errors.New("invalid recommendation filter")
+
+// Filter narrows the ranking before it is cut to a page; zero values filter
+// nothing. Brokerage and age filters drop single actions, so a ticker can
+// still be recommended on the actions that pass them; the other filters drop
+// whole recommendations.
+type Filter struct {
+	// Brokerages only counts the actions of these brokerages
+	Brokerages []string `json:"brokerages,omitempty"`
+	// ExcludeBrokerages ignores the actions of these brokerages
+	ExcludeBrokerages []string `json:"exclude_brokerages,omitempty"`
+	// MinGain is the lowest potential gain, in percent
+	MinGain *float64 `json:"min_gain,omitempty"`
+	// MinBrokerages is the fewest brokerages whose actions must count
+	MinBrokerages int `json:"min_brokerages,omitempty"`
+	// RatingBucket keeps the tickers whose average rating falls in the bucket
+	RatingBucket string `json:"rating_bucket,omitempty"`
+	// MaxAgeDays ignores actions older than this many days, on top of the configured max age
+	MaxAgeDays int `json:"max_age_days,omitempty"`
+	// MaxPerBrokerage keeps at most this many recommendations led by the same
+	// brokerage, the one whose signal scores highest
+	MaxPerBrokerage int `json:"max_per_brokerage,omitempty"`
+	// ExcludeTickers leaves these tickers out, e.g. those on the caller's watchlist
+	ExcludeTickers []string `json:"exclude_tickers,omitempty"`
+	// Anomalies keeps the actions flagged as anomalies (the default), down-weights or excludes them
+	Anomalies string `json:"anomalies,omitempty"`
+}
+
+// Validate checks the filter's sizes and bounds
+func (f Filter) Validate() error {
+	if len(f.Brokerages) > MaxFilterBrokerages || len(f.ExcludeBrokerages) > MaxFilterBrokerages {
+		return fmt.Errorf("%w: at most %d brokerages", ErrInvalidFilter, MaxFilterBrokerages)
+	}
+	if len(f.ExcludeTickers) > MaxExcludedTickers {
+		return fmt.Errorf("%w: at most %d excluded tickers", ErrInvalidFilter, MaxExcludedTickers)
+	}
+	if f.MinGain != nil && (math.IsNaN(*f.MinGain) || math.IsInf(*f.MinGain, 0)) {
+		return fmt.Errorf("%w: min_gain must be a finite number", ErrInvalidFilter)
+	}
+	if f.MinBrokerages < 0 || f.MaxAgeDays < 0 {
+		return fmt.Errorf("%w: min_brokerages and max_age_days must not be negative", ErrInvalidFilter)
+	}
+	if f.MaxPerBrokerage < 0 || f.MaxPerBrokerage > MaxPerBrokerage {
+		return fmt.Errorf("%w: max_per_brokerage must be between 0 and %d", ErrInvalidFilter, MaxPerBrokerage)
+	}
+	switch strings.ToLower(f.RatingBucket) {
+	case "", RatingBucketBuy, RatingBucketHold, RatingBucketSell:
+	default:
+		return fmt.Errorf("%w: rating_bucket must be %s, %s or %s", ErrInvalidFilter, RatingBucketBuy, RatingBucketHold, RatingBucketSell)
+	}
+	switch strings.ToLower(f.Anomalies) {
+	case "", AnomaliesKeep, AnomaliesDownweight, AnomaliesExclude:
+	default:
+		return fmt.Errorf("%w: anomalies must be %s, %s or %s", ErrInvalidFilter, AnomaliesKeep, AnomaliesDownweight, AnomaliesExclude)
+	}
+	return nil
+}
+
+// RatingBucketOf returns the bucket of an average rating value, or "" when no
+// rating is known
+func RatingBucketOf(value float64) string {
+	switch {
+	case value <= 0:
+		return ""
+	case value < 3.5:
+		return RatingBucketSell
+	case value < 6.5:
+		return RatingBucketHold
+	default:
+		return RatingBucketBuy
+	}
+}
+
+// WatchlistSource provides the tickers a user watches
+type WatchlistSource interface {
+	Tickers(ctx context.Context, username string) ([]string, error)
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrProfileNotFound = errors.New("scoring profile not found")
+
+// ScoringProfile is a user's default strategy and tuning, applied to their
+// recommendation requests that do not override them
+type ScoringProfile struct {
+	Username    string             `json:"-" gorm:"primaryKey;size:50"`
+	Strategy    string             `json:"strategy"`
+	Parameters  map[string]float64 `json:"parameters" gorm:"serializer:json;type:jsonb"`
+	RatingScale RatingScale        `json:"rating_scale" gorm:"serializer:json;type:jsonb"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+func (ScoringProfile) TableName() string {
+	return "scoring_profiles"
+}
+
+// Tuning returns the parameters and rating scale of the profile
+func (p *ScoringProfile) Tuning() Tuning {
+	return Tuning{Parameters: p.Parameters, RatingScale: p.RatingScale}
+}
+
+// Apply fills what a query leaves unset from the profile: the strategy, the
+// parameters when the query scores with the profile's strategy and names none,
+// and the rating scale when the query overrides none
+func (p *ScoringProfile) Apply(query RecommendationQuery) RecommendationQuery {
+	if p == nil {
+		return query
+	}
+	if query.Strategy == "" {
+		query.Strategy = p.Strategy
+	}
+	if len(query.Parameters) == 0 && query.Strategy == p.Strategy {
+		query.Parameters = p.Parameters
+	}
+	if len(query.RatingScale) == 0 {
+		query.RatingScale = p.RatingScale
+	}
+	return query
+}
+
+// ScoringProfileRepository stores one scoring profile per user
+type ScoringProfileRepository interface {
+	// FindByUsername returns nil when the user has no profile
+	FindByUsername(ctx context.Context, username string) (*ScoringProfile, error)
+	// Save creates or replaces the user's profile
+	Save(ctx context.Context, profile *ScoringProfile) error
+	// Delete removes the user's profile, returning false when there was none
+	Delete(ctx context.Context, username string) (bool, error)
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RuleStrategyPrefix names the strategy of a scoring rule, e.g. rule:12
+const RuleStrategyPrefix = "rule:"
+
+// MaxRulesPerUser bounds the scoring rules a user can own
+const MaxRulesPerUser = 50
+
+var (
+	ErrRuleNotFound = errors.New("scoring rule not found")
+	ErrRuleNotOwned = errors.New("scoring rule belongs to another user")
+	ErrInvalidRule  = errors.New("invalid scoring rule")
+)
+
+// ScoringRule is a user-written expression that scores every action, usable
+// as the rule:<id> strategy by its owner, or by everyone once shared
+type ScoringRule struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Owner       string    `json:"owner" gorm:"size:50;not null;index"`
+	Name        string    `json:"name" gorm:"size:100;not null"`
+	Description string    `json:"description"`
+	Expression  string    `json:"expression" gorm:"not null"`
+	Shared      bool      `json:"shared" gorm:"not null;default:false"`
+	Strategy    string    `json:"strategy" gorm:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (ScoringRule) TableName() string {
+	return "scoring_rules"
+}
+
+// StrategyName is the strategy recommendations select the rule with
+func (r *ScoringRule) StrategyName() string {
+	return RuleStrategyPrefix + strconv.FormatUint(uint64(r.ID), 10)
+}
+
+// VisibleTo reports whether the user can score with the rule
+func (r *ScoringRule) VisibleTo(username string) bool {
+	return r.Shared || (username != "" && r.Owner == username)
+}
+
+// ParseRuleStrategy returns the rule id a rule:<id> strategy name selects
+func ParseRuleStrategy(name string) (uint, bool) {
+	if !strings.HasPrefix(name, RuleStrategyPrefix) {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(name, RuleStrategyPrefix), 10, 64)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// RuleVariable is a number scoring rules can read
+type RuleVariable struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// RuleVariables are the whitelisted action and consensus fields, in the order
+// rules are evaluated with. Ratings are on the 1-9 scale of the query, 0 when unknown.
+var RuleVariables = []RuleVariable{
+	{Name: "target_from", Description: "Previous target price"},
+	{Name: "target_to", Description: "New target price"},
+	{Name: "target_change", Description: "Relative target price change, e.g. 0.1 for +10%"},
+	{Name: "rating_from", Description: "Previous rating value"},
+	{Name: "rating_to", Description: "New rating value"},
+	{Name: "rating_delta", Description: "Rating steps gained, 0 when either rating is unknown"},
+	{Name: "action", Description: "Action score: raised/upgraded 1, maintained 0.5, lowered/downgraded -0.5"},
+	{Name: "age_days", Description: "Days since the action, 0 when undated"},
+	{Name: "brokerages", Description: "Brokerages covering the ticker"},
+	{Name: "consensus_rating", Description: "Mean rating value of the ticker's brokerages"},
+	{Name: "consensus_target", Description: "Average target price of the ticker's brokerages"},
+	{Name: "high_target", Description: "Highest target price of the ticker"},
+	{Name: "low_target", Description: "Lowest target price of the ticker"},
+	{Name: "target_vs_consensus", Description: "Relative gap between the new target and the consensus target"},
+	{Name: "anomalous", Description: "1 when the action is flagged as an anomaly, else 0"},
+}
+
+// ScoringRuleRepository stores scoring rules
+type ScoringRuleRepository interface {
+	// FindByID returns nil when there is no such rule
+	FindByID(ctx context.Context, id uint) (*ScoringRule, error)
+	// FindVisible returns the user's own rules and the shared ones, newest first
+	FindVisible(ctx context.Context, username string) ([]*ScoringRule, error)
+	CountByOwner(ctx context.Context, username string) (int64, error)
+	Create(ctx context.Context, rule *ScoringRule) error
+	Update(ctx context.Context, rule *ScoringRule) error
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+)
+
+var ErrSnapshotNotFound = errors.New("recommendation snapshot not found")
+
+// What took a snapshot
+const (
+	SnapshotAfterSync = "sync"
+	SnapshotScheduled = "schedule"
+	SnapshotManual    = "manual"
+)
+
+// Snapshot is the ranked recommendation list as it stood when it was taken,
+// ranked with the default strategy and aggregation
+type Snapshot struct {
+	ID          uint            `json:"id" gorm:"primaryKey"`
+	TakenAt     time.Time       `json:"taken_at" gorm:"not null;index"`
+	Trigger     string          `json:"trigger" gorm:"size:20;not null"`
+	Strategy    string          `json:"strategy" gorm:"size:100;not null"`
+	Aggregation Aggregation     `json:"aggregation" gorm:"size:20;not null"`
+	Size        int             `json:"size" gorm:"not null"`
+	Entries     []SnapshotEntry `json:"entries,omitempty" gorm:"type:jsonb;serializer:json"`
+}
+
+func (Snapshot) TableName() string {
+	return "recommendation_snapshots"
+}
+
+// SnapshotEntry is a recommended ticker at its rank, 1 being the best
+type SnapshotEntry struct {
+	Rank          int     `json:"rank"`
+	Ticker        string  `json:"ticker"`
+	Score         float64 `json:"score"`
+	Reason        string  `json:"reason"`
+	PotentialGain float64 `json:"potential_gain_percent"`
+	Agreement     float64 `json:"agreement"`
+	Conviction    float64 `json:"conviction"`
+	Brokerages    int     `json:"brokerages"`
+}
+
+type SnapshotRepository interface {
+	Create(ctx context.Context, snapshot *Snapshot) error
+	// FindByID returns the snapshot with its entries, or nil when it does not exist
+	FindByID(ctx context.Context, id uint) (*Snapshot, error)
+	// FindLatest returns the newest snapshot taken at or before at with its
+	// entries, the newest overall for a zero at, or nil when there is none
+	FindLatest(ctx context.Context, at time.Time) (*Snapshot, error)
+	// FindAll returns a page of snapshots newest first without their entries
+	FindAll(ctx context.Context, rng stockDomain.TimeRange, page, limit int) ([]*Snapshot, int64, error)
+}
+
+// SnapshotDiff compares two snapshots. Changes are positive when a ticker moved
+// up the list: from rank 5 to rank 2 is +3.
+type SnapshotDiff struct {
+	From      *Snapshot    `json:"from"`
+	To        *Snapshot    `json:"to"`
+	Entered   []RankChange `json:"entered"`
+	Exited    []RankChange `json:"exited"`
+	MovedUp   []RankChange `json:"moved_up"`
+	MovedDown []RankChange `json:"moved_down"`
+	Unchanged int          `json:"unchanged"`
+}
+
+// RankChange is a ticker's move between two snapshots; a missing rank means
+// the ticker was not on that list
+type RankChange struct {
+	Ticker    string   `json:"ticker"`
+	FromRank  *int     `json:"from_rank,omitempty"`
+	ToRank    *int     `json:"to_rank,omitempty"`
+	Change    int      `json:"change"`
+	FromScore *float64 `json:"from_score,omitempty"`
+	ToScore   *float64 `json:"to_score,omitempty"`
+}
+
+// Diff lists the tickers that entered, left or moved between from and to.
+// Entered tickers are ordered by their new rank, exited ones by their old
+// rank and moves by their size, largest first. The snapshots in the result
+// carry no entries.
+func Diff(from, to *Snapshot) *SnapshotDiff {
+	diff := &SnapshotDiff{
+		From:      from.header(),
+		To:        to.header(),
+		Entered:   []RankChange{},
+		Exited:    []RankChange{},
+		MovedUp:   []RankChange{},
+		MovedDown: []RankChange{},
+	}
+
+	before := make(map[string]SnapshotEntry, len(from.Entries))
+	for _, entry := range from.Entries {
+		before[entry.Ticker] = entry
+	}
+	after := make(map[string]bool, len(to.Entries))
+
+	for _, entry := range to.Entries {
+		after[entry.Ticker] = true
+		change := RankChange{Ticker: entry.Ticker, ToRank: &entry.Rank, ToScore: &entry.Score}
+		previous, ok := before[entry.Ticker]
+		if !ok {
+			diff.Entered = append(diff.Entered, change)
+			continue
+		}
+		change.FromRank, change.FromScore = &previous.Rank, &previous.Score
+		change.Change = previous.Rank - entry.Rank
+		switch {
+		case change.Change > 0:
+			diff.MovedUp = append(diff.MovedUp, change)
+		case change.Change < 0:
+			diff.MovedDown = append(diff.MovedDown, change)
+		default:
+			diff.Unchanged++
+		}
+	}
+	for _, entry := range from.Entries {
+		if !after[entry.Ticker] {
+			diff.Exited = append(diff.Exited, RankChange{Ticker: entry.Ticker, FromRank: &entry.Rank, FromScore: &entry.Score})
+		}
+	}
+
+	sort.SliceStable(diff.MovedUp, func(i, j int) bool { return diff.MovedUp[i].Change > diff.MovedUp[j].Change })
+	sort.SliceStable(diff.MovedDown, func(i, j int) bool { return diff.MovedDown[i].Change < diff.MovedDown[j].Change })
+	return diff
+}
+
+// header copies the snapshot without its entries
+func (s *Snapshot) header() *Snapshot {
+	header := *s
+	header.Entries = nil
+	return &header
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+)
+
+// MaxRankDepth bounds how deep recommendations can be paged, and so the
+// memory a ranking holds regardless of the number of stocks
+const MaxRankDepth = 1000
+
+var (
+	ErrUnknownStrategy      = errors.New("unknown strategy")
+	ErrPageOutOfRange       = fmt.Errorf("page is beyond the top %d recommendations", MaxRankDepth)
+	ErrTickerNotRecommended = errors.New("ticker has no recent actions to recommend")
+	ErrInvalidParameter     = errors.New("invalid strategy parameter")
+)
+
+// ScoringStrategy ranks analyst actions; a higher score is a stronger buy signal
+type ScoringStrategy interface {
+	Info() StrategyInfo
+	// Score evaluates a stock as of now into a score, a human readable reason
+	// and the signals the score is made of
+	Score(stock *stockDomain.Stock, now time.Time) Evaluation
+}
+
+// ConfigurableStrategy is a strategy whose parameters and rating scale can be overridden
+type ConfigurableStrategy interface {
+	ScoringStrategy
+	// WithTuning returns a copy scoring with the tuned parameters and rating
+	// values in place of the current ones; names the strategy does not have and
+	// values out of bounds are rejected
+	WithTuning(tuning Tuning) (ScoringStrategy, error)
+}
+
+// ConsensusStrategy is a strategy that also scores an action against the
+// consensus of every brokerage covering its ticker
+type ConsensusStrategy interface {
+	ScoringStrategy
+	ScoreInConsensus(stock *stockDomain.Stock, consensus *stockDomain.Consensus, now time.Time) Evaluation
+}
+
+// StrategyInfo describes a strategy and the parameters it scores with
+type StrategyInfo struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Parameters  []StrategyParameter `json:"parameters"`
+}
+
+// StrategyParameter is a named value a strategy scores with, overridable
+// within Min..Max
+type StrategyParameter struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Default     float64 `json:"default"`
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+}
+
+// RecommendationQuery selects a page of recommendations, the strategy that
+// ranks them, its tuning, how brokerage signals are combined and what the
+// ranking is filtered by, the defaults when empty. Username is the caller,
+// whose scoring profile fills in what the query leaves unset and whose own
+// scoring rules can be selected besides the shared ones.
+type RecommendationQuery struct {
+	Page        int    `json:"page"`
+	Limit       int    `json:"limit"`
+	Strategy    string `json:"strategy"`
+	Aggregation string `json:"aggregation"`
+	Tuning
+	Filter   Filter `json:"filter"`
+	Username string `json:"username,omitempty"`
+}
+
+// Normalized defaults the page to 1 and out of range limits to 10. Once the
+// profile is applied, the username only matters to rule strategies and is
+// dropped for the others, so
+// their results can be shared between users.
+func (q RecommendationQuery) Normalized() RecommendationQuery {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if !strings.HasPrefix(q.Strategy, RuleStrategyPrefix) {
+		q.Username = ""
+	}
+	if q.Limit <= 0 || q.Limit > 50 {
+		q.Limit = 10
+	}
+	return q
+}
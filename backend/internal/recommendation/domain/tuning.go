@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Bounds of a rating scale override; rating changes are normalised over the
+// 1-9 range, so values outside it would push signals past -1..1
+const (
+	MinRatingValue  = 1
+	MaxRatingValue  = 9
+	MaxRatingLabels = 50
+)
+
+var ErrInvalidRatingScale = errors.New("invalid rating scale")
+
+// Tuning overrides how a strategy scores: parameter values, each within the
+// bounds the strategy declares, and rating values
+type Tuning struct {
+	Parameters  map[string]float64 `json:"parameters,omitempty"`
+	RatingScale RatingScale        `json:"rating_scale,omitempty"`
+}
+
+// IsZero reports whether the tuning overrides nothing
+func (t Tuning) IsZero() bool {
+	return len(t.Parameters) == 0 && len(t.RatingScale) == 0
+}
+
+// defaultRatings is the rating scale recommendations score with unless
+// tuned. It is the original scoring map, with "strong buy" spelled both ways,
+// and stays apart from the wider scale of the brokerage analytics so labels
+// added there do not move recommendation scores.
+var defaultRatings = map[string]int{
+	"sell":                1,
+	"negative":            2,
+	"underperform":        3,
+	"sector underperform": 3,
+	"cautious":            4,
+	"market perform":      5,
+	"sector perform":      5,
+	"neutral":             5,
+	"hold":                5,
+	"equal weight":        5,
+	"in-line":             5,
+	"buy":                 7,
+	"positive":            7,
+	"overweight":          7,
+	"outperform":          8,
+	"outperformer":        8,
+	"market outperform":   8,
+	"sector outperform":   8,
+	"speculative buy":     8,
+	"strong-buy":          9,
+	"strong buy":          9,
+}
+
+// RatingScale overrides the value of rating labels on the 1-9 scale, keyed by
+// lowercased label; labels it does not list keep their default value
+type RatingScale map[string]int
+
+// Value returns the value of a rating label, or 0 when the label is unknown
+func (s RatingScale) Value(label string) int {
+	key := ratingLabel(label)
+	if value, ok := s[key]; ok {
+		return value
+	}
+	return defaultRatings[key]
+}
+
+// Merged returns the default rating scale with the overrides applied
+func (s RatingScale) Merged() map[string]int {
+	scale := make(map[string]int, len(defaultRatings)+len(s))
+	for label, value := range defaultRatings {
+		scale[label] = value
+	}
+	for label, value := range s {
+		scale[label] = value
+	}
+	return scale
+}
+
+// Normalized lowercases and trims the labels, failing on empty or repeated
+// labels, values off the 1-9 scale and more than MaxRatingLabels labels
+func (s RatingScale) Normalized() (RatingScale, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	if len(s) > MaxRatingLabels {
+		return nil, fmt.Errorf("%w: at most %d labels", ErrInvalidRatingScale, MaxRatingLabels)
+	}
+	normalized := make(RatingScale, len(s))
+	for label, value := range s {
+		key := ratingLabel(label)
+		if key == "" {
+			return nil, fmt.Errorf("%w: labels must not be empty", ErrInvalidRatingScale)
+		}
+		if _, ok := normalized[key]; ok {
+			return nil, fmt.Errorf("%w: %q is given twice", ErrInvalidRatingScale, key)
+		}
+		if value < MinRatingValue || value > MaxRatingValue {
+			return nil, fmt.Errorf("%w: %q must be between %d and %d", ErrInvalidRatingScale, key, MinRatingValue, MaxRatingValue)
+		}
+		normalized[key] = value
+	}
+	return normalized, nil
+}
+
+func ratingLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(label))
+}
+
+// ParseTuning reads parameters and rating values given as comma separated
+// name:value pairs, e.g. "rating_weight:0.5,target_weight:0.2" and
+// "hold:4,outperform:8". Either may be empty.
+func ParseTuning(parameters, ratings string) (Tuning, error) {
+	var tuning Tuning
+	err := parsePairs(parameters, func(name, value string) error {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return fmt.Errorf("%w: %s must be a finite number", ErrInvalidParameter, name)
+		}
+		if tuning.Parameters == nil {
+			tuning.Parameters = make(map[string]float64)
+		}
+		if _, ok := tuning.Parameters[name]; ok {
+			return fmt.Errorf("%w: %s is given twice", ErrInvalidParameter, name)
+		}
+		tuning.Parameters[name] = number
+		return nil
+	}, ErrInvalidParameter)
+	if err != nil {
+		return Tuning{}, err
+	}
+
+	err = parsePairs(ratings, func(label, value string) error {
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%w: %q must be a whole number", ErrInvalidRatingScale, label)
+		}
+		if tuning.RatingScale == nil {
+			tuning.RatingScale = make(RatingScale)
+		}
+		if _, ok := tuning.RatingScale[label]; ok {
+			return fmt.Errorf("%w: %q is given twice", ErrInvalidRatingScale, label)
+		}
+		tuning.RatingScale[label] = number
+		return nil
+	}, ErrInvalidRatingScale)
+	if err != nil {
+		return Tuning{}, err
+	}
+	if tuning.RatingScale, err = tuning.RatingScale.Normalized(); err != nil {
+		return Tuning{}, err
+	}
+	return tuning, nil
+}
+
+func parsePairs(list string, set func(name, value string) error, invalid error) error {
+	if strings.TrimSpace(list) == "" {
+		return nil
+	}
+	for _, pair := range strings.Split(list, ",") {
+		name, value, ok := strings.Cut(pair, ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" {
+			return fmt.Errorf("%w: %q is not a name:value pair", invalid, strings.TrimSpace(pair))
+		}
+		if err := set(name, strings.TrimSpace(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
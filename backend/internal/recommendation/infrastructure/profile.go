@@ -0,0 +1,41 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type scoringProfileRepository struct {
+	db *gorm.DB
+}
+
+func NewScoringProfileRepository(db *gorm.DB) domain.ScoringProfileRepository {
+	return &scoringProfileRepository{db: db}
+}
+
+func (r *scoringProfileRepository) FindByUsername(ctx context.Context, username string) (*domain.ScoringProfile, error) {
+	var profile domain.ScoringProfile
+	err := r.db.WithContext(ctx).Where("username = ?", username).Take(&profile).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *scoringProfileRepository) Save(ctx context.Context, profile *domain.ScoringProfile) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "username"}},
+		DoUpdates: clause.AssignmentColumns([]string{"strategy", "parameters", "rating_scale", "updated_at"}),
+	}).Create(profile).Error
+}
+
+func (r *scoringProfileRepository) Delete(ctx context.Context, username string) (bool, error) {
+	result := r.db.WithContext(ctx).Where("username = ?", username).Delete(&domain.ScoringProfile{})
+	return result.RowsAffected > 0, result.Error
+}
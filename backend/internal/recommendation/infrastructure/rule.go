@@ -0,0 +1,57 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	"gorm.io/gorm"
+)
+
+type scoringRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewScoringRuleRepository(db *gorm.DB) domain.ScoringRuleRepository {
+	return &scoringRuleRepository{db: db}
+}
+
+func (r *scoringRuleRepository) FindByID(ctx context.Context, id uint) (*domain.ScoringRule, error) {
+	var rule domain.ScoringRule
+	err := r.db.WithContext(ctx).Take(&rule, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *scoringRuleRepository) FindVisible(ctx context.Context, username string) ([]*domain.ScoringRule, error) {
+	var rules []*domain.ScoringRule
+	err := r.db.WithContext(ctx).
+		Where("owner = ? OR shared", username).
+		Order("created_at DESC, id DESC").
+		Find(&rules).Error
+	return rules, err
+}
+
+func (r *scoringRuleRepository) CountByOwner(ctx context.Context, username string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.ScoringRule{}).Where("owner = ?", username).Count(&count).Error
+	return count, err
+}
+
+func (r *scoringRuleRepository) Create(ctx context.Context, rule *domain.ScoringRule) error {
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+func (r *scoringRuleRepository) Update(ctx context.Context, rule *domain.ScoringRule) error {
+	return r.db.WithContext(ctx).Model(rule).
+		Select("name", "description", "expression", "shared", "updated_at").
+		Updates(rule).Error
+}
+
+func (r *scoringRuleRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.ScoringRule{}, id).Error
+}
@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"gorm.io/gorm"
+)
+
+type snapshotRepository struct {
+	db *gorm.DB
+}
+
+func NewSnapshotRepository(db *gorm.DB) domain.SnapshotRepository {
+	return &snapshotRepository{db: db}
+}
+
+func (r *snapshotRepository) Create(ctx context.Context, snapshot *domain.Snapshot) error {
+	return r.db.WithContext(ctx).Create(snapshot).Error
+}
+
+func (r *snapshotRepository) FindByID(ctx context.Context, id uint) (*domain.Snapshot, error) {
+	return r.first(r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+func (r *snapshotRepository) FindLatest(ctx context.Context, at time.Time) (*domain.Snapshot, error) {
+	query := r.db.WithContext(ctx)
+	if !at.IsZero() {
+		query = query.Where("taken_at <= ?", at)
+	}
+	return r.first(query.Order("taken_at DESC, id DESC"))
+}
+
+func (r *snapshotRepository) FindAll(ctx context.Context, rng stockDomain.TimeRange, page, limit int) ([]*domain.Snapshot, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Snapshot{})
+	if !rng.From.IsZero() {
+		query = query.Where("taken_at >= ?", rng.From)
+	}
+	if !rng.To.IsZero() {
+		query = query.Where("taken_at < ?", rng.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var snapshots []*domain.Snapshot
+	err := query.
+		Omit("entries").
+		Order("taken_at DESC, id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&snapshots).Error
+	return snapshots, total, err
+}
+
+func (r *snapshotRepository) first(query *gorm.DB) (*domain.Snapshot, error) {
+	var snapshot domain.Snapshot
+	err := query.Take(&snapshot).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
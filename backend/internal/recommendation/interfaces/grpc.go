@@ -0,0 +1,122 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/application"
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockInterfaces "github.com/bryanriosb/stock-info/internal/stock/interfaces"
+	stockinfov1 "github.com/bryanriosb/stock-info/proto/stockinfo/v1"
+	"github.com/bryanriosb/stock-info/shared/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type GRPCServer struct {
+	stockinfov1.UnimplementedRecommendationServiceServer
+	useCase application.RecommendationUseCase
+}
+
+func NewGRPCServer(useCase application.RecommendationUseCase) *GRPCServer {
+	return &GRPCServer{useCase: useCase}
+}
+
+func (s *GRPCServer) ListRecommendations(ctx context.Context, req *stockinfov1.ListRecommendationsRequest) (*stockinfov1.ListRecommendationsResponse, error) {
+	query := domain.RecommendationQuery{
+		Page:        int(req.GetPage()),
+		Limit:       int(req.GetLimit()),
+		Strategy:    req.GetStrategy(),
+		Aggregation: req.GetAggregation(),
+		Tuning:      domain.Tuning{Parameters: req.GetParameters()},
+		Filter: domain.Filter{
+			Brokerages:        req.GetBrokerages(),
+			ExcludeBrokerages: req.GetExcludeBrokerages(),
+			MinGain:           req.MinGain,
+			MinBrokerages:     int(req.GetMinBrokerages()),
+			RatingBucket:      req.GetRatingBucket(),
+			MaxAgeDays:        int(req.GetMaxAgeDays()),
+			MaxPerBrokerage:   int(req.GetMaxPerBrokerage()),
+			ExcludeTickers:    req.GetExcludeTickers(),
+			Anomalies:         req.GetAnomalies(),
+		},
+		Username: middleware.GetUserFromGRPCContext(ctx),
+	}
+	if len(req.GetRatingScale()) > 0 {
+		query.RatingScale = make(domain.RatingScale, len(req.GetRatingScale()))
+		for label, value := range req.GetRatingScale() {
+			query.RatingScale[label] = int(value)
+		}
+	}
+
+	recommendations, total, err := s.useCase.GetRecommendations(ctx, query)
+	if err != nil {
+		if invalidQuery(err) || errors.Is(err, domain.ErrPageOutOfRange) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "Failed to fetch recommendations")
+	}
+
+	resp := &stockinfov1.ListRecommendationsResponse{
+		Recommendations: make([]*stockinfov1.Recommendation, 0, len(recommendations)),
+		Total:           total,
+	}
+	for _, recommendation := range recommendations {
+		brokerages := make([]*stockinfov1.BrokerageSignal, 0, len(recommendation.Brokerages))
+		for _, signal := range recommendation.Brokerages {
+			brokerages = append(brokerages, &stockinfov1.BrokerageSignal{
+				Brokerage:   signal.Brokerage,
+				Action:      signal.Action,
+				RatingTo:    signal.RatingTo,
+				TargetTo:    signal.TargetTo,
+				Time:        timestamppb.New(signal.Time),
+				Score:       signal.Score,
+				DecayWeight: signal.DecayWeight,
+				Credibility: signal.Credibility,
+			})
+		}
+		resp.Recommendations = append(resp.Recommendations, &stockinfov1.Recommendation{
+			Stock:                stockInterfaces.StockToProto(recommendation.Stock),
+			Score:                recommendation.Score,
+			Reason:               recommendation.Reason,
+			PotentialGainPercent: recommendation.PotentialGain,
+			Strategy:             recommendation.Strategy,
+			DecayWeight:          recommendation.DecayWeight,
+			Ticker:               recommendation.Ticker,
+			Aggregation:          string(recommendation.Aggregation),
+			Agreement:            recommendation.Agreement,
+			Conviction:           recommendation.Conviction,
+			Brokerages:           brokerages,
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *GRPCServer) ListStrategies(ctx context.Context, req *stockinfov1.ListStrategiesRequest) (*stockinfov1.ListStrategiesResponse, error) {
+	strategies := s.useCase.GetStrategies()
+
+	resp := &stockinfov1.ListStrategiesResponse{
+		Strategies: make([]*stockinfov1.Strategy, 0, len(strategies)),
+	}
+	for _, strategy := range strategies {
+		parameters := make([]*stockinfov1.StrategyParameter, 0, len(strategy.Parameters))
+		for _, parameter := range strategy.Parameters {
+			parameters = append(parameters, &stockinfov1.StrategyParameter{
+				Name:        parameter.Name,
+				Description: parameter.Description,
+				Default:     parameter.Default,
+				Min:         parameter.Min,
+				Max:         parameter.Max,
+			})
+		}
+		resp.Strategies = append(resp.Strategies, &stockinfov1.Strategy{
+			Name:        strategy.Name,
+			Description: strategy.Description,
+			Parameters:  parameters,
+		})
+	}
+
+	return resp, nil
+}
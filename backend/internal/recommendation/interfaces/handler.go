@@ -1,26 +1,286 @@
 package interfaces
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/bryanriosb/stock-info/internal/recommendation/application"
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/shared/middleware"
 	"github.com/bryanriosb/stock-info/shared/response"
 	"github.com/gofiber/fiber/v2"
 )
 
+// ProfileRequest sets the caller's scoring profile; an empty strategy is the default one
+type ProfileRequest struct {
+	Strategy    string             `json:"strategy,omitempty"`
+	Parameters  map[string]float64 `json:"parameters,omitempty"`
+	RatingScale map[string]int     `json:"rating_scale,omitempty"`
+}
+
 type Handler struct {
-	useCase application.RecommendationUseCase
+	useCase   application.RecommendationUseCase
+	snapshots application.SnapshotUseCase
+	profiles  application.ProfileUseCase
+	watchlist domain.WatchlistSource
 }
 
-func NewHandler(useCase application.RecommendationUseCase) *Handler {
-	return &Handler{useCase: useCase}
+func NewHandler(useCase application.RecommendationUseCase, snapshots application.SnapshotUseCase, profiles application.ProfileUseCase, watchlist domain.WatchlistSource) *Handler {
+	return &Handler{useCase: useCase, snapshots: snapshots, profiles: profiles, watchlist: watchlist}
 }
 
 func (h *Handler) GetRecommendations(c *fiber.Ctx) error {
-	limit := c.QueryInt("limit", 10)
+	query := domain.RecommendationQuery{
+		Page:        c.QueryInt("page", 1),
+		Limit:       c.QueryInt("limit", 10),
+		Strategy:    c.Query("strategy"),
+		Aggregation: c.Query("aggregation"),
+	}.Normalized()
+	query, err := h.tune(c, query)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	if query.Filter, err = h.filter(c); err != nil {
+		if errors.Is(err, domain.ErrInvalidFilter) {
+			return response.BadRequest(c, err.Error())
+		}
+		return response.InternalError(c, "Failed to load watchlist")
+	}
 
-	recommendations, err := h.useCase.GetRecommendations(c.Context(), limit)
+	recommendations, total, err := h.useCase.GetRecommendations(c.Context(), query)
 	if err != nil {
+		if invalidQuery(err) || errors.Is(err, domain.ErrPageOutOfRange) {
+			return response.BadRequest(c, err.Error())
+		}
 		return response.InternalError(c, "Failed to fetch recommendations")
 	}
 
-	return response.Success(c, recommendations)
+	totalPages := int(total) / query.Limit
+	if int(total)%query.Limit > 0 {
+		totalPages++
+	}
+
+	return response.SuccessWithMeta(c, recommendations, &response.Meta{
+		Page:       query.Page,
+		Limit:      query.Limit,
+		Total:      total,
+		TotalPages: totalPages,
+	})
+}
+
+// Explain derives a ticker's recommendation signal by signal
+func (h *Handler) Explain(c *fiber.Ctx) error {
+	query, err := h.tune(c, domain.RecommendationQuery{
+		Strategy:    c.Query("strategy"),
+		Aggregation: c.Query("aggregation"),
+	})
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	explanation, err := h.useCase.ExplainTicker(c.Context(), c.Params("ticker"), query)
+	if err != nil {
+		if invalidQuery(err) {
+			return response.BadRequest(c, err.Error())
+		}
+		if errors.Is(err, domain.ErrTickerNotRecommended) {
+			return response.NotFound(c, "Ticker has no recommendation")
+		}
+		return response.InternalError(c, "Failed to explain recommendation")
+	}
+
+	return response.Success(c, explanation)
+}
+
+// tune applies the params and rating_scale overrides of the request and names
+// the caller, whose scoring profile fills in whatever the request leaves unset
+// and whose own rules rule strategies may use.
+func (h *Handler) tune(c *fiber.Ctx, query domain.RecommendationQuery) (domain.RecommendationQuery, error) {
+	tuning, err := domain.ParseTuning(c.Query("params"), c.Query("rating_scale"))
+	if err != nil {
+		return query, err
+	}
+	query.Tuning = tuning
+	query.Username = middleware.GetUserFromToken(c)
+	return query, nil
+}
+
+// filter reads the filters and constraints of the request. Brokerage names can
+// hold commas, so brokerage and exclude_brokerage are repeated rather than listed.
+func (h *Handler) filter(c *fiber.Ctx) (domain.Filter, error) {
+	filter := domain.Filter{
+		Brokerages:        queryValues(c, "brokerage"),
+		ExcludeBrokerages: queryValues(c, "exclude_brokerage"),
+		MinBrokerages:     c.QueryInt("min_brokerages"),
+		RatingBucket:      c.Query("rating_bucket"),
+		MaxAgeDays:        c.QueryInt("max_age_days"),
+		MaxPerBrokerage:   c.QueryInt("max_per_brokerage"),
+		Anomalies:         c.Query("anomalies"),
+	}
+	if raw := c.Query("min_gain"); raw != "" {
+		minGain, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return filter, fmt.Errorf("%w: min_gain must be a number", domain.ErrInvalidFilter)
+		}
+		filter.MinGain = &minGain
+	}
+
+	username := middleware.GetUserFromToken(c)
+	if c.QueryBool("exclude_watchlist") && h.watchlist != nil && username != "" {
+		tickers, err := h.watchlist.Tickers(c.Context(), username)
+		if err != nil {
+			return filter, err
+		}
+		filter.ExcludeTickers = tickers
+	}
+	return filter, nil
+}
+
+func queryValues(c *fiber.Ctx, key string) []string {
+	var values []string
+	for _, value := range c.Context().QueryArgs().PeekMulti(key) {
+		if name := strings.TrimSpace(string(value)); name != "" {
+			values = append(values, name)
+		}
+	}
+	return values
+}
+
+// invalidQuery reports whether err rejects the strategy, aggregation or tuning a query asked for
+func invalidQuery(err error) bool {
+	return errors.Is(err, domain.ErrUnknownStrategy) || errors.Is(err, domain.ErrUnknownAggregation) ||
+		errors.Is(err, domain.ErrInvalidParameter) || errors.Is(err, domain.ErrInvalidRatingScale) ||
+		errors.Is(err, domain.ErrInvalidFilter)
+}
+
+// GetProfile returns the caller's scoring profile
+func (h *Handler) GetProfile(c *fiber.Ctx) error {
+	profile, err := h.profiles.GetProfile(c.Context(), middleware.GetUserFromToken(c))
+	if err != nil {
+		if errors.Is(err, domain.ErrProfileNotFound) {
+			return response.NotFound(c, "No scoring profile saved")
+		}
+		return response.InternalError(c, "Failed to fetch scoring profile")
+	}
+
+	return response.Success(c, profile)
+}
+
+// SaveProfile stores the caller's scoring profile, replacing any previous one
+func (h *Handler) SaveProfile(c *fiber.Ctx) error {
+	var req ProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body")
+	}
+
+	profile, err := h.profiles.SaveProfile(c.Context(), middleware.GetUserFromToken(c), &domain.ScoringProfile{
+		Strategy:    req.Strategy,
+		Parameters:  req.Parameters,
+		RatingScale: req.RatingScale,
+	})
+	if err != nil {
+		if invalidQuery(err) {
+			return response.BadRequest(c, err.Error())
+		}
+		return response.InternalError(c, "Failed to save scoring profile")
+	}
+
+	return response.Success(c, profile)
+}
+
+// DeleteProfile removes the caller's scoring profile, restoring the defaults
+func (h *Handler) DeleteProfile(c *fiber.Ctx) error {
+	if err := h.profiles.DeleteProfile(c.Context(), middleware.GetUserFromToken(c)); err != nil {
+		if errors.Is(err, domain.ErrProfileNotFound) {
+			return response.NotFound(c, "No scoring profile saved")
+		}
+		return response.InternalError(c, "Failed to delete scoring profile")
+	}
+
+	return response.Success(c, fiber.Map{"message": "Scoring profile deleted"})
+}
+
+// GetStrategies describes the strategies GetRecommendations accepts
+func (h *Handler) GetStrategies(c *fiber.Ctx) error {
+	return response.Success(c, h.useCase.GetStrategies())
+}
+
+// ListSnapshots pages through past snapshots, newest first
+func (h *Handler) ListSnapshots(c *fiber.Ctx) error {
+	rng, err := stockDomain.ParseTimeRange(c.Query("from"), c.Query("to"))
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	page, limit := c.QueryInt("page", 1), c.QueryInt("limit", 20)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	snapshots, total, err := h.snapshots.ListSnapshots(c.Context(), rng, page, limit)
+	if err != nil {
+		return response.InternalError(c, "Failed to fetch recommendation snapshots")
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+	return response.SuccessWithMeta(c, snapshots, &response.Meta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	})
+}
+
+// GetSnapshot returns a snapshot with its ranked entries
+func (h *Handler) GetSnapshot(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.BadRequest(c, "Invalid snapshot ID")
+	}
+
+	snapshot, err := h.snapshots.GetSnapshot(c.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrSnapshotNotFound) {
+			return response.NotFound(c, "Snapshot not found")
+		}
+		return response.InternalError(c, "Failed to fetch recommendation snapshot")
+	}
+
+	return response.Success(c, snapshot)
+}
+
+// DiffSnapshots compares two snapshots, by default the latest with the one before it
+func (h *Handler) DiffSnapshots(c *fiber.Ctx) error {
+	from, to := c.QueryInt("from"), c.QueryInt("to")
+	days := c.QueryInt("days")
+	if from < 0 || to < 0 || days < 0 {
+		return response.BadRequest(c, "from, to and days must not be negative")
+	}
+
+	diff, err := h.snapshots.Diff(c.Context(), uint(from), uint(to), days)
+	if err != nil {
+		if errors.Is(err, domain.ErrSnapshotNotFound) {
+			return response.NotFound(c, "Snapshot not found")
+		}
+		return response.InternalError(c, "Failed to compare recommendation snapshots")
+	}
+
+	return response.Success(c, diff)
+}
+
+// TakeSnapshot snapshots the current recommendations without waiting for a sync
+func (h *Handler) TakeSnapshot(c *fiber.Ctx) error {
+	snapshot, err := h.snapshots.Take(c.Context(), domain.SnapshotManual)
+	if err != nil {
+		return response.InternalError(c, "Failed to take recommendation snapshot")
+	}
+	return response.Created(c, snapshot)
 }
@@ -4,13 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
 	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
 	"github.com/bryanriosb/stock-info/shared/response"
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -20,23 +24,153 @@ type MockRecommendationUseCase struct {
 	mock.Mock
 }
 
-func (m *MockRecommendationUseCase) GetRecommendations(ctx context.Context, limit int) ([]*domain.StockRecommendation, error) {
-	args := m.Called(ctx, limit)
+func (m *MockRecommendationUseCase) GetRecommendations(ctx context.Context, query domain.RecommendationQuery) ([]*domain.StockRecommendation, int64, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.StockRecommendation), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRecommendationUseCase) ExplainTicker(ctx context.Context, ticker string, query domain.RecommendationQuery) (*domain.Explanation, error) {
+	args := m.Called(ctx, ticker, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Explanation), args.Error(1)
+}
+
+func (m *MockRecommendationUseCase) TopRecommendations(ctx context.Context, query domain.RecommendationQuery, n int) ([]*domain.StockRecommendation, error) {
+	args := m.Called(ctx, query, n)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*domain.StockRecommendation), args.Error(1)
 }
 
+func (m *MockRecommendationUseCase) RankActions(ctx context.Context, query domain.RecommendationQuery, tickers [][]*stockDomain.Stock, now time.Time) ([]*domain.StockRecommendation, error) {
+	args := m.Called(ctx, query, tickers, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.StockRecommendation), args.Error(1)
+}
+
+func (m *MockRecommendationUseCase) GetStrategies() []domain.StrategyInfo {
+	args := m.Called()
+	return args.Get(0).([]domain.StrategyInfo)
+}
+
+// Mock SnapshotUseCase
+type MockSnapshotUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSnapshotUseCase) Take(ctx context.Context, trigger string) (*domain.Snapshot, error) {
+	args := m.Called(ctx, trigger)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Snapshot), args.Error(1)
+}
+
+func (m *MockSnapshotUseCase) ListSnapshots(ctx context.Context, rng stockDomain.TimeRange, page, limit int) ([]*domain.Snapshot, int64, error) {
+	args := m.Called(ctx, rng, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.Snapshot), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSnapshotUseCase) GetSnapshot(ctx context.Context, id uint) (*domain.Snapshot, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Snapshot), args.Error(1)
+}
+
+func (m *MockSnapshotUseCase) Diff(ctx context.Context, from, to uint, days int) (*domain.SnapshotDiff, error) {
+	args := m.Called(ctx, from, to, days)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SnapshotDiff), args.Error(1)
+}
+
+// Mock ProfileUseCase
+type MockProfileUseCase struct {
+	mock.Mock
+}
+
+func (m *MockProfileUseCase) GetProfile(ctx context.Context, username string) (*domain.ScoringProfile, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScoringProfile), args.Error(1)
+}
+
+func (m *MockProfileUseCase) SaveProfile(ctx context.Context, username string, profile *domain.ScoringProfile) (*domain.ScoringProfile, error) {
+	args := m.Called(ctx, username, profile)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScoringProfile), args.Error(1)
+}
+
+func (m *MockProfileUseCase) DeleteProfile(ctx context.Context, username string) error {
+	args := m.Called(ctx, username)
+	return args.Error(0)
+}
+
+func (m *MockProfileUseCase) Apply(ctx context.Context, username string, query domain.RecommendationQuery) (domain.RecommendationQuery, error) {
+	args := m.Called(ctx, username, query)
+	return args.Get(0).(domain.RecommendationQuery), args.Error(1)
+}
+
+// setupUserApp mounts the handler for requests authenticated as username
+func setupUserApp(handler *Handler, username string) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{"sub": username}})
+		return c.Next()
+	})
+	app.Get("/recommendations", handler.GetRecommendations)
+	app.Get("/users/me/scoring-profile", handler.GetProfile)
+	app.Put("/users/me/scoring-profile", handler.SaveProfile)
+	app.Delete("/users/me/scoring-profile", handler.DeleteProfile)
+	return app
+}
+
+// Mock WatchlistSource
+type MockWatchlistSource struct {
+	mock.Mock
+}
+
+func (m *MockWatchlistSource) Tickers(ctx context.Context, username string) ([]string, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func setupTestApp(handler *Handler) *fiber.App {
 	app := fiber.New()
 	app.Get("/recommendations", handler.GetRecommendations)
+	app.Get("/recommendations/snapshots", handler.ListSnapshots)
+	app.Post("/recommendations/snapshots", handler.TakeSnapshot)
+	app.Get("/recommendations/snapshots/diff", handler.DiffSnapshots)
+	app.Get("/recommendations/snapshots/:id<int>", handler.GetSnapshot)
+	app.Get("/recommendations/:ticker/explain", handler.Explain)
+	app.Get("/recommendation-strategies", handler.GetStrategies)
 	return app
 }
 
 func TestGetRecommendations_Success(t *testing.T) {
 	mockUC := new(MockRecommendationUseCase)
-	handler := NewHandler(mockUC)
+	handler := NewHandler(mockUC, nil, nil, nil)
 	app := setupTestApp(handler)
 
 	recommendations := []*domain.StockRecommendation{
@@ -54,7 +188,7 @@ func TestGetRecommendations_Success(t *testing.T) {
 		},
 	}
 
-	mockUC.On("GetRecommendations", mock.Anything, 10).Return(recommendations, nil)
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 1, Limit: 10}).Return(recommendations, int64(len(recommendations)), nil)
 
 	req := httptest.NewRequest("GET", "/recommendations?limit=10", nil)
 	resp, err := app.Test(req)
@@ -72,11 +206,11 @@ func TestGetRecommendations_Success(t *testing.T) {
 
 func TestGetRecommendations_DefaultLimit(t *testing.T) {
 	mockUC := new(MockRecommendationUseCase)
-	handler := NewHandler(mockUC)
+	handler := NewHandler(mockUC, nil, nil, nil)
 	app := setupTestApp(handler)
 
 	recommendations := []*domain.StockRecommendation{}
-	mockUC.On("GetRecommendations", mock.Anything, 10).Return(recommendations, nil)
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 1, Limit: 10}).Return(recommendations, int64(len(recommendations)), nil)
 
 	req := httptest.NewRequest("GET", "/recommendations", nil)
 	resp, err := app.Test(req)
@@ -93,7 +227,7 @@ func TestGetRecommendations_DefaultLimit(t *testing.T) {
 
 func TestGetRecommendations_CustomLimit(t *testing.T) {
 	mockUC := new(MockRecommendationUseCase)
-	handler := NewHandler(mockUC)
+	handler := NewHandler(mockUC, nil, nil, nil)
 	app := setupTestApp(handler)
 
 	recommendations := []*domain.StockRecommendation{
@@ -104,7 +238,7 @@ func TestGetRecommendations_CustomLimit(t *testing.T) {
 			PotentialGain: 20.0,
 		},
 	}
-	mockUC.On("GetRecommendations", mock.Anything, 5).Return(recommendations, nil)
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 1, Limit: 5}).Return(recommendations, int64(len(recommendations)), nil)
 
 	req := httptest.NewRequest("GET", "/recommendations?limit=5", nil)
 	resp, err := app.Test(req)
@@ -116,10 +250,10 @@ func TestGetRecommendations_CustomLimit(t *testing.T) {
 
 func TestGetRecommendations_Error(t *testing.T) {
 	mockUC := new(MockRecommendationUseCase)
-	handler := NewHandler(mockUC)
+	handler := NewHandler(mockUC, nil, nil, nil)
 	app := setupTestApp(handler)
 
-	mockUC.On("GetRecommendations", mock.Anything, 10).Return(nil, errors.New("database error"))
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 1, Limit: 10}).Return(nil, int64(0), errors.New("database error"))
 
 	req := httptest.NewRequest("GET", "/recommendations?limit=10", nil)
 	resp, err := app.Test(req)
@@ -137,10 +271,10 @@ func TestGetRecommendations_Error(t *testing.T) {
 
 func TestGetRecommendations_Empty(t *testing.T) {
 	mockUC := new(MockRecommendationUseCase)
-	handler := NewHandler(mockUC)
+	handler := NewHandler(mockUC, nil, nil, nil)
 	app := setupTestApp(handler)
 
-	mockUC.On("GetRecommendations", mock.Anything, 10).Return([]*domain.StockRecommendation{}, nil)
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 1, Limit: 10}).Return([]*domain.StockRecommendation{}, int64(0), nil)
 
 	req := httptest.NewRequest("GET", "/recommendations?limit=10", nil)
 	resp, err := app.Test(req)
@@ -154,3 +288,468 @@ func TestGetRecommendations_Empty(t *testing.T) {
 	assert.True(t, result.Success)
 	mockUC.AssertExpectations(t)
 }
+
+func TestGetRecommendations_WithStrategy(t *testing.T) {
+	mockUC := new(MockRecommendationUseCase)
+	handler := NewHandler(mockUC, nil, nil, nil)
+	app := setupTestApp(handler)
+
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 1, Limit: 5, Strategy: "momentum"}).
+		Return([]*domain.StockRecommendation{{Score: 0.5, Strategy: "momentum"}}, int64(1), nil)
+
+	req := httptest.NewRequest("GET", "/recommendations?limit=5&strategy=momentum", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetRecommendations_UnknownStrategy(t *testing.T) {
+	mockUC := new(MockRecommendationUseCase)
+	handler := NewHandler(mockUC, nil, nil, nil)
+	app := setupTestApp(handler)
+
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 1, Limit: 10, Strategy: "astrology"}).
+		Return(nil, int64(0), fmt.Errorf("%w %q", domain.ErrUnknownStrategy, "astrology"))
+
+	req := httptest.NewRequest("GET", "/recommendations?strategy=astrology", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	var result response.Response
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	assert.Contains(t, result.Error, "unknown strategy")
+}
+
+func TestGetRecommendations_UnknownAggregation(t *testing.T) {
+	mockUC := new(MockRecommendationUseCase)
+	handler := NewHandler(mockUC, nil, nil, nil)
+	app := setupTestApp(handler)
+
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 1, Limit: 10, Aggregation: "mode"}).
+		Return(nil, int64(0), domain.ErrUnknownAggregation)
+
+	req := httptest.NewRequest("GET", "/recommendations?aggregation=mode", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	var result response.Response
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	assert.Contains(t, result.Error, "unknown aggregation")
+}
+
+func TestGetStrategies(t *testing.T) {
+	mockUC := new(MockRecommendationUseCase)
+	handler := NewHandler(mockUC, nil, nil, nil)
+	app := setupTestApp(handler)
+
+	strategies := []domain.StrategyInfo{{
+		Name:       "balanced",
+		Parameters: []domain.StrategyParameter{{Name: "rating_weight", Default: 0.3}},
+	}}
+	mockUC.On("GetStrategies").Return(strategies)
+
+	req := httptest.NewRequest("GET", "/recommendation-strategies", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data []domain.StrategyInfo `json:"data"`
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	assert.Equal(t, strategies, result.Data)
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetRecommendations_PageMeta(t *testing.T) {
+	mockUC := new(MockRecommendationUseCase)
+	handler := NewHandler(mockUC, nil, nil, nil)
+	app := setupTestApp(handler)
+
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 2, Limit: 20}).
+		Return([]*domain.StockRecommendation{}, int64(45), nil)
+
+	req := httptest.NewRequest("GET", "/recommendations?page=2&limit=20", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result response.Response
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	assert.Equal(t, &response.Meta{Page: 2, Limit: 20, Total: 45, TotalPages: 3}, result.Meta)
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetRecommendations_PageOutOfRange(t *testing.T) {
+	mockUC := new(MockRecommendationUseCase)
+	handler := NewHandler(mockUC, nil, nil, nil)
+	app := setupTestApp(handler)
+
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 500, Limit: 10}).
+		Return(nil, int64(0), domain.ErrPageOutOfRange)
+
+	req := httptest.NewRequest("GET", "/recommendations?page=500", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestExplain_Success(t *testing.T) {
+	mockUC := new(MockRecommendationUseCase)
+	handler := NewHandler(mockUC, nil, nil, nil)
+	app := setupTestApp(handler)
+
+	explanation := &domain.Explanation{
+		StockRecommendation: &domain.StockRecommendation{
+			Ticker: "AAPL",
+			Score:  0.5,
+			Brokerages: []domain.BrokerageSignal{{
+				Brokerage: "Goldman Sachs",
+				Score:     0.5,
+				Breakdown: domain.Breakdown{
+					Signals:       []domain.Signal{{Name: "action", Input: "upgraded by", Raw: 1, Normalized: 1, Weight: 0.5, Contribution: 0.5}},
+					StrategyScore: 0.5,
+				},
+			}},
+		},
+		Parameters: map[string]float64{"action_weight": 0.5},
+	}
+	mockUC.On("ExplainTicker", mock.Anything, "AAPL", domain.RecommendationQuery{Strategy: "momentum"}).Return(explanation, nil)
+
+	req := httptest.NewRequest("GET", "/recommendations/AAPL/explain?strategy=momentum", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			Ticker     string             `json:"ticker"`
+			Parameters map[string]float64 `json:"parameters"`
+			Brokerages []struct {
+				Breakdown struct {
+					Signals []domain.Signal `json:"signals"`
+				} `json:"breakdown"`
+			} `json:"brokerages"`
+		} `json:"data"`
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	assert.Equal(t, "AAPL", result.Data.Ticker)
+	assert.Equal(t, 0.5, result.Data.Parameters["action_weight"])
+	assert.Len(t, result.Data.Brokerages, 1)
+	assert.Equal(t, "action", result.Data.Brokerages[0].Breakdown.Signals[0].Name)
+	mockUC.AssertExpectations(t)
+}
+
+func TestExplain_Errors(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{domain.ErrUnknownStrategy, fiber.StatusBadRequest},
+		{domain.ErrUnknownAggregation, fiber.StatusBadRequest},
+		{domain.ErrTickerNotRecommended, fiber.StatusNotFound},
+		{errors.New("database error"), fiber.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockUC := new(MockRecommendationUseCase)
+		handler := NewHandler(mockUC, nil, nil, nil)
+		app := setupTestApp(handler)
+
+		mockUC.On("ExplainTicker", mock.Anything, "ZZZ", domain.RecommendationQuery{}).Return(nil, tt.err)
+
+		req := httptest.NewRequest("GET", "/recommendations/ZZZ/explain", nil)
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.status, resp.StatusCode, tt.err.Error())
+	}
+}
+
+func TestListSnapshots_Pages(t *testing.T) {
+	mockSnapshots := new(MockSnapshotUseCase)
+	app := setupTestApp(NewHandler(new(MockRecommendationUseCase), mockSnapshots, nil, nil))
+
+	snapshots := []*domain.Snapshot{{ID: 2, Trigger: domain.SnapshotAfterSync}, {ID: 1, Trigger: domain.SnapshotScheduled}}
+	mockSnapshots.On("ListSnapshots", mock.Anything, stockDomain.TimeRange{}, 1, 2).Return(snapshots, int64(5), nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/recommendations/snapshots?limit=2", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result response.Response
+	json.NewDecoder(resp.Body).Decode(&result)
+	assert.Equal(t, 3, result.Meta.TotalPages)
+	mockSnapshots.AssertExpectations(t)
+}
+
+func TestGetSnapshot_NotFound(t *testing.T) {
+	mockSnapshots := new(MockSnapshotUseCase)
+	app := setupTestApp(NewHandler(new(MockRecommendationUseCase), mockSnapshots, nil, nil))
+
+	mockSnapshots.On("GetSnapshot", mock.Anything, uint(42)).Return(nil, domain.ErrSnapshotNotFound)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/recommendations/snapshots/42", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	mockSnapshots := new(MockSnapshotUseCase)
+	app := setupTestApp(NewHandler(new(MockRecommendationUseCase), mockSnapshots, nil, nil))
+
+	rank := 1
+	diff := &domain.SnapshotDiff{
+		From:    &domain.Snapshot{ID: 1},
+		To:      &domain.Snapshot{ID: 2},
+		Entered: []domain.RankChange{{Ticker: "AAPL", ToRank: &rank}},
+	}
+	mockSnapshots.On("Diff", mock.Anything, uint(0), uint(2), 7).Return(diff, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/recommendations/snapshots/diff?to=2&days=7", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data domain.SnapshotDiff `json:"data"`
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+	assert.Equal(t, "AAPL", result.Data.Entered[0].Ticker)
+	mockSnapshots.AssertExpectations(t)
+}
+
+func TestDiffSnapshots_NoSnapshots(t *testing.T) {
+	mockSnapshots := new(MockSnapshotUseCase)
+	app := setupTestApp(NewHandler(new(MockRecommendationUseCase), mockSnapshots, nil, nil))
+
+	mockSnapshots.On("Diff", mock.Anything, uint(0), uint(0), 0).Return(nil, domain.ErrSnapshotNotFound)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/recommendations/snapshots/diff", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestGetRecommendations_Tuning(t *testing.T) {
+	mockUC := new(MockRecommendationUseCase)
+	app := setupTestApp(NewHandler(mockUC, nil, nil, nil))
+
+	query := domain.RecommendationQuery{
+		Page:     1,
+		Limit:    10,
+		Strategy: "balanced",
+		Tuning: domain.Tuning{
+			Parameters:  map[string]float64{"rating_weight": 0.5, "target_weight": 0.2},
+			RatingScale: domain.RatingScale{"hold": 4},
+		},
+	}
+	mockUC.On("GetRecommendations", mock.Anything, query).Return([]*domain.StockRecommendation{}, int64(0), nil)
+
+	req := httptest.NewRequest("GET", "/recommendations?strategy=balanced&params=rating_weight:0.5,target_weight:0.2&rating_scale=Hold:4", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetRecommendations_InvalidTuning(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"not a pair", "params=rating_weight"},
+		{"not a number", "params=rating_weight:heavy"},
+		{"rating off the scale", "rating_scale=hold:12"},
+	}
+
+	for _, tt := range tests {
+		mockUC := new(MockRecommendationUseCase)
+		app := setupTestApp(NewHandler(mockUC, nil, nil, nil))
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/recommendations?"+tt.query, nil))
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, tt.name)
+		mockUC.AssertNotCalled(t, "GetRecommendations")
+	}
+
+	// Bounds are checked by the strategy
+	mockUC := new(MockRecommendationUseCase)
+	app := setupTestApp(NewHandler(mockUC, nil, nil, nil))
+	mockUC.On("GetRecommendations", mock.Anything, mock.Anything).Return(nil, int64(0), fmt.Errorf("%w: rating_weight must be between 0 and 1", domain.ErrInvalidParameter))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/recommendations?params=rating_weight:3", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetRecommendations_AsCaller(t *testing.T) {
+	mockUC := new(MockRecommendationUseCase)
+	app := setupUserApp(NewHandler(mockUC, nil, nil, nil), "alice")
+
+	// The use case applies the caller's scoring profile
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 1, Limit: 10, Username: "alice"}).Return([]*domain.StockRecommendation{}, int64(0), nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/recommendations", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetRecommendations_Filters(t *testing.T) {
+	mockUC := new(MockRecommendationUseCase)
+	app := setupTestApp(NewHandler(mockUC, nil, nil, nil))
+
+	minGain := 5.5
+	expected := domain.RecommendationQuery{Page: 1, Limit: 10, Filter: domain.Filter{
+		Brokerages:        []string{"Keefe, Bruyette & Woods", "Goldman Sachs"},
+		ExcludeBrokerages: []string{"JPMorgan"},
+		MinGain:           &minGain,
+		MinBrokerages:     2,
+		RatingBucket:      "buy",
+		MaxAgeDays:        30,
+		MaxPerBrokerage:   3,
+		Anomalies:         "exclude",
+	}}
+	mockUC.On("GetRecommendations", mock.Anything, expected).Return([]*domain.StockRecommendation{}, int64(0), nil)
+
+	url := "/recommendations?brokerage=Keefe%2C+Bruyette+%26+Woods&brokerage=Goldman+Sachs&exclude_brokerage=JPMorgan" +
+		"&min_gain=5.5&min_brokerages=2&rating_bucket=buy&max_age_days=30&max_per_brokerage=3&anomalies=exclude"
+	resp, err := app.Test(httptest.NewRequest("GET", url, nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetRecommendations_InvalidFilter(t *testing.T) {
+	mockUC := new(MockRecommendationUseCase)
+	app := setupTestApp(NewHandler(mockUC, nil, nil, nil))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/recommendations?min_gain=lots", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "GetRecommendations")
+
+	// Bounds are checked by the use case
+	mockUC.On("GetRecommendations", mock.Anything, mock.Anything).Return(nil, int64(0), fmt.Errorf("%w: unknown rating bucket", domain.ErrInvalidFilter))
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/recommendations?rating_bucket=moon", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetRecommendations_ExcludesWatchlist(t *testing.T) {
+	mockUC := new(MockRecommendationUseCase)
+	mockWatchlist := new(MockWatchlistSource)
+	app := setupUserApp(NewHandler(mockUC, nil, nil, mockWatchlist), "alice")
+
+	mockWatchlist.On("Tickers", mock.Anything, "alice").Return([]string{"AAPL", "MSFT"}, nil)
+	expected := domain.RecommendationQuery{Page: 1, Limit: 10, Username: "alice", Filter: domain.Filter{ExcludeTickers: []string{"AAPL", "MSFT"}}}
+	mockUC.On("GetRecommendations", mock.Anything, expected).Return([]*domain.StockRecommendation{}, int64(0), nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/recommendations?exclude_watchlist=true", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockWatchlist.AssertExpectations(t)
+	mockUC.AssertExpectations(t)
+
+	// The watchlist is only read when asked for
+	mockWatchlist = new(MockWatchlistSource)
+	app = setupUserApp(NewHandler(mockUC, nil, nil, mockWatchlist), "alice")
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 1, Limit: 10, Username: "alice"}).Return([]*domain.StockRecommendation{}, int64(0), nil)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/recommendations", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockWatchlist.AssertNotCalled(t, "Tickers")
+}
+
+func TestGetProfile(t *testing.T) {
+	mockProfiles := new(MockProfileUseCase)
+	app := setupUserApp(NewHandler(nil, nil, mockProfiles, nil), "alice")
+
+	mockProfiles.On("GetProfile", mock.Anything, "alice").Return(&domain.ScoringProfile{Username: "alice", Strategy: "balanced"}, nil).Once()
+	mockProfiles.On("GetProfile", mock.Anything, "alice").Return(nil, domain.ErrProfileNotFound).Once()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/users/me/scoring-profile", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/users/me/scoring-profile", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestSaveProfile(t *testing.T) {
+	mockProfiles := new(MockProfileUseCase)
+	app := setupUserApp(NewHandler(nil, nil, mockProfiles, nil), "alice")
+
+	profile := &domain.ScoringProfile{Strategy: "balanced", Parameters: map[string]float64{"rating_weight": 0.6}, RatingScale: domain.RatingScale{"hold": 4}}
+	saved := &domain.ScoringProfile{Username: "alice", Strategy: "balanced", Parameters: profile.Parameters, RatingScale: profile.RatingScale}
+	mockProfiles.On("SaveProfile", mock.Anything, "alice", profile).Return(saved, nil)
+
+	body := `{"strategy":"balanced","parameters":{"rating_weight":0.6},"rating_scale":{"hold":4}}`
+	req := httptest.NewRequest("PUT", "/users/me/scoring-profile", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockProfiles.AssertExpectations(t)
+}
+
+func TestSaveProfile_Invalid(t *testing.T) {
+	mockProfiles := new(MockProfileUseCase)
+	app := setupUserApp(NewHandler(nil, nil, mockProfiles, nil), "alice")
+
+	mockProfiles.On("SaveProfile", mock.Anything, "alice", mock.Anything).Return(nil, fmt.Errorf("%w: balanced has no parameter \"luck\"", domain.ErrInvalidParameter))
+
+	req := httptest.NewRequest("PUT", "/users/me/scoring-profile", strings.NewReader(`{"parameters":{"luck":1}}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestDeleteProfile(t *testing.T) {
+	mockProfiles := new(MockProfileUseCase)
+	app := setupUserApp(NewHandler(nil, nil, mockProfiles, nil), "alice")
+
+	mockProfiles.On("DeleteProfile", mock.Anything, "alice").Return(nil).Once()
+	mockProfiles.On("DeleteProfile", mock.Anything, "alice").Return(domain.ErrProfileNotFound).Once()
+
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/users/me/scoring-profile", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("DELETE", "/users/me/scoring-profile", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
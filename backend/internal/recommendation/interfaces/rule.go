@@ -0,0 +1,124 @@
+package interfaces
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/application"
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	"github.com/bryanriosb/stock-info/shared/middleware"
+	"github.com/bryanriosb/stock-info/shared/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RuleRequest creates or replaces a scoring rule
+type RuleRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Expression  string `json:"expression"`
+	Shared      bool   `json:"shared,omitempty"`
+}
+
+type RuleHandler struct {
+	rules application.RuleUseCase
+}
+
+func NewRuleHandler(rules application.RuleUseCase) *RuleHandler {
+	return &RuleHandler{rules: rules}
+}
+
+// ListRules returns the caller's scoring rules and the shared ones
+func (h *RuleHandler) ListRules(c *fiber.Ctx) error {
+	rules, err := h.rules.ListRules(c.Context(), middleware.GetUserFromToken(c))
+	if err != nil {
+		return response.InternalError(c, "Failed to fetch scoring rules")
+	}
+
+	return response.Success(c, rules)
+}
+
+// GetLanguage describes the variables, functions and limits rule expressions can use
+func (h *RuleHandler) GetLanguage(c *fiber.Ctx) error {
+	return response.Success(c, h.rules.Language())
+}
+
+// GetRule returns a scoring rule visible to the caller
+func (h *RuleHandler) GetRule(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.BadRequest(c, "Invalid rule ID")
+	}
+
+	rule, err := h.rules.GetRule(c.Context(), middleware.GetUserFromToken(c), uint(id))
+	if err != nil {
+		return ruleError(c, err, "Failed to fetch scoring rule")
+	}
+
+	return response.Success(c, rule)
+}
+
+// CreateRule validates and stores a scoring rule owned by the caller
+func (h *RuleHandler) CreateRule(c *fiber.Ctx) error {
+	var req RuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body")
+	}
+
+	rule, err := h.rules.CreateRule(c.Context(), middleware.GetUserFromToken(c), req.rule())
+	if err != nil {
+		return ruleError(c, err, "Failed to create scoring rule")
+	}
+
+	return response.Created(c, rule)
+}
+
+// UpdateRule replaces one of the caller's scoring rules
+func (h *RuleHandler) UpdateRule(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.BadRequest(c, "Invalid rule ID")
+	}
+	var req RuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body")
+	}
+
+	rule, err := h.rules.UpdateRule(c.Context(), middleware.GetUserFromToken(c), uint(id), req.rule())
+	if err != nil {
+		return ruleError(c, err, "Failed to update scoring rule")
+	}
+
+	return response.Success(c, rule)
+}
+
+// DeleteRule removes one of the caller's scoring rules
+func (h *RuleHandler) DeleteRule(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.BadRequest(c, "Invalid rule ID")
+	}
+
+	if err := h.rules.DeleteRule(c.Context(), middleware.GetUserFromToken(c), uint(id)); err != nil {
+		return ruleError(c, err, "Failed to delete scoring rule")
+	}
+
+	return response.Success(c, fiber.Map{"message": "Scoring rule deleted"})
+}
+
+func (r RuleRequest) rule() *domain.ScoringRule {
+	return &domain.ScoringRule{Name: r.Name, Description: r.Description, Expression: r.Expression, Shared: r.Shared}
+}
+
+// ruleError maps the rule errors to their status, or fails with message
+func ruleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRule):
+		return response.BadRequest(c, err.Error())
+	case errors.Is(err, domain.ErrRuleNotFound):
+		return response.NotFound(c, "Scoring rule not found")
+	case errors.Is(err, domain.ErrRuleNotOwned):
+		return response.Error(c, fiber.StatusForbidden, "Only the owner can change a scoring rule")
+	default:
+		return response.InternalError(c, message)
+	}
+}
@@ -0,0 +1,155 @@
+package interfaces
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bryanriosb/stock-info/internal/recommendation/application"
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock RuleUseCase
+type MockRuleUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRuleUseCase) ListRules(ctx context.Context, username string) ([]*domain.ScoringRule, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ScoringRule), args.Error(1)
+}
+
+func (m *MockRuleUseCase) GetRule(ctx context.Context, username string, id uint) (*domain.ScoringRule, error) {
+	args := m.Called(ctx, username, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScoringRule), args.Error(1)
+}
+
+func (m *MockRuleUseCase) CreateRule(ctx context.Context, username string, rule *domain.ScoringRule) (*domain.ScoringRule, error) {
+	args := m.Called(ctx, username, rule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScoringRule), args.Error(1)
+}
+
+func (m *MockRuleUseCase) UpdateRule(ctx context.Context, username string, id uint, rule *domain.ScoringRule) (*domain.ScoringRule, error) {
+	args := m.Called(ctx, username, id, rule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScoringRule), args.Error(1)
+}
+
+func (m *MockRuleUseCase) DeleteRule(ctx context.Context, username string, id uint) error {
+	args := m.Called(ctx, username, id)
+	return args.Error(0)
+}
+
+func (m *MockRuleUseCase) Language() application.RuleLanguage {
+	args := m.Called()
+	return args.Get(0).(application.RuleLanguage)
+}
+
+func setupRuleApp(handler *RuleHandler, username string) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{"sub": username}})
+		return c.Next()
+	})
+	app.Get("/scoring-rules", handler.ListRules)
+	app.Post("/scoring-rules", handler.CreateRule)
+	app.Get("/scoring-rules/language", handler.GetLanguage)
+	app.Get("/scoring-rules/:id<int>", handler.GetRule)
+	app.Put("/scoring-rules/:id<int>", handler.UpdateRule)
+	app.Delete("/scoring-rules/:id<int>", handler.DeleteRule)
+	return app
+}
+
+func TestCreateRule(t *testing.T) {
+	mockRules := new(MockRuleUseCase)
+	app := setupRuleApp(NewRuleHandler(mockRules), "alice")
+
+	rule := &domain.ScoringRule{Name: "upgrades", Expression: "rating_delta", Shared: true}
+	mockRules.On("CreateRule", mock.Anything, "alice", rule).Return(&domain.ScoringRule{ID: 3, Owner: "alice", Name: "upgrades", Expression: "rating_delta", Shared: true, Strategy: "rule:3"}, nil).Once()
+	mockRules.On("CreateRule", mock.Anything, "alice", mock.Anything).Return(nil, fmt.Errorf("%w: unknown variable \"price\" at 0", domain.ErrInvalidRule)).Once()
+
+	req := httptest.NewRequest("POST", "/scoring-rules", strings.NewReader(`{"name":"upgrades","expression":"rating_delta","shared":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	req = httptest.NewRequest("POST", "/scoring-rules", strings.NewReader(`{"name":"price","expression":"price"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockRules.AssertExpectations(t)
+}
+
+func TestUpdateRule_Errors(t *testing.T) {
+	mockRules := new(MockRuleUseCase)
+	app := setupRuleApp(NewRuleHandler(mockRules), "alice")
+
+	mockRules.On("UpdateRule", mock.Anything, "alice", uint(2), mock.Anything).Return(nil, domain.ErrRuleNotOwned)
+	mockRules.On("UpdateRule", mock.Anything, "alice", uint(3), mock.Anything).Return(nil, domain.ErrRuleNotFound)
+
+	tests := []struct {
+		url    string
+		status int
+	}{
+		{"/scoring-rules/2", fiber.StatusForbidden},
+		{"/scoring-rules/3", fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("PUT", tt.url, strings.NewReader(`{"name":"r","expression":"1"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.status, resp.StatusCode, tt.url)
+	}
+}
+
+func TestDeleteRule(t *testing.T) {
+	mockRules := new(MockRuleUseCase)
+	app := setupRuleApp(NewRuleHandler(mockRules), "alice")
+
+	mockRules.On("DeleteRule", mock.Anything, "alice", uint(1)).Return(nil)
+
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/scoring-rules/1", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockRules.AssertExpectations(t)
+}
+
+func TestGetRecommendations_RuleStrategyUser(t *testing.T) {
+	mockUC := new(MockRecommendationUseCase)
+	app := setupUserApp(NewHandler(mockUC, nil, nil, nil), "alice")
+
+	// Every strategy is queried as the caller, whose profile applies to all of them
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 1, Limit: 10, Strategy: "rule:4", Username: "alice"}).Return([]*domain.StockRecommendation{}, int64(0), nil)
+	mockUC.On("GetRecommendations", mock.Anything, domain.RecommendationQuery{Page: 1, Limit: 10, Strategy: "momentum", Username: "alice"}).Return([]*domain.StockRecommendation{}, int64(0), nil)
+
+	for _, strategy := range []string{"rule:4", "momentum"} {
+		resp, err := app.Test(httptest.NewRequest("GET", "/recommendations?strategy="+strategy, nil))
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	}
+	mockUC.AssertExpectations(t)
+}
@@ -1,17 +1,99 @@
 package recommendation
 
 import (
+	"context"
+	"log"
+
 	"github.com/bryanriosb/stock-info/internal/recommendation/application"
+	"github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	"github.com/bryanriosb/stock-info/internal/recommendation/infrastructure"
 	"github.com/bryanriosb/stock-info/internal/recommendation/interfaces"
 	stockInfra "github.com/bryanriosb/stock-info/internal/stock/infrastructure"
+	stockinfov1 "github.com/bryanriosb/stock-info/proto/stockinfo/v1"
+	"github.com/bryanriosb/stock-info/shared"
+	"github.com/bryanriosb/stock-info/shared/cache"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/bryanriosb/stock-info/shared/middleware"
 	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
-func Register(app fiber.Router, db *gorm.DB) {
-	repo := stockInfra.NewStockRepository(db)
-	useCase := application.NewRecommendationUseCase(repo)
-	handler := interfaces.NewHandler(useCase)
+// Register mounts the recommendations, weighing brokerages by the given
+// credibility, tuned by the callers' scoring profiles and optionally scored
+// by their scoring rules, and snapshots them
+// after every sync and on a schedule. Callers can leave out the tickers on their watchlist.
+// Recommendations report the upside from the latest prices. Snapshots stop once
+// ctx is done.
+func Register(ctx context.Context, app fiber.Router, db *gorm.DB, cfg *shared.Config, appCache cache.Cache, bus *events.Bus, credibility domain.CredibilitySource, watchlist domain.WatchlistSource, prices domain.PriceSource) application.RecommendationUseCase {
+	decay, aggregation := settings(cfg)
+	rules := infrastructure.NewScoringRuleRepository(db)
+	strategies := application.NewDefaultStrategyRegistry()
+	strategies.UseRules(rules)
+	ranking := application.NewRecommendationUseCase(stockInfra.NewStockRepository(db), strategies, decay, aggregation, credibility, prices)
+	// Profiles apply ahead of the cache, which keys on the tuned query
+	profiles := application.NewProfileUseCase(infrastructure.NewScoringProfileRepository(db), strategies, bus)
+	useCase := application.NewProfiledRecommendationUseCase(application.NewCachedRecommendationUseCase(ranking, appCache, cfg.Cache.TTL), profiles)
+
+	// Snapshots rank afresh rather than through the cache a sync is clearing
+	snapshots := application.NewSnapshotJob(ranking, infrastructure.NewSnapshotRepository(db), aggregation, cfg.Recommendation.SnapshotSize)
+	bus.Subscribe(events.SyncCompleted, func(events.Event) { snapshots.Trigger(domain.SnapshotAfterSync) })
+	snapshots.Start(ctx, cfg.Recommendation.SnapshotInterval)
+
+	handler := interfaces.NewHandler(useCase, snapshots, profiles, watchlist)
 
 	app.Get("/recommendations", handler.GetRecommendations)
+	app.Get("/recommendations/snapshots", handler.ListSnapshots)
+	app.Post("/recommendations/snapshots", middleware.RequireAdmin(), handler.TakeSnapshot)
+	app.Get("/recommendations/snapshots/diff", handler.DiffSnapshots)
+	app.Get("/recommendations/snapshots/:id<int>", handler.GetSnapshot)
+	app.Get("/recommendations/:ticker/explain", handler.Explain)
+	app.Get("/recommendation-strategies", handler.GetStrategies)
+	app.Get("/users/me/scoring-profile", handler.GetProfile)
+	app.Put("/users/me/scoring-profile", handler.SaveProfile)
+	app.Delete("/users/me/scoring-profile", handler.DeleteProfile)
+
+	ruleHandler := interfaces.NewRuleHandler(application.NewRuleUseCase(rules, bus))
+	app.Get("/scoring-rules", ruleHandler.ListRules)
+	app.Post("/scoring-rules", ruleHandler.CreateRule)
+	app.Get("/scoring-rules/language", ruleHandler.GetLanguage)
+	app.Get("/scoring-rules/:id<int>", ruleHandler.GetRule)
+	app.Put("/scoring-rules/:id<int>", ruleHandler.UpdateRule)
+	app.Delete("/scoring-rules/:id<int>", ruleHandler.DeleteRule)
+
+	return useCase
+}
+
+// NewUseCase builds the uncached recommendation use case from the recommendation
+// settings, stopping the process when they are invalid. It ranks without prices.
+func NewUseCase(db *gorm.DB, cfg *shared.Config, credibility domain.CredibilitySource) application.RecommendationUseCase {
+	decay, aggregation := settings(cfg)
+	strategies := application.NewDefaultStrategyRegistry()
+	strategies.UseRules(infrastructure.NewScoringRuleRepository(db))
+	return application.NewRecommendationUseCase(stockInfra.NewStockRepository(db), strategies, decay, aggregation, credibility, nil)
+}
+
+// settings reads the decay and default aggregation, stopping the process when they are invalid
+func settings(cfg *shared.Config) (domain.Decay, domain.Aggregation) {
+	decay := domain.Decay{
+		Mode:     domain.DecayMode(cfg.Recommendation.Decay),
+		HalfLife: cfg.Recommendation.HalfLife,
+		Window:   cfg.Recommendation.DecayWindow,
+		MaxAge:   cfg.Recommendation.MaxAge,
+	}
+	if err := decay.Validate(); err != nil {
+		log.Fatalf("Invalid recommendation decay: %v", err)
+	}
+	aggregation := domain.DefaultAggregation
+	if cfg.Recommendation.Aggregation != "" {
+		var err error
+		if aggregation, err = domain.ParseAggregation(cfg.Recommendation.Aggregation); err != nil {
+			log.Fatalf("Invalid recommendation aggregation: %v", err)
+		}
+	}
+	return decay, aggregation
+}
+
+func RegisterGRPC(server grpc.ServiceRegistrar, useCase application.RecommendationUseCase) {
+	stockinfov1.RegisterRecommendationServiceServer(server, interfaces.NewGRPCServer(useCase))
 }
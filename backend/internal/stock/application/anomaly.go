@@ -0,0 +1,214 @@
+package application
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/bryanriosb/stock-info/shared/jobs"
+)
+
+const (
+	// anomalyScanBatchSize is the number of actions read per query while detecting
+	anomalyScanBatchSize = 500
+	// madScale turns a median absolute deviation into a standard deviation
+	// estimate for normally distributed values
+	madScale = 1.4826
+)
+
+type AnomalyUseCase interface {
+	// ListAnomalies returns a page of flagged actions, newest first, with their total
+	ListAnomalies(ctx context.Context, params domain.AnomalyParams) ([]*domain.Stock, int64, error)
+	// Detect flags the anomalies of every action now
+	Detect(ctx context.Context) (*domain.AnomalyRun, error)
+}
+
+// AnomalyDetector flags actions whose target change is a statistical outlier
+// among all target moves, whose rating jumps several levels, or whose target
+// is far from the other brokerages' targets on the ticker. Flags are stored on
+// the actions, so recommendations can leave out or down-weight them.
+type AnomalyDetector struct {
+	stocks     domain.StockRepository
+	repo       domain.AnomalyRepository
+	bus        *events.Bus
+	thresholds domain.AnomalyThresholds
+	now        func() time.Time
+
+	mu     sync.Mutex
+	runner *jobs.Runner
+}
+
+func NewAnomalyDetector(stocks domain.StockRepository, repo domain.AnomalyRepository, bus *events.Bus, thresholds domain.AnomalyThresholds) *AnomalyDetector {
+	d := &AnomalyDetector{stocks: stocks, repo: repo, bus: bus, thresholds: thresholds, now: time.Now}
+	d.runner = jobs.NewRunner("Anomaly detection", func(ctx context.Context) error {
+		_, err := d.Detect(ctx)
+		return err
+	})
+	return d
+}
+
+func (d *AnomalyDetector) ListAnomalies(ctx context.Context, params domain.AnomalyParams) ([]*domain.Stock, int64, error) {
+	params.Kind = strings.ToLower(strings.TrimSpace(params.Kind))
+	if params.Kind != "" && !domain.ValidAnomalyKind(params.Kind) {
+		return nil, 0, domain.ErrInvalidAnomaly
+	}
+	params.Ticker = strings.ToUpper(strings.TrimSpace(params.Ticker))
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 20
+	}
+	return d.repo.FindAnomalies(ctx, params)
+}
+
+// Start runs triggered detections with ctx until it is done
+func (d *AnomalyDetector) Start(ctx context.Context) {
+	d.runner.Start(ctx)
+}
+
+// Trigger detects anomalies in the background, or once more after a detection
+// that is already running. Syncs keep the stored flags of the actions they
+// upsert, so a sync completing mid-run must not go without its own detection.
+func (d *AnomalyDetector) Trigger() {
+	d.runner.Trigger()
+}
+
+// Detect reads every action twice: once to learn the spread of target moves,
+// then to flag each ticker's actions. Only the actions whose flags changed are
+// written, and AnomaliesDetected is published when there were any.
+func (d *AnomalyDetector) Detect(ctx context.Context) (*domain.AnomalyRun, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	run := &domain.AnomalyRun{Kinds: make(map[string]int, len(domain.AnomalyKinds)), Thresholds: d.thresholds, DetectedAt: d.now()}
+	for _, kind := range domain.AnomalyKinds {
+		run.Kinds[kind] = 0
+	}
+
+	var moves []float64
+	err := d.stocks.ScanTickers(ctx, anomalyScanBatchSize, func(actions []*domain.Stock) error {
+		for _, stock := range actions {
+			if move, ok := targetMove(stock); ok {
+				moves = append(moves, move)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(moves) >= d.thresholds.MinTargetMoves {
+		run.TargetMedian, run.TargetSpread = robustSpread(moves)
+	}
+
+	changes := make(map[int64][]string)
+	err = d.stocks.ScanTickers(ctx, anomalyScanBatchSize, func(actions []*domain.Stock) error {
+		for i, stock := range actions {
+			flags := d.flag(actions, i, run.TargetMedian, run.TargetSpread)
+			run.Actions++
+			if len(flags) > 0 {
+				run.Flagged++
+			}
+			for _, kind := range flags {
+				run.Kinds[kind]++
+			}
+			if !slices.Equal(flags, stock.Anomalies) {
+				changes[stock.ID] = flags
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	run.Changed = len(changes)
+	if run.Changed == 0 {
+		return run, nil
+	}
+	if err := d.repo.SetAnomalies(ctx, changes); err != nil {
+		return nil, err
+	}
+	d.bus.Publish(events.AnomaliesDetected, run.Flagged)
+	return run, nil
+}
+
+// flag returns the anomalies of the i-th action on a ticker in the order of
+// domain.AnomalyKinds, or nil when there are none. A zero spread flags no
+// target outliers.
+func (d *AnomalyDetector) flag(actions []*domain.Stock, i int, median, spread float64) []string {
+	stock := actions[i]
+	var flags []string
+
+	if move, ok := targetMove(stock); ok && spread > 0 && math.Abs(move-median)/spread > d.thresholds.TargetZScore {
+		flags = append(flags, domain.AnomalyTargetOutlier)
+	}
+
+	from, to := domain.RatingValue(stock.RatingFrom), domain.RatingValue(stock.RatingTo)
+	if from > 0 && to > 0 && d.thresholds.RatingJump > 0 && abs(to-from) >= d.thresholds.RatingJump {
+		flags = append(flags, domain.AnomalyRatingJump)
+	}
+
+	if stock.TargetTo > 0 && d.thresholds.ConsensusRatio > 1 {
+		others := make([]float64, 0, len(actions)-1)
+		for j, other := range actions {
+			if j != i && other.TargetTo > 0 {
+				others = append(others, other.TargetTo)
+			}
+		}
+		if len(others) >= d.thresholds.MinConsensus && len(others) > 0 {
+			ratio := stock.TargetTo / medianOf(others)
+			if ratio >= d.thresholds.ConsensusRatio || ratio <= 1/d.thresholds.ConsensusRatio {
+				flags = append(flags, domain.AnomalyConsensusOutlier)
+			}
+		}
+	}
+	return flags
+}
+
+// targetMove is the log ratio of an action's new and previous targets, so
+// doubling and halving a target are equally far from no change. Actions that
+// keep or lack a target do not move it.
+func targetMove(stock *domain.Stock) (float64, bool) {
+	if stock.TargetFrom <= 0 || stock.TargetTo <= 0 || stock.TargetFrom == stock.TargetTo {
+		return 0, false
+	}
+	return math.Log(stock.TargetTo / stock.TargetFrom), true
+}
+
+// robustSpread returns the median of values and their median absolute
+// deviation scaled to a standard deviation, which a few extreme values cannot
+// inflate the way they inflate the standard deviation
+func robustSpread(values []float64) (float64, float64) {
+	median := medianOf(values)
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - median)
+	}
+	return median, madScale * medianOf(deviations)
+}
+
+// medianOf returns the median of values without reordering them
+func medianOf(values []float64) float64 {
+	sorted := slices.Clone(values)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock AnomalyRepository
+type MockAnomalyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAnomalyRepository) SetAnomalies(ctx context.Context, anomalies map[int64][]string) error {
+	args := m.Called(ctx, anomalies)
+	return args.Error(0)
+}
+
+func (m *MockAnomalyRepository) FindAnomalies(ctx context.Context, params domain.AnomalyParams) ([]*domain.Stock, int64, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.Stock), args.Get(1).(int64), args.Error(2)
+}
+
+func expectTickers(repo *MockStockRepository, tickers ...[]*domain.Stock) {
+	repo.On("ScanTickers", mock.Anything, anomalyScanBatchSize, mock.Anything).Run(func(args mock.Arguments) {
+		for _, actions := range tickers {
+			args.Get(2).(func([]*domain.Stock) error)(actions)
+		}
+	}).Return(nil)
+}
+
+func testThresholds() domain.AnomalyThresholds {
+	thresholds := domain.DefaultAnomalyThresholds
+	thresholds.MinTargetMoves = 5
+	return thresholds
+}
+
+func TestDetect_FlagsAnomalies(t *testing.T) {
+	stocks := new(MockStockRepository)
+	repo := new(MockAnomalyRepository)
+	bus := events.NewBus()
+	var published []interface{}
+	bus.Subscribe(events.AnomaliesDetected, func(e events.Event) { published = append(published, e.Payload) })
+
+	expectTickers(stocks, []*domain.Stock{
+		{ID: 1, Ticker: "AAPL", Brokerage: "Barclays", TargetFrom: 100, TargetTo: 105},
+		{ID: 2, Ticker: "AAPL", Brokerage: "Citigroup", RatingFrom: "Underperform", RatingTo: "Outperform", TargetFrom: 100, TargetTo: 110},
+		{ID: 3, Ticker: "AAPL", Brokerage: "Goldman Sachs", TargetFrom: 100, TargetTo: 95, Anomalies: []string{domain.AnomalyRatingJump}},
+		{ID: 4, Ticker: "AAPL", Brokerage: "JPMorgan", RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 100, TargetTo: 102},
+		{ID: 5, Ticker: "AAPL", Brokerage: "Morgan Stanley", TargetFrom: 100, TargetTo: 108},
+		// A typo turning 140 into 400
+		{ID: 6, Ticker: "AAPL", Brokerage: "UBS", TargetFrom: 100, TargetTo: 400},
+	})
+	// Flags that did not change are not written again
+	repo.On("SetAnomalies", mock.Anything, map[int64][]string{
+		2: {domain.AnomalyRatingJump},
+		3: nil,
+		6: {domain.AnomalyTargetOutlier, domain.AnomalyConsensusOutlier},
+	}).Return(nil)
+
+	detector := NewAnomalyDetector(stocks, repo, bus, testThresholds())
+	detector.now = func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }
+	run, err := detector.Detect(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, run.Actions)
+	assert.Equal(t, 2, run.Flagged)
+	assert.Equal(t, 3, run.Changed)
+	assert.Equal(t, map[string]int{domain.AnomalyTargetOutlier: 1, domain.AnomalyRatingJump: 1, domain.AnomalyConsensusOutlier: 1}, run.Kinds)
+	assert.Greater(t, run.TargetSpread, 0.0)
+	assert.Equal(t, []interface{}{2}, published)
+	repo.AssertExpectations(t)
+}
+
+func TestDetect_TooFewToJudge(t *testing.T) {
+	stocks := new(MockStockRepository)
+	repo := new(MockAnomalyRepository)
+
+	// Without enough target moves or other brokerages nothing stands out
+	expectTickers(stocks,
+		[]*domain.Stock{{ID: 1, Ticker: "AAPL", Brokerage: "UBS", TargetFrom: 100, TargetTo: 400}},
+		[]*domain.Stock{{ID: 2, Ticker: "MSFT", Brokerage: "UBS", TargetFrom: 100, TargetTo: 110}, {ID: 3, Ticker: "MSFT", Brokerage: "Barclays", TargetTo: 500}},
+	)
+
+	run, err := NewAnomalyDetector(stocks, repo, nil, testThresholds()).Detect(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, run.Actions)
+	assert.Equal(t, 0, run.Flagged)
+	assert.Zero(t, run.TargetSpread)
+	repo.AssertNotCalled(t, "SetAnomalies")
+}
+
+func TestListAnomalies(t *testing.T) {
+	repo := new(MockAnomalyRepository)
+	detector := NewAnomalyDetector(nil, repo, nil, domain.DefaultAnomalyThresholds)
+
+	repo.On("FindAnomalies", mock.Anything, domain.AnomalyParams{Kind: domain.AnomalyRatingJump, Ticker: "AAPL", Page: 1, Limit: 20}).
+		Return([]*domain.Stock{{ID: 2, Ticker: "AAPL", Anomalies: []string{domain.AnomalyRatingJump}}}, int64(1), nil)
+
+	stocks, total, err := detector.ListAnomalies(context.Background(), domain.AnomalyParams{Kind: " Rating_Jump", Ticker: "aapl"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, stocks, 1)
+
+	_, _, err = detector.ListAnomalies(context.Background(), domain.AnomalyParams{Kind: "typo"})
+	assert.ErrorIs(t, err, domain.ErrInvalidAnomaly)
+}
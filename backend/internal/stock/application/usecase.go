@@ -2,32 +2,50 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
 	"github.com/bryanriosb/stock-info/internal/rating/application"
 	"github.com/bryanriosb/stock-info/internal/stock/domain"
 	"github.com/bryanriosb/stock-info/internal/stock/infrastructure"
+	"github.com/bryanriosb/stock-info/shared/events"
 )
 
+var ErrTickerNotFound = errors.New("ticker not found")
+
 type StockUseCase interface {
 	SyncStocks(ctx context.Context) (int, error)
 	SyncStocksWithProgress(ctx context.Context, onProgress infrastructure.ProgressCallback) (int, error)
 	GetStocks(ctx context.Context, params domain.QueryParams) ([]*domain.Stock, int64, error)
+	GetFacets(ctx context.Context, params domain.QueryParams, facets domain.FacetParams) (domain.Facets, error)
+	GetIncludes(ctx context.Context, stocks []*domain.Stock, includes []string) (*domain.Includes, error)
 	GetStockByID(ctx context.Context, id int64) (*domain.Stock, error)
+	GetTimeline(ctx context.Context, ticker string, params domain.TimelineParams) (*domain.Timeline, int64, error)
+	LookupStocks(ctx context.Context, params domain.LookupParams) (*domain.LookupResult, error)
 }
 
 type stockUseCase struct {
 	repo          domain.StockRepository
+	history       domain.ActionHistoryRepository
 	apiClient     infrastructure.StockAPIClient
 	ratingService *application.RatingService
+	bus           *events.Bus
+	prices        priceDomain.LastPriceSource
 }
 
-func NewStockUseCase(repo domain.StockRepository, apiClient infrastructure.StockAPIClient, ratingService *application.RatingService) StockUseCase {
+// NewStockUseCase serves the synced analyst actions with the latest price of
+// their ticker when prices is not nil. Timelines replay the action history.
+func NewStockUseCase(repo domain.StockRepository, history domain.ActionHistoryRepository, apiClient infrastructure.StockAPIClient, ratingService *application.RatingService, bus *events.Bus, prices priceDomain.LastPriceSource) StockUseCase {
 	return &stockUseCase{
 		repo:          repo,
+		history:       history,
 		apiClient:     apiClient,
 		ratingService: ratingService,
+		bus:           bus,
+		prices:        prices,
 	}
 }
 
@@ -73,6 +91,8 @@ func (uc *stockUseCase) SyncStocksWithProgress(ctx context.Context, onProgress i
 		return 0, err
 	}
 
+	uc.bus.Publish(events.SyncCompleted, len(stocks))
+
 	// Report completion
 	if onProgress != nil {
 		onProgress(infrastructure.SyncProgress{
@@ -89,9 +109,222 @@ func (uc *stockUseCase) SyncStocksWithProgress(ctx context.Context, onProgress i
 }
 
 func (uc *stockUseCase) GetStocks(ctx context.Context, params domain.QueryParams) ([]*domain.Stock, int64, error) {
-	return uc.repo.FindAll(ctx, params)
+	stocks, total, err := uc.repo.FindAll(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := uc.setLastPrices(ctx, stocks); err != nil {
+		return nil, 0, err
+	}
+	return stocks, total, nil
+}
+
+func (uc *stockUseCase) GetFacets(ctx context.Context, params domain.QueryParams, facets domain.FacetParams) (domain.Facets, error) {
+	return uc.repo.CountFacets(ctx, params, facets)
+}
+
+// GetIncludes loads the requested relations for the tickers of a page of stocks
+func (uc *stockUseCase) GetIncludes(ctx context.Context, stocks []*domain.Stock, includes []string) (*domain.Includes, error) {
+	seen := make(map[string]bool, len(stocks))
+	tickers := make([]string, 0, len(stocks))
+	for _, stock := range stocks {
+		if !seen[stock.Ticker] {
+			seen[stock.Ticker] = true
+			tickers = append(tickers, stock.Ticker)
+		}
+	}
+
+	result := &domain.Includes{}
+	for _, include := range includes {
+		var err error
+		switch include {
+		case domain.IncludeConsensus:
+			result.Consensus, err = uc.repo.FindConsensus(ctx, tickers)
+		case domain.IncludeCompany:
+			result.Companies, err = uc.repo.FindCompanies(ctx, tickers)
+		default:
+			err = domain.ErrInvalidInclude
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
 }
 
 func (uc *stockUseCase) GetStockByID(ctx context.Context, id int64) (*domain.Stock, error) {
-	return uc.repo.FindByID(ctx, id)
+	stock, err := uc.repo.FindByID(ctx, id)
+	if err != nil || stock == nil {
+		return stock, err
+	}
+	if err := uc.setLastPrices(ctx, []*domain.Stock{stock}); err != nil {
+		return nil, err
+	}
+	return stock, nil
+}
+
+// setLastPrices sets the latest price and upside of the stocks whose ticker has a price
+func (uc *stockUseCase) setLastPrices(ctx context.Context, stocks []*domain.Stock) error {
+	if uc.prices == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(stocks))
+	tickers := make([]string, 0, len(stocks))
+	for _, stock := range stocks {
+		if stock.Ticker != "" && !seen[stock.Ticker] {
+			seen[stock.Ticker] = true
+			tickers = append(tickers, stock.Ticker)
+		}
+	}
+	if len(tickers) == 0 {
+		return nil
+	}
+
+	prices, err := uc.prices.LastPrices(ctx, tickers)
+	if err != nil {
+		return err
+	}
+	for _, stock := range stocks {
+		if price, ok := prices[stock.Ticker]; ok {
+			stock.SetLastPrice(price)
+		}
+	}
+	return nil
+}
+
+// GetTimeline returns every recorded analyst action on a ticker in
+// chronological order, including the calls a brokerage has since replaced.
+// Until a sync has recorded the ticker's history, the latest actions stand in.
+// The page applies to events, or to buckets when a bucket is requested.
+func (uc *stockUseCase) GetTimeline(ctx context.Context, ticker string, params domain.TimelineParams) (*domain.Timeline, int64, error) {
+	params = params.Normalized()
+
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	stocks, err := uc.history.FindByTicker(ctx, ticker, params.Range)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(stocks) == 0 {
+		if stocks, err = uc.repo.FindByTicker(ctx, ticker, params.Range); err != nil {
+			return nil, 0, err
+		}
+	}
+	if len(stocks) == 0 && params.Range == (domain.TimeRange{}) {
+		return nil, 0, ErrTickerNotFound
+	}
+
+	timeline := &domain.Timeline{Ticker: ticker, Bucket: params.Bucket}
+	events := make([]domain.TimelineEvent, 0, len(stocks))
+	for _, stock := range stocks {
+		if timeline.Company == "" {
+			timeline.Company = stock.Company
+		}
+		events = append(events, domain.NewTimelineEvent(stock))
+	}
+
+	if params.Bucket == "" {
+		timeline.Events = paginate(events, params.Page, params.Limit)
+		return timeline, int64(len(events)), nil
+	}
+
+	buckets := bucketEvents(events, params.Bucket)
+	timeline.Buckets = paginate(buckets, params.Page, params.Limit)
+	return timeline, int64(len(buckets)), nil
+}
+
+// LookupStocks groups the latest actions of each requested ticker, reporting
+// the tickers without a matching action instead of failing
+func (uc *stockUseCase) LookupStocks(ctx context.Context, params domain.LookupParams) (*domain.LookupResult, error) {
+	tickers, err := domain.NormalizeTickers(params.Tickers)
+	if err != nil {
+		return nil, err
+	}
+
+	stocks, err := uc.repo.FindByTickers(ctx, tickers, params.Brokerages)
+	if err != nil {
+		return nil, err
+	}
+
+	byTicker := make(map[string]*domain.TickerLookup, len(tickers))
+	for _, stock := range stocks {
+		lookup, ok := byTicker[stock.Ticker]
+		if !ok {
+			lookup = &domain.TickerLookup{Ticker: stock.Ticker, Company: stock.Company}
+			byTicker[stock.Ticker] = lookup
+		}
+		lookup.Ratings = append(lookup.Ratings, stock)
+	}
+
+	result := &domain.LookupResult{
+		Results:  make([]*domain.TickerLookup, 0, len(byTicker)),
+		NotFound: []string{},
+	}
+	for _, ticker := range tickers {
+		if lookup, ok := byTicker[ticker]; ok {
+			result.Results = append(result.Results, lookup)
+		} else {
+			result.NotFound = append(result.NotFound, ticker)
+		}
+	}
+
+	return result, nil
+}
+
+// bucketEvents groups chronologically ordered events by period
+func bucketEvents(events []domain.TimelineEvent, bucket domain.Bucket) []domain.TimelineBucket {
+	var buckets []domain.TimelineBucket
+
+	for _, event := range events {
+		period := bucket.Truncate(event.Time)
+		if len(buckets) == 0 || !buckets[len(buckets)-1].Period.Equal(period) {
+			buckets = append(buckets, domain.TimelineBucket{Period: period})
+		}
+
+		b := &buckets[len(buckets)-1]
+		b.Events = append(b.Events, event)
+		b.EventCount++
+		if event.RatingChange > 0 {
+			b.Upgrades++
+		}
+		if event.RatingChange < 0 {
+			b.Downgrades++
+		}
+		if event.TargetTo > 0 {
+			if b.HighTargetTo == 0 || event.TargetTo > b.HighTargetTo {
+				b.HighTargetTo = event.TargetTo
+			}
+			if b.LowTargetTo == 0 || event.TargetTo < b.LowTargetTo {
+				b.LowTargetTo = event.TargetTo
+			}
+		}
+	}
+
+	for i := range buckets {
+		sum, count := 0.0, 0
+		for _, event := range buckets[i].Events {
+			if event.TargetTo > 0 {
+				sum += event.TargetTo
+				count++
+			}
+		}
+		if count > 0 {
+			buckets[i].AvgTargetTo = sum / float64(count)
+		}
+	}
+
+	return buckets
+}
+
+func paginate[T any](items []T, page, limit int) []T {
+	start := (page - 1) * limit
+	if start >= len(items) {
+		return []T{}
+	}
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
 }
@@ -3,8 +3,11 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
 	"github.com/bryanriosb/stock-info/internal/stock/domain"
 	"github.com/bryanriosb/stock-info/internal/stock/infrastructure"
 	"github.com/stretchr/testify/assert"
@@ -34,6 +37,35 @@ func (m *MockStockRepository) FindAll(ctx context.Context, params domain.QueryPa
 	return args.Get(0).([]*domain.Stock), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockStockRepository) ScanTickers(ctx context.Context, batchSize int, fn func(actions []*domain.Stock) error) error {
+	args := m.Called(ctx, batchSize, fn)
+	return args.Error(0)
+}
+
+func (m *MockStockRepository) CountFacets(ctx context.Context, params domain.QueryParams, facets domain.FacetParams) (domain.Facets, error) {
+	args := m.Called(ctx, params, facets)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.Facets), args.Error(1)
+}
+
+func (m *MockStockRepository) FindConsensus(ctx context.Context, tickers []string) (map[string]*domain.Consensus, error) {
+	args := m.Called(ctx, tickers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*domain.Consensus), args.Error(1)
+}
+
+func (m *MockStockRepository) FindCompanies(ctx context.Context, tickers []string) (map[string]*domain.Company, error) {
+	args := m.Called(ctx, tickers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*domain.Company), args.Error(1)
+}
+
 func (m *MockStockRepository) FindByID(ctx context.Context, id int64) (*domain.Stock, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -42,6 +74,40 @@ func (m *MockStockRepository) FindByID(ctx context.Context, id int64) (*domain.S
 	return args.Get(0).(*domain.Stock), args.Error(1)
 }
 
+func (m *MockStockRepository) FindByTicker(ctx context.Context, ticker string, rng domain.TimeRange) ([]*domain.Stock, error) {
+	args := m.Called(ctx, ticker, rng)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Stock), args.Error(1)
+}
+
+func (m *MockStockRepository) FindByTickers(ctx context.Context, tickers []string, brokerages []string) ([]*domain.Stock, error) {
+	args := m.Called(ctx, tickers, brokerages)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Stock), args.Error(1)
+}
+
+// Mock ActionHistoryRepository
+type MockActionHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockActionHistoryRepository) ScanTickers(ctx context.Context, batchSize int, fn func(actions []*domain.Stock) error) error {
+	args := m.Called(ctx, batchSize, fn)
+	return args.Error(0)
+}
+
+func (m *MockActionHistoryRepository) FindByTicker(ctx context.Context, ticker string, rng domain.TimeRange) ([]*domain.Stock, error) {
+	args := m.Called(ctx, ticker, rng)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Stock), args.Error(1)
+}
+
 // Mock StockAPIClient
 type MockStockAPIClient struct {
 	mock.Mock
@@ -83,7 +149,7 @@ func TestSyncStocks_Success(t *testing.T) {
 	mockAPI.On("FetchAllStocksWithProgress", mock.Anything, mock.Anything).Return(stocks, nil)
 	mockRepo.On("CreateBatch", mock.Anything, stocks).Return(nil)
 
-	uc := NewStockUseCase(mockRepo, mockAPI, nil)
+	uc := NewStockUseCase(mockRepo, nil, mockAPI, nil, nil, nil)
 	count, err := uc.SyncStocks(context.Background())
 
 	assert.NoError(t, err)
@@ -98,7 +164,7 @@ func TestSyncStocks_APIError(t *testing.T) {
 
 	mockAPI.On("FetchAllStocksWithProgress", mock.Anything, mock.Anything).Return(nil, errors.New("API error"))
 
-	uc := NewStockUseCase(mockRepo, mockAPI, nil)
+	uc := NewStockUseCase(mockRepo, nil, mockAPI, nil, nil, nil)
 	count, err := uc.SyncStocks(context.Background())
 
 	assert.Error(t, err)
@@ -117,7 +183,7 @@ func TestSyncStocks_RepoError(t *testing.T) {
 	mockAPI.On("FetchAllStocksWithProgress", mock.Anything, mock.Anything).Return(stocks, nil)
 	mockRepo.On("CreateBatch", mock.Anything, stocks).Return(errors.New("DB error"))
 
-	uc := NewStockUseCase(mockRepo, mockAPI, nil)
+	uc := NewStockUseCase(mockRepo, nil, mockAPI, nil, nil, nil)
 	count, err := uc.SyncStocks(context.Background())
 
 	assert.Error(t, err)
@@ -138,7 +204,7 @@ func TestGetStocks_Success(t *testing.T) {
 
 	mockRepo.On("FindAll", mock.Anything, params).Return(stocks, int64(2), nil)
 
-	uc := NewStockUseCase(mockRepo, mockAPI, nil)
+	uc := NewStockUseCase(mockRepo, nil, mockAPI, nil, nil, nil)
 	result, total, err := uc.GetStocks(context.Background(), params)
 
 	assert.NoError(t, err)
@@ -154,7 +220,7 @@ func TestGetStocks_Empty(t *testing.T) {
 	params := domain.QueryParams{Page: 1, Limit: 10}
 	mockRepo.On("FindAll", mock.Anything, params).Return([]*domain.Stock{}, int64(0), nil)
 
-	uc := NewStockUseCase(mockRepo, mockAPI, nil)
+	uc := NewStockUseCase(mockRepo, nil, mockAPI, nil, nil, nil)
 	result, total, err := uc.GetStocks(context.Background(), params)
 
 	assert.NoError(t, err)
@@ -163,6 +229,67 @@ func TestGetStocks_Empty(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// Mock LastPriceSource
+type MockLastPriceSource struct {
+	mock.Mock
+}
+
+func (m *MockLastPriceSource) LastPrices(ctx context.Context, tickers []string) (map[string]priceDomain.LastPrice, error) {
+	args := m.Called(ctx, tickers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]priceDomain.LastPrice), args.Error(1)
+}
+
+func TestGetStocks_WithLastPrices(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+	prices := new(MockLastPriceSource)
+	at := time.Date(2025, 6, 2, 16, 0, 0, 0, time.UTC)
+
+	stocks := []*domain.Stock{
+		{ID: 1, Ticker: "AAPL", TargetFrom: 180, TargetTo: 250},
+		{ID: 2, Ticker: "AAPL", TargetTo: 150},
+		{ID: 3, Ticker: "MSFT"},
+		{ID: 4, Ticker: "NVDA", TargetTo: 120},
+	}
+	params := domain.QueryParams{Page: 1, Limit: 10}
+
+	mockRepo.On("FindAll", mock.Anything, params).Return(stocks, int64(4), nil)
+	prices.On("LastPrices", mock.Anything, []string{"AAPL", "MSFT", "NVDA"}).Return(map[string]priceDomain.LastPrice{
+		"AAPL": {Ticker: "AAPL", Price: 200, At: at},
+		"MSFT": {Ticker: "MSFT", Price: 400, At: at},
+	}, nil)
+
+	uc := NewStockUseCase(mockRepo, nil, new(MockStockAPIClient), nil, nil, prices)
+	result, _, err := uc.GetStocks(context.Background(), params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200.0, *result[0].LastPrice)
+	assert.Equal(t, at, *result[0].LastPriceAt)
+	assert.InDelta(t, 25, *result[0].UpsidePercent, 1e-9)
+	assert.InDelta(t, -25, *result[1].UpsidePercent, 1e-9)
+	// Without a target there is no upside, without a price neither
+	assert.Equal(t, 400.0, *result[2].LastPrice)
+	assert.Nil(t, result[2].UpsidePercent)
+	assert.Nil(t, result[3].LastPrice)
+	assert.Nil(t, result[3].UpsidePercent)
+	prices.AssertExpectations(t)
+}
+
+func TestGetStockByID_PriceError(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+	prices := new(MockLastPriceSource)
+
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&domain.Stock{ID: 1, Ticker: "AAPL"}, nil)
+	prices.On("LastPrices", mock.Anything, []string{"AAPL"}).Return(nil, errors.New("database error"))
+
+	uc := NewStockUseCase(mockRepo, nil, new(MockStockAPIClient), nil, nil, prices)
+	_, err := uc.GetStockByID(context.Background(), 1)
+
+	assert.EqualError(t, err, "database error")
+}
+
 func TestGetStockByID_Success(t *testing.T) {
 	mockRepo := new(MockStockRepository)
 	mockAPI := new(MockStockAPIClient)
@@ -171,7 +298,7 @@ func TestGetStockByID_Success(t *testing.T) {
 
 	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(stock, nil)
 
-	uc := NewStockUseCase(mockRepo, mockAPI, nil)
+	uc := NewStockUseCase(mockRepo, nil, mockAPI, nil, nil, nil)
 	result, err := uc.GetStockByID(context.Background(), 1)
 
 	assert.NoError(t, err)
@@ -186,10 +313,196 @@ func TestGetStockByID_NotFound(t *testing.T) {
 
 	mockRepo.On("FindByID", mock.Anything, int64(999)).Return(nil, errors.New("not found"))
 
-	uc := NewStockUseCase(mockRepo, mockAPI, nil)
+	uc := NewStockUseCase(mockRepo, nil, mockAPI, nil, nil, nil)
 	result, err := uc.GetStockByID(context.Background(), 999)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestGetTimeline_Events(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+	history := new(MockActionHistoryRepository)
+	mockAPI := new(MockStockAPIClient)
+
+	stocks := []*domain.Stock{
+		{ID: 1, Ticker: "AAPL", Company: "Apple Inc.", Brokerage: "A", RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 100, TargetTo: 120, Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Ticker: "AAPL", Company: "Apple Inc.", Brokerage: "B", RatingFrom: "Buy", RatingTo: "Hold", TargetFrom: 0, TargetTo: 90, Time: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, Ticker: "AAPL", Company: "Apple Inc.", Brokerage: "C", RatingFrom: "Buy", RatingTo: "Buy", TargetFrom: 100, TargetTo: 110, Time: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	history.On("FindByTicker", mock.Anything, "AAPL", domain.TimeRange{}).Return(stocks, nil)
+
+	uc := NewStockUseCase(mockRepo, history, mockAPI, nil, nil, nil)
+	timeline, total, err := uc.GetTimeline(context.Background(), "aapl", domain.TimelineParams{Page: 1, Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Equal(t, "Apple Inc.", timeline.Company)
+	assert.Len(t, timeline.Events, 2)
+	assert.Equal(t, 2, timeline.Events[0].RatingChange)
+	assert.Equal(t, 20.0, timeline.Events[0].TargetChangePercent)
+	assert.Equal(t, -2, timeline.Events[1].RatingChange)
+	assert.Equal(t, 0.0, timeline.Events[1].TargetChangePercent)
+	history.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "FindByTicker", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetTimeline_Buckets(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+	history := new(MockActionHistoryRepository)
+	mockAPI := new(MockStockAPIClient)
+
+	stocks := []*domain.Stock{
+		{ID: 1, Ticker: "AAPL", RatingFrom: "Hold", RatingTo: "Buy", TargetTo: 120, Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Ticker: "AAPL", RatingFrom: "Buy", RatingTo: "Hold", TargetTo: 90, Time: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, Ticker: "AAPL", TargetTo: 110, Time: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	history.On("FindByTicker", mock.Anything, "AAPL", domain.TimeRange{}).Return(stocks, nil)
+
+	uc := NewStockUseCase(mockRepo, history, mockAPI, nil, nil, nil)
+	timeline, total, err := uc.GetTimeline(context.Background(), "AAPL", domain.TimelineParams{Bucket: domain.BucketMonth})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, timeline.Buckets, 2)
+	january := timeline.Buckets[0]
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), january.Period)
+	assert.Equal(t, 2, january.EventCount)
+	assert.Equal(t, 1, january.Upgrades)
+	assert.Equal(t, 1, january.Downgrades)
+	assert.Equal(t, 105.0, january.AvgTargetTo)
+	assert.Equal(t, 120.0, january.HighTargetTo)
+	assert.Equal(t, 90.0, january.LowTargetTo)
+	history.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "FindByTicker", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetTimeline_NotFound(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+	mockAPI := new(MockStockAPIClient)
+
+	history := new(MockActionHistoryRepository)
+	history.On("FindByTicker", mock.Anything, "NOPE", domain.TimeRange{}).Return([]*domain.Stock{}, nil)
+	mockRepo.On("FindByTicker", mock.Anything, "NOPE", domain.TimeRange{}).Return([]*domain.Stock{}, nil)
+
+	uc := NewStockUseCase(mockRepo, history, mockAPI, nil, nil, nil)
+	timeline, _, err := uc.GetTimeline(context.Background(), "NOPE", domain.TimelineParams{})
+
+	assert.ErrorIs(t, err, ErrTickerNotFound)
+	assert.Nil(t, timeline)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetTimeline_ReplaysReplacedCalls(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+	history := new(MockActionHistoryRepository)
+
+	// Stocks only keep the second call; the history charts both targets
+	history.On("FindByTicker", mock.Anything, "AAPL", domain.TimeRange{}).Return([]*domain.Stock{
+		{ID: 1, Ticker: "AAPL", Brokerage: "A", TargetFrom: 100, TargetTo: 120, Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Ticker: "AAPL", Brokerage: "A", TargetFrom: 120, TargetTo: 140, Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil)
+
+	uc := NewStockUseCase(mockRepo, history, new(MockStockAPIClient), nil, nil, nil)
+	timeline, total, err := uc.GetTimeline(context.Background(), "AAPL", domain.TimelineParams{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, []float64{120, 140}, []float64{timeline.Events[0].TargetTo, timeline.Events[1].TargetTo})
+	mockRepo.AssertNotCalled(t, "FindByTicker", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetTimeline_LatestActionsBeforeHistory(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+	history := new(MockActionHistoryRepository)
+
+	history.On("FindByTicker", mock.Anything, "AAPL", domain.TimeRange{}).Return([]*domain.Stock{}, nil)
+	mockRepo.On("FindByTicker", mock.Anything, "AAPL", domain.TimeRange{}).Return([]*domain.Stock{
+		{ID: 7, Ticker: "AAPL", Brokerage: "A", TargetTo: 140, Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil)
+
+	uc := NewStockUseCase(mockRepo, history, new(MockStockAPIClient), nil, nil, nil)
+	timeline, total, err := uc.GetTimeline(context.Background(), "AAPL", domain.TimelineParams{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, int64(7), timeline.Events[0].ID)
+}
+
+func TestGetIncludes_DeduplicatesTickers(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+	mockAPI := new(MockStockAPIClient)
+
+	stocks := []*domain.Stock{
+		{ID: 1, Ticker: "AAPL", Brokerage: "A"},
+		{ID: 2, Ticker: "AAPL", Brokerage: "B"},
+		{ID: 3, Ticker: "MSFT", Brokerage: "A"},
+	}
+	consensus := map[string]*domain.Consensus{"AAPL": {Ticker: "AAPL", Brokerages: 2}}
+	companies := map[string]*domain.Company{"MSFT": {Ticker: "MSFT", Name: "Microsoft"}}
+
+	mockRepo.On("FindConsensus", mock.Anything, []string{"AAPL", "MSFT"}).Return(consensus, nil)
+	mockRepo.On("FindCompanies", mock.Anything, []string{"AAPL", "MSFT"}).Return(companies, nil)
+
+	uc := NewStockUseCase(mockRepo, nil, mockAPI, nil, nil, nil)
+	includes, err := uc.GetIncludes(context.Background(), stocks, []string{domain.IncludeConsensus, domain.IncludeCompany})
+
+	assert.NoError(t, err)
+	assert.Equal(t, consensus, includes.Consensus)
+	assert.Equal(t, companies, includes.Companies)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLookupStocks_GroupsByTickerInRequestOrder(t *testing.T) {
+	mockRepo := new(MockStockRepository)
+	mockAPI := new(MockStockAPIClient)
+
+	stocks := []*domain.Stock{
+		{ID: 1, Ticker: "AAPL", Company: "Apple Inc.", Brokerage: "A"},
+		{ID: 2, Ticker: "AAPL", Company: "Apple Inc.", Brokerage: "B"},
+		{ID: 3, Ticker: "MSFT", Company: "Microsoft", Brokerage: "A"},
+	}
+	mockRepo.On("FindByTickers", mock.Anything, []string{"MSFT", "NOPE", "AAPL"}, []string(nil)).Return(stocks, nil)
+
+	uc := NewStockUseCase(mockRepo, nil, mockAPI, nil, nil, nil)
+	result, err := uc.LookupStocks(context.Background(), domain.LookupParams{Tickers: []string{" msft", "NOPE", "aapl", "MSFT"}})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Results, 2)
+	assert.Equal(t, "MSFT", result.Results[0].Ticker)
+	assert.Equal(t, "AAPL", result.Results[1].Ticker)
+	assert.Equal(t, "Apple Inc.", result.Results[1].Company)
+	assert.Len(t, result.Results[1].Ratings, 2)
+	assert.Equal(t, []string{"NOPE"}, result.NotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLookupStocks_ValidatesTickers(t *testing.T) {
+	tooMany := make([]string, domain.MaxLookupTickers+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("T%d", i)
+	}
+
+	tests := []struct {
+		name    string
+		tickers []string
+		err     error
+	}{
+		{"no tickers", nil, domain.ErrNoLookupTickers},
+		{"blank ticker", []string{"AAPL", " "}, domain.ErrEmptyLookupTicker},
+		{"too many tickers", tooMany, domain.ErrTooManyTickers},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockStockRepository)
+			uc := NewStockUseCase(mockRepo, nil, new(MockStockAPIClient), nil, nil, nil)
+
+			_, err := uc.LookupStocks(context.Background(), domain.LookupParams{Tickers: tt.tickers})
+
+			assert.ErrorIs(t, err, tt.err)
+			mockRepo.AssertNotCalled(t, "FindByTickers")
+		})
+	}
+}
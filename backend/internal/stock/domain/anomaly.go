@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Kinds of anomalies an action can be flagged with
+const (
+	// AnomalyTargetOutlier is a target change far outside the usual target moves
+	AnomalyTargetOutlier = "target_outlier"
+	// AnomalyRatingJump is a rating change that skips several levels at once
+	AnomalyRatingJump = "rating_jump"
+	// AnomalyConsensusOutlier is a target far from the other brokerages' targets on the ticker
+	AnomalyConsensusOutlier = "consensus_outlier"
+)
+
+// AnomalyKinds lists the anomaly kinds in the order flags are stored in
+var AnomalyKinds = []string{AnomalyTargetOutlier, AnomalyRatingJump, AnomalyConsensusOutlier}
+
+var ErrInvalidAnomaly = errors.New("invalid anomaly: use target_outlier, rating_jump or consensus_outlier")
+
+// AnomalyThresholds decide what the anomaly detector flags
+type AnomalyThresholds struct {
+	// TargetZScore is the robust z-score of the log target change above which
+	// a change is an outlier, measured against the median and median absolute
+	// deviation of every target move
+	TargetZScore float64 `json:"target_z_score"`
+	// MinTargetMoves is the fewest target moves the outliers are judged against
+	MinTargetMoves int `json:"min_target_moves"`
+	// RatingJump is the fewest steps on the 1-9 rating scale a jump spans
+	RatingJump int `json:"rating_jump"`
+	// ConsensusRatio is how many times above or below the median target of
+	// the other brokerages a target must be to stand out
+	ConsensusRatio float64 `json:"consensus_ratio"`
+	// MinConsensus is the fewest other brokerages with a target a consensus needs
+	MinConsensus int `json:"min_consensus"`
+}
+
+// DefaultAnomalyThresholds flag a 5σ target move, a jump of 5 rating steps
+// (e.g. Underperform to Outperform) and a target double or half the consensus
+// of at least 2 other brokerages
+var DefaultAnomalyThresholds = AnomalyThresholds{
+	TargetZScore:   5,
+	MinTargetMoves: 30,
+	RatingJump:     5,
+	ConsensusRatio: 2,
+	MinConsensus:   2,
+}
+
+// AnomalyRun summarises a pass of the anomaly detector
+type AnomalyRun struct {
+	Actions int `json:"actions"`
+	// Flagged counts the actions with at least one anomaly
+	Flagged int `json:"flagged"`
+	// Kinds counts the actions flagged with each anomaly kind
+	Kinds map[string]int `json:"kinds"`
+	// Changed counts the actions whose flags were updated
+	Changed int `json:"changed"`
+	// TargetMedian and TargetSpread are the median and scaled median absolute
+	// deviation of the log target moves, 0 when there were too few
+	TargetMedian float64           `json:"target_median"`
+	TargetSpread float64           `json:"target_spread"`
+	Thresholds   AnomalyThresholds `json:"thresholds"`
+	DetectedAt   time.Time         `json:"detected_at"`
+}
+
+// AnomalyParams pages through flagged actions, optionally of one kind and ticker
+type AnomalyParams struct {
+	Kind   string
+	Ticker string
+	Page   int
+	Limit  int
+}
+
+// ValidAnomalyKind reports whether kind names an anomaly
+func ValidAnomalyKind(kind string) bool {
+	for _, k := range AnomalyKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// AnomalyRepository stores the anomaly flags of actions
+type AnomalyRepository interface {
+	// SetAnomalies replaces the flags of the given actions, clearing them when empty
+	SetAnomalies(ctx context.Context, anomalies map[int64][]string) error
+	// FindAnomalies returns a page of flagged actions, newest first, with their total
+	FindAnomalies(ctx context.Context, params AnomalyParams) ([]*Stock, int64, error)
+}
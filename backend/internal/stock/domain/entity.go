@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
+)
 
 type Stock struct {
 	ID         int64     `json:"id" gorm:"primaryKey;autoIncrement"`
@@ -15,8 +19,29 @@ type Stock struct {
 	Time       time.Time `json:"time" gorm:"type:timestamp;index"`
 	CreatedAt  time.Time `json:"created_at" gorm:"type:timestamp;autoCreateTime"`
 	UpdatedAt  time.Time `json:"updated_at" gorm:"type:timestamp;autoUpdateTime"`
+
+	// Anomalies lists what the anomaly detector flagged on the action, see AnomalyKinds
+	Anomalies []string `json:"anomalies,omitempty" gorm:"serializer:json;type:jsonb"`
+
+	// LastPrice is the ticker's latest price and UpsidePercent how far TargetTo is
+	// above it; they come from the price module rather than the stocks table
+	LastPrice     *float64   `json:"last_price,omitempty" gorm:"-"`
+	LastPriceAt   *time.Time `json:"last_price_at,omitempty" gorm:"-"`
+	UpsidePercent *float64   `json:"upside_percent,omitempty" gorm:"-"`
 }
 
 func (Stock) TableName() string {
 	return "stocks"
 }
+
+// SetLastPrice sets the ticker's latest price and, when the action has a
+// target, the upside from that price to the target
+func (s *Stock) SetLastPrice(price priceDomain.LastPrice) {
+	s.LastPrice = &price.Price
+	s.LastPriceAt = &price.At
+	s.UpsidePercent = nil
+	if s.TargetTo > 0 {
+		upside := priceDomain.Upside(s.TargetTo, price.Price)
+		s.UpsidePercent = &upside
+	}
+}
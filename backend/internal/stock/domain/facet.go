@@ -0,0 +1,40 @@
+package domain
+
+import "errors"
+
+var ErrInvalidFacet = errors.New("invalid facet: use brokerage, rating_to, action or time")
+
+// Facets supported on stock searches
+const (
+	FacetBrokerage = "brokerage"
+	FacetRatingTo  = "rating_to"
+	FacetAction    = "action"
+	FacetTime      = "time"
+)
+
+// FacetCount is the number of matching stocks sharing a value
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// Facets maps a facet name to its counts, ordered by count descending
+// (chronologically for the time facet)
+type Facets map[string][]FacetCount
+
+// FacetParams selects the facets computed alongside a stock search
+type FacetParams struct {
+	Names  []string
+	Bucket Bucket // granularity of the time facet
+}
+
+// ParseFacets parses a comma-separated facet list, ignoring duplicates
+func ParseFacets(value string) ([]string, error) {
+	return parseList(value, func(name string) bool {
+		switch name {
+		case FacetBrokerage, FacetRatingTo, FacetAction, FacetTime:
+			return true
+		}
+		return false
+	}, ErrInvalidFacet)
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ActionHistory records every analyst action a sync has seen. Stocks keep only
+// the latest action per ticker and brokerage, so the past is replayed from the
+// history instead. Rows are only ever added.
+type ActionHistory struct {
+	ID         int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Ticker     string    `json:"ticker" gorm:"size:10;not null;uniqueIndex:idx_action_history_action"`
+	Brokerage  string    `json:"brokerage" gorm:"size:255;not null;uniqueIndex:idx_action_history_action"`
+	Company    string    `json:"company" gorm:"size:255;not null"`
+	Action     string    `json:"action" gorm:"size:100"`
+	RatingFrom string    `json:"rating_from" gorm:"size:50"`
+	RatingTo   string    `json:"rating_to" gorm:"size:50"`
+	TargetFrom float64   `json:"target_from" gorm:"type:decimal(10,2)"`
+	TargetTo   float64   `json:"target_to" gorm:"type:decimal(10,2)"`
+	Time       time.Time `json:"time" gorm:"type:timestamp;not null;uniqueIndex:idx_action_history_action"`
+	RecordedAt time.Time `json:"recorded_at" gorm:"type:timestamp;autoCreateTime"`
+}
+
+func (ActionHistory) TableName() string {
+	return "action_history"
+}
+
+// NewActionHistory records a synced action
+func NewActionHistory(stock *Stock) *ActionHistory {
+	return &ActionHistory{
+		Ticker:     stock.Ticker,
+		Brokerage:  stock.Brokerage,
+		Company:    stock.Company,
+		Action:     stock.Action,
+		RatingFrom: stock.RatingFrom,
+		RatingTo:   stock.RatingTo,
+		TargetFrom: stock.TargetFrom,
+		TargetTo:   stock.TargetTo,
+		Time:       stock.Time,
+	}
+}
+
+// Stock returns the recorded action as the stocks table held it at the time
+func (h *ActionHistory) Stock() *Stock {
+	return &Stock{
+		ID:         h.ID,
+		Ticker:     h.Ticker,
+		Company:    h.Company,
+		Brokerage:  h.Brokerage,
+		Action:     h.Action,
+		RatingFrom: h.RatingFrom,
+		RatingTo:   h.RatingTo,
+		TargetFrom: h.TargetFrom,
+		TargetTo:   h.TargetTo,
+		Time:       h.Time,
+	}
+}
+
+type ActionHistoryRepository interface {
+	// ScanTickers passes every recorded action on each ticker to fn, one ticker
+	// at a time in ticker order and each brokerage's actions oldest first,
+	// reading batchSize rows per query and stopping at the first error
+	ScanTickers(ctx context.Context, batchSize int, fn func(actions []*Stock) error) error
+	// FindByTicker returns every recorded action on a ticker in chronological order
+	FindByTicker(ctx context.Context, ticker string, rng TimeRange) ([]*Stock, error)
+}
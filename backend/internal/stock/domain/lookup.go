@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxLookupTickers bounds a bulk lookup so it stays a single indexed query
+const MaxLookupTickers = 500
+
+var (
+	ErrNoLookupTickers   = errors.New("tickers are required")
+	ErrTooManyTickers    = fmt.Errorf("at most %d tickers can be looked up at once", MaxLookupTickers)
+	ErrEmptyLookupTicker = errors.New("tickers must not be empty")
+)
+
+// LookupParams selects the latest actions of a list of tickers, optionally
+// restricted to some brokerages
+type LookupParams struct {
+	Tickers    []string
+	Brokerages []string
+}
+
+// TickerLookup holds the latest action of every brokerage covering a ticker, newest first
+type TickerLookup struct {
+	Ticker  string   `json:"ticker"`
+	Company string   `json:"company"`
+	Ratings []*Stock `json:"ratings"`
+}
+
+// LookupResult lists the found tickers in request order and the tickers
+// without any action matching the filters
+type LookupResult struct {
+	Results  []*TickerLookup `json:"results"`
+	NotFound []string        `json:"not_found"`
+}
+
+// NormalizeTickers upper-cases and deduplicates tickers, keeping request order
+func NormalizeTickers(tickers []string) ([]string, error) {
+	if len(tickers) == 0 {
+		return nil, ErrNoLookupTickers
+	}
+
+	seen := make(map[string]bool, len(tickers))
+	normalized := make([]string, 0, len(tickers))
+	for _, ticker := range tickers {
+		ticker = strings.ToUpper(strings.TrimSpace(ticker))
+		if ticker == "" {
+			return nil, ErrEmptyLookupTicker
+		}
+		if !seen[ticker] {
+			seen[ticker] = true
+			normalized = append(normalized, ticker)
+		}
+	}
+
+	if len(normalized) > MaxLookupTickers {
+		return nil, ErrTooManyTickers
+	}
+	return normalized, nil
+}
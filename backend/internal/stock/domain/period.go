@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidBucket    = errors.New("invalid bucket: use day, week or month")
+	ErrInvalidTimeRange = errors.New("invalid time range: use YYYY-MM-DD or RFC3339 dates with from before to")
+)
+
+// Bucket is the granularity used to group analyst actions over time
+type Bucket string
+
+const (
+	BucketDay   Bucket = "day"
+	BucketWeek  Bucket = "week"
+	BucketMonth Bucket = "month"
+)
+
+// ParseBucket validates a bucket name, returning fallback when value is empty
+func ParseBucket(value string, fallback Bucket) (Bucket, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	switch b := Bucket(strings.ToLower(value)); b {
+	case BucketDay, BucketWeek, BucketMonth:
+		return b, nil
+	}
+	return "", ErrInvalidBucket
+}
+
+// Truncate returns the start of the bucket containing t (weeks start on Monday)
+func (b Bucket) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch b {
+	case BucketWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case BucketMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// TimeRange filters actions by Stock.Time; zero bounds are open. To is exclusive.
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// ParseTimeRange parses optional from/to query values. A date-only "to"
+// includes the whole day.
+func ParseTimeRange(from, to string) (TimeRange, error) {
+	var r TimeRange
+	var err error
+
+	if from != "" {
+		if r.From, _, err = parseDate(from); err != nil {
+			return TimeRange{}, ErrInvalidTimeRange
+		}
+	}
+	if to != "" {
+		var dateOnly bool
+		if r.To, dateOnly, err = parseDate(to); err != nil {
+			return TimeRange{}, ErrInvalidTimeRange
+		}
+		if dateOnly {
+			r.To = r.To.AddDate(0, 0, 1)
+		}
+	}
+	if !r.From.IsZero() && !r.To.IsZero() && !r.From.Before(r.To) {
+		return TimeRange{}, ErrInvalidTimeRange
+	}
+
+	return r, nil
+}
+
+// Contains reports whether t falls inside the range
+func (r TimeRange) Contains(t time.Time) bool {
+	if !r.From.IsZero() && t.Before(r.From) {
+		return false
+	}
+	if !r.To.IsZero() && !t.Before(r.To) {
+		return false
+	}
+	return true
+}
+
+func parseDate(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidField   = errors.New("invalid field")
+	ErrInvalidInclude = errors.New("invalid include: use consensus or company")
+)
+
+// Relations that can be embedded in stock responses
+const (
+	IncludeConsensus = "consensus"
+	IncludeCompany   = "company"
+)
+
+// stockFields lists the selectable fields, which match their JSON names. Every
+// field but the price fields is a column.
+var stockFields = map[string]func(s *Stock) interface{}{
+	"id":          func(s *Stock) interface{} { return s.ID },
+	"ticker":      func(s *Stock) interface{} { return s.Ticker },
+	"company":     func(s *Stock) interface{} { return s.Company },
+	"brokerage":   func(s *Stock) interface{} { return s.Brokerage },
+	"action":      func(s *Stock) interface{} { return s.Action },
+	"rating_from": func(s *Stock) interface{} { return s.RatingFrom },
+	"rating_to":   func(s *Stock) interface{} { return s.RatingTo },
+	"target_from": func(s *Stock) interface{} { return s.TargetFrom },
+	"target_to":   func(s *Stock) interface{} { return s.TargetTo },
+	"time":        func(s *Stock) interface{} { return s.Time },
+	"anomalies":   func(s *Stock) interface{} { return s.Anomalies },
+	"created_at":  func(s *Stock) interface{} { return s.CreatedAt },
+	"updated_at":  func(s *Stock) interface{} { return s.UpdatedAt },
+
+	"last_price":     func(s *Stock) interface{} { return s.LastPrice },
+	"last_price_at":  func(s *Stock) interface{} { return s.LastPriceAt },
+	"upside_percent": func(s *Stock) interface{} { return s.UpsidePercent },
+}
+
+// IsPriceField reports whether a field is read from the ticker's latest price
+// rather than selected from the stocks table
+func IsPriceField(name string) bool {
+	return name == "last_price" || name == "last_price_at" || name == "upside_percent"
+}
+
+// Consensus aggregates the current view of every brokerage covering a ticker
+type Consensus struct {
+	Ticker          string  `json:"ticker"`
+	Brokerages      int64   `json:"brokerages"`
+	RatedBrokerages int64   `json:"rated_brokerages"`
+	MeanRating      float64 `json:"mean_rating"` // on the 1-9 rating scale, 0 when no rating is known
+	Rating          string  `json:"rating"`
+	AvgTarget       float64 `json:"avg_target"`
+	HighTarget      float64 `json:"high_target"`
+	LowTarget       float64 `json:"low_target"`
+}
+
+// Company summarises the analyst coverage of a ticker
+type Company struct {
+	Ticker        string    `json:"ticker"`
+	Name          string    `json:"name"`
+	Brokerages    int64     `json:"brokerages"`
+	Actions       int64     `json:"actions"`
+	FirstActionAt time.Time `json:"first_action_at"`
+	LastActionAt  time.Time `json:"last_action_at"`
+}
+
+// Includes holds the embedded relations of a page of stocks, keyed by ticker
+type Includes struct {
+	Consensus map[string]*Consensus
+	Companies map[string]*Company
+}
+
+// ParseFields parses a comma-separated list of stock fields, keeping request order
+func ParseFields(value string) ([]string, error) {
+	return parseList(value, func(name string) bool {
+		_, ok := stockFields[name]
+		return ok
+	}, ErrInvalidField)
+}
+
+// ParseIncludes parses a comma-separated list of relations
+func ParseIncludes(value string) ([]string, error) {
+	return parseList(value, func(name string) bool {
+		return name == IncludeConsensus || name == IncludeCompany
+	}, ErrInvalidInclude)
+}
+
+// Project returns the requested fields of a stock (every field when none is given)
+// with its embedded relations under "included"
+func (s *Stock) Project(fields []string, includes *Includes) map[string]interface{} {
+	if len(fields) == 0 {
+		fields = make([]string, 0, len(stockFields))
+		for name := range stockFields {
+			fields = append(fields, name)
+		}
+	}
+
+	projected := make(map[string]interface{}, len(fields)+1)
+	for _, name := range fields {
+		if value, ok := stockFields[name]; ok {
+			projected[name] = value(s)
+		}
+	}
+
+	if includes != nil {
+		included := map[string]interface{}{}
+		if includes.Consensus != nil {
+			included[IncludeConsensus] = includes.Consensus[s.Ticker]
+		}
+		if includes.Companies != nil {
+			included[IncludeCompany] = includes.Companies[s.Ticker]
+		}
+		projected["included"] = included
+	}
+
+	return projected
+}
+
+func parseList(value string, valid func(string) bool, invalid error) ([]string, error) {
+	var names []string
+	seen := map[string]bool{}
+
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		if !valid(name) {
+			return nil, invalid
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	return names, nil
+}
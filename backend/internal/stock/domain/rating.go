@@ -0,0 +1,69 @@
+package domain
+
+import "strings"
+
+// ratingScale maps lowercased analyst ratings to an ordinal 1 (sell) to 9 (strong buy) scale
+var ratingScale = map[string]int{
+	"sell":                1,
+	"strong sell":         1,
+	"negative":            2,
+	"underperform":        3,
+	"underweight":         3,
+	"sector underperform": 3,
+	"reduce":              3,
+	"cautious":            4,
+	"market perform":      5,
+	"sector perform":      5,
+	"neutral":             5,
+	"hold":                5,
+	"equal weight":        5,
+	"in-line":             5,
+	"peer perform":        5,
+	"sector weight":       5,
+	"buy":                 7,
+	"positive":            7,
+	"overweight":          7,
+	"moderate buy":        7,
+	"accumulate":          7,
+	"outperform":          8,
+	"outperformer":        8,
+	"market outperform":   8,
+	"sector outperform":   8,
+	"speculative buy":     8,
+	"strong-buy":          9,
+	"strong buy":          9,
+}
+
+// RatingValue returns the ordinal value of a rating label, or 0 when the label is unknown
+func RatingValue(label string) int {
+	return ratingScale[strings.ToLower(strings.TrimSpace(label))]
+}
+
+// RatingScale returns a copy of the rating scale keyed by lowercased label
+func RatingScale() map[string]int {
+	scale := make(map[string]int, len(ratingScale))
+	for label, value := range ratingScale {
+		scale[label] = value
+	}
+	return scale
+}
+
+// ConsensusRating converts an average rating value back to a coarse label
+func ConsensusRating(value float64) string {
+	switch {
+	case value <= 0:
+		return ""
+	case value < 2.5:
+		return "Sell"
+	case value < 4.5:
+		return "Underperform"
+	case value < 6:
+		return "Hold"
+	case value < 7.5:
+		return "Buy"
+	case value < 8.5:
+		return "Outperform"
+	default:
+		return "Strong Buy"
+	}
+}
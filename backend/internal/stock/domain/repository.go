@@ -7,14 +7,28 @@ type QueryParams struct {
 	Limit      int
 	SortBy     string
 	SortDir    string
-	Search     string // Combined search for ticker or company
-	RatingFrom string // Rating from filter
-	RatingTo   string // Rating to filter
+	Search     string   // Combined search for ticker or company
+	RatingFrom string   // Rating from filter
+	RatingTo   string   // Rating to filter
+	Fields     []string // Columns to select, all when empty
 }
 
 type StockRepository interface {
 	Create(ctx context.Context, stock *Stock) error
+	// CreateBatch upserts the latest action per ticker and brokerage and appends
+	// the actions not seen before to the action history
 	CreateBatch(ctx context.Context, stocks []*Stock) error
 	FindAll(ctx context.Context, params QueryParams) ([]*Stock, int64, error)
+	// ScanTickers passes the actions on each ticker to fn, one ticker at a time in
+	// ticker order, reading batchSize rows per query and stopping at the first error
+	ScanTickers(ctx context.Context, batchSize int, fn func(actions []*Stock) error) error
+	// CountFacets counts the stocks matching the filters of params per facet value
+	CountFacets(ctx context.Context, params QueryParams, facets FacetParams) (Facets, error)
 	FindByID(ctx context.Context, id int64) (*Stock, error)
+	// FindByTicker returns the actions on a ticker in chronological order
+	FindByTicker(ctx context.Context, ticker string, rng TimeRange) ([]*Stock, error)
+	// FindByTickers returns the actions on several tickers ordered by ticker, newest first
+	FindByTickers(ctx context.Context, tickers []string, brokerages []string) ([]*Stock, error)
+	FindConsensus(ctx context.Context, tickers []string) (map[string]*Consensus, error)
+	FindCompanies(ctx context.Context, tickers []string) (map[string]*Company, error)
 }
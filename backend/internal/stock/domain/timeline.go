@@ -0,0 +1,81 @@
+package domain
+
+import "time"
+
+// TimelineEvent is a single analyst action on a ticker
+type TimelineEvent struct {
+	ID                  int64     `json:"id"`
+	Time                time.Time `json:"time"`
+	Brokerage           string    `json:"brokerage"`
+	Action              string    `json:"action"`
+	RatingFrom          string    `json:"rating_from"`
+	RatingTo            string    `json:"rating_to"`
+	RatingChange        int       `json:"rating_change"` // steps on the rating scale, 0 when either rating is unknown
+	TargetFrom          float64   `json:"target_from"`
+	TargetTo            float64   `json:"target_to"`
+	TargetChange        float64   `json:"target_change"`
+	TargetChangePercent float64   `json:"target_change_percent"`
+}
+
+// TimelineBucket groups the events of a day, week or month
+type TimelineBucket struct {
+	Period       time.Time       `json:"period"`
+	EventCount   int             `json:"event_count"`
+	Upgrades     int             `json:"upgrades"`
+	Downgrades   int             `json:"downgrades"`
+	AvgTargetTo  float64         `json:"avg_target_to"`
+	HighTargetTo float64         `json:"high_target_to"`
+	LowTargetTo  float64         `json:"low_target_to"`
+	Events       []TimelineEvent `json:"events"`
+}
+
+// Timeline holds either raw events or buckets, depending on whether a bucket was requested
+type Timeline struct {
+	Ticker  string           `json:"ticker"`
+	Company string           `json:"company"`
+	Bucket  Bucket           `json:"bucket,omitempty"`
+	Events  []TimelineEvent  `json:"events,omitempty"`
+	Buckets []TimelineBucket `json:"buckets,omitempty"`
+}
+
+type TimelineParams struct {
+	Range  TimeRange
+	Bucket Bucket // empty returns individual events
+	Page   int
+	Limit  int
+}
+
+// Normalized defaults the page to 1 and out of range limits to 50
+func (p TimelineParams) Normalized() TimelineParams {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.Limit < 1 || p.Limit > 500 {
+		p.Limit = 50
+	}
+	return p
+}
+
+// NewTimelineEvent derives the rating transition and target change of a stock action
+func NewTimelineEvent(s *Stock) TimelineEvent {
+	event := TimelineEvent{
+		ID:           s.ID,
+		Time:         s.Time,
+		Brokerage:    s.Brokerage,
+		Action:       s.Action,
+		RatingFrom:   s.RatingFrom,
+		RatingTo:     s.RatingTo,
+		TargetFrom:   s.TargetFrom,
+		TargetTo:     s.TargetTo,
+		TargetChange: s.TargetTo - s.TargetFrom,
+	}
+
+	if from, to := RatingValue(s.RatingFrom), RatingValue(s.RatingTo); from > 0 && to > 0 {
+		event.RatingChange = to - from
+	}
+	if s.TargetFrom > 0 {
+		event.TargetChangePercent = event.TargetChange / s.TargetFrom * 100
+	}
+
+	return event
+}
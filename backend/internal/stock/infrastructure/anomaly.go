@@ -0,0 +1,85 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/bryanriosb/stock-info/internal/stock/domain"
+	"gorm.io/gorm"
+)
+
+// anomalyUpdateBatch is the number of actions updated per statement
+const anomalyUpdateBatch = 500
+
+type anomalyRepository struct {
+	db *gorm.DB
+}
+
+func NewAnomalyRepository(db *gorm.DB) domain.AnomalyRepository {
+	return &anomalyRepository{db: db}
+}
+
+// SetAnomalies updates the actions sharing the same flags together. The
+// columns are written directly so updated_at keeps following the upstream data.
+func (r *anomalyRepository) SetAnomalies(ctx context.Context, anomalies map[int64][]string) error {
+	if len(anomalies) == 0 {
+		return nil
+	}
+
+	groups := make(map[string][]int64)
+	for id, flags := range anomalies {
+		key := strings.Join(flags, ",")
+		groups[key] = append(groups[key], id)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for key, ids := range groups {
+			var value interface{}
+			if key != "" {
+				encoded, err := json.Marshal(strings.Split(key, ","))
+				if err != nil {
+					return err
+				}
+				value = string(encoded)
+			}
+			for start := 0; start < len(ids); start += anomalyUpdateBatch {
+				end := min(start+anomalyUpdateBatch, len(ids))
+				err := tx.Model(&domain.Stock{}).
+					Where("id IN ?", ids[start:end]).
+					UpdateColumn("anomalies", value).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (r *anomalyRepository) FindAnomalies(ctx context.Context, params domain.AnomalyParams) ([]*domain.Stock, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Stock{}).Where("anomalies IS NOT NULL")
+	if params.Kind != "" {
+		encoded, err := json.Marshal([]string{params.Kind})
+		if err != nil {
+			return nil, 0, err
+		}
+		query = query.Where("anomalies @> ?", string(encoded))
+	}
+	if params.Ticker != "" {
+		query = query.Where("ticker = ?", strings.ToUpper(params.Ticker))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var stocks []*domain.Stock
+	err := query.
+		Order("time DESC, id DESC").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).
+		Find(&stocks).Error
+	return stocks, total, err
+}
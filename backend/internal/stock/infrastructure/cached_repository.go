@@ -0,0 +1,39 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/shared/cache"
+)
+
+// CacheNamespace prefixes every stock cache key so a sync can invalidate them together
+const CacheNamespace = "stocks:"
+
+type stockPage struct {
+	Stocks []*domain.Stock `json:"stocks"`
+	Total  int64           `json:"total"`
+}
+
+// cachedStockRepository serves FindAll pages from the application cache
+type cachedStockRepository struct {
+	domain.StockRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+func NewCachedStockRepository(repo domain.StockRepository, c cache.Cache, ttl time.Duration) domain.StockRepository {
+	return &cachedStockRepository{StockRepository: repo, cache: c, ttl: ttl}
+}
+
+func (r *cachedStockRepository) FindAll(ctx context.Context, params domain.QueryParams) ([]*domain.Stock, int64, error) {
+	page, err := cache.GetOrLoad(ctx, r.cache, cache.Key(CacheNamespace+"findall:", params), r.ttl, func() (stockPage, error) {
+		stocks, total, err := r.StockRepository.FindAll(ctx, params)
+		return stockPage{Stocks: stocks, Total: total}, err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return page.Stocks, page.Total, nil
+}
@@ -0,0 +1,74 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/bryanriosb/stock-info/internal/stock/domain"
+	"gorm.io/gorm"
+)
+
+type actionHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewActionHistoryRepository(db *gorm.DB) domain.ActionHistoryRepository {
+	return &actionHistoryRepository{db: db}
+}
+
+func (r *actionHistoryRepository) ScanTickers(ctx context.Context, batchSize int, fn func(actions []*domain.Stock) error) error {
+	var group []*domain.Stock
+	var last *domain.ActionHistory
+	for {
+		query := r.db.WithContext(ctx)
+		if last != nil {
+			query = query.Where("(ticker, brokerage, time) > (?, ?, ?)", last.Ticker, last.Brokerage, last.Time)
+		}
+		var batch []*domain.ActionHistory
+		err := query.
+			Order("ticker ASC, brokerage ASC, time ASC").
+			Limit(batchSize).
+			Find(&batch).Error
+		if err != nil {
+			return err
+		}
+
+		for _, action := range batch {
+			if len(group) > 0 && group[0].Ticker != action.Ticker {
+				if err := fn(group); err != nil {
+					return err
+				}
+				group = nil
+			}
+			group = append(group, action.Stock())
+		}
+		if len(batch) < batchSize {
+			break
+		}
+		last = batch[len(batch)-1]
+	}
+
+	if len(group) == 0 {
+		return nil
+	}
+	return fn(group)
+}
+
+func (r *actionHistoryRepository) FindByTicker(ctx context.Context, ticker string, rng domain.TimeRange) ([]*domain.Stock, error) {
+	query := r.db.WithContext(ctx).Where("ticker = ?", ticker)
+	if !rng.From.IsZero() {
+		query = query.Where("time >= ?", rng.From)
+	}
+	if !rng.To.IsZero() {
+		query = query.Where("time < ?", rng.To)
+	}
+
+	var history []*domain.ActionHistory
+	if err := query.Order("time ASC, id ASC").Find(&history).Error; err != nil {
+		return nil, err
+	}
+	stocks := make([]*domain.Stock, 0, len(history))
+	for _, action := range history {
+		stocks = append(stocks, action.Stock())
+	}
+	return stocks, nil
+}
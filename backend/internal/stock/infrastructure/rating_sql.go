@@ -0,0 +1,30 @@
+package infrastructure
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bryanriosb/stock-info/internal/stock/domain"
+)
+
+// RatingValueSQL builds a CASE expression that maps a rating column to the
+// ordinal rating scale, yielding NULL for unknown labels
+func RatingValueSQL(column string) string {
+	scale := domain.RatingScale()
+
+	labels := make([]string, 0, len(scale))
+	for label := range scale {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CASE lower(trim(%s))", column)
+	for _, label := range labels {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", strings.ReplaceAll(label, "'", "''"), scale[label])
+	}
+	b.WriteString(" END")
+
+	return b.String()
+}
@@ -2,6 +2,8 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/bryanriosb/stock-info/internal/stock/domain"
@@ -28,45 +30,52 @@ func (r *stockRepository) CreateBatch(ctx context.Context, stocks []*domain.Stoc
 		return nil
 	}
 
-	// Upsert: update existing records based on ticker+brokerage unique constraint
-	return r.db.WithContext(ctx).
-		Clauses(clause.OnConflict{
-			Columns: []clause.Column{{Name: "ticker"}, {Name: "brokerage"}},
-			DoUpdates: clause.AssignmentColumns([]string{
-				"company", "action", "rating_from", "rating_to",
-				"target_from", "target_to", "time", "updated_at",
-			}),
-		}).
-		CreateInBatches(stocks, 100).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Upsert: update existing records based on ticker+brokerage unique constraint
+		err := tx.
+			Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "ticker"}, {Name: "brokerage"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"company", "action", "rating_from", "rating_to",
+					"target_from", "target_to", "time", "updated_at",
+				}),
+			}).
+			CreateInBatches(stocks, 100).Error
+		if err != nil {
+			return err
+		}
+
+		// The history keeps the actions the upsert replaces; actions seen before are skipped
+		history := make([]*domain.ActionHistory, 0, len(stocks))
+		for _, stock := range stocks {
+			history = append(history, domain.NewActionHistory(stock))
+		}
+		return tx.
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "ticker"}, {Name: "brokerage"}, {Name: "time"}},
+				DoNothing: true,
+			}).
+			CreateInBatches(history, 100).Error
+	})
 }
 
 func (r *stockRepository) FindAll(ctx context.Context, params domain.QueryParams) ([]*domain.Stock, int64, error) {
 	if params.Page < 1 {
 		params.Page = 1
 	}
-	if params.Limit < 1 || params.Limit > 100 {
+	// Sparse rows are small enough to allow larger pages for charting
+	maxLimit := 100
+	if len(params.Fields) > 0 {
+		maxLimit = 1000
+	}
+	if params.Limit < 1 || params.Limit > maxLimit {
 		params.Limit = 20
 	}
 
 	var stocks []*domain.Stock
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.Stock{})
-
-	// Combined search: ticker OR company
-	if params.Search != "" {
-		searchTerm := "%" + params.Search + "%"
-		query = query.Where("ticker ILIKE ? OR company ILIKE ?", searchTerm, searchTerm)
-	}
-
-	// Rating filters
-	if params.RatingFrom != "" {
-		query = query.Where("rating_from = ?", params.RatingFrom)
-	}
-
-	if params.RatingTo != "" {
-		query = query.Where("rating_to = ?", params.RatingTo)
-	}
+	query := applyFilters(r.db.WithContext(ctx).Model(&domain.Stock{}), params)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -85,6 +94,10 @@ func (r *stockRepository) FindAll(ctx context.Context, params domain.QueryParams
 
 	offset := (params.Page - 1) * params.Limit
 
+	if len(params.Fields) > 0 {
+		query = query.Select(params.Fields)
+	}
+
 	err := query.Order(sortBy + " " + sortDir).
 		Limit(params.Limit).
 		Offset(offset).
@@ -93,6 +106,91 @@ func (r *stockRepository) FindAll(ctx context.Context, params domain.QueryParams
 	return stocks, total, err
 }
 
+// ScanTickers pages by the unique (ticker, brokerage) index rather than offset,
+// so every batch is an index range scan. A ticker split across batches is held
+// back until its last action has been read.
+func (r *stockRepository) ScanTickers(ctx context.Context, batchSize int, fn func(actions []*domain.Stock) error) error {
+	var group []*domain.Stock
+	var last *domain.Stock
+	for {
+		query := r.db.WithContext(ctx)
+		if last != nil {
+			query = query.Where("(ticker, brokerage) > (?, ?)", last.Ticker, last.Brokerage)
+		}
+		var batch []*domain.Stock
+		err := query.
+			Order("ticker ASC, brokerage ASC").
+			Limit(batchSize).
+			Find(&batch).Error
+		if err != nil {
+			return err
+		}
+
+		for _, stock := range batch {
+			if len(group) > 0 && group[0].Ticker != stock.Ticker {
+				if err := fn(group); err != nil {
+					return err
+				}
+				group = nil
+			}
+			group = append(group, stock)
+		}
+		if len(batch) < batchSize {
+			break
+		}
+		last = batch[len(batch)-1]
+	}
+
+	if len(group) == 0 {
+		return nil
+	}
+	return fn(group)
+}
+
+// CountFacets computes every requested facet in a single UNION ALL query
+func (r *stockRepository) CountFacets(ctx context.Context, params domain.QueryParams, facets domain.FacetParams) (domain.Facets, error) {
+	result := make(domain.Facets, len(facets.Names))
+	if len(facets.Names) == 0 {
+		return result, nil
+	}
+
+	parts := make([]string, 0, len(facets.Names))
+	subqueries := make([]interface{}, 0, len(facets.Names))
+	for _, name := range facets.Names {
+		expr, ok := facetExpression(name, facets.Bucket)
+		if !ok {
+			return nil, domain.ErrInvalidFacet
+		}
+		subquery := applyFilters(r.db.Model(&domain.Stock{}), params).
+			Select("? AS facet, "+expr+" AS value, COUNT(*) AS count", name).
+			Group(expr)
+		parts = append(parts, "(?)")
+		subqueries = append(subqueries, subquery)
+		result[name] = []domain.FacetCount{}
+	}
+
+	var rows []struct {
+		Facet string
+		Value string
+		Count int64
+	}
+	err := r.db.WithContext(ctx).
+		Raw(strings.Join(parts, " UNION ALL ")+" ORDER BY facet, count DESC, value", subqueries...).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.Facet] = append(result[row.Facet], domain.FacetCount{Value: row.Value, Count: row.Count})
+	}
+	if counts := result[domain.FacetTime]; len(counts) > 0 {
+		sort.Slice(counts, func(i, j int) bool { return counts[i].Value < counts[j].Value })
+	}
+
+	return result, nil
+}
+
 func (r *stockRepository) FindByID(ctx context.Context, id int64) (*domain.Stock, error) {
 	var stock domain.Stock
 	err := r.db.WithContext(ctx).First(&stock, id).Error
@@ -104,3 +202,119 @@ func (r *stockRepository) FindByID(ctx context.Context, id int64) (*domain.Stock
 	}
 	return &stock, nil
 }
+
+func (r *stockRepository) FindByTicker(ctx context.Context, ticker string, rng domain.TimeRange) ([]*domain.Stock, error) {
+	query := r.db.WithContext(ctx).Where("ticker = ?", ticker)
+	if !rng.From.IsZero() {
+		query = query.Where("time >= ?", rng.From)
+	}
+	if !rng.To.IsZero() {
+		query = query.Where("time < ?", rng.To)
+	}
+
+	var stocks []*domain.Stock
+	err := query.Order("time ASC, id ASC").Find(&stocks).Error
+	return stocks, err
+}
+
+func (r *stockRepository) FindByTickers(ctx context.Context, tickers []string, brokerages []string) ([]*domain.Stock, error) {
+	query := r.db.WithContext(ctx).Where("ticker IN ?", tickers)
+	if len(brokerages) > 0 {
+		query = query.Where("brokerage IN ?", brokerages)
+	}
+
+	var stocks []*domain.Stock
+	err := query.Order("ticker ASC, time DESC, id DESC").Find(&stocks).Error
+	return stocks, err
+}
+
+// applyFilters adds the search and rating filters shared by listings and facets
+func applyFilters(query *gorm.DB, params domain.QueryParams) *gorm.DB {
+	// Combined search: ticker OR company
+	if params.Search != "" {
+		searchTerm := "%" + params.Search + "%"
+		query = query.Where("ticker ILIKE ? OR company ILIKE ?", searchTerm, searchTerm)
+	}
+
+	// Rating filters
+	if params.RatingFrom != "" {
+		query = query.Where("rating_from = ?", params.RatingFrom)
+	}
+
+	if params.RatingTo != "" {
+		query = query.Where("rating_to = ?", params.RatingTo)
+	}
+
+	return query
+}
+
+func facetExpression(name string, bucket domain.Bucket) (string, bool) {
+	switch name {
+	case domain.FacetBrokerage, domain.FacetRatingTo, domain.FacetAction:
+		return name, true
+	case domain.FacetTime:
+		if bucket != domain.BucketDay && bucket != domain.BucketWeek {
+			bucket = domain.BucketMonth
+		}
+		return fmt.Sprintf("date_trunc('%s', time)::DATE::TEXT", bucket), true
+	}
+	return "", false
+}
+
+func (r *stockRepository) FindConsensus(ctx context.Context, tickers []string) (map[string]*domain.Consensus, error) {
+	result := make(map[string]*domain.Consensus, len(tickers))
+	if len(tickers) == 0 {
+		return result, nil
+	}
+
+	ratingValue := RatingValueSQL("rating_to")
+
+	var rows []*domain.Consensus
+	err := r.db.WithContext(ctx).Model(&domain.Stock{}).
+		Select(`ticker,
+			COUNT(DISTINCT brokerage) AS brokerages,
+			COUNT(`+ratingValue+`) AS rated_brokerages,
+			COALESCE(AVG(`+ratingValue+`), 0)::FLOAT AS mean_rating,
+			COALESCE(AVG(NULLIF(target_to, 0)), 0)::FLOAT AS avg_target,
+			COALESCE(MAX(target_to), 0)::FLOAT AS high_target,
+			COALESCE(MIN(NULLIF(target_to, 0)), 0)::FLOAT AS low_target`).
+		Where("ticker IN ?", tickers).
+		Group("ticker").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		row.Rating = domain.ConsensusRating(row.MeanRating)
+		result[row.Ticker] = row
+	}
+	return result, nil
+}
+
+func (r *stockRepository) FindCompanies(ctx context.Context, tickers []string) (map[string]*domain.Company, error) {
+	result := make(map[string]*domain.Company, len(tickers))
+	if len(tickers) == 0 {
+		return result, nil
+	}
+
+	var rows []*domain.Company
+	err := r.db.WithContext(ctx).Model(&domain.Stock{}).
+		Select(`ticker,
+			MAX(company) AS name,
+			COUNT(DISTINCT brokerage) AS brokerages,
+			COUNT(*) AS actions,
+			MIN(time) AS first_action_at,
+			MAX(time) AS last_action_at`).
+		Where("ticker IN ?", tickers).
+		Group("ticker").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.Ticker] = row
+	}
+	return result, nil
+}
@@ -0,0 +1,53 @@
+package interfaces
+
+import (
+	"errors"
+
+	"github.com/bryanriosb/stock-info/internal/stock/application"
+	"github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/shared/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AnomalyHandler struct {
+	anomalies application.AnomalyUseCase
+}
+
+func NewAnomalyHandler(anomalies application.AnomalyUseCase) *AnomalyHandler {
+	return &AnomalyHandler{anomalies: anomalies}
+}
+
+// ListAnomalies pages through the flagged actions, optionally of one kind and ticker
+func (h *AnomalyHandler) ListAnomalies(c *fiber.Ctx) error {
+	params := domain.AnomalyParams{
+		Kind:   c.Query("kind"),
+		Ticker: c.Query("ticker"),
+		Page:   c.QueryInt("page", 1),
+		Limit:  c.QueryInt("limit", 20),
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 20
+	}
+
+	stocks, total, err := h.anomalies.ListAnomalies(c.Context(), params)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAnomaly) {
+			return response.BadRequest(c, err.Error())
+		}
+		return response.InternalError(c, "Failed to fetch anomalies")
+	}
+
+	return response.SuccessWithMeta(c, stocks, newMeta(params.Page, params.Limit, total))
+}
+
+// DetectAnomalies flags anomalies now without waiting for the next sync
+func (h *AnomalyHandler) DetectAnomalies(c *fiber.Ctx) error {
+	run, err := h.anomalies.Detect(c.Context())
+	if err != nil {
+		return response.InternalError(c, "Failed to detect anomalies")
+	}
+	return response.Success(c, run)
+}
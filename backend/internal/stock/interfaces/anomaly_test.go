@@ -0,0 +1,88 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/shared/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock AnomalyUseCase
+type MockAnomalyUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAnomalyUseCase) ListAnomalies(ctx context.Context, params domain.AnomalyParams) ([]*domain.Stock, int64, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.Stock), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAnomalyUseCase) Detect(ctx context.Context) (*domain.AnomalyRun, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AnomalyRun), args.Error(1)
+}
+
+func setupAnomalyApp(handler *AnomalyHandler) *fiber.App {
+	app := fiber.New()
+	app.Get("/stocks/anomalies", handler.ListAnomalies)
+	app.Post("/stocks/anomalies/detect", handler.DetectAnomalies)
+	return app
+}
+
+func TestListAnomalies_Success(t *testing.T) {
+	mockUC := new(MockAnomalyUseCase)
+	app := setupAnomalyApp(NewAnomalyHandler(mockUC))
+
+	mockUC.On("ListAnomalies", mock.Anything, domain.AnomalyParams{Kind: domain.AnomalyRatingJump, Ticker: "AAPL", Page: 2, Limit: 5}).
+		Return([]*domain.Stock{{ID: 7, Ticker: "AAPL", Anomalies: []string{domain.AnomalyRatingJump}}}, int64(6), nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/stocks/anomalies?kind=rating_jump&ticker=AAPL&page=2&limit=5", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result response.Response
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	assert.True(t, result.Success)
+	assert.Equal(t, int64(6), result.Meta.Total)
+	assert.Equal(t, 2, result.Meta.TotalPages)
+	mockUC.AssertExpectations(t)
+}
+
+func TestListAnomalies_InvalidKind(t *testing.T) {
+	mockUC := new(MockAnomalyUseCase)
+	app := setupAnomalyApp(NewAnomalyHandler(mockUC))
+
+	mockUC.On("ListAnomalies", mock.Anything, mock.Anything).Return(nil, int64(0), domain.ErrInvalidAnomaly)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/stocks/anomalies?kind=typo", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestDetectAnomalies(t *testing.T) {
+	mockUC := new(MockAnomalyUseCase)
+	app := setupAnomalyApp(NewAnomalyHandler(mockUC))
+
+	mockUC.On("Detect", mock.Anything).Return(&domain.AnomalyRun{Actions: 10, Flagged: 1}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/stocks/anomalies/detect", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
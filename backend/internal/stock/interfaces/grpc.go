@@ -0,0 +1,234 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/bryanriosb/stock-info/internal/stock/application"
+	"github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/internal/stock/infrastructure"
+	stockinfov1 "github.com/bryanriosb/stock-info/proto/stockinfo/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type GRPCServer struct {
+	stockinfov1.UnimplementedStockServiceServer
+	useCase application.StockUseCase
+}
+
+func NewGRPCServer(useCase application.StockUseCase) *GRPCServer {
+	return &GRPCServer{useCase: useCase}
+}
+
+func (s *GRPCServer) ListStocks(ctx context.Context, req *stockinfov1.ListStocksRequest) (*stockinfov1.ListStocksResponse, error) {
+	params := domain.QueryParams{
+		Page:       int(req.GetPage()),
+		Limit:      int(req.GetLimit()),
+		SortBy:     req.GetSortBy(),
+		SortDir:    req.GetSortDir(),
+		Search:     req.GetSearch(),
+		RatingFrom: req.GetRatingFrom(),
+		RatingTo:   req.GetRatingTo(),
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.Limit < 1 {
+		params.Limit = 20
+	}
+	if params.SortBy == "" {
+		params.SortBy = "id"
+	}
+	if params.SortDir == "" {
+		params.SortDir = "asc"
+	}
+
+	facetNames, err := domain.ParseFacets(strings.Join(req.GetFacets(), ","))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	facetBucket, err := domain.ParseBucket(req.GetFacetBucket(), domain.BucketMonth)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	stocks, total, err := s.useCase.GetStocks(ctx, params)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to fetch stocks")
+	}
+
+	resp := &stockinfov1.ListStocksResponse{
+		Stocks:     make([]*stockinfov1.Stock, 0, len(stocks)),
+		Total:      total,
+		Page:       int32(params.Page),
+		Limit:      int32(params.Limit),
+		TotalPages: int32(math.Ceil(float64(total) / float64(params.Limit))),
+	}
+	for _, stock := range stocks {
+		resp.Stocks = append(resp.Stocks, StockToProto(stock))
+	}
+
+	if len(facetNames) > 0 {
+		facets, err := s.useCase.GetFacets(ctx, params, domain.FacetParams{Names: facetNames, Bucket: facetBucket})
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Failed to fetch stock facets")
+		}
+		resp.Facets = make(map[string]*stockinfov1.FacetCounts, len(facets))
+		for name, counts := range facets {
+			message := &stockinfov1.FacetCounts{}
+			for _, count := range counts {
+				message.Counts = append(message.Counts, &stockinfov1.FacetCount{Value: count.Value, Count: count.Count})
+			}
+			resp.Facets[name] = message
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *GRPCServer) GetStock(ctx context.Context, req *stockinfov1.GetStockRequest) (*stockinfov1.Stock, error) {
+	stock, err := s.useCase.GetStockByID(ctx, req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to fetch stock")
+	}
+	if stock == nil {
+		return nil, status.Error(codes.NotFound, "Stock not found")
+	}
+	return StockToProto(stock), nil
+}
+
+func (s *GRPCServer) GetTimeline(ctx context.Context, req *stockinfov1.GetTimelineRequest) (*stockinfov1.Timeline, error) {
+	rng, err := domain.ParseTimeRange(req.GetFrom(), req.GetTo())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	bucket, err := domain.ParseBucket(req.GetBucket(), "")
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	timeline, total, err := s.useCase.GetTimeline(ctx, req.GetTicker(), domain.TimelineParams{
+		Range:  rng,
+		Bucket: bucket,
+		Page:   int(req.GetPage()),
+		Limit:  int(req.GetLimit()),
+	})
+	if err != nil {
+		if errors.Is(err, application.ErrTickerNotFound) {
+			return nil, status.Error(codes.NotFound, "Ticker not found")
+		}
+		return nil, status.Error(codes.Internal, "Failed to fetch ticker timeline")
+	}
+
+	resp := &stockinfov1.Timeline{
+		Ticker:  timeline.Ticker,
+		Company: timeline.Company,
+		Bucket:  string(timeline.Bucket),
+		Total:   total,
+	}
+	for _, event := range timeline.Events {
+		resp.Events = append(resp.Events, timelineEventToProto(event))
+	}
+	for _, b := range timeline.Buckets {
+		message := &stockinfov1.TimelineBucket{
+			Period:       timestamppb.New(b.Period),
+			EventCount:   int32(b.EventCount),
+			Upgrades:     int32(b.Upgrades),
+			Downgrades:   int32(b.Downgrades),
+			AvgTargetTo:  b.AvgTargetTo,
+			HighTargetTo: b.HighTargetTo,
+			LowTargetTo:  b.LowTargetTo,
+		}
+		for _, event := range b.Events {
+			message.Events = append(message.Events, timelineEventToProto(event))
+		}
+		resp.Buckets = append(resp.Buckets, message)
+	}
+
+	return resp, nil
+}
+
+// SyncStocks streams the same progress events as the SSE endpoint. The sync
+// stops if the client cancels the call.
+func (s *GRPCServer) SyncStocks(_ *stockinfov1.SyncStocksRequest, stream grpc.ServerStreamingServer[stockinfov1.SyncProgress]) error {
+	ctx := stream.Context()
+
+	if err := stream.Send(&stockinfov1.SyncProgress{Status: "starting", Message: "Starting sync..."}); err != nil {
+		return err
+	}
+
+	var sendErr error
+	count, err := s.useCase.SyncStocksWithProgress(ctx, func(p infrastructure.SyncProgress) {
+		if sendErr == nil {
+			sendErr = stream.Send(syncProgressToProto(p))
+		}
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return stream.Send(&stockinfov1.SyncProgress{
+		Current: int32(count),
+		Total:   int32(count),
+		Percent: 100,
+		Status:  "completed",
+		Message: fmt.Sprintf("Synced %d stocks", count),
+	})
+}
+
+// StockToProto converts a stock for the gRPC API
+func StockToProto(stock *domain.Stock) *stockinfov1.Stock {
+	if stock == nil {
+		return nil
+	}
+	return &stockinfov1.Stock{
+		Id:         stock.ID,
+		Ticker:     stock.Ticker,
+		Company:    stock.Company,
+		Brokerage:  stock.Brokerage,
+		Action:     stock.Action,
+		RatingFrom: stock.RatingFrom,
+		RatingTo:   stock.RatingTo,
+		TargetFrom: stock.TargetFrom,
+		TargetTo:   stock.TargetTo,
+		Time:       timestamppb.New(stock.Time),
+	}
+}
+
+func timelineEventToProto(event domain.TimelineEvent) *stockinfov1.TimelineEvent {
+	return &stockinfov1.TimelineEvent{
+		Id:                  event.ID,
+		Time:                timestamppb.New(event.Time),
+		Brokerage:           event.Brokerage,
+		Action:              event.Action,
+		RatingFrom:          event.RatingFrom,
+		RatingTo:            event.RatingTo,
+		RatingChange:        int32(event.RatingChange),
+		TargetFrom:          event.TargetFrom,
+		TargetTo:            event.TargetTo,
+		TargetChange:        event.TargetChange,
+		TargetChangePercent: event.TargetChangePercent,
+	}
+}
+
+func syncProgressToProto(p infrastructure.SyncProgress) *stockinfov1.SyncProgress {
+	return &stockinfov1.SyncProgress{
+		Current: int32(p.Current),
+		Total:   int32(p.Total),
+		Percent: int32(p.Percent),
+		Status:  p.Status,
+		Message: p.Message,
+	}
+}
@@ -0,0 +1,141 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/stock/application"
+	"github.com/bryanriosb/stock-info/internal/stock/domain"
+	"github.com/bryanriosb/stock-info/internal/stock/infrastructure"
+	stockinfov1 "github.com/bryanriosb/stock-info/proto/stockinfo/v1"
+	"github.com/bryanriosb/stock-info/shared/middleware"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testSecret = "test-secret"
+
+func setupTestGRPC(t *testing.T) (stockinfov1.StockServiceClient, *MockStockUseCase) {
+	mockUseCase := new(MockStockUseCase)
+
+	authUnary, authStream := middleware.GRPCAuth(testSecret)
+	server := grpc.NewServer(grpc.UnaryInterceptor(authUnary), grpc.StreamInterceptor(authStream))
+	stockinfov1.RegisterStockServiceServer(server, NewGRPCServer(mockUseCase))
+
+	listener := bufconn.Listen(1024 * 1024)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return stockinfov1.NewStockServiceClient(conn), mockUseCase
+}
+
+func authorized(t *testing.T) context.Context {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "john",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}).SignedString([]byte(testSecret))
+	assert.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestGRPC_RequiresToken(t *testing.T) {
+	client, mockUseCase := setupTestGRPC(t)
+
+	_, err := client.GetStock(context.Background(), &stockinfov1.GetStockRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer invalid")
+	_, err = client.GetStock(ctx, &stockinfov1.GetStockRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	mockUseCase.AssertNotCalled(t, "GetStockByID", mock.Anything, mock.Anything)
+}
+
+func TestGRPC_ListStocks(t *testing.T) {
+	client, mockUseCase := setupTestGRPC(t)
+
+	params := domain.QueryParams{Page: 1, Limit: 20, SortBy: "id", SortDir: "asc", Search: "AAPL"}
+	mockUseCase.On("GetStocks", mock.Anything, params).Return([]*domain.Stock{{ID: 1, Ticker: "AAPL"}}, int64(1), nil)
+	mockUseCase.On("GetFacets", mock.Anything, params, domain.FacetParams{Names: []string{"brokerage"}, Bucket: domain.BucketMonth}).
+		Return(domain.Facets{"brokerage": {{Value: "Goldman Sachs", Count: 1}}}, nil)
+
+	resp, err := client.ListStocks(authorized(t), &stockinfov1.ListStocksRequest{Search: "AAPL", Facets: []string{"brokerage"}})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Stocks, 1)
+	assert.Equal(t, "AAPL", resp.Stocks[0].Ticker)
+	assert.Equal(t, int32(1), resp.TotalPages)
+	assert.Equal(t, "Goldman Sachs", resp.Facets["brokerage"].Counts[0].Value)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGRPC_ListStocks_InvalidFacet(t *testing.T) {
+	client, _ := setupTestGRPC(t)
+
+	_, err := client.ListStocks(authorized(t), &stockinfov1.ListStocksRequest{Facets: []string{"password"}})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPC_GetStock_NotFound(t *testing.T) {
+	client, mockUseCase := setupTestGRPC(t)
+
+	mockUseCase.On("GetStockByID", mock.Anything, int64(999)).Return(nil, nil)
+
+	_, err := client.GetStock(authorized(t), &stockinfov1.GetStockRequest{Id: 999})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPC_GetTimeline_NotFound(t *testing.T) {
+	client, mockUseCase := setupTestGRPC(t)
+
+	mockUseCase.On("GetTimeline", mock.Anything, "NOPE", mock.Anything).Return(nil, int64(0), application.ErrTickerNotFound)
+
+	_, err := client.GetTimeline(authorized(t), &stockinfov1.GetTimelineRequest{Ticker: "NOPE"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPC_SyncStocks_StreamsProgress(t *testing.T) {
+	client, mockUseCase := setupTestGRPC(t)
+
+	mockUseCase.On("SyncStocksWithProgress", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			onProgress := args.Get(1).(infrastructure.ProgressCallback)
+			onProgress(infrastructure.SyncProgress{Current: 1, Total: 2, Percent: 50, Status: "fetching"})
+		}).
+		Return(2, nil)
+
+	stream, err := client.SyncStocks(authorized(t), &stockinfov1.SyncStocksRequest{})
+	assert.NoError(t, err)
+
+	var statuses []string
+	for {
+		progress, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		statuses = append(statuses, progress.Status)
+	}
+
+	assert.Equal(t, []string{"starting", "fetching", "completed"}, statuses)
+}
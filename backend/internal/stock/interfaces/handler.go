@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"time"
 
@@ -24,6 +26,12 @@ func NewHandler(useCase application.StockUseCase) *Handler {
 	return &Handler{useCase: useCase}
 }
 
+// LookupRequest asks for the latest actions on a list of tickers
+type LookupRequest struct {
+	Tickers    []string `json:"tickers"`
+	Brokerages []string `json:"brokerages,omitempty"`
+}
+
 func (h *Handler) GetStocks(c *fiber.Ctx) error {
 	params := domain.QueryParams{
 		Page:    c.QueryInt("page", 1),
@@ -43,22 +51,57 @@ func (h *Handler) GetStocks(c *fiber.Ctx) error {
 		params.RatingTo = ratingTo
 	}
 
+	fields, err := domain.ParseFields(c.Query("fields"))
+	if err != nil {
+		return response.BadRequest(c, "Invalid fields: use stock attributes such as ticker,target_to,rating_to")
+	}
+	includes, err := domain.ParseIncludes(c.Query("include"))
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	params.Fields = selectColumns(fields, includes)
+
+	facetNames, err := domain.ParseFacets(c.Query("facets"))
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	facetBucket, err := domain.ParseBucket(c.Query("facet_bucket"), domain.BucketMonth)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
 	stocks, total, err := h.useCase.GetStocks(c.Context(), params)
 	if err != nil {
 		return response.InternalError(c, "Failed to fetch stocks")
 	}
 
-	totalPages := int(total) / params.Limit
-	if int(total)%params.Limit > 0 {
-		totalPages++
+	var data interface{} = stocks
+	if len(fields) > 0 || len(includes) > 0 {
+		var included *domain.Includes
+		if len(includes) > 0 {
+			if included, err = h.useCase.GetIncludes(c.Context(), stocks, includes); err != nil {
+				return response.InternalError(c, "Failed to fetch stock relations")
+			}
+		}
+
+		projected := make([]map[string]interface{}, 0, len(stocks))
+		for _, stock := range stocks {
+			projected = append(projected, stock.Project(fields, included))
+		}
+		data = projected
 	}
 
-	return response.SuccessWithMeta(c, stocks, &response.Meta{
-		Page:       params.Page,
-		Limit:      params.Limit,
-		Total:      total,
-		TotalPages: totalPages,
-	})
+	meta := newMeta(params.Page, params.Limit, total)
+	if len(facetNames) == 0 {
+		return response.SuccessWithMeta(c, data, meta)
+	}
+
+	facets, err := h.useCase.GetFacets(c.Context(), params, domain.FacetParams{Names: facetNames, Bucket: facetBucket})
+	if err != nil {
+		return response.InternalError(c, "Failed to fetch stock facets")
+	}
+
+	return response.SuccessWithFacets(c, data, meta, facets)
 }
 
 func (h *Handler) GetStockByID(c *fiber.Ctx) error {
@@ -79,6 +122,54 @@ func (h *Handler) GetStockByID(c *fiber.Ctx) error {
 	return response.Success(c, stock)
 }
 
+func (h *Handler) GetTimeline(c *fiber.Ctx) error {
+	rng, err := domain.ParseTimeRange(c.Query("from"), c.Query("to"))
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	bucket, err := domain.ParseBucket(c.Query("bucket"), "")
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	params := domain.TimelineParams{
+		Range:  rng,
+		Bucket: bucket,
+		Page:   c.QueryInt("page", 1),
+		Limit:  c.QueryInt("limit", 50),
+	}
+
+	timeline, total, err := h.useCase.GetTimeline(c.Context(), c.Params("symbol"), params)
+	if err != nil {
+		if errors.Is(err, application.ErrTickerNotFound) {
+			return response.NotFound(c, "Ticker not found")
+		}
+		return response.InternalError(c, "Failed to fetch ticker timeline")
+	}
+
+	params = params.Normalized()
+	return response.SuccessWithMeta(c, timeline, newMeta(params.Page, params.Limit, total))
+}
+
+// LookupStocks returns the latest actions of up to 500 tickers in one request
+func (h *Handler) LookupStocks(c *fiber.Ctx) error {
+	var req LookupRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body")
+	}
+
+	result, err := h.useCase.LookupStocks(c.Context(), domain.LookupParams{Tickers: req.Tickers, Brokerages: req.Brokerages})
+	if err != nil {
+		if errors.Is(err, domain.ErrNoLookupTickers) || errors.Is(err, domain.ErrEmptyLookupTicker) || errors.Is(err, domain.ErrTooManyTickers) {
+			return response.BadRequest(c, err.Error())
+		}
+		return response.InternalError(c, "Failed to look up stocks")
+	}
+
+	return response.Success(c, result)
+}
+
 // SyncStocksStream handles SSE streaming for stock sync with progress
 func (h *Handler) SyncStocksStream(c *fiber.Ctx) error {
 	c.Set("Content-Type", "text/event-stream")
@@ -162,6 +253,47 @@ func (h *Handler) SyncStocksStream(c *fiber.Ctx) error {
 	return nil
 }
 
+// selectColumns returns the columns to load for a sparse fieldset; relations
+// and prices are keyed by ticker, so it is loaded even when not requested, and
+// the price fields are not columns
+func selectColumns(fields, includes []string) []string {
+	if len(fields) == 0 {
+		return fields
+	}
+
+	columns := make([]string, 0, len(fields)+2)
+	priced := false
+	for _, field := range fields {
+		if domain.IsPriceField(field) {
+			priced = true
+			continue
+		}
+		columns = append(columns, field)
+	}
+	if (len(includes) > 0 || priced) && !slices.Contains(columns, "ticker") {
+		columns = append(columns, "ticker")
+	}
+	// The upside is computed from the target
+	if priced && !slices.Contains(columns, "target_to") {
+		columns = append(columns, "target_to")
+	}
+	return columns
+}
+
+func newMeta(page, limit int, total int64) *response.Meta {
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &response.Meta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
+
 func mustJSON(v interface{}) string {
 	data, err := json.Marshal(v)
 	if err != nil {
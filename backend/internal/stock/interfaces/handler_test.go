@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/bryanriosb/stock-info/internal/stock/application"
 	"github.com/bryanriosb/stock-info/internal/stock/domain"
 	"github.com/bryanriosb/stock-info/internal/stock/infrastructure"
 	"github.com/bryanriosb/stock-info/shared/response"
@@ -38,6 +40,22 @@ func (m *MockStockUseCase) GetStocks(ctx context.Context, params domain.QueryPar
 	return args.Get(0).([]*domain.Stock), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockStockUseCase) GetFacets(ctx context.Context, params domain.QueryParams, facets domain.FacetParams) (domain.Facets, error) {
+	args := m.Called(ctx, params, facets)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.Facets), args.Error(1)
+}
+
+func (m *MockStockUseCase) GetIncludes(ctx context.Context, stocks []*domain.Stock, includes []string) (*domain.Includes, error) {
+	args := m.Called(ctx, stocks, includes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Includes), args.Error(1)
+}
+
 func (m *MockStockUseCase) GetStockByID(ctx context.Context, id int64) (*domain.Stock, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -46,10 +64,28 @@ func (m *MockStockUseCase) GetStockByID(ctx context.Context, id int64) (*domain.
 	return args.Get(0).(*domain.Stock), args.Error(1)
 }
 
+func (m *MockStockUseCase) GetTimeline(ctx context.Context, ticker string, params domain.TimelineParams) (*domain.Timeline, int64, error) {
+	args := m.Called(ctx, ticker, params)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).(*domain.Timeline), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockStockUseCase) LookupStocks(ctx context.Context, params domain.LookupParams) (*domain.LookupResult, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LookupResult), args.Error(1)
+}
+
 func setupTestApp(handler *Handler) *fiber.App {
 	app := fiber.New()
 	app.Get("/stocks", handler.GetStocks)
 	app.Get("/stocks/:id", handler.GetStockByID)
+	app.Get("/tickers/:symbol/timeline", handler.GetTimeline)
+	app.Post("/stocks/lookup", handler.LookupStocks)
 	// Note: SyncStocksStream is SSE and tested separately
 	return app
 }
@@ -226,6 +262,135 @@ func TestGetStocks_Error(t *testing.T) {
 	mockUC.AssertExpectations(t)
 }
 
+func TestGetStocks_WithFacets(t *testing.T) {
+	mockUC := new(MockStockUseCase)
+	handler := NewHandler(mockUC)
+	app := setupTestApp(handler)
+
+	stocks := []*domain.Stock{{ID: 1, Ticker: "AAPL", Brokerage: "Goldman Sachs", RatingTo: "Buy"}}
+	facets := domain.Facets{
+		domain.FacetBrokerage: {{Value: "Goldman Sachs", Count: 1}},
+		domain.FacetTime:      {{Value: "2024-01-01", Count: 1}},
+	}
+
+	mockUC.On("GetStocks", mock.Anything, mock.AnythingOfType("domain.QueryParams")).Return(stocks, int64(1), nil)
+	mockUC.On("GetFacets", mock.Anything, mock.AnythingOfType("domain.QueryParams"), domain.FacetParams{
+		Names:  []string{domain.FacetBrokerage, domain.FacetTime},
+		Bucket: domain.BucketWeek,
+	}).Return(facets, nil)
+
+	req := httptest.NewRequest("GET", "/stocks?rating_to=Buy&facets=brokerage,time&facet_bucket=week", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Success bool          `json:"success"`
+		Meta    response.Meta `json:"meta"`
+		Facets  domain.Facets `json:"facets"`
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	assert.True(t, result.Success)
+	assert.Equal(t, int64(1), result.Meta.Total)
+	assert.Equal(t, facets, result.Facets)
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetStocks_InvalidFacet(t *testing.T) {
+	mockUC := new(MockStockUseCase)
+	handler := NewHandler(mockUC)
+	app := setupTestApp(handler)
+
+	req := httptest.NewRequest("GET", "/stocks?facets=company", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "GetStocks")
+}
+
+func TestGetStocks_SparseFieldsWithInclude(t *testing.T) {
+	mockUC := new(MockStockUseCase)
+	handler := NewHandler(mockUC)
+	app := setupTestApp(handler)
+
+	stocks := []*domain.Stock{{Ticker: "AAPL", TargetTo: 200, RatingTo: "Buy"}}
+	includes := &domain.Includes{Consensus: map[string]*domain.Consensus{"AAPL": {Ticker: "AAPL", Rating: "Buy"}}}
+
+	mockUC.On("GetStocks", mock.Anything, mock.MatchedBy(func(p domain.QueryParams) bool {
+		return assert.ObjectsAreEqual([]string{"target_to", "rating_to", "ticker"}, p.Fields)
+	})).Return(stocks, int64(1), nil)
+	mockUC.On("GetIncludes", mock.Anything, stocks, []string{domain.IncludeConsensus}).Return(includes, nil)
+
+	req := httptest.NewRequest("GET", "/stocks?fields=target_to,rating_to&include=consensus", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	assert.Len(t, result.Data, 1)
+	assert.ElementsMatch(t, []string{"target_to", "rating_to", "included"}, keys(result.Data[0]))
+	assert.Equal(t, "Buy", result.Data[0]["included"].(map[string]interface{})["consensus"].(map[string]interface{})["rating"])
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetStocks_PriceFields(t *testing.T) {
+	mockUC := new(MockStockUseCase)
+	handler := NewHandler(mockUC)
+	app := setupTestApp(handler)
+
+	price, upside := 200.0, 25.0
+	stocks := []*domain.Stock{{ID: 1, Ticker: "AAPL", TargetTo: 250, LastPrice: &price, UpsidePercent: &upside}}
+
+	// Price fields are not columns; the ticker and target they are computed from are read instead
+	mockUC.On("GetStocks", mock.Anything, mock.MatchedBy(func(p domain.QueryParams) bool {
+		return assert.ObjectsAreEqual([]string{"id", "ticker", "target_to"}, p.Fields)
+	})).Return(stocks, int64(1), nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/stocks?fields=id,upside_percent,last_price", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	assert.Len(t, result.Data, 1)
+	assert.ElementsMatch(t, []string{"id", "upside_percent", "last_price"}, keys(result.Data[0]))
+	assert.Equal(t, 25.0, result.Data[0]["upside_percent"])
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetStocks_InvalidField(t *testing.T) {
+	mockUC := new(MockStockUseCase)
+	handler := NewHandler(mockUC)
+	app := setupTestApp(handler)
+
+	req := httptest.NewRequest("GET", "/stocks?fields=ticker,password", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "GetStocks")
+}
+
+func keys(m map[string]interface{}) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
+
 func TestGetStockByID_Success(t *testing.T) {
 	mockUC := new(MockStockUseCase)
 	handler := NewHandler(mockUC)
@@ -287,6 +452,146 @@ func TestGetStockByID_NotFound(t *testing.T) {
 	mockUC.AssertExpectations(t)
 }
 
+func TestGetTimeline_Success(t *testing.T) {
+	mockUC := new(MockStockUseCase)
+	handler := NewHandler(mockUC)
+	app := setupTestApp(handler)
+
+	timeline := &domain.Timeline{Ticker: "AAPL", Bucket: domain.BucketWeek, Buckets: []domain.TimelineBucket{{EventCount: 1}}}
+	mockUC.On("GetTimeline", mock.Anything, "AAPL", mock.MatchedBy(func(p domain.TimelineParams) bool {
+		return p.Bucket == domain.BucketWeek && !p.Range.From.IsZero() && p.Page == 1 && p.Limit == 50
+	})).Return(timeline, int64(1), nil)
+
+	req := httptest.NewRequest("GET", "/tickers/AAPL/timeline?bucket=week&from=2024-01-01", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result response.Response
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	assert.True(t, result.Success)
+	assert.Equal(t, int64(1), result.Meta.Total)
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetTimeline_LimitOutOfRange(t *testing.T) {
+	mockUC := new(MockStockUseCase)
+	handler := NewHandler(mockUC)
+	app := setupTestApp(handler)
+
+	// The use case bounds the page size; the meta reports the bounded one
+	mockUC.On("GetTimeline", mock.Anything, "AAPL", mock.MatchedBy(func(p domain.TimelineParams) bool {
+		return p.Page == 0 && p.Limit == 1000
+	})).Return(&domain.Timeline{Ticker: "AAPL"}, int64(120), nil)
+
+	req := httptest.NewRequest("GET", "/tickers/AAPL/timeline?page=0&limit=1000", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result response.Response
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	assert.Equal(t, 1, result.Meta.Page)
+	assert.Equal(t, 50, result.Meta.Limit)
+	assert.Equal(t, 3, result.Meta.TotalPages)
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetTimeline_InvalidBucket(t *testing.T) {
+	mockUC := new(MockStockUseCase)
+	handler := NewHandler(mockUC)
+	app := setupTestApp(handler)
+
+	req := httptest.NewRequest("GET", "/tickers/AAPL/timeline?bucket=year", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "GetTimeline")
+}
+
+func TestGetTimeline_NotFound(t *testing.T) {
+	mockUC := new(MockStockUseCase)
+	handler := NewHandler(mockUC)
+	app := setupTestApp(handler)
+
+	mockUC.On("GetTimeline", mock.Anything, "NOPE", mock.Anything).Return(nil, int64(0), application.ErrTickerNotFound)
+
+	req := httptest.NewRequest("GET", "/tickers/NOPE/timeline", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestLookupStocks_Success(t *testing.T) {
+	mockUC := new(MockStockUseCase)
+	handler := NewHandler(mockUC)
+	app := setupTestApp(handler)
+
+	result := &domain.LookupResult{
+		Results:  []*domain.TickerLookup{{Ticker: "AAPL", Company: "Apple Inc.", Ratings: []*domain.Stock{{ID: 1, Ticker: "AAPL"}}}},
+		NotFound: []string{"NOPE"},
+	}
+	mockUC.On("LookupStocks", mock.Anything, domain.LookupParams{
+		Tickers:    []string{"AAPL", "NOPE"},
+		Brokerages: []string{"Goldman Sachs"},
+	}).Return(result, nil)
+
+	body := `{"tickers":["AAPL","NOPE"],"brokerages":["Goldman Sachs"]}`
+	req := httptest.NewRequest("POST", "/stocks/lookup", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var decoded struct {
+		Success bool                `json:"success"`
+		Data    domain.LookupResult `json:"data"`
+	}
+	json.NewDecoder(resp.Body).Decode(&decoded)
+
+	assert.True(t, decoded.Success)
+	assert.Len(t, decoded.Data.Results, 1)
+	assert.Equal(t, []string{"NOPE"}, decoded.Data.NotFound)
+	mockUC.AssertExpectations(t)
+}
+
+func TestLookupStocks_TooManyTickers(t *testing.T) {
+	mockUC := new(MockStockUseCase)
+	handler := NewHandler(mockUC)
+	app := setupTestApp(handler)
+
+	mockUC.On("LookupStocks", mock.Anything, mock.Anything).Return(nil, domain.ErrTooManyTickers)
+
+	req := httptest.NewRequest("POST", "/stocks/lookup", strings.NewReader(`{"tickers":["AAPL"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestLookupStocks_InvalidBody(t *testing.T) {
+	mockUC := new(MockStockUseCase)
+	handler := NewHandler(mockUC)
+	app := setupTestApp(handler)
+
+	req := httptest.NewRequest("POST", "/stocks/lookup", strings.NewReader(`{`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "LookupStocks")
+}
+
 // Note: SyncStocksStream uses SSE (Server-Sent Events) which requires
 // integration tests rather than unit tests. The streaming nature of SSE
 // makes it difficult to test with httptest.
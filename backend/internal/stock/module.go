@@ -1,28 +1,56 @@
 package stock
 
 import (
+	"context"
+
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
 	"github.com/bryanriosb/stock-info/internal/rating/application"
 	"github.com/bryanriosb/stock-info/internal/rating/infrastructure"
 	stockApp "github.com/bryanriosb/stock-info/internal/stock/application"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
 	stockInfra "github.com/bryanriosb/stock-info/internal/stock/infrastructure"
 	"github.com/bryanriosb/stock-info/internal/stock/interfaces"
+	stockinfov1 "github.com/bryanriosb/stock-info/proto/stockinfo/v1"
 	"github.com/bryanriosb/stock-info/shared"
+	"github.com/bryanriosb/stock-info/shared/cache"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/bryanriosb/stock-info/shared/middleware"
 	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
-func Register(app fiber.Router, db *gorm.DB, cfg *shared.Config) {
+// Register mounts the stocks, reported with the latest price of their ticker
+// from prices, and flags anomalies after every sync until ctx is done
+func Register(ctx context.Context, app fiber.Router, db *gorm.DB, cfg *shared.Config, bus *events.Bus, appCache cache.Cache, prices priceDomain.LastPriceSource) stockApp.StockUseCase {
 	// Initialize rating service
 	ratingRepo := infrastructure.NewRatingOptionRepository(db)
 	ratingService := application.NewRatingService(ratingRepo)
 
-	repo := stockInfra.NewStockRepository(db)
+	repo := stockInfra.NewCachedStockRepository(stockInfra.NewStockRepository(db), appCache, cfg.Cache.TTL)
 	apiClient := stockInfra.NewStockAPIClient(cfg.StockAPI)
-	useCase := stockApp.NewStockUseCase(repo, apiClient, ratingService)
+	useCase := stockApp.NewStockUseCase(repo, stockInfra.NewActionHistoryRepository(db), apiClient, ratingService, bus, prices)
 	handler := interfaces.NewHandler(useCase)
 
+	// Anomalies are flagged again after every sync
+	detector := stockApp.NewAnomalyDetector(repo, stockInfra.NewAnomalyRepository(db), bus, stockDomain.DefaultAnomalyThresholds)
+	bus.Subscribe(events.SyncCompleted, func(events.Event) { detector.Trigger() })
+	detector.Start(ctx)
+	anomalyHandler := interfaces.NewAnomalyHandler(detector)
+
 	group := app.Group("/stocks")
 	group.Get("/", handler.GetStocks)
+	group.Post("/lookup", handler.LookupStocks)
+	group.Get("/anomalies", anomalyHandler.ListAnomalies)
+	group.Post("/anomalies/detect", middleware.RequireAdmin(), anomalyHandler.DetectAnomalies)
 	group.Get("/sync-stream", handler.SyncStocksStream) // SSE endpoint - must be before :id
 	group.Get("/:id", handler.GetStockByID)
+
+	app.Get("/tickers/:symbol/timeline", handler.GetTimeline)
+
+	return useCase
+}
+
+func RegisterGRPC(server grpc.ServiceRegistrar, useCase stockApp.StockUseCase) {
+	stockinfov1.RegisterStockServiceServer(server, interfaces.NewGRPCServer(useCase))
 }
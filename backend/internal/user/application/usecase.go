@@ -18,6 +18,8 @@ type UserUseCase interface {
 	Create(ctx context.Context, req CreateUserRequest) (*domain.User, error)
 	GetByID(ctx context.Context, id int64) (*domain.User, error)
 	GetAll(ctx context.Context) ([]*domain.User, error)
+	// GetPage returns a page of users ordered by id
+	GetPage(ctx context.Context, page, limit int) ([]*domain.User, error)
 	Update(ctx context.Context, id int64, req UpdateUserRequest) (*domain.User, error)
 	Delete(ctx context.Context, id int64) error
 	Authenticate(ctx context.Context, username, password string) (*domain.User, error)
@@ -84,6 +86,10 @@ func (uc *userUseCase) GetAll(ctx context.Context) ([]*domain.User, error) {
 	return uc.repo.FindAll(ctx)
 }
 
+func (uc *userUseCase) GetPage(ctx context.Context, page, limit int) ([]*domain.User, error) {
+	return uc.repo.FindPage(ctx, page, limit)
+}
+
 func (uc *userUseCase) Update(ctx context.Context, id int64, req UpdateUserRequest) (*domain.User, error) {
 	user, err := uc.repo.FindByID(ctx, id)
 	if err != nil {
@@ -62,6 +62,14 @@ func (m *MockUserRepository) FindAll(ctx context.Context) ([]*domain.User, error
 	return args.Get(0).([]*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) FindPage(ctx context.Context, page, limit int) ([]*domain.User, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
 func (m *MockUserRepository) CountByRole(ctx context.Context, role domain.Role) (int64, error) {
 	args := m.Called(ctx, role)
 	return args.Get(0).(int64), args.Error(1)
@@ -173,6 +181,20 @@ func TestGetAll_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestGetPage_Success(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+
+	users := []*domain.User{{ID: 3, Username: "user3"}}
+	mockRepo.On("FindPage", mock.Anything, 2, 2).Return(users, nil)
+
+	uc := NewUserUseCase(mockRepo)
+	result, err := uc.GetPage(context.Background(), 2, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, users, result)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestUpdate_Success(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 
@@ -10,5 +10,7 @@ type UserRepository interface {
 	Update(ctx context.Context, user *User) error
 	Delete(ctx context.Context, id int64) error
 	FindAll(ctx context.Context) ([]*User, error)
+	// FindPage returns a page of users ordered by id
+	FindPage(ctx context.Context, page, limit int) ([]*User, error)
 	CountByRole(ctx context.Context, role Role) (int64, error)
 }
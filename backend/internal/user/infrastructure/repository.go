@@ -60,6 +60,16 @@ func (r *userRepository) FindAll(ctx context.Context) ([]*domain.User, error) {
 	return users, err
 }
 
+func (r *userRepository) FindPage(ctx context.Context, page, limit int) ([]*domain.User, error) {
+	var users []*domain.User
+	err := r.db.WithContext(ctx).
+		Order("id ASC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&users).Error
+	return users, err
+}
+
 func (r *userRepository) CountByRole(ctx context.Context, role domain.Role) (int64, error) {
 	var count int64
 	err := r.db.WithContext(ctx).Model(&domain.User{}).Where("role = ?", role).Count(&count).Error
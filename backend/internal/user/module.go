@@ -23,12 +23,14 @@ func RegisterPublicOnly(publicRouter fiber.Router, db *gorm.DB) application.User
 func RegisterProtected(protectedRouter fiber.Router, useCase application.UserUseCase) {
 	handler := interfaces.NewHandler(useCase)
 
-	// Admin-only routes
-	users := protectedRouter.Group("/users", middleware.RequireAdmin())
-	users.Get("/", handler.GetAll)
-	users.Get("/:id", handler.GetByID)
-	users.Put("/:id", handler.Update)
-	users.Delete("/:id", handler.Delete)
+	// Admin-only routes. The check is per route so that modules can mount the
+	// caller's own resources under /users/me.
+	admin := middleware.RequireAdmin()
+	users := protectedRouter.Group("/users")
+	users.Get("/", admin, handler.GetAll)
+	users.Get("/:id", admin, handler.GetByID)
+	users.Put("/:id", admin, handler.Update)
+	users.Delete("/:id", admin, handler.Delete)
 }
 
 // Legacy function for backward compatibility
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/watchlist/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+)
+
+type WatchlistUseCase interface {
+	// List returns the user's watchlist, most recently added first
+	List(ctx context.Context, username string) ([]*domain.Item, error)
+	// Add watches a ticker; watching it again is a no-op
+	Add(ctx context.Context, username, ticker string) (*domain.Item, error)
+	// Remove stops watching a ticker or returns ErrItemNotFound
+	Remove(ctx context.Context, username, ticker string) error
+	// Tickers returns the tickers the user watches
+	Tickers(ctx context.Context, username string) ([]string, error)
+}
+
+type watchlistUseCase struct {
+	repo domain.WatchlistRepository
+	bus  *events.Bus
+	now  func() time.Time
+}
+
+// NewWatchlistUseCase keeps the users' watchlists, announcing every change on bus
+func NewWatchlistUseCase(repo domain.WatchlistRepository, bus *events.Bus) WatchlistUseCase {
+	return &watchlistUseCase{repo: repo, bus: bus, now: time.Now}
+}
+
+func (uc *watchlistUseCase) List(ctx context.Context, username string) ([]*domain.Item, error) {
+	return uc.repo.FindAll(ctx, username)
+}
+
+func (uc *watchlistUseCase) Add(ctx context.Context, username, ticker string) (*domain.Item, error) {
+	ticker, err := normalizeTicker(ticker)
+	if err != nil {
+		return nil, err
+	}
+	count, err := uc.repo.Count(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if count >= domain.MaxItems {
+		return nil, domain.ErrWatchlistFull
+	}
+
+	item := &domain.Item{Username: username, Ticker: ticker, AddedAt: uc.now().UTC()}
+	if err := uc.repo.Add(ctx, item); err != nil {
+		return nil, err
+	}
+	uc.bus.Publish(events.WatchlistChanged, username)
+	return item, nil
+}
+
+func (uc *watchlistUseCase) Remove(ctx context.Context, username, ticker string) error {
+	ticker, err := normalizeTicker(ticker)
+	if err != nil {
+		return err
+	}
+	removed, err := uc.repo.Remove(ctx, username, ticker)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return domain.ErrItemNotFound
+	}
+	uc.bus.Publish(events.WatchlistChanged, username)
+	return nil
+}
+
+func (uc *watchlistUseCase) Tickers(ctx context.Context, username string) ([]string, error) {
+	items, err := uc.repo.FindAll(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	tickers := make([]string, 0, len(items))
+	for _, item := range items {
+		tickers = append(tickers, item.Ticker)
+	}
+	return tickers, nil
+}
+
+func normalizeTicker(ticker string) (string, error) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" || len(ticker) > 10 {
+		return "", domain.ErrInvalidTicker
+	}
+	return ticker, nil
+}
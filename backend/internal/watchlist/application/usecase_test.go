@@ -0,0 +1,110 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bryanriosb/stock-info/internal/watchlist/domain"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock WatchlistRepository
+type MockWatchlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWatchlistRepository) FindAll(ctx context.Context, username string) ([]*domain.Item, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Item), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) Count(ctx context.Context, username string) (int64, error) {
+	args := m.Called(ctx, username)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) Add(ctx context.Context, item *domain.Item) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockWatchlistRepository) Remove(ctx context.Context, username, ticker string) (bool, error) {
+	args := m.Called(ctx, username, ticker)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestAdd_NormalizesTickerAndPublishes(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo := new(MockWatchlistRepository)
+	bus := events.NewBus()
+	var changed []interface{}
+	bus.Subscribe(events.WatchlistChanged, func(e events.Event) { changed = append(changed, e.Payload) })
+
+	uc := NewWatchlistUseCase(mockRepo, bus).(*watchlistUseCase)
+	uc.now = func() time.Time { return now }
+	expected := &domain.Item{Username: "alice", Ticker: "AAPL", AddedAt: now}
+	mockRepo.On("Count", mock.Anything, "alice").Return(int64(3), nil)
+	mockRepo.On("Add", mock.Anything, expected).Return(nil)
+
+	item, err := uc.Add(context.Background(), "alice", " aapl ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, item)
+	assert.Equal(t, []interface{}{"alice"}, changed)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAdd_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		ticker string
+		count  int64
+		err    error
+	}{
+		{"empty ticker", "  ", 0, domain.ErrInvalidTicker},
+		{"long ticker", "ABCDEFGHIJK", 0, domain.ErrInvalidTicker},
+		{"full watchlist", "AAPL", domain.MaxItems, domain.ErrWatchlistFull},
+	}
+
+	for _, tt := range tests {
+		mockRepo := new(MockWatchlistRepository)
+		mockRepo.On("Count", mock.Anything, "alice").Return(tt.count, nil)
+		uc := NewWatchlistUseCase(mockRepo, nil)
+
+		_, err := uc.Add(context.Background(), "alice", tt.ticker)
+
+		assert.ErrorIs(t, err, tt.err, tt.name)
+		mockRepo.AssertNotCalled(t, "Add")
+	}
+}
+
+func TestRemove_NotFound(t *testing.T) {
+	mockRepo := new(MockWatchlistRepository)
+	mockRepo.On("Remove", mock.Anything, "alice", "AAPL").Return(false, nil)
+	uc := NewWatchlistUseCase(mockRepo, nil)
+
+	err := uc.Remove(context.Background(), "alice", "aapl")
+
+	assert.ErrorIs(t, err, domain.ErrItemNotFound)
+}
+
+func TestTickers(t *testing.T) {
+	mockRepo := new(MockWatchlistRepository)
+	mockRepo.On("FindAll", mock.Anything, "alice").Return([]*domain.Item{{Ticker: "MSFT"}, {Ticker: "AAPL"}}, nil)
+	mockRepo.On("FindAll", mock.Anything, "bob").Return(nil, errors.New("database error"))
+	uc := NewWatchlistUseCase(mockRepo, nil)
+
+	tickers, err := uc.Tickers(context.Background(), "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"MSFT", "AAPL"}, tickers)
+
+	_, err = uc.Tickers(context.Background(), "bob")
+	assert.Error(t, err)
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxItems bounds the tickers a user can watch
+const MaxItems = 500
+
+var (
+	ErrInvalidTicker = errors.New("ticker must be 1 to 10 characters")
+	ErrItemNotFound  = errors.New("ticker is not on the watchlist")
+	ErrWatchlistFull = fmt.Errorf("a watchlist holds at most %d tickers", MaxItems)
+)
+
+// Item is a ticker a user watches
+type Item struct {
+	Username string    `json:"-" gorm:"primaryKey;size:50"`
+	Ticker   string    `json:"ticker" gorm:"primaryKey;size:10"`
+	AddedAt  time.Time `json:"added_at" gorm:"not null"`
+}
+
+func (Item) TableName() string {
+	return "watchlist_items"
+}
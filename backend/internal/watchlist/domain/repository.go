@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+type WatchlistRepository interface {
+	// FindAll returns the user's items, most recently added first
+	FindAll(ctx context.Context, username string) ([]*Item, error)
+	Count(ctx context.Context, username string) (int64, error)
+	// Add stores the item, keeping the original one when the ticker is already watched
+	Add(ctx context.Context, item *Item) error
+	// Remove deletes the user's item, returning false when there was none
+	Remove(ctx context.Context, username, ticker string) (bool, error)
+}
@@ -0,0 +1,41 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/bryanriosb/stock-info/internal/watchlist/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type watchlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWatchlistRepository(db *gorm.DB) domain.WatchlistRepository {
+	return &watchlistRepository{db: db}
+}
+
+func (r *watchlistRepository) FindAll(ctx context.Context, username string) ([]*domain.Item, error) {
+	var items []*domain.Item
+	err := r.db.WithContext(ctx).
+		Where("username = ?", username).
+		Order("added_at DESC, ticker").
+		Find(&items).Error
+	return items, err
+}
+
+func (r *watchlistRepository) Count(ctx context.Context, username string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.Item{}).Where("username = ?", username).Count(&count).Error
+	return count, err
+}
+
+func (r *watchlistRepository) Add(ctx context.Context, item *domain.Item) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(item).Error
+}
+
+func (r *watchlistRepository) Remove(ctx context.Context, username, ticker string) (bool, error) {
+	result := r.db.WithContext(ctx).Where("username = ? AND ticker = ?", username, ticker).Delete(&domain.Item{})
+	return result.RowsAffected > 0, result.Error
+}
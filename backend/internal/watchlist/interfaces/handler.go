@@ -0,0 +1,57 @@
+package interfaces
+
+import (
+	"errors"
+
+	"github.com/bryanriosb/stock-info/internal/watchlist/application"
+	"github.com/bryanriosb/stock-info/internal/watchlist/domain"
+	"github.com/bryanriosb/stock-info/shared/middleware"
+	"github.com/bryanriosb/stock-info/shared/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	useCase application.WatchlistUseCase
+}
+
+func NewHandler(useCase application.WatchlistUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// GetWatchlist returns the tickers the caller watches
+func (h *Handler) GetWatchlist(c *fiber.Ctx) error {
+	items, err := h.useCase.List(c.Context(), middleware.GetUserFromToken(c))
+	if err != nil {
+		return response.InternalError(c, "Failed to fetch watchlist")
+	}
+
+	return response.Success(c, items)
+}
+
+// AddTicker puts a ticker on the caller's watchlist
+func (h *Handler) AddTicker(c *fiber.Ctx) error {
+	item, err := h.useCase.Add(c.Context(), middleware.GetUserFromToken(c), c.Params("ticker"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTicker) || errors.Is(err, domain.ErrWatchlistFull) {
+			return response.BadRequest(c, err.Error())
+		}
+		return response.InternalError(c, "Failed to add ticker to watchlist")
+	}
+
+	return response.Success(c, item)
+}
+
+// RemoveTicker takes a ticker off the caller's watchlist
+func (h *Handler) RemoveTicker(c *fiber.Ctx) error {
+	if err := h.useCase.Remove(c.Context(), middleware.GetUserFromToken(c), c.Params("ticker")); err != nil {
+		if errors.Is(err, domain.ErrInvalidTicker) {
+			return response.BadRequest(c, err.Error())
+		}
+		if errors.Is(err, domain.ErrItemNotFound) {
+			return response.NotFound(c, "Ticker is not on the watchlist")
+		}
+		return response.InternalError(c, "Failed to remove ticker from watchlist")
+	}
+
+	return response.Success(c, fiber.Map{"message": "Ticker removed from watchlist"})
+}
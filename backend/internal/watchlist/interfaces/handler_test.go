@@ -0,0 +1,105 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bryanriosb/stock-info/internal/watchlist/domain"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock WatchlistUseCase
+type MockWatchlistUseCase struct {
+	mock.Mock
+}
+
+func (m *MockWatchlistUseCase) List(ctx context.Context, username string) ([]*domain.Item, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Item), args.Error(1)
+}
+
+func (m *MockWatchlistUseCase) Add(ctx context.Context, username, ticker string) (*domain.Item, error) {
+	args := m.Called(ctx, username, ticker)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Item), args.Error(1)
+}
+
+func (m *MockWatchlistUseCase) Remove(ctx context.Context, username, ticker string) error {
+	args := m.Called(ctx, username, ticker)
+	return args.Error(0)
+}
+
+func (m *MockWatchlistUseCase) Tickers(ctx context.Context, username string) ([]string, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func setupTestApp(handler *Handler, username string) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{"sub": username}})
+		return c.Next()
+	})
+	app.Get("/users/me/watchlist", handler.GetWatchlist)
+	app.Put("/users/me/watchlist/:ticker", handler.AddTicker)
+	app.Delete("/users/me/watchlist/:ticker", handler.RemoveTicker)
+	return app
+}
+
+func TestGetWatchlist(t *testing.T) {
+	mockUC := new(MockWatchlistUseCase)
+	app := setupTestApp(NewHandler(mockUC), "alice")
+	mockUC.On("List", mock.Anything, "alice").Return([]*domain.Item{{Ticker: "AAPL"}}, nil).Once()
+	mockUC.On("List", mock.Anything, "alice").Return(nil, errors.New("database error")).Once()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/users/me/watchlist", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/users/me/watchlist", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestAddTicker(t *testing.T) {
+	mockUC := new(MockWatchlistUseCase)
+	app := setupTestApp(NewHandler(mockUC), "alice")
+	mockUC.On("Add", mock.Anything, "alice", "aapl").Return(&domain.Item{Ticker: "AAPL"}, nil)
+	mockUC.On("Add", mock.Anything, "alice", "TOOLONGTICKER").Return(nil, domain.ErrInvalidTicker)
+
+	resp, err := app.Test(httptest.NewRequest("PUT", "/users/me/watchlist/aapl", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("PUT", "/users/me/watchlist/TOOLONGTICKER", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRemoveTicker(t *testing.T) {
+	mockUC := new(MockWatchlistUseCase)
+	app := setupTestApp(NewHandler(mockUC), "alice")
+	mockUC.On("Remove", mock.Anything, "alice", "AAPL").Return(nil)
+	mockUC.On("Remove", mock.Anything, "alice", "MSFT").Return(domain.ErrItemNotFound)
+
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/users/me/watchlist/AAPL", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("DELETE", "/users/me/watchlist/MSFT", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
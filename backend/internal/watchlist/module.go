@@ -0,0 +1,22 @@
+package watchlist
+
+import (
+	"github.com/bryanriosb/stock-info/internal/watchlist/application"
+	"github.com/bryanriosb/stock-info/internal/watchlist/infrastructure"
+	"github.com/bryanriosb/stock-info/internal/watchlist/interfaces"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Register mounts the callers' watchlists and returns them for other modules
+func Register(app fiber.Router, db *gorm.DB, bus *events.Bus) application.WatchlistUseCase {
+	useCase := application.NewWatchlistUseCase(infrastructure.NewWatchlistRepository(db), bus)
+	handler := interfaces.NewHandler(useCase)
+
+	app.Get("/users/me/watchlist", handler.GetWatchlist)
+	app.Put("/users/me/watchlist/:ticker", handler.AddTicker)
+	app.Delete("/users/me/watchlist/:ticker", handler.RemoveTicker)
+
+	return useCase
+}
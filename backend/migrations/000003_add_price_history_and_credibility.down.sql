@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS brokerage_credibility;
+DROP TABLE IF EXISTS price_history;
\ No newline at end of file
@@ -0,0 +1,21 @@
+-- Migration: 000003_add_price_history_and_credibility
+-- Description: Daily closing prices and the brokerage credibility learned from them
+
+CREATE TABLE IF NOT EXISTS price_history (
+    ticker STRING(10) NOT NULL,
+    date DATE NOT NULL,
+    close DECIMAL(12,4) NOT NULL,
+    PRIMARY KEY (ticker, date)
+);
+
+CREATE TABLE IF NOT EXISTS brokerage_credibility (
+    brokerage STRING(255) PRIMARY KEY,
+    score FLOAT8 NOT NULL,
+    weight FLOAT8 NOT NULL,
+    hit_rate FLOAT8,
+    target_accuracy FLOAT8,
+    avg_return_percent FLOAT8,
+    calls INT8,
+    targets INT8,
+    computed_at TIMESTAMP NOT NULL
+);
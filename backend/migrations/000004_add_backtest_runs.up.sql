@@ -0,0 +1,22 @@
+-- Migration: 000004_add_backtest_runs
+-- Description: Persisted backtests of recommendation strategies
+
+CREATE TABLE IF NOT EXISTS backtest_runs (
+    id INT8 PRIMARY KEY DEFAULT unique_rowid(),
+    status STRING(20) NOT NULL,
+    strategy STRING(100) NOT NULL,
+    config JSONB NOT NULL,
+    rebalances INT8,
+    evaluated INT8,
+    hit_rate FLOAT8,
+    avg_return_percent FLOAT8,
+    total_return_percent FLOAT8,
+    max_drawdown_percent FLOAT8,
+    turnover FLOAT8,
+    periods JSONB,
+    error STRING,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_backtest_runs_strategy ON backtest_runs (strategy);
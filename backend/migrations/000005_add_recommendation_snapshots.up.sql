@@ -0,0 +1,14 @@
+-- Migration: 000005_add_recommendation_snapshots
+-- Description: Ranked recommendation lists kept after every sync and on a schedule
+
+CREATE TABLE IF NOT EXISTS recommendation_snapshots (
+    id INT8 PRIMARY KEY DEFAULT unique_rowid(),
+    taken_at TIMESTAMP NOT NULL,
+    trigger STRING(20) NOT NULL,
+    strategy STRING(100) NOT NULL,
+    aggregation STRING(20) NOT NULL,
+    size INT8 NOT NULL,
+    entries JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_recommendation_snapshots_taken_at ON recommendation_snapshots (taken_at);
@@ -0,0 +1,10 @@
+-- Migration: 000006_add_scoring_profiles
+-- Description: Per-user default strategy, parameters and rating scale for recommendations
+
+CREATE TABLE IF NOT EXISTS scoring_profiles (
+    username STRING(50) PRIMARY KEY,
+    strategy STRING(100) NOT NULL,
+    parameters JSONB,
+    rating_scale JSONB,
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
@@ -0,0 +1,9 @@
+-- Migration: 000007_add_watchlist
+-- Description: Tickers each user watches, which recommendations can leave out
+
+CREATE TABLE IF NOT EXISTS watchlist_items (
+    username STRING(50) NOT NULL,
+    ticker STRING(10) NOT NULL,
+    added_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (username, ticker)
+);
@@ -0,0 +1,15 @@
+-- Migration: 000008_add_scoring_rules
+-- Description: User-written scoring expressions, selectable as rule:<id> strategies
+
+CREATE TABLE IF NOT EXISTS scoring_rules (
+    id INT8 PRIMARY KEY DEFAULT unique_rowid(),
+    owner STRING(50) NOT NULL,
+    name STRING(100) NOT NULL,
+    description STRING,
+    expression STRING NOT NULL,
+    shared BOOL NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_scoring_rules_owner ON scoring_rules (owner);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS stocks@idx_stocks_anomalies;
+ALTER TABLE stocks DROP COLUMN IF EXISTS anomalies;
@@ -0,0 +1,6 @@
+-- Migration: 000009_add_stock_anomalies
+-- Description: Anomaly flags the detector stores on analyst actions after each sync
+
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS anomalies JSONB;
+
+CREATE INVERTED INDEX IF NOT EXISTS idx_stocks_anomalies ON stocks (anomalies);
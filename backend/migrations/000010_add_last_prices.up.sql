@@ -0,0 +1,9 @@
+-- Migration: 000010_add_last_prices
+-- Description: Latest price of each ticker, read from the price feed
+
+CREATE TABLE IF NOT EXISTS last_prices (
+    ticker STRING(10) PRIMARY KEY,
+    price DECIMAL(12,4) NOT NULL,
+    at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS price_history@idx_price_history_splits;
+ALTER TABLE price_history DROP COLUMN IF EXISTS split_factor;
+ALTER TABLE price_history DROP COLUMN IF EXISTS adj_close;
+ALTER TABLE price_history DROP COLUMN IF EXISTS volume;
+ALTER TABLE price_history DROP COLUMN IF EXISTS low;
+ALTER TABLE price_history DROP COLUMN IF EXISTS high;
+ALTER TABLE price_history DROP COLUMN IF EXISTS open;
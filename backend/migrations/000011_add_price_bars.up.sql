@@ -0,0 +1,11 @@
+-- Migration: 000011_add_price_bars
+-- Description: Open, high, low, volume and split adjustment of the daily prices; 0 where the source gave only the close
+
+ALTER TABLE price_history ADD COLUMN IF NOT EXISTS open DECIMAL(12,4) NOT NULL DEFAULT 0;
+ALTER TABLE price_history ADD COLUMN IF NOT EXISTS high DECIMAL(12,4) NOT NULL DEFAULT 0;
+ALTER TABLE price_history ADD COLUMN IF NOT EXISTS low DECIMAL(12,4) NOT NULL DEFAULT 0;
+ALTER TABLE price_history ADD COLUMN IF NOT EXISTS volume INT8 NOT NULL DEFAULT 0;
+ALTER TABLE price_history ADD COLUMN IF NOT EXISTS adj_close DECIMAL(12,4) NOT NULL DEFAULT 0;
+ALTER TABLE price_history ADD COLUMN IF NOT EXISTS split_factor DECIMAL(12,6) NOT NULL DEFAULT 1;
+
+CREATE INDEX IF NOT EXISTS idx_price_history_splits ON price_history (ticker, date) WHERE split_factor <> 1;
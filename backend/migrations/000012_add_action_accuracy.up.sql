@@ -0,0 +1,24 @@
+-- Migration: 000012_add_action_accuracy
+-- Description: Whether the price reached each analyst target within 3, 6 and 12 months, with the excursions on the way
+
+CREATE TABLE IF NOT EXISTS action_accuracy (
+    stock_id INT8 PRIMARY KEY,
+    ticker STRING(10) NOT NULL,
+    brokerage STRING(255) NOT NULL,
+    time TIMESTAMP NOT NULL,
+    base_close FLOAT8 NOT NULL,
+    target_to FLOAT8 NOT NULL,
+    direction STRING(4) NOT NULL,
+    hit_3m BOOL,
+    hit_6m BOOL,
+    hit_12m BOOL,
+    hit_at DATE,
+    days_to_target INT8,
+    max_favorable_percent FLOAT8 NOT NULL,
+    max_adverse_percent FLOAT8 NOT NULL,
+    priced_through DATE NOT NULL,
+    evaluated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_action_accuracy_ticker ON action_accuracy (ticker);
+CREATE INDEX IF NOT EXISTS idx_action_accuracy_brokerage ON action_accuracy (brokerage);
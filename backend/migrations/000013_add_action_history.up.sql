@@ -0,0 +1,19 @@
+-- Migration: 000013_add_action_history
+-- Description: Every analyst action a sync has seen, as stocks keep only the latest per ticker and brokerage
+
+CREATE TABLE IF NOT EXISTS action_history (
+    id INT8 PRIMARY KEY DEFAULT unique_rowid(),
+    ticker STRING(10) NOT NULL,
+    brokerage STRING(255) NOT NULL,
+    company STRING(255) NOT NULL,
+    action STRING(100),
+    rating_from STRING(50),
+    rating_to STRING(50),
+    target_from DECIMAL(10,2),
+    target_to DECIMAL(10,2),
+    time TIMESTAMP NOT NULL,
+    recorded_at TIMESTAMP DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_action_history_action ON action_history (ticker, brokerage, time);
+
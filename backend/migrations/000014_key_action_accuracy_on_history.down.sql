@@ -0,0 +1,2 @@
+DELETE FROM action_accuracy WHERE true;
+ALTER TABLE action_accuracy RENAME COLUMN action_id TO stock_id;
@@ -0,0 +1,5 @@
+-- Migration: 000014_key_action_accuracy_on_history
+-- Description: Target accuracy scores every action in action_history, keyed by its row; scores keyed by stocks rows are dropped and recomputed by the next run
+
+DELETE FROM action_accuracy WHERE true;
+ALTER TABLE action_accuracy RENAME COLUMN stock_id TO action_id;
@@ -0,0 +1,852 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        (unknown)
+// source: stockinfo/v1/recommendation.proto
+
+package stockinfov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListRecommendationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Limit int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Scoring strategy, balanced when empty
+	Strategy string `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	// Page of the ranking, 1 when unset; pages reach the top 1000 recommendations
+	Page int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	// How brokerage signals are combined per ticker: mean, median or weighted;
+	// the server default when empty
+	Aggregation string `protobuf:"bytes,4,opt,name=aggregation,proto3" json:"aggregation,omitempty"`
+	// Strategy parameter overrides, each within the bounds ListStrategies reports
+	Parameters map[string]float64 `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	// Rating value overrides on the 1-9 scale, by rating label
+	RatingScale map[string]int32 `protobuf:"bytes,6,rep,name=rating_scale,json=ratingScale,proto3" json:"rating_scale,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// Only count the actions of these brokerages
+	Brokerages []string `protobuf:"bytes,7,rep,name=brokerages,proto3" json:"brokerages,omitempty"`
+	// Ignore the actions of these brokerages
+	ExcludeBrokerages []string `protobuf:"bytes,8,rep,name=exclude_brokerages,json=excludeBrokerages,proto3" json:"exclude_brokerages,omitempty"`
+	// Lowest potential gain, in percent
+	MinGain *float64 `protobuf:"fixed64,9,opt,name=min_gain,json=minGain,proto3,oneof" json:"min_gain,omitempty"`
+	// Fewest brokerages whose actions count towards the ticker
+	MinBrokerages int32 `protobuf:"varint,10,opt,name=min_brokerages,json=minBrokerages,proto3" json:"min_brokerages,omitempty"`
+	// Keep tickers whose average rating is buy, hold or sell
+	RatingBucket string `protobuf:"bytes,11,opt,name=rating_bucket,json=ratingBucket,proto3" json:"rating_bucket,omitempty"`
+	// Ignore actions older than this many days
+	MaxAgeDays int32 `protobuf:"varint,12,opt,name=max_age_days,json=maxAgeDays,proto3" json:"max_age_days,omitempty"`
+	// At most this many recommendations led by the same brokerage
+	MaxPerBrokerage int32 `protobuf:"varint,13,opt,name=max_per_brokerage,json=maxPerBrokerage,proto3" json:"max_per_brokerage,omitempty"`
+	// Leave these tickers out
+	ExcludeTickers []string `protobuf:"bytes,14,rep,name=exclude_tickers,json=excludeTickers,proto3" json:"exclude_tickers,omitempty"`
+	// What to do with actions flagged as anomalies: keep (default), downweight or exclude
+	Anomalies     string `protobuf:"bytes,15,opt,name=anomalies,proto3" json:"anomalies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecommendationsRequest) Reset() {
+	*x = ListRecommendationsRequest{}
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecommendationsRequest) ProtoMessage() {}
+
+func (x *ListRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*ListRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_recommendation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListRecommendationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRecommendationsRequest) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *ListRecommendationsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListRecommendationsRequest) GetAggregation() string {
+	if x != nil {
+		return x.Aggregation
+	}
+	return ""
+}
+
+func (x *ListRecommendationsRequest) GetParameters() map[string]float64 {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *ListRecommendationsRequest) GetRatingScale() map[string]int32 {
+	if x != nil {
+		return x.RatingScale
+	}
+	return nil
+}
+
+func (x *ListRecommendationsRequest) GetBrokerages() []string {
+	if x != nil {
+		return x.Brokerages
+	}
+	return nil
+}
+
+func (x *ListRecommendationsRequest) GetExcludeBrokerages() []string {
+	if x != nil {
+		return x.ExcludeBrokerages
+	}
+	return nil
+}
+
+func (x *ListRecommendationsRequest) GetMinGain() float64 {
+	if x != nil && x.MinGain != nil {
+		return *x.MinGain
+	}
+	return 0
+}
+
+func (x *ListRecommendationsRequest) GetMinBrokerages() int32 {
+	if x != nil {
+		return x.MinBrokerages
+	}
+	return 0
+}
+
+func (x *ListRecommendationsRequest) GetRatingBucket() string {
+	if x != nil {
+		return x.RatingBucket
+	}
+	return ""
+}
+
+func (x *ListRecommendationsRequest) GetMaxAgeDays() int32 {
+	if x != nil {
+		return x.MaxAgeDays
+	}
+	return 0
+}
+
+func (x *ListRecommendationsRequest) GetMaxPerBrokerage() int32 {
+	if x != nil {
+		return x.MaxPerBrokerage
+	}
+	return 0
+}
+
+func (x *ListRecommendationsRequest) GetExcludeTickers() []string {
+	if x != nil {
+		return x.ExcludeTickers
+	}
+	return nil
+}
+
+func (x *ListRecommendationsRequest) GetAnomalies() string {
+	if x != nil {
+		return x.Anomalies
+	}
+	return ""
+}
+
+// BrokerageSignal is one brokerage's latest action on a recommended ticker
+type BrokerageSignal struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Brokerage   string                 `protobuf:"bytes,1,opt,name=brokerage,proto3" json:"brokerage,omitempty"`
+	Action      string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	RatingTo    string                 `protobuf:"bytes,3,opt,name=rating_to,json=ratingTo,proto3" json:"rating_to,omitempty"`
+	TargetTo    float64                `protobuf:"fixed64,4,opt,name=target_to,json=targetTo,proto3" json:"target_to,omitempty"`
+	Time        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	Score       float64                `protobuf:"fixed64,6,opt,name=score,proto3" json:"score,omitempty"`
+	DecayWeight float64                `protobuf:"fixed64,7,opt,name=decay_weight,json=decayWeight,proto3" json:"decay_weight,omitempty"`
+	// Learned weight of the brokerage, 1 without a track record
+	Credibility   float64 `protobuf:"fixed64,8,opt,name=credibility,proto3" json:"credibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrokerageSignal) Reset() {
+	*x = BrokerageSignal{}
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrokerageSignal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrokerageSignal) ProtoMessage() {}
+
+func (x *BrokerageSignal) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrokerageSignal.ProtoReflect.Descriptor instead.
+func (*BrokerageSignal) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_recommendation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BrokerageSignal) GetBrokerage() string {
+	if x != nil {
+		return x.Brokerage
+	}
+	return ""
+}
+
+func (x *BrokerageSignal) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *BrokerageSignal) GetRatingTo() string {
+	if x != nil {
+		return x.RatingTo
+	}
+	return ""
+}
+
+func (x *BrokerageSignal) GetTargetTo() float64 {
+	if x != nil {
+		return x.TargetTo
+	}
+	return 0
+}
+
+func (x *BrokerageSignal) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *BrokerageSignal) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *BrokerageSignal) GetDecayWeight() float64 {
+	if x != nil {
+		return x.DecayWeight
+	}
+	return 0
+}
+
+func (x *BrokerageSignal) GetCredibility() float64 {
+	if x != nil {
+		return x.Credibility
+	}
+	return 0
+}
+
+// Recommendation combines every brokerage covering a ticker; stock is its
+// most recent action
+type Recommendation struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Stock                *Stock                 `protobuf:"bytes,1,opt,name=stock,proto3" json:"stock,omitempty"`
+	Score                float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Reason               string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	PotentialGainPercent float64                `protobuf:"fixed64,4,opt,name=potential_gain_percent,json=potentialGainPercent,proto3" json:"potential_gain_percent,omitempty"`
+	Strategy             string                 `protobuf:"bytes,5,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	// Average factor the brokerage scores were weighted with for their age, 1 when fresh
+	DecayWeight float64 `protobuf:"fixed64,6,opt,name=decay_weight,json=decayWeight,proto3" json:"decay_weight,omitempty"`
+	Ticker      string  `protobuf:"bytes,7,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Aggregation string  `protobuf:"bytes,8,opt,name=aggregation,proto3" json:"aggregation,omitempty"`
+	// Share of brokerages whose signal points the same way as the score
+	Agreement float64 `protobuf:"fixed64,9,opt,name=agreement,proto3" json:"agreement,omitempty"`
+	// Agreement discounted for thin coverage: agreement × n/(n+1)
+	Conviction    float64            `protobuf:"fixed64,10,opt,name=conviction,proto3" json:"conviction,omitempty"`
+	Brokerages    []*BrokerageSignal `protobuf:"bytes,11,rep,name=brokerages,proto3" json:"brokerages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Recommendation) Reset() {
+	*x = Recommendation{}
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recommendation) ProtoMessage() {}
+
+func (x *Recommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recommendation.ProtoReflect.Descriptor instead.
+func (*Recommendation) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_recommendation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Recommendation) GetStock() *Stock {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+func (x *Recommendation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Recommendation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Recommendation) GetPotentialGainPercent() float64 {
+	if x != nil {
+		return x.PotentialGainPercent
+	}
+	return 0
+}
+
+func (x *Recommendation) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *Recommendation) GetDecayWeight() float64 {
+	if x != nil {
+		return x.DecayWeight
+	}
+	return 0
+}
+
+func (x *Recommendation) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *Recommendation) GetAggregation() string {
+	if x != nil {
+		return x.Aggregation
+	}
+	return ""
+}
+
+func (x *Recommendation) GetAgreement() float64 {
+	if x != nil {
+		return x.Agreement
+	}
+	return 0
+}
+
+func (x *Recommendation) GetConviction() float64 {
+	if x != nil {
+		return x.Conviction
+	}
+	return 0
+}
+
+func (x *Recommendation) GetBrokerages() []*BrokerageSignal {
+	if x != nil {
+		return x.Brokerages
+	}
+	return nil
+}
+
+type ListRecommendationsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Recommendations []*Recommendation      `protobuf:"bytes,1,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	// Recommendations that can be paged through
+	Total         int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecommendationsResponse) Reset() {
+	*x = ListRecommendationsResponse{}
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecommendationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecommendationsResponse) ProtoMessage() {}
+
+func (x *ListRecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*ListRecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_recommendation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListRecommendationsResponse) GetRecommendations() []*Recommendation {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+func (x *ListRecommendationsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ListStrategiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStrategiesRequest) Reset() {
+	*x = ListStrategiesRequest{}
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStrategiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStrategiesRequest) ProtoMessage() {}
+
+func (x *ListStrategiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStrategiesRequest.ProtoReflect.Descriptor instead.
+func (*ListStrategiesRequest) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_recommendation_proto_rawDescGZIP(), []int{4}
+}
+
+type StrategyParameter struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Default     float64                `protobuf:"fixed64,3,opt,name=default,proto3" json:"default,omitempty"`
+	// Bounds an override must stay within
+	Min           float64 `protobuf:"fixed64,4,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64 `protobuf:"fixed64,5,opt,name=max,proto3" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StrategyParameter) Reset() {
+	*x = StrategyParameter{}
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StrategyParameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StrategyParameter) ProtoMessage() {}
+
+func (x *StrategyParameter) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StrategyParameter.ProtoReflect.Descriptor instead.
+func (*StrategyParameter) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_recommendation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StrategyParameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StrategyParameter) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StrategyParameter) GetDefault() float64 {
+	if x != nil {
+		return x.Default
+	}
+	return 0
+}
+
+func (x *StrategyParameter) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *StrategyParameter) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type Strategy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Parameters    []*StrategyParameter   `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Strategy) Reset() {
+	*x = Strategy{}
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Strategy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Strategy) ProtoMessage() {}
+
+func (x *Strategy) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Strategy.ProtoReflect.Descriptor instead.
+func (*Strategy) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_recommendation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Strategy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Strategy) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Strategy) GetParameters() []*StrategyParameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type ListStrategiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Strategies    []*Strategy            `protobuf:"bytes,1,rep,name=strategies,proto3" json:"strategies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStrategiesResponse) Reset() {
+	*x = ListStrategiesResponse{}
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStrategiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStrategiesResponse) ProtoMessage() {}
+
+func (x *ListStrategiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_recommendation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStrategiesResponse.ProtoReflect.Descriptor instead.
+func (*ListStrategiesResponse) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_recommendation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListStrategiesResponse) GetStrategies() []*Strategy {
+	if x != nil {
+		return x.Strategies
+	}
+	return nil
+}
+
+var File_stockinfo_v1_recommendation_proto protoreflect.FileDescriptor
+
+const file_stockinfo_v1_recommendation_proto_rawDesc = "" +
+	"\n" +
+	"!stockinfo/v1/recommendation.proto\x12\fstockinfo.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18stockinfo/v1/stock.proto\"\x98\x06\n" +
+	"\x1aListRecommendationsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x1a\n" +
+	"\bstrategy\x18\x02 \x01(\tR\bstrategy\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12 \n" +
+	"\vaggregation\x18\x04 \x01(\tR\vaggregation\x12X\n" +
+	"\n" +
+	"parameters\x18\x05 \x03(\v28.stockinfo.v1.ListRecommendationsRequest.ParametersEntryR\n" +
+	"parameters\x12\\\n" +
+	"\frating_scale\x18\x06 \x03(\v29.stockinfo.v1.ListRecommendationsRequest.RatingScaleEntryR\vratingScale\x12\x1e\n" +
+	"\n" +
+	"brokerages\x18\a \x03(\tR\n" +
+	"brokerages\x12-\n" +
+	"\x12exclude_brokerages\x18\b \x03(\tR\x11excludeBrokerages\x12\x1e\n" +
+	"\bmin_gain\x18\t \x01(\x01H\x00R\aminGain\x88\x01\x01\x12%\n" +
+	"\x0emin_brokerages\x18\n" +
+	" \x01(\x05R\rminBrokerages\x12#\n" +
+	"\rrating_bucket\x18\v \x01(\tR\fratingBucket\x12 \n" +
+	"\fmax_age_days\x18\f \x01(\x05R\n" +
+	"maxAgeDays\x12*\n" +
+	"\x11max_per_brokerage\x18\r \x01(\x05R\x0fmaxPerBrokerage\x12'\n" +
+	"\x0fexclude_tickers\x18\x0e \x03(\tR\x0eexcludeTickers\x12\x1c\n" +
+	"\tanomalies\x18\x0f \x01(\tR\tanomalies\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a>\n" +
+	"\x10RatingScaleEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01B\v\n" +
+	"\t_min_gain\"\x8c\x02\n" +
+	"\x0fBrokerageSignal\x12\x1c\n" +
+	"\tbrokerage\x18\x01 \x01(\tR\tbrokerage\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x1b\n" +
+	"\trating_to\x18\x03 \x01(\tR\bratingTo\x12\x1b\n" +
+	"\ttarget_to\x18\x04 \x01(\x01R\btargetTo\x12.\n" +
+	"\x04time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x01R\x05score\x12!\n" +
+	"\fdecay_weight\x18\a \x01(\x01R\vdecayWeight\x12 \n" +
+	"\vcredibility\x18\b \x01(\x01R\vcredibility\"\x95\x03\n" +
+	"\x0eRecommendation\x12)\n" +
+	"\x05stock\x18\x01 \x01(\v2\x13.stockinfo.v1.StockR\x05stock\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x124\n" +
+	"\x16potential_gain_percent\x18\x04 \x01(\x01R\x14potentialGainPercent\x12\x1a\n" +
+	"\bstrategy\x18\x05 \x01(\tR\bstrategy\x12!\n" +
+	"\fdecay_weight\x18\x06 \x01(\x01R\vdecayWeight\x12\x16\n" +
+	"\x06ticker\x18\a \x01(\tR\x06ticker\x12 \n" +
+	"\vaggregation\x18\b \x01(\tR\vaggregation\x12\x1c\n" +
+	"\tagreement\x18\t \x01(\x01R\tagreement\x12\x1e\n" +
+	"\n" +
+	"conviction\x18\n" +
+	" \x01(\x01R\n" +
+	"conviction\x12=\n" +
+	"\n" +
+	"brokerages\x18\v \x03(\v2\x1d.stockinfo.v1.BrokerageSignalR\n" +
+	"brokerages\"{\n" +
+	"\x1bListRecommendationsResponse\x12F\n" +
+	"\x0frecommendations\x18\x01 \x03(\v2\x1c.stockinfo.v1.RecommendationR\x0frecommendations\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\x17\n" +
+	"\x15ListStrategiesRequest\"\x87\x01\n" +
+	"\x11StrategyParameter\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\adefault\x18\x03 \x01(\x01R\adefault\x12\x10\n" +
+	"\x03min\x18\x04 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\x05 \x01(\x01R\x03max\"\x81\x01\n" +
+	"\bStrategy\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12?\n" +
+	"\n" +
+	"parameters\x18\x03 \x03(\v2\x1f.stockinfo.v1.StrategyParameterR\n" +
+	"parameters\"P\n" +
+	"\x16ListStrategiesResponse\x126\n" +
+	"\n" +
+	"strategies\x18\x01 \x03(\v2\x16.stockinfo.v1.StrategyR\n" +
+	"strategies2\xe0\x01\n" +
+	"\x15RecommendationService\x12j\n" +
+	"\x13ListRecommendations\x12(.stockinfo.v1.ListRecommendationsRequest\x1a).stockinfo.v1.ListRecommendationsResponse\x12[\n" +
+	"\x0eListStrategies\x12#.stockinfo.v1.ListStrategiesRequest\x1a$.stockinfo.v1.ListStrategiesResponseBAZ?github.com/bryanriosb/stock-info/proto/stockinfo/v1;stockinfov1b\x06proto3"
+
+var (
+	file_stockinfo_v1_recommendation_proto_rawDescOnce sync.Once
+	file_stockinfo_v1_recommendation_proto_rawDescData []byte
+)
+
+func file_stockinfo_v1_recommendation_proto_rawDescGZIP() []byte {
+	file_stockinfo_v1_recommendation_proto_rawDescOnce.Do(func() {
+		file_stockinfo_v1_recommendation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_stockinfo_v1_recommendation_proto_rawDesc), len(file_stockinfo_v1_recommendation_proto_rawDesc)))
+	})
+	return file_stockinfo_v1_recommendation_proto_rawDescData
+}
+
+var file_stockinfo_v1_recommendation_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_stockinfo_v1_recommendation_proto_goTypes = []any{
+	(*ListRecommendationsRequest)(nil),  // 0: stockinfo.v1.ListRecommendationsRequest
+	(*BrokerageSignal)(nil),             // 1: stockinfo.v1.BrokerageSignal
+	(*Recommendation)(nil),              // 2: stockinfo.v1.Recommendation
+	(*ListRecommendationsResponse)(nil), // 3: stockinfo.v1.ListRecommendationsResponse
+	(*ListStrategiesRequest)(nil),       // 4: stockinfo.v1.ListStrategiesRequest
+	(*StrategyParameter)(nil),           // 5: stockinfo.v1.StrategyParameter
+	(*Strategy)(nil),                    // 6: stockinfo.v1.Strategy
+	(*ListStrategiesResponse)(nil),      // 7: stockinfo.v1.ListStrategiesResponse
+	nil,                                 // 8: stockinfo.v1.ListRecommendationsRequest.ParametersEntry
+	nil,                                 // 9: stockinfo.v1.ListRecommendationsRequest.RatingScaleEntry
+	(*timestamppb.Timestamp)(nil),       // 10: google.protobuf.Timestamp
+	(*Stock)(nil),                       // 11: stockinfo.v1.Stock
+}
+var file_stockinfo_v1_recommendation_proto_depIdxs = []int32{
+	8,  // 0: stockinfo.v1.ListRecommendationsRequest.parameters:type_name -> stockinfo.v1.ListRecommendationsRequest.ParametersEntry
+	9,  // 1: stockinfo.v1.ListRecommendationsRequest.rating_scale:type_name -> stockinfo.v1.ListRecommendationsRequest.RatingScaleEntry
+	10, // 2: stockinfo.v1.BrokerageSignal.time:type_name -> google.protobuf.Timestamp
+	11, // 3: stockinfo.v1.Recommendation.stock:type_name -> stockinfo.v1.Stock
+	1,  // 4: stockinfo.v1.Recommendation.brokerages:type_name -> stockinfo.v1.BrokerageSignal
+	2,  // 5: stockinfo.v1.ListRecommendationsResponse.recommendations:type_name -> stockinfo.v1.Recommendation
+	5,  // 6: stockinfo.v1.Strategy.parameters:type_name -> stockinfo.v1.StrategyParameter
+	6,  // 7: stockinfo.v1.ListStrategiesResponse.strategies:type_name -> stockinfo.v1.Strategy
+	0,  // 8: stockinfo.v1.RecommendationService.ListRecommendations:input_type -> stockinfo.v1.ListRecommendationsRequest
+	4,  // 9: stockinfo.v1.RecommendationService.ListStrategies:input_type -> stockinfo.v1.ListStrategiesRequest
+	3,  // 10: stockinfo.v1.RecommendationService.ListRecommendations:output_type -> stockinfo.v1.ListRecommendationsResponse
+	7,  // 11: stockinfo.v1.RecommendationService.ListStrategies:output_type -> stockinfo.v1.ListStrategiesResponse
+	10, // [10:12] is the sub-list for method output_type
+	8,  // [8:10] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_stockinfo_v1_recommendation_proto_init() }
+func file_stockinfo_v1_recommendation_proto_init() {
+	if File_stockinfo_v1_recommendation_proto != nil {
+		return
+	}
+	file_stockinfo_v1_stock_proto_init()
+	file_stockinfo_v1_recommendation_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stockinfo_v1_recommendation_proto_rawDesc), len(file_stockinfo_v1_recommendation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_stockinfo_v1_recommendation_proto_goTypes,
+		DependencyIndexes: file_stockinfo_v1_recommendation_proto_depIdxs,
+		MessageInfos:      file_stockinfo_v1_recommendation_proto_msgTypes,
+	}.Build()
+	File_stockinfo_v1_recommendation_proto = out.File
+	file_stockinfo_v1_recommendation_proto_goTypes = nil
+	file_stockinfo_v1_recommendation_proto_depIdxs = nil
+}
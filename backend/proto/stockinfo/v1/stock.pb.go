@@ -0,0 +1,1164 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        (unknown)
+// source: stockinfo/v1/stock.proto
+
+package stockinfov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Stock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ticker        string                 `protobuf:"bytes,2,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Company       string                 `protobuf:"bytes,3,opt,name=company,proto3" json:"company,omitempty"`
+	Brokerage     string                 `protobuf:"bytes,4,opt,name=brokerage,proto3" json:"brokerage,omitempty"`
+	Action        string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	RatingFrom    string                 `protobuf:"bytes,6,opt,name=rating_from,json=ratingFrom,proto3" json:"rating_from,omitempty"`
+	RatingTo      string                 `protobuf:"bytes,7,opt,name=rating_to,json=ratingTo,proto3" json:"rating_to,omitempty"`
+	TargetFrom    float64                `protobuf:"fixed64,8,opt,name=target_from,json=targetFrom,proto3" json:"target_from,omitempty"`
+	TargetTo      float64                `protobuf:"fixed64,9,opt,name=target_to,json=targetTo,proto3" json:"target_to,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stock) Reset() {
+	*x = Stock{}
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stock) ProtoMessage() {}
+
+func (x *Stock) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stock.ProtoReflect.Descriptor instead.
+func (*Stock) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_stock_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Stock) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Stock) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *Stock) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *Stock) GetBrokerage() string {
+	if x != nil {
+		return x.Brokerage
+	}
+	return ""
+}
+
+func (x *Stock) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Stock) GetRatingFrom() string {
+	if x != nil {
+		return x.RatingFrom
+	}
+	return ""
+}
+
+func (x *Stock) GetRatingTo() string {
+	if x != nil {
+		return x.RatingTo
+	}
+	return ""
+}
+
+func (x *Stock) GetTargetFrom() float64 {
+	if x != nil {
+		return x.TargetFrom
+	}
+	return 0
+}
+
+func (x *Stock) GetTargetTo() float64 {
+	if x != nil {
+		return x.TargetTo
+	}
+	return 0
+}
+
+func (x *Stock) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type ListStocksRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Page       int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit      int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	SortBy     string                 `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	SortDir    string                 `protobuf:"bytes,4,opt,name=sort_dir,json=sortDir,proto3" json:"sort_dir,omitempty"`
+	Search     string                 `protobuf:"bytes,5,opt,name=search,proto3" json:"search,omitempty"`
+	RatingFrom string                 `protobuf:"bytes,6,opt,name=rating_from,json=ratingFrom,proto3" json:"rating_from,omitempty"`
+	RatingTo   string                 `protobuf:"bytes,7,opt,name=rating_to,json=ratingTo,proto3" json:"rating_to,omitempty"`
+	// Facets to count over the matching stocks: brokerage, rating_to, action, time
+	Facets []string `protobuf:"bytes,8,rep,name=facets,proto3" json:"facets,omitempty"`
+	// Bucket of the time facet: day, week or month
+	FacetBucket   string `protobuf:"bytes,9,opt,name=facet_bucket,json=facetBucket,proto3" json:"facet_bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStocksRequest) Reset() {
+	*x = ListStocksRequest{}
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStocksRequest) ProtoMessage() {}
+
+func (x *ListStocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStocksRequest.ProtoReflect.Descriptor instead.
+func (*ListStocksRequest) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_stock_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListStocksRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListStocksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListStocksRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListStocksRequest) GetSortDir() string {
+	if x != nil {
+		return x.SortDir
+	}
+	return ""
+}
+
+func (x *ListStocksRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListStocksRequest) GetRatingFrom() string {
+	if x != nil {
+		return x.RatingFrom
+	}
+	return ""
+}
+
+func (x *ListStocksRequest) GetRatingTo() string {
+	if x != nil {
+		return x.RatingTo
+	}
+	return ""
+}
+
+func (x *ListStocksRequest) GetFacets() []string {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *ListStocksRequest) GetFacetBucket() string {
+	if x != nil {
+		return x.FacetBucket
+	}
+	return ""
+}
+
+type FacetCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacetCount) Reset() {
+	*x = FacetCount{}
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetCount) ProtoMessage() {}
+
+func (x *FacetCount) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetCount.ProtoReflect.Descriptor instead.
+func (*FacetCount) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_stock_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FacetCount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FacetCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type FacetCounts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        []*FacetCount          `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacetCounts) Reset() {
+	*x = FacetCounts{}
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetCounts) ProtoMessage() {}
+
+func (x *FacetCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetCounts.ProtoReflect.Descriptor instead.
+func (*FacetCounts) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_stock_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FacetCounts) GetCounts() []*FacetCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type ListStocksResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Stocks        []*Stock                `protobuf:"bytes,1,rep,name=stocks,proto3" json:"stocks,omitempty"`
+	Total         int64                   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                   `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                   `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	TotalPages    int32                   `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	Facets        map[string]*FacetCounts `protobuf:"bytes,6,rep,name=facets,proto3" json:"facets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStocksResponse) Reset() {
+	*x = ListStocksResponse{}
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStocksResponse) ProtoMessage() {}
+
+func (x *ListStocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStocksResponse.ProtoReflect.Descriptor instead.
+func (*ListStocksResponse) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_stock_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListStocksResponse) GetStocks() []*Stock {
+	if x != nil {
+		return x.Stocks
+	}
+	return nil
+}
+
+func (x *ListStocksResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListStocksResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListStocksResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListStocksResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *ListStocksResponse) GetFacets() map[string]*FacetCounts {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+type GetStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockRequest) Reset() {
+	*x = GetStockRequest{}
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockRequest) ProtoMessage() {}
+
+func (x *GetStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockRequest.ProtoReflect.Descriptor instead.
+func (*GetStockRequest) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_stock_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetStockRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetTimelineRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Ticker string                 `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	// RFC 3339 or YYYY-MM-DD bounds; a date-only upper bound includes the whole day
+	From string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// day, week or month; empty returns individual events
+	Bucket        string `protobuf:"bytes,4,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Page          int32  `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTimelineRequest) Reset() {
+	*x = GetTimelineRequest{}
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTimelineRequest) ProtoMessage() {}
+
+func (x *GetTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTimelineRequest.ProtoReflect.Descriptor instead.
+func (*GetTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_stock_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetTimelineRequest) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *GetTimelineRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetTimelineRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GetTimelineRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *GetTimelineRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetTimelineRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TimelineEvent struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time                *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Brokerage           string                 `protobuf:"bytes,3,opt,name=brokerage,proto3" json:"brokerage,omitempty"`
+	Action              string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	RatingFrom          string                 `protobuf:"bytes,5,opt,name=rating_from,json=ratingFrom,proto3" json:"rating_from,omitempty"`
+	RatingTo            string                 `protobuf:"bytes,6,opt,name=rating_to,json=ratingTo,proto3" json:"rating_to,omitempty"`
+	RatingChange        int32                  `protobuf:"varint,7,opt,name=rating_change,json=ratingChange,proto3" json:"rating_change,omitempty"`
+	TargetFrom          float64                `protobuf:"fixed64,8,opt,name=target_from,json=targetFrom,proto3" json:"target_from,omitempty"`
+	TargetTo            float64                `protobuf:"fixed64,9,opt,name=target_to,json=targetTo,proto3" json:"target_to,omitempty"`
+	TargetChange        float64                `protobuf:"fixed64,10,opt,name=target_change,json=targetChange,proto3" json:"target_change,omitempty"`
+	TargetChangePercent float64                `protobuf:"fixed64,11,opt,name=target_change_percent,json=targetChangePercent,proto3" json:"target_change_percent,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *TimelineEvent) Reset() {
+	*x = TimelineEvent{}
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimelineEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimelineEvent) ProtoMessage() {}
+
+func (x *TimelineEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimelineEvent.ProtoReflect.Descriptor instead.
+func (*TimelineEvent) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_stock_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TimelineEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TimelineEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *TimelineEvent) GetBrokerage() string {
+	if x != nil {
+		return x.Brokerage
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetRatingFrom() string {
+	if x != nil {
+		return x.RatingFrom
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetRatingTo() string {
+	if x != nil {
+		return x.RatingTo
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetRatingChange() int32 {
+	if x != nil {
+		return x.RatingChange
+	}
+	return 0
+}
+
+func (x *TimelineEvent) GetTargetFrom() float64 {
+	if x != nil {
+		return x.TargetFrom
+	}
+	return 0
+}
+
+func (x *TimelineEvent) GetTargetTo() float64 {
+	if x != nil {
+		return x.TargetTo
+	}
+	return 0
+}
+
+func (x *TimelineEvent) GetTargetChange() float64 {
+	if x != nil {
+		return x.TargetChange
+	}
+	return 0
+}
+
+func (x *TimelineEvent) GetTargetChangePercent() float64 {
+	if x != nil {
+		return x.TargetChangePercent
+	}
+	return 0
+}
+
+type TimelineBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Period        *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	EventCount    int32                  `protobuf:"varint,2,opt,name=event_count,json=eventCount,proto3" json:"event_count,omitempty"`
+	Upgrades      int32                  `protobuf:"varint,3,opt,name=upgrades,proto3" json:"upgrades,omitempty"`
+	Downgrades    int32                  `protobuf:"varint,4,opt,name=downgrades,proto3" json:"downgrades,omitempty"`
+	AvgTargetTo   float64                `protobuf:"fixed64,5,opt,name=avg_target_to,json=avgTargetTo,proto3" json:"avg_target_to,omitempty"`
+	HighTargetTo  float64                `protobuf:"fixed64,6,opt,name=high_target_to,json=highTargetTo,proto3" json:"high_target_to,omitempty"`
+	LowTargetTo   float64                `protobuf:"fixed64,7,opt,name=low_target_to,json=lowTargetTo,proto3" json:"low_target_to,omitempty"`
+	Events        []*TimelineEvent       `protobuf:"bytes,8,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimelineBucket) Reset() {
+	*x = TimelineBucket{}
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimelineBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimelineBucket) ProtoMessage() {}
+
+func (x *TimelineBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimelineBucket.ProtoReflect.Descriptor instead.
+func (*TimelineBucket) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_stock_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TimelineBucket) GetPeriod() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Period
+	}
+	return nil
+}
+
+func (x *TimelineBucket) GetEventCount() int32 {
+	if x != nil {
+		return x.EventCount
+	}
+	return 0
+}
+
+func (x *TimelineBucket) GetUpgrades() int32 {
+	if x != nil {
+		return x.Upgrades
+	}
+	return 0
+}
+
+func (x *TimelineBucket) GetDowngrades() int32 {
+	if x != nil {
+		return x.Downgrades
+	}
+	return 0
+}
+
+func (x *TimelineBucket) GetAvgTargetTo() float64 {
+	if x != nil {
+		return x.AvgTargetTo
+	}
+	return 0
+}
+
+func (x *TimelineBucket) GetHighTargetTo() float64 {
+	if x != nil {
+		return x.HighTargetTo
+	}
+	return 0
+}
+
+func (x *TimelineBucket) GetLowTargetTo() float64 {
+	if x != nil {
+		return x.LowTargetTo
+	}
+	return 0
+}
+
+func (x *TimelineBucket) GetEvents() []*TimelineEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type Timeline struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ticker        string                 `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Company       string                 `protobuf:"bytes,2,opt,name=company,proto3" json:"company,omitempty"`
+	Bucket        string                 `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Events        []*TimelineEvent       `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	Buckets       []*TimelineBucket      `protobuf:"bytes,5,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	Total         int64                  `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Timeline) Reset() {
+	*x = Timeline{}
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Timeline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Timeline) ProtoMessage() {}
+
+func (x *Timeline) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Timeline.ProtoReflect.Descriptor instead.
+func (*Timeline) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_stock_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Timeline) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *Timeline) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *Timeline) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *Timeline) GetEvents() []*TimelineEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *Timeline) GetBuckets() []*TimelineBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *Timeline) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type SyncStocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncStocksRequest) Reset() {
+	*x = SyncStocksRequest{}
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncStocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncStocksRequest) ProtoMessage() {}
+
+func (x *SyncStocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncStocksRequest.ProtoReflect.Descriptor instead.
+func (*SyncStocksRequest) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_stock_proto_rawDescGZIP(), []int{10}
+}
+
+type SyncProgress struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Current int32                  `protobuf:"varint,1,opt,name=current,proto3" json:"current,omitempty"`
+	Total   int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Percent int32                  `protobuf:"varint,3,opt,name=percent,proto3" json:"percent,omitempty"`
+	// starting, fetching, saving, completed or error
+	Status        string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncProgress) Reset() {
+	*x = SyncProgress{}
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncProgress) ProtoMessage() {}
+
+func (x *SyncProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_stockinfo_v1_stock_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncProgress.ProtoReflect.Descriptor instead.
+func (*SyncProgress) Descriptor() ([]byte, []int) {
+	return file_stockinfo_v1_stock_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SyncProgress) GetCurrent() int32 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *SyncProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SyncProgress) GetPercent() int32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *SyncProgress) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SyncProgress) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_stockinfo_v1_stock_proto protoreflect.FileDescriptor
+
+const file_stockinfo_v1_stock_proto_rawDesc = "" +
+	"\n" +
+	"\x18stockinfo/v1/stock.proto\x12\fstockinfo.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xab\x02\n" +
+	"\x05Stock\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06ticker\x18\x02 \x01(\tR\x06ticker\x12\x18\n" +
+	"\acompany\x18\x03 \x01(\tR\acompany\x12\x1c\n" +
+	"\tbrokerage\x18\x04 \x01(\tR\tbrokerage\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x1f\n" +
+	"\vrating_from\x18\x06 \x01(\tR\n" +
+	"ratingFrom\x12\x1b\n" +
+	"\trating_to\x18\a \x01(\tR\bratingTo\x12\x1f\n" +
+	"\vtarget_from\x18\b \x01(\x01R\n" +
+	"targetFrom\x12\x1b\n" +
+	"\ttarget_to\x18\t \x01(\x01R\btargetTo\x12.\n" +
+	"\x04time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"\x82\x02\n" +
+	"\x11ListStocksRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x17\n" +
+	"\asort_by\x18\x03 \x01(\tR\x06sortBy\x12\x19\n" +
+	"\bsort_dir\x18\x04 \x01(\tR\asortDir\x12\x16\n" +
+	"\x06search\x18\x05 \x01(\tR\x06search\x12\x1f\n" +
+	"\vrating_from\x18\x06 \x01(\tR\n" +
+	"ratingFrom\x12\x1b\n" +
+	"\trating_to\x18\a \x01(\tR\bratingTo\x12\x16\n" +
+	"\x06facets\x18\b \x03(\tR\x06facets\x12!\n" +
+	"\ffacet_bucket\x18\t \x01(\tR\vfacetBucket\"8\n" +
+	"\n" +
+	"FacetCount\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"?\n" +
+	"\vFacetCounts\x120\n" +
+	"\x06counts\x18\x01 \x03(\v2\x18.stockinfo.v1.FacetCountR\x06counts\"\xbe\x02\n" +
+	"\x12ListStocksResponse\x12+\n" +
+	"\x06stocks\x18\x01 \x03(\v2\x13.stockinfo.v1.StockR\x06stocks\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vtotal_pages\x18\x05 \x01(\x05R\n" +
+	"totalPages\x12D\n" +
+	"\x06facets\x18\x06 \x03(\v2,.stockinfo.v1.ListStocksResponse.FacetsEntryR\x06facets\x1aT\n" +
+	"\vFacetsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.stockinfo.v1.FacetCountsR\x05value:\x028\x01\"!\n" +
+	"\x0fGetStockRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x92\x01\n" +
+	"\x12GetTimelineRequest\x12\x16\n" +
+	"\x06ticker\x18\x01 \x01(\tR\x06ticker\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x16\n" +
+	"\x06bucket\x18\x04 \x01(\tR\x06bucket\x12\x12\n" +
+	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"\xff\x02\n" +
+	"\rTimelineEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1c\n" +
+	"\tbrokerage\x18\x03 \x01(\tR\tbrokerage\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x1f\n" +
+	"\vrating_from\x18\x05 \x01(\tR\n" +
+	"ratingFrom\x12\x1b\n" +
+	"\trating_to\x18\x06 \x01(\tR\bratingTo\x12#\n" +
+	"\rrating_change\x18\a \x01(\x05R\fratingChange\x12\x1f\n" +
+	"\vtarget_from\x18\b \x01(\x01R\n" +
+	"targetFrom\x12\x1b\n" +
+	"\ttarget_to\x18\t \x01(\x01R\btargetTo\x12#\n" +
+	"\rtarget_change\x18\n" +
+	" \x01(\x01R\ftargetChange\x122\n" +
+	"\x15target_change_percent\x18\v \x01(\x01R\x13targetChangePercent\"\xc4\x02\n" +
+	"\x0eTimelineBucket\x122\n" +
+	"\x06period\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x06period\x12\x1f\n" +
+	"\vevent_count\x18\x02 \x01(\x05R\n" +
+	"eventCount\x12\x1a\n" +
+	"\bupgrades\x18\x03 \x01(\x05R\bupgrades\x12\x1e\n" +
+	"\n" +
+	"downgrades\x18\x04 \x01(\x05R\n" +
+	"downgrades\x12\"\n" +
+	"\ravg_target_to\x18\x05 \x01(\x01R\vavgTargetTo\x12$\n" +
+	"\x0ehigh_target_to\x18\x06 \x01(\x01R\fhighTargetTo\x12\"\n" +
+	"\rlow_target_to\x18\a \x01(\x01R\vlowTargetTo\x123\n" +
+	"\x06events\x18\b \x03(\v2\x1b.stockinfo.v1.TimelineEventR\x06events\"\xd7\x01\n" +
+	"\bTimeline\x12\x16\n" +
+	"\x06ticker\x18\x01 \x01(\tR\x06ticker\x12\x18\n" +
+	"\acompany\x18\x02 \x01(\tR\acompany\x12\x16\n" +
+	"\x06bucket\x18\x03 \x01(\tR\x06bucket\x123\n" +
+	"\x06events\x18\x04 \x03(\v2\x1b.stockinfo.v1.TimelineEventR\x06events\x126\n" +
+	"\abuckets\x18\x05 \x03(\v2\x1c.stockinfo.v1.TimelineBucketR\abuckets\x12\x14\n" +
+	"\x05total\x18\x06 \x01(\x03R\x05total\"\x13\n" +
+	"\x11SyncStocksRequest\"\x8a\x01\n" +
+	"\fSyncProgress\x12\x18\n" +
+	"\acurrent\x18\x01 \x01(\x05R\acurrent\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x18\n" +
+	"\apercent\x18\x03 \x01(\x05R\apercent\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage2\xb5\x02\n" +
+	"\fStockService\x12O\n" +
+	"\n" +
+	"ListStocks\x12\x1f.stockinfo.v1.ListStocksRequest\x1a .stockinfo.v1.ListStocksResponse\x12>\n" +
+	"\bGetStock\x12\x1d.stockinfo.v1.GetStockRequest\x1a\x13.stockinfo.v1.Stock\x12G\n" +
+	"\vGetTimeline\x12 .stockinfo.v1.GetTimelineRequest\x1a\x16.stockinfo.v1.Timeline\x12K\n" +
+	"\n" +
+	"SyncStocks\x12\x1f.stockinfo.v1.SyncStocksRequest\x1a\x1a.stockinfo.v1.SyncProgress0\x01BAZ?github.com/bryanriosb/stock-info/proto/stockinfo/v1;stockinfov1b\x06proto3"
+
+var (
+	file_stockinfo_v1_stock_proto_rawDescOnce sync.Once
+	file_stockinfo_v1_stock_proto_rawDescData []byte
+)
+
+func file_stockinfo_v1_stock_proto_rawDescGZIP() []byte {
+	file_stockinfo_v1_stock_proto_rawDescOnce.Do(func() {
+		file_stockinfo_v1_stock_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_stockinfo_v1_stock_proto_rawDesc), len(file_stockinfo_v1_stock_proto_rawDesc)))
+	})
+	return file_stockinfo_v1_stock_proto_rawDescData
+}
+
+var file_stockinfo_v1_stock_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_stockinfo_v1_stock_proto_goTypes = []any{
+	(*Stock)(nil),                 // 0: stockinfo.v1.Stock
+	(*ListStocksRequest)(nil),     // 1: stockinfo.v1.ListStocksRequest
+	(*FacetCount)(nil),            // 2: stockinfo.v1.FacetCount
+	(*FacetCounts)(nil),           // 3: stockinfo.v1.FacetCounts
+	(*ListStocksResponse)(nil),    // 4: stockinfo.v1.ListStocksResponse
+	(*GetStockRequest)(nil),       // 5: stockinfo.v1.GetStockRequest
+	(*GetTimelineRequest)(nil),    // 6: stockinfo.v1.GetTimelineRequest
+	(*TimelineEvent)(nil),         // 7: stockinfo.v1.TimelineEvent
+	(*TimelineBucket)(nil),        // 8: stockinfo.v1.TimelineBucket
+	(*Timeline)(nil),              // 9: stockinfo.v1.Timeline
+	(*SyncStocksRequest)(nil),     // 10: stockinfo.v1.SyncStocksRequest
+	(*SyncProgress)(nil),          // 11: stockinfo.v1.SyncProgress
+	nil,                           // 12: stockinfo.v1.ListStocksResponse.FacetsEntry
+	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
+}
+var file_stockinfo_v1_stock_proto_depIdxs = []int32{
+	13, // 0: stockinfo.v1.Stock.time:type_name -> google.protobuf.Timestamp
+	2,  // 1: stockinfo.v1.FacetCounts.counts:type_name -> stockinfo.v1.FacetCount
+	0,  // 2: stockinfo.v1.ListStocksResponse.stocks:type_name -> stockinfo.v1.Stock
+	12, // 3: stockinfo.v1.ListStocksResponse.facets:type_name -> stockinfo.v1.ListStocksResponse.FacetsEntry
+	13, // 4: stockinfo.v1.TimelineEvent.time:type_name -> google.protobuf.Timestamp
+	13, // 5: stockinfo.v1.TimelineBucket.period:type_name -> google.protobuf.Timestamp
+	7,  // 6: stockinfo.v1.TimelineBucket.events:type_name -> stockinfo.v1.TimelineEvent
+	7,  // 7: stockinfo.v1.Timeline.events:type_name -> stockinfo.v1.TimelineEvent
+	8,  // 8: stockinfo.v1.Timeline.buckets:type_name -> stockinfo.v1.TimelineBucket
+	3,  // 9: stockinfo.v1.ListStocksResponse.FacetsEntry.value:type_name -> stockinfo.v1.FacetCounts
+	1,  // 10: stockinfo.v1.StockService.ListStocks:input_type -> stockinfo.v1.ListStocksRequest
+	5,  // 11: stockinfo.v1.StockService.GetStock:input_type -> stockinfo.v1.GetStockRequest
+	6,  // 12: stockinfo.v1.StockService.GetTimeline:input_type -> stockinfo.v1.GetTimelineRequest
+	10, // 13: stockinfo.v1.StockService.SyncStocks:input_type -> stockinfo.v1.SyncStocksRequest
+	4,  // 14: stockinfo.v1.StockService.ListStocks:output_type -> stockinfo.v1.ListStocksResponse
+	0,  // 15: stockinfo.v1.StockService.GetStock:output_type -> stockinfo.v1.Stock
+	9,  // 16: stockinfo.v1.StockService.GetTimeline:output_type -> stockinfo.v1.Timeline
+	11, // 17: stockinfo.v1.StockService.SyncStocks:output_type -> stockinfo.v1.SyncProgress
+	14, // [14:18] is the sub-list for method output_type
+	10, // [10:14] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_stockinfo_v1_stock_proto_init() }
+func file_stockinfo_v1_stock_proto_init() {
+	if File_stockinfo_v1_stock_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stockinfo_v1_stock_proto_rawDesc), len(file_stockinfo_v1_stock_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_stockinfo_v1_stock_proto_goTypes,
+		DependencyIndexes: file_stockinfo_v1_stock_proto_depIdxs,
+		MessageInfos:      file_stockinfo_v1_stock_proto_msgTypes,
+	}.Build()
+	File_stockinfo_v1_stock_proto = out.File
+	file_stockinfo_v1_stock_proto_goTypes = nil
+	file_stockinfo_v1_stock_proto_depIdxs = nil
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// Cache stores opaque values by key. Values are byte slices and invalidation
+// works by key prefix, so a Redis-compatible backend can implement it with
+// GET/SET EX/SCAN+DEL.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// DeletePrefix removes every key starting with prefix; an empty prefix clears the cache
+	DeletePrefix(ctx context.Context, prefix string) error
+	Stats() Stats
+}
+
+type Stats struct {
+	Hits          uint64  `json:"hits"`
+	Misses        uint64  `json:"misses"`
+	HitRatio      float64 `json:"hit_ratio"`
+	Sets          uint64  `json:"sets"`
+	Evictions     uint64  `json:"evictions"`
+	Invalidations uint64  `json:"invalidations"`
+	Entries       int     `json:"entries"`
+	Capacity      int     `json:"capacity"`
+}
+
+// counters is embedded by implementations to track Stats
+type counters struct {
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	sets          atomic.Uint64
+	evictions     atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+func (c *counters) snapshot(entries, capacity int) Stats {
+	stats := Stats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Sets:          c.sets.Load(),
+		Evictions:     c.evictions.Load(),
+		Invalidations: c.invalidations.Load(),
+		Entries:       entries,
+		Capacity:      capacity,
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// GetOrLoad returns the JSON-decoded value cached under key, or calls load and
+// caches its result. Cache failures are logged and fall back to load.
+func GetOrLoad[T any](ctx context.Context, c Cache, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	if c == nil {
+		return load()
+	}
+
+	if data, ok, err := c.Get(ctx, key); err != nil {
+		log.Printf("Cache get %s failed: %v", key, err)
+	} else if ok {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("Cache encode %s failed: %v", key, err)
+		return value, nil
+	}
+	if err := c.Set(ctx, key, data, ttl); err != nil {
+		log.Printf("Cache set %s failed: %v", key, err)
+	}
+
+	return value, nil
+}
+
+// Key builds a cache key from a namespace and the JSON encoding of its parameters
+func Key(namespace string, params interface{}) string {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return namespace
+	}
+	return namespace + string(data)
+}
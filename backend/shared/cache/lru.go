@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// LRU is an in-memory Cache bounded by entry count that evicts the least
+// recently used entry when full
+type LRU struct {
+	counters
+
+	mu         sync.Mutex
+	capacity   int
+	defaultTTL time.Duration
+	items      map[string]*list.Element
+	order      *list.List
+}
+
+// NewLRU creates an in-memory cache; a zero ttl passed to Set uses defaultTTL
+func NewLRU(capacity int, defaultTTL time.Duration) *LRU {
+	if capacity < 1 {
+		capacity = 1000
+	}
+	return &LRU{
+		capacity:   capacity,
+		defaultTTL: defaultTTL,
+		items:      make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (c *LRU) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		c.misses.Add(1)
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		c.removeElement(element)
+		c.misses.Add(1)
+		return nil, false, nil
+	}
+
+	c.order.MoveToFront(element)
+	c.hits.Add(1)
+	return entry.value, true, nil
+}
+
+func (c *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sets.Add(1)
+	if element, ok := c.items[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return nil
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+		c.evictions.Add(1)
+	}
+	return nil
+}
+
+func (c *LRU) DeletePrefix(_ context.Context, prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, element := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			c.removeElement(element)
+			c.invalidations.Add(1)
+		}
+	}
+	return nil
+}
+
+func (c *LRU) Stats() Stats {
+	c.mu.Lock()
+	entries := c.order.Len()
+	c.mu.Unlock()
+	return c.snapshot(entries, c.capacity)
+}
+
+func (c *LRU) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.items, element.Value.(*lruEntry).key)
+}
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU_GetSet(t *testing.T) {
+	c := NewLRU(2, time.Minute)
+	ctx := context.Background()
+
+	_, ok, _ := c.Get(ctx, "a")
+	assert.False(t, ok)
+
+	c.Set(ctx, "a", []byte("1"), 0)
+	value, ok, err := c.Get(ctx, "a")
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, 0.5, stats.HitRatio)
+	assert.Equal(t, 1, stats.Entries)
+}
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU(2, time.Minute)
+	ctx := context.Background()
+
+	c.Set(ctx, "a", []byte("1"), 0)
+	c.Set(ctx, "b", []byte("2"), 0)
+	c.Get(ctx, "a")
+	c.Set(ctx, "c", []byte("3"), 0)
+
+	_, ok, _ := c.Get(ctx, "b")
+	assert.False(t, ok)
+	_, ok, _ = c.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), c.Stats().Evictions)
+}
+
+func TestLRU_Expires(t *testing.T) {
+	c := NewLRU(2, time.Minute)
+	ctx := context.Background()
+
+	c.Set(ctx, "a", []byte("1"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok, _ := c.Get(ctx, "a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Stats().Entries)
+}
+
+func TestLRU_DeletePrefix(t *testing.T) {
+	c := NewLRU(10, time.Minute)
+	ctx := context.Background()
+
+	c.Set(ctx, "stocks:1", []byte("1"), 0)
+	c.Set(ctx, "stocks:2", []byte("2"), 0)
+	c.Set(ctx, "ratings:all", []byte("3"), 0)
+
+	c.DeletePrefix(ctx, "stocks:")
+
+	_, ok, _ := c.Get(ctx, "stocks:1")
+	assert.False(t, ok)
+	_, ok, _ = c.Get(ctx, "ratings:all")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), c.Stats().Invalidations)
+}
+
+func TestGetOrLoad(t *testing.T) {
+	c := NewLRU(10, time.Minute)
+	ctx := context.Background()
+	calls := 0
+	load := func() ([]string, error) {
+		calls++
+		return []string{"AAPL"}, nil
+	}
+
+	first, err := GetOrLoad(ctx, c, "k", 0, load)
+	assert.NoError(t, err)
+	second, err := GetOrLoad(ctx, c, "k", 0, load)
+	assert.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, calls)
+
+	// Errors are not cached
+	_, err = GetOrLoad(ctx, c, "fail", 0, func() ([]string, error) { return nil, errors.New("db down") })
+	assert.Error(t, err)
+	_, ok, _ := c.Get(ctx, "fail")
+	assert.False(t, ok)
+
+	// A nil cache always loads
+	_, err = GetOrLoad[[]string](ctx, nil, "k", 0, load)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
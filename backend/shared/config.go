@@ -2,16 +2,22 @@ package shared
 
 import (
 	"os"
+	"strconv"
 	"time"
 )
 
 type Config struct {
-	Env      string
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	StockAPI StockAPIConfig
-	Admin    AdminConfig
+	Env            string
+	Server         ServerConfig
+	Database       DatabaseConfig
+	JWT            JWTConfig
+	StockAPI       StockAPIConfig
+	Admin          AdminConfig
+	Cache          CacheConfig
+	Recommendation RecommendationConfig
+	Credibility    CredibilityConfig
+	Accuracy       AccuracyConfig
+	Price          PriceConfig
 }
 
 func (c *Config) IsDevelopment() bool {
@@ -29,7 +35,8 @@ type AdminConfig struct {
 }
 
 type ServerConfig struct {
-	Port string
+	Port     string
+	GRPCPort string
 }
 
 type DatabaseConfig struct {
@@ -47,6 +54,40 @@ type JWTConfig struct {
 	RefreshExpiration time.Duration
 }
 
+// CacheConfig sizes the in-memory read cache; a sync invalidates it regardless of TTL
+type CacheConfig struct {
+	Size int
+	TTL  time.Duration
+}
+
+// RecommendationConfig ages analyst actions out of recommendations and schedules their snapshots
+type RecommendationConfig struct {
+	Decay            string        // exponential, step or none
+	HalfLife         time.Duration // exponential decay halves a signal every half-life
+	DecayWindow      time.Duration // step decay keeps full weight for a window, then halves per window
+	MaxAge           time.Duration // older actions are not recommended; 0 keeps every action
+	Aggregation      string        // combines brokerage signals per ticker: mean, median or weighted
+	SnapshotInterval time.Duration // time between scheduled snapshots; 0 snapshots only after syncs
+	SnapshotSize     int           // recommendations kept per snapshot
+}
+
+// CredibilityConfig schedules the brokerage credibility job
+type CredibilityConfig struct {
+	Horizon  time.Duration // how long after an action the price is compared with the call
+	Interval time.Duration // time between background runs; 0 runs only after syncs and price imports
+}
+
+// AccuracyConfig schedules the analyst target accuracy job
+type AccuracyConfig struct {
+	Interval time.Duration // time between background runs; 0 runs only after syncs and price imports
+}
+
+// PriceConfig locates the current price feed and how often it is read
+type PriceConfig struct {
+	Feed         string        // CSV or JSON file path, or http(s) URL; empty disables current prices
+	FeedInterval time.Duration // time between feed reads; 0 reads only on request
+}
+
 type StockAPIConfig struct {
 	URL   string
 	Token string
@@ -56,7 +97,8 @@ func LoadConfig() *Config {
 	return &Config{
 		Env: getEnv("APP_ENV", "development"),
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", "5000"),
+			Port:     getEnv("SERVER_PORT", "5000"),
+			GRPCPort: getEnv("GRPC_PORT", "5001"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -80,6 +122,30 @@ func LoadConfig() *Config {
 			Email:    getEnv("ADMIN_EMAIL", "admin@stockinfo.com"),
 			Password: getEnv("ADMIN_PASSWORD", "admin123"),
 		},
+		Cache: CacheConfig{
+			Size: parseInt(getEnv("CACHE_SIZE", "1000"), 1000),
+			TTL:  parseDuration(getEnv("CACHE_TTL", "10m")),
+		},
+		Recommendation: RecommendationConfig{
+			Decay:            getEnv("RECOMMENDATION_DECAY", "exponential"),
+			HalfLife:         parseDuration(getEnv("RECOMMENDATION_HALF_LIFE", "90d")),
+			DecayWindow:      parseDuration(getEnv("RECOMMENDATION_DECAY_WINDOW", "30d")),
+			MaxAge:           parseDuration(getEnv("RECOMMENDATION_MAX_AGE", "365d")),
+			Aggregation:      getEnv("RECOMMENDATION_AGGREGATION", "weighted"),
+			SnapshotInterval: parseDuration(getEnv("RECOMMENDATION_SNAPSHOT_INTERVAL", "24h")),
+			SnapshotSize:     parseInt(getEnv("RECOMMENDATION_SNAPSHOT_SIZE", "50"), 50),
+		},
+		Credibility: CredibilityConfig{
+			Horizon:  parseDuration(getEnv("CREDIBILITY_HORIZON", "90d")),
+			Interval: parseDuration(getEnv("CREDIBILITY_INTERVAL", "24h")),
+		},
+		Accuracy: AccuracyConfig{
+			Interval: parseDuration(getEnv("ACCURACY_INTERVAL", "24h")),
+		},
+		Price: PriceConfig{
+			Feed:         getEnv("PRICE_FEED", ""),
+			FeedInterval: parseDuration(getEnv("PRICE_FEED_INTERVAL", "15m")),
+		},
 	}
 }
 
@@ -90,6 +156,14 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func parseInt(value string, defaultValue int) int {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+	return n
+}
+
 func parseDuration(value string) time.Duration {
 	duration, err := time.ParseDuration(value)
 	if err != nil {
@@ -0,0 +1,82 @@
+package events
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+type Topic string
+
+const (
+	// SyncCompleted is published after stocks from the external API are saved; the payload is the synced count
+	SyncCompleted Topic = "stock.sync.completed"
+	// PricesImported is published after a price history import; the payload is the imported count
+	PricesImported Topic = "price.history.imported"
+	// CredibilityUpdated is published after brokerage credibility is recomputed; the payload is the brokerage count
+	CredibilityUpdated Topic = "brokerage.credibility.updated"
+	// AccuracyUpdated is published after the target accuracy of analyst actions is rescored; the payload is the scored count
+	AccuracyUpdated Topic = "brokerage.accuracy.updated"
+	// ScoringProfileChanged is published after a user saves or deletes their scoring profile; the payload is the username
+	ScoringProfileChanged Topic = "recommendation.profile.changed"
+	// WatchlistChanged is published after a user adds or removes a watched ticker; the payload is the username
+	WatchlistChanged Topic = "watchlist.changed"
+	// ScoringRuleChanged is published after a scoring rule is updated or deleted; the payload is the rule ID
+	ScoringRuleChanged Topic = "recommendation.rule.changed"
+	// AnomaliesDetected is published after the anomaly flags of analyst actions change; the payload is the flagged count
+	AnomaliesDetected Topic = "stock.anomalies.detected"
+	// LastPricesUpdated is published after the price feed is read; the payload is the updated count
+	LastPricesUpdated Topic = "price.last.updated"
+)
+
+type Event struct {
+	Topic      Topic
+	Payload    interface{}
+	OccurredAt time.Time
+}
+
+// Handler reacts to an event. Handlers run synchronously in the publisher's
+// goroutine, so long-running work should be started in a new goroutine.
+type Handler func(event Event)
+
+// Bus is an in-process publish/subscribe dispatcher used to decouple modules
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[Topic][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[Topic][]Handler)}
+}
+
+func (b *Bus) Subscribe(topic Topic, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[topic] = append(b.handlers[topic], handler)
+}
+
+// Publish dispatches an event to every subscriber of its topic. A nil bus is a no-op.
+func (b *Bus) Publish(topic Topic, payload interface{}) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	handlers := append([]Handler(nil), b.handlers[topic]...)
+	b.mu.RUnlock()
+
+	event := Event{Topic: topic, Payload: payload, OccurredAt: time.Now()}
+	for _, handler := range handlers {
+		dispatch(handler, event)
+	}
+}
+
+// dispatch isolates subscribers so a panicking handler doesn't break the publisher
+func dispatch(handler Handler, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Event handler for %s panicked: %v", event.Topic, r)
+		}
+	}()
+	handler(event)
+}
@@ -0,0 +1,210 @@
+// Package expr compiles and evaluates arithmetic expressions over a fixed set
+// of named numbers. Expressions cannot loop, allocate or call out, and are
+// rejected at compile time when they are longer, deeper or costlier than the
+// limits, so evaluating one takes bounded time.
+package expr
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+var (
+	ErrSyntax      = errors.New("syntax error")
+	ErrUnknownName = errors.New("unknown name")
+	ErrTooComplex  = errors.New("expression too complex")
+	ErrNotFinite   = errors.New("expression evaluated to a non-finite number")
+)
+
+// Limits bound what Compile accepts. Cost counts every operand and operator,
+// with functions weighing their listed cost.
+type Limits struct {
+	MaxLength int
+	MaxDepth  int
+	MaxCost   int
+}
+
+// DefaultLimits fit hand-written scoring formulas with room to spare
+var DefaultLimits = Limits{MaxLength: 1000, MaxDepth: 16, MaxCost: 200}
+
+// Function is a whitelisted function expressions can call
+type Function struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Arity       int    `json:"arity"`
+	Cost        int    `json:"cost"`
+	call        func(args []float64) float64
+}
+
+// Functions lists what expressions can call; if evaluates lazily
+var Functions = []Function{
+	{Name: "abs", Description: "Absolute value", Arity: 1, Cost: 1, call: func(a []float64) float64 { return math.Abs(a[0]) }},
+	{Name: "min", Description: "Smaller of two values", Arity: 2, Cost: 1, call: func(a []float64) float64 { return math.Min(a[0], a[1]) }},
+	{Name: "max", Description: "Larger of two values", Arity: 2, Cost: 1, call: func(a []float64) float64 { return math.Max(a[0], a[1]) }},
+	{Name: "clamp", Description: "clamp(x, low, high) keeps x within low..high", Arity: 3, Cost: 1, call: func(a []float64) float64 { return math.Max(a[1], math.Min(a[0], a[2])) }},
+	{Name: "if", Description: "if(condition, then, else), a non-zero condition is true", Arity: 3, Cost: 1},
+	{Name: "sqrt", Description: "Square root", Arity: 1, Cost: 4, call: func(a []float64) float64 { return math.Sqrt(a[0]) }},
+	{Name: "log", Description: "Natural logarithm", Arity: 1, Cost: 8, call: func(a []float64) float64 { return math.Log(a[0]) }},
+	{Name: "exp", Description: "e raised to the value", Arity: 1, Cost: 8, call: func(a []float64) float64 { return math.Exp(a[0]) }},
+	{Name: "pow", Description: "pow(x, y) raises x to y", Arity: 2, Cost: 8, call: func(a []float64) float64 { return math.Pow(a[0], a[1]) }},
+}
+
+func lookupFunction(name string) (*Function, bool) {
+	for i := range Functions {
+		if Functions[i].Name == name {
+			return &Functions[i], true
+		}
+	}
+	return nil, false
+}
+
+// Program is a compiled expression, safe for concurrent use
+type Program struct {
+	root  *node
+	names []string
+	used  []int
+	cost  int
+}
+
+// Compile parses source over the given variable names. The values passed to
+// Eval are read by position in names.
+func Compile(source string, names []string, limits Limits) (*Program, error) {
+	if len(source) > limits.MaxLength {
+		return nil, errorf(ErrTooComplex, "longer than %d characters", limits.MaxLength)
+	}
+	p := &parser{lexer: lexer{src: source}, names: names, limits: limits, used: make(map[int]bool)}
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	program := &Program{root: root, names: names, cost: p.cost}
+	for index := range p.used {
+		program.used = append(program.used, index)
+	}
+	sort.Ints(program.used)
+	return program, nil
+}
+
+// Cost is the weight the program was admitted with
+func (p *Program) Cost() int {
+	return p.cost
+}
+
+// Variables returns the names the expression reads, in the order they were given to Compile
+func (p *Program) Variables() []string {
+	names := make([]string, 0, len(p.used))
+	for _, index := range p.used {
+		names = append(names, p.names[index])
+	}
+	return names
+}
+
+// Eval computes the expression. Division by zero yields 0; any other
+// non-finite result is an error.
+func (p *Program) Eval(values []float64) (float64, error) {
+	if len(values) < len(p.names) {
+		return 0, errorf(ErrUnknownName, "%d values for %d variables", len(values), len(p.names))
+	}
+	result := p.root.eval(values)
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, ErrNotFinite
+	}
+	return result, nil
+}
+
+type opcode uint8
+
+const (
+	opConst opcode = iota
+	opVar
+	opNeg
+	opNot
+	opAdd
+	opSub
+	opMul
+	opDiv
+	opLess
+	opLessEqual
+	opGreater
+	opGreaterEqual
+	opEqual
+	opNotEqual
+	opAnd
+	opOr
+	opCall
+)
+
+type node struct {
+	op    opcode
+	value float64
+	index int
+	fn    *Function
+	args  []*node
+}
+
+func (n *node) eval(values []float64) float64 {
+	switch n.op {
+	case opConst:
+		return n.value
+	case opVar:
+		return values[n.index]
+	case opNeg:
+		return -n.args[0].eval(values)
+	case opNot:
+		return truth(n.args[0].eval(values) == 0)
+	case opAnd:
+		return truth(n.args[0].eval(values) != 0 && n.args[1].eval(values) != 0)
+	case opOr:
+		return truth(n.args[0].eval(values) != 0 || n.args[1].eval(values) != 0)
+	case opCall:
+		if n.fn.call == nil {
+			// if evaluates only the branch it takes
+			if n.args[0].eval(values) != 0 {
+				return n.args[1].eval(values)
+			}
+			return n.args[2].eval(values)
+		}
+		var buf [3]float64
+		args := buf[:len(n.args)]
+		for i, arg := range n.args {
+			args[i] = arg.eval(values)
+		}
+		return n.fn.call(args)
+	}
+
+	a, b := n.args[0].eval(values), n.args[1].eval(values)
+	switch n.op {
+	case opAdd:
+		return a + b
+	case opSub:
+		return a - b
+	case opMul:
+		return a * b
+	case opDiv:
+		if b == 0 {
+			return 0
+		}
+		return a / b
+	case opLess:
+		return truth(a < b)
+	case opLessEqual:
+		return truth(a <= b)
+	case opGreater:
+		return truth(a > b)
+	case opGreaterEqual:
+		return truth(a >= b)
+	case opEqual:
+		return truth(a == b)
+	default:
+		return truth(a != b)
+	}
+}
+
+func truth(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
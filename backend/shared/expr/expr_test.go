@@ -0,0 +1,90 @@
+package expr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var names = []string{"target_change", "rating_delta", "age_days"}
+
+func TestEval(t *testing.T) {
+	values := []float64{0.2, 2, 60}
+
+	tests := []struct {
+		source   string
+		expected float64
+	}{
+		{"score = 0.5*target_change + 0.5*rating_delta - 0.2*(age_days/30)", 0.7},
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"-rating_delta + +1", -1},
+		{"10 - 4 - 3", 3},
+		{"12 / 3 / 2", 2},
+		{"rating_delta / 0", 0},
+		{"1e-1 * 10", 1},
+		{"age_days > 30 && rating_delta >= 2", 1},
+		{"age_days < 30 || !(rating_delta == 2)", 0},
+		{"rating_delta != 2", 0},
+		{"if(age_days > 90, 0, target_change)", 0.2},
+		{"clamp(rating_delta, -1, 1) + min(1, 2) + max(1, 2) + abs(-1)", 5},
+		{"sqrt(4) + pow(2, 3) + log(exp(1))", 11},
+	}
+
+	for _, tt := range tests {
+		program, err := Compile(tt.source, names, DefaultLimits)
+		if !assert.NoError(t, err, tt.source) {
+			continue
+		}
+		value, err := program.Eval(values)
+		assert.NoError(t, err, tt.source)
+		assert.InDelta(t, tt.expected, value, 1e-9, tt.source)
+	}
+}
+
+func TestCompile_Rejects(t *testing.T) {
+	tests := []struct {
+		source string
+		err    error
+	}{
+		{"", ErrSyntax},
+		{"score =", ErrSyntax},
+		{"1 +", ErrSyntax},
+		{"(1 + 2", ErrSyntax},
+		{"1 2", ErrSyntax},
+		{"target_change = 1", ErrSyntax},
+		{"1.2.3", ErrSyntax},
+		{"rating_delta; drop", ErrSyntax},
+		{"min(1)", ErrSyntax},
+		{"max(1, 2, 3)", ErrSyntax},
+		{"price", ErrUnknownName},
+		{"system(1)", ErrUnknownName},
+		{strings.Repeat("(", 20) + "1" + strings.Repeat(")", 20), ErrTooComplex},
+		{strings.Repeat("-", 20) + "1", ErrTooComplex},
+		{strings.Repeat("exp(1)+", 25) + "1", ErrTooComplex},
+		{strings.Repeat("1+", 150) + "1", ErrTooComplex},
+		{strings.Repeat(" ", 1001), ErrTooComplex},
+	}
+
+	for _, tt := range tests {
+		_, err := Compile(tt.source, names, DefaultLimits)
+		assert.ErrorIs(t, err, tt.err, tt.source)
+	}
+}
+
+func TestEval_NotFinite(t *testing.T) {
+	program, err := Compile("log(0) + sqrt(-1)", names, DefaultLimits)
+	assert.NoError(t, err)
+
+	_, err = program.Eval([]float64{0, 0, 0})
+	assert.ErrorIs(t, err, ErrNotFinite)
+}
+
+func TestProgram_Variables(t *testing.T) {
+	program, err := Compile("age_days + target_change * age_days", names, DefaultLimits)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"target_change", "age_days"}, program.Variables())
+	assert.Equal(t, 5, program.Cost())
+}
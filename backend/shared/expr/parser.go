@@ -0,0 +1,325 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind uint8
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// twoCharOperators are matched before their one character prefixes
+var twoCharOperators = []string{"<=", ">=", "==", "!=", "&&", "||"}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) && unicode.IsSpace(rune(l.src[l.pos])) {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case isDigit(c) || c == '.':
+		for l.pos < len(l.src) && (isDigit(l.src[l.pos]) || l.src[l.pos] == '.') {
+			l.pos++
+		}
+		// Exponents, e.g. 1e-3
+		if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+			l.pos++
+			if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+				l.pos++
+			}
+			for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+				l.pos++
+			}
+		}
+		return token{kind: tokenNumber, text: l.src[start:l.pos], pos: start}, nil
+	case isLetter(c):
+		for l.pos < len(l.src) && (isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokenIdent, text: l.src[start:l.pos], pos: start}, nil
+	}
+
+	for _, op := range twoCharOperators {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			l.pos += 2
+			return token{kind: tokenOperator, text: op, pos: start}, nil
+		}
+	}
+	if strings.IndexByte("+-*/()<>!,=", c) >= 0 {
+		l.pos++
+		return token{kind: tokenOperator, text: string(c), pos: start}, nil
+	}
+	return token{}, errorf(ErrSyntax, "unexpected character %q at %d", c, start)
+}
+
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+func isLetter(c byte) bool { return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+
+var binaryOperators = map[string]opcode{
+	"+": opAdd, "-": opSub, "*": opMul, "/": opDiv,
+	"<": opLess, "<=": opLessEqual, ">": opGreater, ">=": opGreaterEqual, "==": opEqual, "!=": opNotEqual,
+	"&&": opAnd, "||": opOr,
+}
+
+// precedence of the binary operators, loosest first
+var precedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"<", "<=", ">", ">=", "==", "!="},
+	{"+", "-"},
+	{"*", "/"},
+}
+
+// parser is a recursive descent parser that counts the cost and depth of what
+// it builds, stopping as soon as a limit is crossed
+type parser struct {
+	lexer  lexer
+	tok    token
+	names  []string
+	limits Limits
+	used   map[int]bool
+	cost   int
+	depth  int
+}
+
+func (p *parser) parse() (*node, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	// A leading "score =" reads naturally and is optional
+	if p.tok.kind == tokenIdent && p.tok.text == "score" {
+		saved := p.lexer
+		if next, err := p.lexer.next(); err == nil && next.text == "=" {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		} else {
+			p.lexer = saved
+		}
+	}
+	if p.tok.kind == tokenEOF {
+		return nil, errorf(ErrSyntax, "empty expression")
+	}
+
+	root, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.unexpected()
+	}
+	return root, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+// enter accounts for one more node nested in the current one
+func (p *parser) enter(cost int) error {
+	p.cost += cost
+	if p.cost > p.limits.MaxCost {
+		return errorf(ErrTooComplex, "cost above %d", p.limits.MaxCost)
+	}
+	p.depth++
+	if p.depth > p.limits.MaxDepth {
+		return errorf(ErrTooComplex, "nested deeper than %d", p.limits.MaxDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) binary(level int) (*node, error) {
+	if level == len(precedence) {
+		return p.unary()
+	}
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokenOperator && contains(precedence[level], p.tok.text) {
+		op := binaryOperators[p.tok.text]
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if err := p.enter(1); err != nil {
+			return nil, err
+		}
+		right, err := p.binary(level + 1)
+		p.leave()
+		if err != nil {
+			return nil, err
+		}
+		left = &node{op: op, args: []*node{left, right}}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (*node, error) {
+	if p.tok.kind == tokenOperator && (p.tok.text == "-" || p.tok.text == "!" || p.tok.text == "+") {
+		op := p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if err := p.enter(1); err != nil {
+			return nil, err
+		}
+		operand, err := p.unary()
+		p.leave()
+		if err != nil || op == "+" {
+			return operand, err
+		}
+		if op == "-" {
+			return &node{op: opNeg, args: []*node{operand}}, nil
+		}
+		return &node{op: opNot, args: []*node{operand}}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (*node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, errorf(ErrSyntax, "invalid number %q at %d", tok.text, tok.pos)
+		}
+		p.cost++
+		return &node{op: opConst, value: value}, p.advance()
+	case tokenIdent:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokenOperator && p.tok.text == "(" {
+			return p.call(tok)
+		}
+		for i, name := range p.names {
+			if name == tok.text {
+				p.used[i] = true
+				p.cost++
+				return &node{op: opVar, index: i}, nil
+			}
+		}
+		return nil, errorf(ErrUnknownName, "unknown variable %q at %d", tok.text, tok.pos)
+	case tokenOperator:
+		if tok.text == "(" {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if err := p.enter(0); err != nil {
+				return nil, err
+			}
+			inner, err := p.binary(0)
+			p.leave()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		}
+	}
+	return nil, p.unexpected()
+}
+
+func (p *parser) call(name token) (*node, error) {
+	fn, ok := lookupFunction(name.text)
+	if !ok {
+		return nil, errorf(ErrUnknownName, "unknown function %q at %d", name.text, name.pos)
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if err := p.enter(fn.Cost); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	var args []*node
+	for !(p.tok.kind == tokenOperator && p.tok.text == ")") {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.binary(0)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if len(args) > fn.Arity {
+			break
+		}
+	}
+	if len(args) != fn.Arity {
+		return nil, errorf(ErrSyntax, "%s takes %d arguments at %d", fn.Name, fn.Arity, name.pos)
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return &node{op: opCall, fn: fn, args: args}, nil
+}
+
+func (p *parser) expect(text string) error {
+	if p.tok.kind != tokenOperator || p.tok.text != text {
+		if p.tok.kind == tokenEOF {
+			return errorf(ErrSyntax, "expected %q at the end", text)
+		}
+		return errorf(ErrSyntax, "expected %q at %d", text, p.tok.pos)
+	}
+	return p.advance()
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return errorf(ErrSyntax, "unexpected end of expression")
+	}
+	return errorf(ErrSyntax, "unexpected %q at %d", p.tok.text, p.tok.pos)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func errorf(err error, format string, args ...interface{}) error {
+	return fmt.Errorf("%w: "+format, append([]interface{}{err}, args...)...)
+}
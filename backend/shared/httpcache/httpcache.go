@@ -0,0 +1,121 @@
+package httpcache
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bryanriosb/stock-info/shared/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+// DataVersion tracks the version of the synced dataset. It is seeded with the
+// startup time so validators issued by a previous process never match.
+type DataVersion struct {
+	mu       sync.RWMutex
+	version  uint64
+	modified time.Time
+}
+
+func NewDataVersion() *DataVersion {
+	now := time.Now().UTC()
+	return &DataVersion{
+		version:  uint64(now.UnixNano()),
+		modified: now.Truncate(time.Second),
+	}
+}
+
+// Bump marks the dataset as changed
+func (v *DataVersion) Bump() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
+	v.modified = time.Now().UTC().Truncate(time.Second)
+}
+
+func (v *DataVersion) Current() (uint64, time.Time) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.version, v.modified
+}
+
+// Cache issues strong validators for read endpoints and answers conditional requests
+type Cache struct {
+	version *DataVersion
+}
+
+func New(version *DataVersion) *Cache {
+	return &Cache{version: version}
+}
+
+// Policy returns a middleware that serves 304 Not Modified when the client's
+// validators match the current data version, and otherwise sets ETag,
+// Last-Modified and the given Cache-Control on successful responses.
+func (c *Cache) Policy(cacheControl string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if ctx.Method() != fiber.MethodGet && ctx.Method() != fiber.MethodHead {
+			return ctx.Next()
+		}
+
+		version, modified := c.version.Current()
+		etag := c.etag(ctx, version)
+
+		if notModified(ctx, etag, modified) {
+			setValidators(ctx, etag, modified, cacheControl)
+			return ctx.SendStatus(fiber.StatusNotModified)
+		}
+
+		if err := ctx.Next(); err != nil {
+			return err
+		}
+
+		// Skip validators if a sync finished while the response was being built
+		if current, _ := c.version.Current(); current != version {
+			return nil
+		}
+		if ctx.Response().StatusCode() == fiber.StatusOK {
+			setValidators(ctx, etag, modified, cacheControl)
+		}
+		return nil
+	}
+}
+
+// etag derives a strong validator from the data version and everything that
+// shapes the representation: path, query string and the authenticated user
+func (c *Cache) etag(ctx *fiber.Ctx, version uint64) string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d|%s|%s|%s", version, ctx.Path(), ctx.Request().URI().QueryString(), middleware.GetUserFromToken(ctx))
+	return fmt.Sprintf(`"%x"`, h.Sum64())
+}
+
+func notModified(ctx *fiber.Ctx, etag string, modified time.Time) bool {
+	if match := ctx.Get(fiber.HeaderIfNoneMatch); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		// If-Modified-Since is ignored when If-None-Match is present (RFC 9110)
+		return false
+	}
+
+	if since := ctx.Get(fiber.HeaderIfModifiedSince); since != "" {
+		t, err := http.ParseTime(since)
+		return err == nil && !modified.After(t)
+	}
+
+	return false
+}
+
+func setValidators(ctx *fiber.Ctx, etag string, modified time.Time, cacheControl string) {
+	ctx.Set(fiber.HeaderETag, etag)
+	ctx.Set(fiber.HeaderLastModified, modified.Format(http.TimeFormat))
+	ctx.Set(fiber.HeaderVary, fiber.HeaderAuthorization)
+	if cacheControl != "" {
+		ctx.Set(fiber.HeaderCacheControl, cacheControl)
+	}
+}
@@ -0,0 +1,104 @@
+package httpcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTestApp(version *DataVersion) (*fiber.App, *int) {
+	calls := 0
+	app := fiber.New()
+
+	// The policy is registered as its own route, as router.Setup does
+	app.Get("/stocks", New(version).Policy("private, max-age=60"))
+	app.Get("/stocks", func(c *fiber.Ctx) error {
+		calls++
+		return c.JSON(fiber.Map{"success": true})
+	})
+
+	return app, &calls
+}
+
+func TestPolicy_SetsValidators(t *testing.T) {
+	app, calls := setupTestApp(NewDataVersion())
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/stocks?page=1", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("ETag"))
+	assert.NotEmpty(t, resp.Header.Get("Last-Modified"))
+	assert.Equal(t, "private, max-age=60", resp.Header.Get("Cache-Control"))
+	assert.Equal(t, 1, *calls)
+}
+
+func TestPolicy_IfNoneMatch(t *testing.T) {
+	version := NewDataVersion()
+	app, calls := setupTestApp(version)
+
+	first, _ := app.Test(httptest.NewRequest("GET", "/stocks?page=1", nil))
+	etag := first.Header.Get("ETag")
+
+	req := httptest.NewRequest("GET", "/stocks?page=1", nil)
+	req.Header.Set("If-None-Match", etag)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, etag, resp.Header.Get("ETag"))
+	assert.Equal(t, 1, *calls)
+
+	// A different query string is a different representation
+	req = httptest.NewRequest("GET", "/stocks?page=2", nil)
+	req.Header.Set("If-None-Match", etag)
+	resp, _ = app.Test(req)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	// A sync invalidates the validator
+	version.Bump()
+	req = httptest.NewRequest("GET", "/stocks?page=1", nil)
+	req.Header.Set("If-None-Match", etag)
+	resp, _ = app.Test(req)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+}
+
+func TestPolicy_IfModifiedSince(t *testing.T) {
+	version := NewDataVersion()
+	app, _ := setupTestApp(version)
+
+	_, modified := version.Current()
+
+	req := httptest.NewRequest("GET", "/stocks", nil)
+	req.Header.Set("If-Modified-Since", modified.Format(http.TimeFormat))
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotModified, resp.StatusCode)
+
+	req = httptest.NewRequest("GET", "/stocks", nil)
+	req.Header.Set("If-Modified-Since", modified.Add(-time.Hour).Format(http.TimeFormat))
+	resp, _ = app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestPolicy_NoValidatorsOnError(t *testing.T) {
+	app := fiber.New()
+	app.Get("/stocks/:id", New(NewDataVersion()).Policy("private, max-age=300"))
+	app.Get("/stocks/:id", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false})
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/stocks/999", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("ETag"))
+	assert.Empty(t, resp.Header.Get("Cache-Control"))
+}
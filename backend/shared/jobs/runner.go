@@ -0,0 +1,88 @@
+// Package jobs runs background work that is triggered by events and schedules
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Runner runs a job in the background, one run at a time. A trigger that
+// arrives during a run is not dropped: it runs the job once more after the
+// current run, so data that changed mid-run is picked up.
+type Runner struct {
+	name string
+	run  func(ctx context.Context) error
+
+	mu      sync.Mutex
+	ctx     context.Context
+	running bool
+	rerun   bool
+}
+
+// NewRunner returns a runner for run; failures are logged as "<name> failed"
+func NewRunner(name string, run func(ctx context.Context) error) *Runner {
+	return &Runner{name: name, run: run, ctx: context.Background()}
+}
+
+// Start runs later triggers with ctx; once ctx is done, triggers are ignored
+// and no rerun follows the current run
+func (r *Runner) Start(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ctx = ctx
+}
+
+// Trigger runs the job in the background, or once more after the current run
+func (r *Runner) Trigger() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ctx.Err() != nil {
+		return
+	}
+	if r.running {
+		r.rerun = true
+		return
+	}
+	r.running = true
+	go r.loop()
+}
+
+func (r *Runner) loop() {
+	for {
+		r.mu.Lock()
+		ctx := r.ctx
+		r.rerun = false
+		r.mu.Unlock()
+
+		if err := r.run(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("%s failed: %v", r.name, err)
+		}
+
+		r.mu.Lock()
+		if !r.rerun || r.ctx.Err() != nil {
+			r.running = false
+			r.rerun = false
+			r.mu.Unlock()
+			return
+		}
+		r.mu.Unlock()
+	}
+}
+
+// Every calls fn every interval until ctx is done
+func Every(ctx context.Context, interval time.Duration, fn func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn()
+		}
+	}
+}
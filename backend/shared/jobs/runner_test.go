@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunner_RerunsWhenTriggeredMidRun(t *testing.T) {
+	var runs atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{}, 2)
+
+	r := NewRunner("test job", func(ctx context.Context) error {
+		if runs.Add(1) == 1 {
+			close(started)
+			<-release
+		}
+		done <- struct{}{}
+		return nil
+	})
+
+	r.Trigger()
+	<-started
+	// Both arrive mid-run and fold into a single rerun
+	r.Trigger()
+	r.Trigger()
+	close(release)
+
+	<-done
+	<-done
+	assert.Eventually(t, func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return !r.running
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+func TestRunner_IgnoresTriggersOnceStopped(t *testing.T) {
+	var runs atomic.Int32
+	r := NewRunner("test job", func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.Start(ctx)
+	cancel()
+	r.Trigger()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	assert.False(t, r.running)
+	assert.Equal(t, int32(0), runs.Load())
+}
+
+func TestRunner_RunsWithStartContext(t *testing.T) {
+	type key struct{}
+	got := make(chan any, 1)
+	r := NewRunner("test job", func(ctx context.Context) error {
+		got <- ctx.Value(key{})
+		return nil
+	})
+
+	r.Start(context.WithValue(context.Background(), key{}, "app"))
+	r.Trigger()
+
+	assert.Equal(t, "app", <-got)
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type grpcClaimsKey struct{}
+
+// GRPCAuth validates the bearer token in the "authorization" metadata of every
+// call except the listed public methods, and stores its claims in the context
+func GRPCAuth(secret string, publicMethods ...string) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	public := make(map[string]bool, len(publicMethods))
+	for _, method := range publicMethods {
+		public[method] = true
+	}
+
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if public[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticateGRPC(ctx, secret)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if public[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticateGRPC(ss.Context(), secret)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+
+	return unary, stream
+}
+
+// GRPCRecover turns handler panics into Internal errors, like recover.New does for Fiber
+func GRPCRecover() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer recoverGRPC(info.FullMethod, &err)
+		return handler(ctx, req)
+	}
+
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer recoverGRPC(info.FullMethod, &err)
+		return handler(srv, ss)
+	}
+
+	return unary, stream
+}
+
+// GetRoleFromGRPCContext mirrors GetRoleFromToken for gRPC handlers
+func GetRoleFromGRPCContext(ctx context.Context) string {
+	claims, ok := ctx.Value(grpcClaimsKey{}).(jwt.MapClaims)
+	if !ok {
+		return defaultRole
+	}
+	if role, ok := claims["role"].(string); ok {
+		return role
+	}
+	return defaultRole
+}
+
+// GetUserFromGRPCContext mirrors GetUserFromToken for gRPC handlers
+func GetUserFromGRPCContext(ctx context.Context) string {
+	claims, ok := ctx.Value(grpcClaimsKey{}).(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	sub, _ := claims["sub"].(string)
+	return sub
+}
+
+func authenticateGRPC(ctx context.Context, secret string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "Missing bearer token")
+	}
+
+	raw, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found {
+		return nil, status.Error(codes.Unauthenticated, "Missing bearer token")
+	}
+
+	token, err := jwt.Parse(raw, func(t *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !token.Valid {
+		return nil, status.Error(codes.Unauthenticated, "Invalid or expired token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Invalid or expired token")
+	}
+
+	return context.WithValue(ctx, grpcClaimsKey{}, claims), nil
+}
+
+func recoverGRPC(method string, err *error) {
+	if r := recover(); r != nil {
+		log.Printf("gRPC panic in %s: %v\n%s", method, r, debug.Stack())
+		*err = status.Error(codes.Internal, "Internal server error")
+	}
+}
+
+// contextStream replaces the context of a server stream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,271 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Document is an OpenAPI 3.1 document. It is built in Go next to the routes
+// so the contract and the handlers live in one place.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+
+	types map[reflect.Type]string
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// PathItem maps lower-case HTTP methods to operations
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+
+	doc *Document
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+const (
+	bearerScheme = "bearerAuth"
+	jsonType     = "application/json"
+)
+
+// New creates a document with the response envelope and error shape registered
+func New(title, version string) *Document {
+	d := &Document{
+		OpenAPI: "3.1.0",
+		Info:    Info{Title: title, Version: version},
+		Paths:   map[string]*PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]*SecurityScheme{
+				bearerScheme: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+		types: map[reflect.Type]string{},
+	}
+
+	d.Components.Schemas["Meta"] = &Schema{
+		Type:     "object",
+		Required: []string{"page", "limit", "total", "total_pages"},
+		Properties: map[string]*Schema{
+			"page":        Integer(),
+			"limit":       Integer(),
+			"total":       Integer(),
+			"total_pages": Integer(),
+		},
+	}
+	d.Components.Schemas["Response"] = &Schema{
+		Type:        "object",
+		Description: "Envelope of every REST response",
+		Required:    []string{"success"},
+		Properties: map[string]*Schema{
+			"success": Boolean(),
+			"data":    {},
+			"error":   String(),
+			"meta":    Ref("Meta"),
+			"facets":  {Type: "object", AdditionalProperties: Array(&Schema{Type: "object"})},
+		},
+	}
+	d.Components.Schemas["Error"] = &Schema{
+		Type:     "object",
+		Required: []string{"success", "error"},
+		Properties: map[string]*Schema{
+			"success": {Const: false},
+			"error":   String(),
+		},
+	}
+
+	return d
+}
+
+var fiberParam = regexp.MustCompile(`:(\w+)(<[^>]*>)?\??`)
+
+// PathFromFiber converts a Fiber route path such as /stocks/:id<int> to /stocks/{id}
+func PathFromFiber(path string) string {
+	path = fiberParam.ReplaceAllString(path, "{$1}")
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
+// Operation declares the operation for a method and Fiber route path. Path
+// parameters are declared as strings unless PathParam overrides them.
+func (d *Document) Operation(method, path, tag, summary string) *Operation {
+	path = PathFromFiber(path)
+	method = strings.ToLower(method)
+
+	item, ok := d.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		d.Paths[path] = item
+	}
+
+	op := &Operation{
+		Tags:        []string{tag},
+		Summary:     summary,
+		OperationID: operationID(method, path),
+		Responses:   map[string]*Response{},
+		doc:         d,
+	}
+	op.Fails(500, "Internal error")
+	for _, match := range regexp.MustCompile(`\{(\w+)\}`).FindAllStringSubmatch(path, -1) {
+		op.Parameters = append(op.Parameters, &Parameter{Name: match[1], In: "path", Required: true, Schema: String()})
+	}
+	(*item)[method] = op
+
+	return op
+}
+
+// Routes lists the documented operations as "METHOD /path"
+func (d *Document) Routes() []string {
+	var routes []string
+	for path, item := range d.Paths {
+		for method := range *item {
+			routes = append(routes, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+func (o *Operation) Describe(description string) *Operation {
+	o.Description = description
+	return o
+}
+
+// Deprecate marks an operation that clients should move away from
+func (o *Operation) Deprecate() *Operation {
+	o.Deprecated = true
+	return o
+}
+
+// Secured requires a bearer token
+func (o *Operation) Secured() *Operation {
+	o.Security = []map[string][]string{{bearerScheme: {}}}
+	return o.Fails(401, "Missing, invalid or expired token")
+}
+
+// Admin requires a bearer token with the admin role
+func (o *Operation) Admin() *Operation {
+	return o.Secured().Fails(403, "Admin access required")
+}
+
+func (o *Operation) Query(name string, schema *Schema, description string) *Operation {
+	o.Parameters = append(o.Parameters, &Parameter{Name: name, In: "query", Description: description, Schema: schema})
+	return o.invalidRequest()
+}
+
+// PathParam replaces the schema of a path parameter
+func (o *Operation) PathParam(name string, schema *Schema, description string) *Operation {
+	for _, p := range o.Parameters {
+		if p.In == "path" && p.Name == name {
+			p.Schema = schema
+			p.Description = description
+			return o.invalidRequest()
+		}
+	}
+	panic(fmt.Sprintf("openapi: %s has no path parameter %q", o.OperationID, name))
+}
+
+// Body declares a required JSON request body
+func (o *Operation) Body(schema *Schema) *Operation {
+	return o.BodyContent(jsonType, schema)
+}
+
+// BodyContent declares a required request body of another media type; only
+// JSON bodies are checked by the validator
+func (o *Operation) BodyContent(contentType string, schema *Schema) *Operation {
+	o.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{contentType: {Schema: schema}}}
+	return o.invalidRequest()
+}
+
+// Returns declares a JSON response
+func (o *Operation) Returns(status int, description string, schema *Schema) *Operation {
+	return o.ReturnsContent(status, description, jsonType, schema)
+}
+
+func (o *Operation) ReturnsContent(status int, description, contentType string, schema *Schema) *Operation {
+	o.Responses[fmt.Sprint(status)] = &Response{
+		Description: description,
+		Content:     map[string]*MediaType{contentType: {Schema: schema}},
+	}
+	return o
+}
+
+// Fails declares an error response using the Error shape
+func (o *Operation) Fails(status int, description string) *Operation {
+	return o.Returns(status, description, Ref("Error"))
+}
+
+// invalidRequest declares the 400 raised by the validator, keeping a more
+// specific description declared with Fails
+func (o *Operation) invalidRequest() *Operation {
+	if _, ok := o.Responses["400"]; ok {
+		return o
+	}
+	return o.Fails(400, "Invalid request")
+}
+
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(method)
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '-' || r == '.' || r == '_' }) {
+		part = strings.Trim(part, "{}")
+		if part == "api" || part == "v1" || part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
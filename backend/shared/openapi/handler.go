@@ -0,0 +1,50 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Serve returns the document as JSON. It is encoded once, as the document
+// does not change after startup.
+func Serve(d *Document) fiber.Handler {
+	body, err := json.Marshal(d)
+	if err != nil {
+		panic(fmt.Sprintf("openapi: encode document: %v", err))
+	}
+
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(body)
+	}
+}
+
+// Docs serves an interactive Swagger UI page for the document at specURL
+func Docs(specURL, title string) fiber.Handler {
+	page := fmt.Sprintf(docsPage, html.EscapeString(title), html.EscapeString(specURL))
+
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(page)
+	}
+}
+
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>%s</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "%s", dom_id: "#swagger-ui", persistAuthorization: true });
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,122 @@
+package openapi
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	ID       int64             `json:"id"`
+	Name     string            `json:"name"`
+	Note     string            `json:"note,omitempty"`
+	Secret   string            `json:"-"`
+	Parent   *item             `json:"parent"`
+	Labels   map[string]string `json:"labels"`
+	Created  time.Time         `json:"created_at"`
+	internal int
+}
+
+func TestPathFromFiber(t *testing.T) {
+	assert.Equal(t, "/stocks/{id}", PathFromFiber("/stocks/:id<int>"))
+	assert.Equal(t, "/stocks", PathFromFiber("/stocks/"))
+	assert.Equal(t, "/brokerages/{name}/stats", PathFromFiber("/brokerages/:name/stats"))
+	assert.Equal(t, "/", PathFromFiber("/"))
+}
+
+func TestOfRegistersComponents(t *testing.T) {
+	doc := New("test", "1")
+
+	ref := doc.Of(item{})
+	assert.Equal(t, "#/components/schemas/item", ref.Ref)
+
+	schema := doc.Components.Schemas["item"]
+	require.NotNil(t, schema)
+	assert.ElementsMatch(t, []string{"id", "name", "labels", "created_at"}, schema.Required)
+	assert.NotContains(t, schema.Properties, "Secret")
+	assert.NotContains(t, schema.Properties, "internal")
+	assert.Equal(t, "#/components/schemas/item", schema.Properties["parent"].Ref)
+	assert.Equal(t, "date-time", schema.Properties["created_at"].Format)
+	assert.Equal(t, "string", schema.Properties["labels"].AdditionalProperties.Type)
+
+	// the same type reuses its component
+	assert.Equal(t, ref.Ref, doc.Of(&item{}).Ref)
+	assert.Equal(t, "array", doc.Of([]item{}).Type)
+}
+
+func TestOperationDeclaresPathParamsAndErrors(t *testing.T) {
+	doc := New("test", "1")
+	op := doc.Operation("GET", "/api/v1/items/:id<int>", "items", "Get an item").Admin().
+		PathParam("id", Integer(), "Item ID")
+
+	assert.Equal(t, "getItemsId", op.OperationID)
+	require.Len(t, op.Parameters, 1)
+	assert.Equal(t, "integer", op.Parameters[0].Schema.Type)
+	for _, status := range []string{"400", "401", "403", "500"} {
+		assert.Contains(t, op.Responses, status)
+	}
+	assert.Equal(t, []string{"GET /api/v1/items/{id}"}, doc.Routes())
+}
+
+func newValidatedApp() *fiber.App {
+	doc := New("test", "1")
+	doc.Operation("GET", "/items", "items", "List items").
+		Query("limit", Integer().Min(1), "Page size").
+		Query("sort_dir", Enum("asc", "desc"), "Sort direction")
+	doc.Operation("GET", "/items/sync", "items", "Sync items")
+	doc.Operation("GET", "/items/:id", "items", "Get an item").
+		PathParam("id", Integer(), "Item ID")
+	doc.Operation("POST", "/items", "items", "Create an item").
+		Body(doc.Of(item{}))
+
+	app := fiber.New()
+	app.Use(Validator(doc))
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app.Get("/items", ok)
+	app.Get("/items/sync", ok)
+	app.Get("/items/:id", ok)
+	app.Post("/items", ok)
+	app.Get("/other", ok)
+	return app
+}
+
+func TestValidator(t *testing.T) {
+	app := newValidatedApp()
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+	}{
+		{"valid query", "GET", "/items?limit=5&sort_dir=DESC&unknown=x", "", fiber.StatusOK},
+		{"non integer query", "GET", "/items?limit=five", "", fiber.StatusBadRequest},
+		{"query below minimum", "GET", "/items?limit=0", "", fiber.StatusBadRequest},
+		{"query outside enum", "GET", "/items?sort_dir=up", "", fiber.StatusBadRequest},
+		{"literal segment wins over parameter", "GET", "/items/sync", "", fiber.StatusOK},
+		{"non integer path parameter", "GET", "/items/abc", "", fiber.StatusBadRequest},
+		{"undocumented route", "GET", "/other?limit=five", "", fiber.StatusOK},
+		{"valid body", "POST", "/items", `{"id":1,"name":"a","parent":null,"labels":{"k":"v"},"created_at":"2024-01-01T00:00:00Z"}`, fiber.StatusOK},
+		{"missing required field", "POST", "/items", `{"id":1,"labels":{},"created_at":""}`, fiber.StatusBadRequest},
+		{"wrong field type", "POST", "/items", `{"id":1.5,"name":"a","labels":{},"created_at":""}`, fiber.StatusBadRequest},
+		{"wrong nested type", "POST", "/items", `{"id":1,"name":"a","labels":{"k":1},"created_at":""}`, fiber.StatusBadRequest},
+		{"malformed body", "POST", "/items", `{`, fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schema is the subset of JSON Schema used by the API
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Const                interface{}        `json:"const,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+func Ref(name string) *Schema { return &Schema{Ref: "#/components/schemas/" + name} }
+
+func String() *Schema  { return &Schema{Type: "string"} }
+func Integer() *Schema { return &Schema{Type: "integer"} }
+func Number() *Schema  { return &Schema{Type: "number"} }
+func Boolean() *Schema { return &Schema{Type: "boolean"} }
+
+func Array(items *Schema) *Schema { return &Schema{Type: "array", Items: items} }
+
+// Enum restricts a string to the given values
+func Enum(values ...string) *Schema {
+	s := String()
+	for _, v := range values {
+		s.Enum = append(s.Enum, v)
+	}
+	return s
+}
+
+// Date is an RFC 3339 date-time or YYYY-MM-DD, as accepted by the handlers
+func Date() *Schema {
+	return &Schema{Type: "string", Description: "RFC 3339 timestamp or YYYY-MM-DD"}
+}
+
+func (s *Schema) Min(v float64) *Schema {
+	s.Minimum = &v
+	return s
+}
+
+func (s *Schema) Max(v float64) *Schema {
+	s.Maximum = &v
+	return s
+}
+
+func (s *Schema) WithDefault(v interface{}) *Schema {
+	s.Default = v
+	return s
+}
+
+func (s *Schema) Describe(description string) *Schema {
+	s.Description = description
+	return s
+}
+
+// Envelope wraps data in the response.Response envelope
+func Envelope(data *Schema) *Schema {
+	return &Schema{AllOf: []*Schema{
+		Ref("Response"),
+		{Type: "object", Required: []string{"data"}, Properties: map[string]*Schema{"data": data}},
+	}}
+}
+
+// Paged wraps a list in the response.Response envelope with pagination meta
+func Paged(items *Schema) *Schema {
+	return &Schema{AllOf: []*Schema{
+		Ref("Response"),
+		{
+			Type:     "object",
+			Required: []string{"data", "meta"},
+			Properties: map[string]*Schema{
+				"data": Array(items),
+				"meta": Ref("Meta"),
+			},
+		},
+	}}
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	rawJSONType = reflect.TypeOf(json.RawMessage{})
+)
+
+// Of derives a schema from a Go value using its json tags. Named structs are
+// registered as components and referenced; fields tagged omitempty or held
+// by pointer are optional, every other field is required.
+func (d *Document) Of(v interface{}) *Schema {
+	return d.schemaOf(reflect.TypeOf(v))
+}
+
+func (d *Document) schemaOf(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawJSONType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Boolean()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Integer()
+	case reflect.Float32, reflect.Float64:
+		return Number()
+	case reflect.String:
+		return String()
+	case reflect.Slice, reflect.Array:
+		return Array(d.schemaOf(t.Elem()))
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return d.structSchema(t)
+		}
+		return Ref(d.component(t))
+	}
+
+	return &Schema{}
+}
+
+func (d *Document) component(t reflect.Type) string {
+	if name, ok := d.types[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := d.Components.Schemas[name]; taken {
+		pkg := moduleOf(t.PkgPath())
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+
+	// register before walking the fields so recursive types terminate
+	d.types[t] = name
+	d.Components.Schemas[name] = &Schema{}
+	*d.Components.Schemas[name] = *d.structSchema(t)
+
+	return name
+}
+
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := d.structSchema(indirect(field.Type))
+			for k, v := range embedded.Properties {
+				s.Properties[k] = v
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		s.Properties[name] = d.schemaOf(field.Type)
+		if !strings.Contains(opts, "omitempty") && field.Type.Kind() != reflect.Ptr {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	return s
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// moduleOf names the module a package belongs to: brokerage for
+// internal/brokerage/domain, cache for shared/cache
+func moduleOf(pkg string) string {
+	if _, rest, ok := strings.Cut(pkg, "/internal/"); ok {
+		module, _, _ := strings.Cut(rest, "/")
+		return module
+	}
+	return pkg[strings.LastIndex(pkg, "/")+1:]
+}
@@ -0,0 +1,256 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bryanriosb/stock-info/shared/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+type route struct {
+	segments []string
+	literals int
+	item     *PathItem
+}
+
+// Validator rejects requests whose path parameters, query parameters or JSON
+// body do not match the document. Requests to undocumented routes and unknown
+// query parameters pass through untouched.
+func Validator(d *Document) fiber.Handler {
+	routes := make([]route, 0, len(d.Paths))
+	for path, item := range d.Paths {
+		r := route{segments: strings.Split(strings.Trim(path, "/"), "/"), item: item}
+		for _, s := range r.segments {
+			if !isParam(s) {
+				r.literals++
+			}
+		}
+		routes = append(routes, r)
+	}
+	// literal segments win over parameters, as /stocks/sync-stream over /stocks/{id}
+	sort.SliceStable(routes, func(i, j int) bool { return routes[i].literals > routes[j].literals })
+
+	return func(c *fiber.Ctx) error {
+		op, params := match(routes, strings.ToLower(c.Method()), c.Path())
+		if op == nil {
+			return c.Next()
+		}
+
+		for _, p := range op.Parameters {
+			var value string
+			switch p.In {
+			case "path":
+				value = params[p.Name]
+			case "query":
+				value = c.Query(p.Name)
+			}
+			if value == "" {
+				if p.Required {
+					return response.BadRequest(c, fmt.Sprintf("Missing %s parameter %s", p.In, p.Name))
+				}
+				continue
+			}
+			if err := checkParam(p.Schema, value); err != nil {
+				return response.BadRequest(c, fmt.Sprintf("Invalid %s parameter %s: %v", p.In, p.Name, err))
+			}
+		}
+
+		if op.RequestBody != nil && strings.HasPrefix(string(c.Request().Header.ContentType()), jsonType) {
+			if media, ok := op.RequestBody.Content[jsonType]; ok {
+				var body interface{}
+				if err := json.Unmarshal(c.Body(), &body); err != nil {
+					return response.BadRequest(c, "Invalid request body")
+				}
+				if err := d.check(media.Schema, body, "body"); err != nil {
+					return response.BadRequest(c, err.Error())
+				}
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+func match(routes []route, method, path string) (*Operation, map[string]string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for _, r := range routes {
+		if len(r.segments) != len(segments) {
+			continue
+		}
+		params := map[string]string{}
+		matched := true
+		for i, s := range r.segments {
+			if isParam(s) {
+				params[strings.Trim(s, "{}")] = segments[i]
+			} else if s != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		if op, ok := (*r.item)[method]; ok {
+			return op, params
+		}
+	}
+
+	return nil, nil
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// checkParam validates a raw parameter value. Enums are matched without case,
+// as the handlers lower-case sort directions and buckets themselves.
+func checkParam(s *Schema, value string) error {
+	switch s.Type {
+	case "integer":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("must be an integer")
+		}
+		return checkRange(s, float64(n))
+	case "number":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("must be a number")
+		}
+		return checkRange(s, n)
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("must be a boolean")
+		}
+	}
+
+	if len(s.Enum) > 0 {
+		for _, allowed := range s.Enum {
+			if strings.EqualFold(fmt.Sprint(allowed), value) {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %s", enumList(s.Enum))
+	}
+
+	return nil
+}
+
+func checkRange(s *Schema, n float64) error {
+	if s.Minimum != nil && n < *s.Minimum {
+		return fmt.Errorf("must be at least %v", *s.Minimum)
+	}
+	if s.Maximum != nil && n > *s.Maximum {
+		return fmt.Errorf("must be at most %v", *s.Maximum)
+	}
+	return nil
+}
+
+// check validates a decoded JSON value against a schema. null passes as the
+// handlers decode it to the zero value and validate that themselves.
+func (d *Document) check(s *Schema, value interface{}, at string) error {
+	if value == nil {
+		return nil
+	}
+	if s.Ref != "" {
+		resolved, ok := d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+		if !ok {
+			return fmt.Errorf("%s: unknown schema %s", at, s.Ref)
+		}
+		return d.check(resolved, value, at)
+	}
+	for _, part := range s.AllOf {
+		if err := d.check(part, value, at); err != nil {
+			return err
+		}
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an object", at)
+		}
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				return fmt.Errorf("%s.%s is required", at, name)
+			}
+		}
+		for name, v := range object {
+			if prop, ok := s.Properties[name]; ok {
+				if err := d.check(prop, v, at+"."+name); err != nil {
+					return err
+				}
+			} else if s.AdditionalProperties != nil {
+				if err := d.check(s.AdditionalProperties, v, at+"."+name); err != nil {
+					return err
+				}
+			}
+		}
+	case "array":
+		list, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an array", at)
+		}
+		if s.Items != nil {
+			for i, v := range list {
+				if err := d.check(s.Items, v, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", at)
+		}
+		if s.MinLength != nil && len(str) < *s.MinLength {
+			return fmt.Errorf("%s must be at least %d characters", at, *s.MinLength)
+		}
+	case "integer":
+		n, ok := value.(float64)
+		if !ok || n != math.Trunc(n) {
+			return fmt.Errorf("%s must be an integer", at)
+		}
+		if err := checkRange(s, n); err != nil {
+			return fmt.Errorf("%s %v", at, err)
+		}
+	case "number":
+		n, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("%s must be a number", at)
+		}
+		if err := checkRange(s, n); err != nil {
+			return fmt.Errorf("%s %v", at, err)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s must be a boolean", at)
+		}
+	}
+
+	if len(s.Enum) > 0 {
+		for _, allowed := range s.Enum {
+			if allowed == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s must be one of %s", at, enumList(s.Enum))
+	}
+
+	return nil
+}
+
+func enumList(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprint(v)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -7,6 +7,7 @@ type Response struct {
 	Data    interface{} `json:"data,omitempty"`
 	Error   string      `json:"error,omitempty"`
 	Meta    *Meta       `json:"meta,omitempty"`
+	Facets  interface{} `json:"facets,omitempty"`
 }
 
 type Meta struct {
@@ -31,6 +32,15 @@ func SuccessWithMeta(c *fiber.Ctx, data interface{}, meta *Meta) error {
 	})
 }
 
+func SuccessWithFacets(c *fiber.Ctx, data interface{}, meta *Meta, facets interface{}) error {
+	return c.JSON(Response{
+		Success: true,
+		Data:    data,
+		Meta:    meta,
+		Facets:  facets,
+	})
+}
+
 func Error(c *fiber.Ctx, status int, message string) error {
 	return c.Status(status).JSON(Response{
 		Success: false,
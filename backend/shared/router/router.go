@@ -1,33 +1,82 @@
 package router
 
 import (
+	"context"
+	"log"
 	"time"
 
 	"github.com/bryanriosb/stock-info/internal/auth"
+	"github.com/bryanriosb/stock-info/internal/backtest"
+	"github.com/bryanriosb/stock-info/internal/brokerage"
+	"github.com/bryanriosb/stock-info/internal/graph"
+	"github.com/bryanriosb/stock-info/internal/price"
 	"github.com/bryanriosb/stock-info/internal/rating"
+	ratingInfra "github.com/bryanriosb/stock-info/internal/rating/infrastructure"
 	"github.com/bryanriosb/stock-info/internal/recommendation"
+	recommendationApp "github.com/bryanriosb/stock-info/internal/recommendation/application"
 	"github.com/bryanriosb/stock-info/internal/stock"
+	stockInfra "github.com/bryanriosb/stock-info/internal/stock/infrastructure"
 	"github.com/bryanriosb/stock-info/internal/user"
+	"github.com/bryanriosb/stock-info/internal/watchlist"
 	"github.com/bryanriosb/stock-info/shared"
+	"github.com/bryanriosb/stock-info/shared/cache"
+	"github.com/bryanriosb/stock-info/shared/events"
+	"github.com/bryanriosb/stock-info/shared/httpcache"
 	"github.com/bryanriosb/stock-info/shared/middleware"
+	"github.com/bryanriosb/stock-info/shared/openapi"
+	"github.com/bryanriosb/stock-info/shared/response"
 	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
-func Setup(app *fiber.App, db *gorm.DB, cfg *shared.Config) {
+// Setup registers every module on the REST API and its services on the gRPC
+// server; background jobs run until ctx is done
+func Setup(ctx context.Context, app *fiber.App, grpcServer grpc.ServiceRegistrar, db *gorm.DB, cfg *shared.Config) {
 	app.Get("/health", healthCheck)
 	app.Get("/", root)
 
+	// OpenAPI document of the REST API; requests are validated against it before reaching the handlers
+	spec := newSpec()
+	app.Get("/openapi.json", openapi.Serve(spec))
+	app.Get("/docs", openapi.Docs("/openapi.json", spec.Info.Title))
+
 	api := app.Group("/api/v1")
+	api.Use(openapi.Validator(spec))
+
+	// Internal events shared across modules
+	bus := events.NewBus()
+
+	// HTTP caching: validators follow the data version, which each completed sync bumps.
+	// Policies must be registered before the routes they wrap.
+	dataVersion := httpcache.NewDataVersion()
+	bus.Subscribe(events.SyncCompleted, func(events.Event) { dataVersion.Bump() })
+	// Recommendations follow the caller's scoring profile
+	bus.Subscribe(events.ScoringProfileChanged, func(events.Event) { dataVersion.Bump() })
+	// and can leave out their watchlist
+	bus.Subscribe(events.WatchlistChanged, func(events.Event) { dataVersion.Bump() })
+	// and scoring rules, which can be edited in place
+	bus.Subscribe(events.ScoringRuleChanged, func(events.Event) { dataVersion.Bump() })
+	// and brokerage credibility, which weighs every brokerage's score
+	bus.Subscribe(events.CredibilityUpdated, func(events.Event) { dataVersion.Bump() })
+	// Anomaly flags are stored on stocks after the sync that bumped the version
+	bus.Subscribe(events.AnomaliesDetected, func(events.Event) { dataVersion.Bump() })
+	// Stocks and recommendations report the upside from the latest prices
+	bus.Subscribe(events.LastPricesUpdated, func(events.Event) { dataVersion.Bump() })
+	cache := httpcache.New(dataVersion)
+
+	// Application read cache, cleared of synced data once a sync completes
+	appCache := newReadCache(cfg, bus)
 
 	// Register user module without protected routes first
 	userUseCase := user.RegisterPublicOnly(api, db)
 
 	// Register public auth routes
-	auth.Register(api, db, cfg, userUseCase)
+	authUseCase := auth.Register(api, db, cfg, userUseCase)
 
 	// Register rating options as public endpoint (needed for filters)
-	rating.Register(api, db)
+	api.Get("/rating-options", cache.Policy("public, max-age=300"))
+	ratingRepo := rating.Register(api, db, cfg, appCache)
 
 	// Create protected group with JWT middleware
 	protected := api.Group("", middleware.JWTProtected(cfg.JWT.Secret))
@@ -35,9 +84,67 @@ func Setup(app *fiber.App, db *gorm.DB, cfg *shared.Config) {
 	// Register protected user routes
 	user.RegisterProtected(protected, userUseCase)
 
+	// Cache policies for protected read endpoints
+	protected.Get("/stocks", cache.Policy("private, max-age=60"))
+	protected.Get("/stocks/:id<int>", cache.Policy("private, max-age=300"))
+	protected.Get("/recommendations", cache.Policy("private, no-cache"))
+	protected.Get("/recommendations/:ticker/explain", cache.Policy("private, no-cache"))
+
 	// Register other protected modules
-	stock.Register(protected, db, cfg)
-	recommendation.Register(protected, db)
+	prices, lastPrices := price.Register(ctx, protected, db, cfg, bus)
+	stockUseCase := stock.Register(ctx, protected, db, cfg, bus, appCache, lastPrices)
+	credibility := brokerage.Register(ctx, protected, db, cfg, bus, prices)
+	watchlists := watchlist.Register(protected, db, bus)
+	recommendationUseCase := recommendation.Register(ctx, protected, db, cfg, appCache, bus, credibility, watchlists, lastPrices)
+	// Backtests weigh brokerages alike: today's credibility was learned from the prices they replay
+	backtest.Register(ctx, protected, db, recommendation.NewUseCase(db, cfg, nil), prices)
+
+	// GraphQL over the same use cases, for clients that need nested data in one round trip
+	graph.Register(protected, stockUseCase, ratingRepo, recommendationUseCase, userUseCase)
+
+	// gRPC services share the use cases of the REST API
+	auth.RegisterGRPC(grpcServer, authUseCase)
+	stock.RegisterGRPC(grpcServer, stockUseCase)
+	recommendation.RegisterGRPC(grpcServer, recommendationUseCase)
+
+	// Cache hit/miss counters for operators
+	protected.Get("/cache/stats", middleware.RequireAdmin(), func(c *fiber.Ctx) error {
+		return response.Success(c, appCache.Stats())
+	})
+}
+
+func newReadCache(cfg *shared.Config, bus *events.Bus) cache.Cache {
+	appCache := cache.NewLRU(cfg.Cache.Size, cfg.Cache.TTL)
+
+	bus.Subscribe(events.SyncCompleted, func(events.Event) {
+		invalidate(appCache, stockInfra.CacheNamespace, ratingInfra.CacheNamespace, recommendationApp.CacheNamespace)
+	})
+	// Recommendations weigh brokerages by credibility and can be scored by rules
+	bus.Subscribe(events.CredibilityUpdated, func(events.Event) {
+		invalidate(appCache, recommendationApp.CacheNamespace)
+	})
+	bus.Subscribe(events.ScoringRuleChanged, func(events.Event) {
+		invalidate(appCache, recommendationApp.CacheNamespace)
+	})
+	// Stocks carry their anomaly flags, which recommendations can leave out
+	bus.Subscribe(events.AnomaliesDetected, func(events.Event) {
+		invalidate(appCache, stockInfra.CacheNamespace, recommendationApp.CacheNamespace)
+	})
+	// Stocks get their prices after the cache, recommendations inside it
+	bus.Subscribe(events.LastPricesUpdated, func(events.Event) {
+		invalidate(appCache, recommendationApp.CacheNamespace)
+	})
+
+	return appCache
+}
+
+func invalidate(appCache cache.Cache, prefixes ...string) {
+	ctx := context.Background()
+	for _, prefix := range prefixes {
+		if err := appCache.DeletePrefix(ctx, prefix); err != nil {
+			log.Printf("Cache invalidation of %s failed: %v", prefix, err)
+		}
+	}
 }
 
 func healthCheck(c *fiber.Ctx) error {
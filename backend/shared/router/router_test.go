@@ -0,0 +1,148 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/bryanriosb/stock-info/shared"
+	"github.com/bryanriosb/stock-info/shared/openapi"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupApp(t *testing.T) *fiber.App {
+	sqlDB, _, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+
+	cfg := &shared.Config{
+		JWT:   shared.JWTConfig{Secret: "test-secret", Expiration: time.Hour, RefreshExpiration: time.Hour},
+		Cache: shared.CacheConfig{Size: 10, TTL: time.Minute},
+	}
+
+	app := fiber.New()
+	Setup(context.Background(), app, grpc.NewServer(), db, cfg)
+	return app
+}
+
+// registeredRoutes lists the routes with a handler, deduplicating the cache
+// policies registered next to the module routes
+func registeredRoutes(app *fiber.App) []string {
+	seen := map[string]bool{}
+	var routes []string
+	for _, r := range app.GetRoutes(true) {
+		if r.Method == fiber.MethodHead {
+			continue
+		}
+		route := r.Method + " " + openapi.PathFromFiber(r.Path)
+		if !seen[route] {
+			seen[route] = true
+			routes = append(routes, route)
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+func TestSpecMatchesRoutes(t *testing.T) {
+	app := setupApp(t)
+
+	registered := registeredRoutes(app)
+	documented := newSpec().Routes()
+
+	for _, route := range registered {
+		assert.Contains(t, documented, route, "route is registered but missing from the OpenAPI document")
+	}
+	for _, route := range documented {
+		assert.Contains(t, registered, route, "route is documented but not registered")
+	}
+}
+
+func TestSpecOperationIDsAreUnique(t *testing.T) {
+	seen := map[string]string{}
+	for path, item := range newSpec().Paths {
+		for method, op := range *item {
+			route := strings.ToUpper(method) + " " + path
+			if other, ok := seen[op.OperationID]; ok {
+				t.Errorf("operationId %s used by %s and %s", op.OperationID, other, route)
+			}
+			seen[op.OperationID] = route
+		}
+	}
+}
+
+func TestSpecRefsResolve(t *testing.T) {
+	body, err := json.Marshal(newSpec())
+	require.NoError(t, err)
+
+	var doc struct {
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(body, &doc))
+
+	for _, ref := range regexp.MustCompile(`"#/components/schemas/(\w+)"`).FindAllStringSubmatch(string(body), -1) {
+		assert.Contains(t, doc.Components.Schemas, ref[1])
+	}
+}
+
+func TestServeSpecAndDocs(t *testing.T) {
+	app := setupApp(t)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/openapi.json", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+	assert.Equal(t, "3.1.0", doc["openapi"])
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/docs", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+}
+
+func TestRequestsAreValidatedAgainstSpec(t *testing.T) {
+	app := setupApp(t)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+	}{
+		{"non integer limit", "GET", "/api/v1/stocks?limit=abc", "", fiber.StatusBadRequest},
+		{"unknown sort direction", "GET", "/api/v1/stocks?sort_dir=up", "", fiber.StatusBadRequest},
+		{"unknown bucket", "GET", "/api/v1/tickers/AAPL/timeline?bucket=year", "", fiber.StatusBadRequest},
+		{"wrong body type", "POST", "/api/v1/auth/login", `{"username":1,"password":"x"}`, fiber.StatusBadRequest},
+		{"valid query reaches auth", "GET", "/api/v1/stocks?limit=5&sort_dir=DESC", "", fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
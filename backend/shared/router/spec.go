@@ -0,0 +1,353 @@
+package router
+
+import (
+	authApp "github.com/bryanriosb/stock-info/internal/auth/application"
+	authInterfaces "github.com/bryanriosb/stock-info/internal/auth/interfaces"
+	backtestDomain "github.com/bryanriosb/stock-info/internal/backtest/domain"
+	backtestInterfaces "github.com/bryanriosb/stock-info/internal/backtest/interfaces"
+	brokerageDomain "github.com/bryanriosb/stock-info/internal/brokerage/domain"
+	priceDomain "github.com/bryanriosb/stock-info/internal/price/domain"
+	ratingDomain "github.com/bryanriosb/stock-info/internal/rating/domain"
+	recommendationApp "github.com/bryanriosb/stock-info/internal/recommendation/application"
+	recommendationDomain "github.com/bryanriosb/stock-info/internal/recommendation/domain"
+	recommendationInterfaces "github.com/bryanriosb/stock-info/internal/recommendation/interfaces"
+	stockDomain "github.com/bryanriosb/stock-info/internal/stock/domain"
+	stockInfra "github.com/bryanriosb/stock-info/internal/stock/infrastructure"
+	stockInterfaces "github.com/bryanriosb/stock-info/internal/stock/interfaces"
+	userDomain "github.com/bryanriosb/stock-info/internal/user/domain"
+	userInterfaces "github.com/bryanriosb/stock-info/internal/user/interfaces"
+	watchlistDomain "github.com/bryanriosb/stock-info/internal/watchlist/domain"
+	"github.com/bryanriosb/stock-info/shared/cache"
+	"github.com/bryanriosb/stock-info/shared/openapi"
+)
+
+// newSpec describes every route registered by Setup. TestSpecMatchesRoutes
+// fails when a route is added or removed without updating it.
+func newSpec() *openapi.Document {
+	doc := openapi.New("Stock Info API", "1.0.0")
+
+	message := func(field string) *openapi.Schema {
+		return &openapi.Schema{Type: "object", Required: []string{field}, Properties: map[string]*openapi.Schema{field: openapi.String()}}
+	}
+	pageQuery := func(op *openapi.Operation, defaultLimit int) *openapi.Operation {
+		return op.
+			Query("page", openapi.Integer().Min(1).WithDefault(1), "Page number").
+			Query("limit", openapi.Integer().Min(1).WithDefault(defaultLimit), "Page size")
+	}
+	rangeQuery := func(op *openapi.Operation) *openapi.Operation {
+		return op.
+			Query("from", openapi.Date(), "Start of the period, inclusive").
+			Query("to", openapi.Date(), "End of the period, inclusive")
+	}
+	tuningQuery := func(op *openapi.Operation) *openapi.Operation {
+		return op.
+			Query("params", openapi.String(), "Strategy parameter overrides as name:value pairs, e.g. rating_weight:0.5,target_weight:0.2; bounds are listed by /recommendation-strategies").
+			Query("rating_scale", openapi.String(), "Rating value overrides on the 1-9 scale as label:value pairs, e.g. hold:4,outperform:8")
+	}
+	bucket := func() *openapi.Schema {
+		return openapi.Enum(string(stockDomain.BucketDay), string(stockDomain.BucketWeek), string(stockDomain.BucketMonth))
+	}
+
+	// System
+	doc.Operation("GET", "/", "system", "API information").
+		Returns(200, "Name and version", message("message"))
+	doc.Operation("GET", "/health", "system", "Health check").
+		Returns(200, "The API is up", message("status"))
+	doc.Operation("GET", "/openapi.json", "system", "This OpenAPI document").
+		Returns(200, "OpenAPI 3.1 document", &openapi.Schema{Type: "object"})
+	doc.Operation("GET", "/docs", "system", "Interactive API documentation").
+		ReturnsContent(200, "Swagger UI page", "text/html", openapi.String())
+
+	// Auth
+	doc.Operation("POST", "/api/v1/auth/login", "auth", "Log in with username and password").
+		Body(doc.Of(authInterfaces.LoginRequest{})).
+		Returns(200, "Access and refresh tokens", openapi.Envelope(doc.Of(authApp.Tokens{}))).
+		Fails(401, "Invalid credentials")
+	doc.Operation("POST", "/api/v1/auth/refresh", "auth", "Exchange a refresh token for new tokens").
+		Body(doc.Of(authInterfaces.RefreshRequest{})).
+		Returns(200, "Access and refresh tokens", openapi.Envelope(doc.Of(authApp.Tokens{}))).
+		Fails(401, "Invalid, revoked or expired refresh token")
+	doc.Operation("POST", "/api/v1/auth/logout", "auth", "Revoke a refresh token").
+		Body(doc.Of(authInterfaces.RefreshRequest{})).
+		Returns(200, "Logged out", openapi.Envelope(message("message")))
+
+	// Users
+	doc.Operation("POST", "/api/v1/users", "users", "Register a user").
+		Body(doc.Of(userInterfaces.CreateUserRequest{})).
+		Returns(201, "Created user", openapi.Envelope(doc.Of(userDomain.User{})))
+	doc.Operation("GET", "/api/v1/users", "users", "List users").Admin().
+		Returns(200, "Users", openapi.Envelope(openapi.Array(doc.Of(userDomain.User{}))))
+	doc.Operation("GET", "/api/v1/users/:id", "users", "Get a user").Admin().
+		PathParam("id", openapi.Integer(), "User ID").
+		Returns(200, "User", openapi.Envelope(doc.Of(userDomain.User{}))).
+		Fails(404, "User not found")
+	update := doc.Of(userInterfaces.UpdateUserRequest{})
+	doc.Components.Schemas["UpdateUserRequest"].Properties["role"] = openapi.Enum(string(userDomain.RoleUser), string(userDomain.RoleAdmin))
+	doc.Operation("PUT", "/api/v1/users/:id", "users", "Update a user").Admin().
+		PathParam("id", openapi.Integer(), "User ID").
+		Body(update).
+		Returns(200, "Updated user", openapi.Envelope(doc.Of(userDomain.User{}))).
+		Fails(404, "User not found")
+	doc.Operation("DELETE", "/api/v1/users/:id", "users", "Delete a user").Admin().
+		PathParam("id", openapi.Integer(), "User ID").
+		Returns(200, "Deleted", openapi.Envelope(message("message"))).
+		Fails(400, "Cannot delete the last admin").
+		Fails(404, "User not found")
+	doc.Operation("GET", "/api/v1/users/me/scoring-profile", "users", "Get your scoring profile").Secured().
+		Describe("The strategy, parameters and rating scale your recommendation requests default to.").
+		Returns(200, "Scoring profile", openapi.Envelope(doc.Of(recommendationDomain.ScoringProfile{}))).
+		Fails(404, "No scoring profile saved")
+	doc.Operation("PUT", "/api/v1/users/me/scoring-profile", "users", "Save your scoring profile").Secured().
+		Describe("Replaces your scoring profile. The parameters must belong to the strategy and stay within the bounds listed by /recommendation-strategies; rating values are on the 1-9 scale. GET /recommendations and /recommendations/:ticker/explain use the profile for whatever a request does not override; the parameters only apply when the request scores with the profile's strategy.").
+		Body(doc.Of(recommendationInterfaces.ProfileRequest{})).
+		Returns(200, "Saved scoring profile", openapi.Envelope(doc.Of(recommendationDomain.ScoringProfile{}))).
+		Fails(400, "Unknown strategy, or invalid parameters or rating scale")
+	doc.Operation("DELETE", "/api/v1/users/me/scoring-profile", "users", "Delete your scoring profile").Secured().
+		Returns(200, "Deleted", openapi.Envelope(message("message"))).
+		Fails(404, "No scoring profile saved")
+	doc.Operation("GET", "/api/v1/users/me/watchlist", "users", "Get your watchlist").Secured().
+		Describe("Tickers you watch, most recently added first. GET /recommendations?exclude_watchlist=true leaves them out.").
+		Returns(200, "Watched tickers", openapi.Envelope(openapi.Array(doc.Of(watchlistDomain.Item{}))))
+	doc.Operation("PUT", "/api/v1/users/me/watchlist/:ticker", "users", "Watch a ticker").Secured().
+		PathParam("ticker", openapi.String(), "Ticker symbol").
+		Returns(200, "Watched ticker; watching it again keeps the original", openapi.Envelope(doc.Of(watchlistDomain.Item{}))).
+		Fails(400, "Invalid ticker or full watchlist")
+	doc.Operation("DELETE", "/api/v1/users/me/watchlist/:ticker", "users", "Stop watching a ticker").Secured().
+		PathParam("ticker", openapi.String(), "Ticker symbol").
+		Returns(200, "Removed", openapi.Envelope(message("message"))).
+		Fails(404, "Ticker is not on the watchlist")
+
+	// Ratings
+	doc.Operation("GET", "/api/v1/rating-options", "ratings", "Distinct ratings, for filters").Deprecate().
+		Describe("Superseded by the rating_to facet of GET /stocks, which only lists the ratings that yield results under the current filter. Kept until the UI's stock filter reads the facet.").
+		Returns(200, "Rating options", openapi.Envelope(openapi.Array(doc.Of(ratingDomain.RatingOption{}))))
+
+	// Stocks
+	stocks := doc.Operation("GET", "/api/v1/stocks", "stocks", "Search analyst actions").Secured()
+	pageQuery(stocks, 20).
+		Query("sort_by", openapi.Enum("id", "ticker", "company", "target_to", "time", "created_at").WithDefault("id"), "Sort column").
+		Query("sort_dir", openapi.Enum("asc", "desc").WithDefault("asc"), "Sort direction").
+		Query("search", openapi.String(), "Matches ticker, company or brokerage").
+		Query("rating_from", openapi.String(), "Previous rating").
+		Query("rating_to", openapi.String(), "New rating").
+		Query("fields", openapi.String(), "Comma-separated stock attributes to return, including last_price, last_price_at and upside_percent").
+		Query("include", openapi.String(), "Comma-separated relations to embed: "+stockDomain.IncludeConsensus+", "+stockDomain.IncludeCompany).
+		Query("facets", openapi.String(), "Comma-separated facets to count: brokerage, rating_to, action, time").
+		Query("facet_bucket", bucket(), "Granularity of the time facet").
+		Returns(200, "Stocks with the latest price of their ticker and the upside to target_to when known; with fields or include each item holds only the requested attributes and relations",
+			openapi.Paged(doc.Of(stockDomain.Stock{}))).
+		Fails(400, "Invalid fields, include or facets")
+	doc.Operation("POST", "/api/v1/stocks/lookup", "stocks", "Latest actions on a list of tickers").Secured().
+		Describe("Returns the latest action of every brokerage covering each ticker, grouped by ticker in request order. Tickers without a matching action are listed in not_found.").
+		Body(doc.Of(stockInterfaces.LookupRequest{})).
+		Returns(200, "Actions grouped by ticker", openapi.Envelope(doc.Of(stockDomain.LookupResult{}))).
+		Fails(400, "Missing, empty or more than 500 tickers")
+	doc.Operation("GET", "/api/v1/stocks/sync-stream", "stocks", "Sync stocks from the upstream API").Secured().
+		Describe("Server-sent events reporting sync progress. EventSource cannot send headers, so the token may be passed as a query parameter.").
+		Query("token", openapi.String(), "Access token, instead of the Authorization header").
+		ReturnsContent(200, "Progress events", "text/event-stream", doc.Of(stockInfra.SyncProgress{}))
+	anomalies := doc.Operation("GET", "/api/v1/stocks/anomalies", "stocks", "Actions flagged as anomalies, newest first").Secured().
+		Describe("After every sync a detector flags actions whose target change is a statistical outlier among all target moves (target_outlier), whose rating jumps several levels at once (rating_jump) or whose target is far from the other brokerages' targets on the ticker (consensus_outlier). Recommendations can keep, downweight or exclude flagged actions.")
+	pageQuery(anomalies, 20).
+		Query("kind", openapi.Enum(stockDomain.AnomalyKinds...), "Only actions flagged with this anomaly").
+		Query("ticker", openapi.String(), "Only actions on this ticker").
+		Returns(200, "Flagged actions", openapi.Paged(doc.Of(stockDomain.Stock{}))).
+		Fails(400, "Unknown anomaly kind")
+	doc.Operation("POST", "/api/v1/stocks/anomalies/detect", "stocks", "Flag anomalies now").Admin().
+		Returns(200, "Detection summary", openapi.Envelope(doc.Of(stockDomain.AnomalyRun{})))
+	doc.Operation("GET", "/api/v1/stocks/:id", "stocks", "Get a stock").Secured().
+		PathParam("id", openapi.Integer(), "Stock ID").
+		Returns(200, "Stock", openapi.Envelope(doc.Of(stockDomain.Stock{}))).
+		Fails(404, "Stock not found")
+	timeline := doc.Operation("GET", "/api/v1/tickers/:symbol/timeline", "stocks", "Analyst actions on a ticker over time").Secured()
+	pageQuery(rangeQuery(timeline), 50).
+		PathParam("symbol", openapi.String(), "Ticker symbol").
+		Query("bucket", bucket(), "Aggregate events per period instead of listing them").
+		Returns(200, "Timeline", openapi.Envelope(doc.Of(stockDomain.Timeline{}))).
+		Fails(400, "Invalid period or bucket").
+		Fails(404, "Ticker not found")
+
+	// Recommendations
+	recommendations := doc.Operation("GET", "/api/v1/recommendations", "recommendations", "Tickers to invest in, best first").Secured().
+		Describe("Every ticker is recommended once: the latest action of each brokerage covering it is scored, weighted by its age and combined with the chosen aggregation. Actions past the configured max age are left out. Pages reach the top 1000 recommendations. Filters apply before the ranking is cut into pages: brokerage and age filters drop single actions, the others whole tickers. What the request does not set or override is taken from the caller's scoring profile.").
+		Query("page", openapi.Integer().Min(1).WithDefault(1), "Page of the ranking").
+		Query("limit", openapi.Integer().Min(1).WithDefault(10), "Recommendations per page, at most 50").
+		Query("strategy", openapi.String().WithDefault(recommendationApp.DefaultStrategy), "Scoring strategy, see /recommendation-strategies, or rule:<id> for one of your scoring rules or a shared one").
+		Query("aggregation", openapi.Enum(string(recommendationDomain.AggregationMean), string(recommendationDomain.AggregationMedian), string(recommendationDomain.AggregationWeighted)),
+			"How brokerage scores are combined per ticker, the server's RECOMMENDATION_AGGREGATION when omitted")
+	tuningQuery(recommendations).
+		Query("brokerage", openapi.String(), "Only count the actions of this brokerage; repeat for several").
+		Query("exclude_brokerage", openapi.String(), "Ignore the actions of this brokerage; repeat for several").
+		Query("min_gain", openapi.Number(), "Lowest potential gain, in percent").
+		Query("min_brokerages", openapi.Integer().Min(0), "Fewest brokerages whose actions count towards the ticker").
+		Query("rating_bucket", openapi.Enum(recommendationDomain.RatingBucketBuy, recommendationDomain.RatingBucketHold, recommendationDomain.RatingBucketSell),
+			"Keep tickers whose average rating is buy (6.5 and up on the 1-9 scale), hold or sell (below 3.5)").
+		Query("max_age_days", openapi.Integer().Min(0), "Ignore actions older than this many days").
+		Query("max_per_brokerage", openapi.Integer().Min(0).Max(recommendationDomain.MaxPerBrokerage), "At most this many recommendations led by the same brokerage, the one whose signal scores highest").
+		Query("exclude_watchlist", openapi.Boolean(), "Leave out the tickers on your watchlist").
+		Query("anomalies", openapi.Enum(recommendationDomain.AnomaliesKeep, recommendationDomain.AnomaliesDownweight, recommendationDomain.AnomaliesExclude).WithDefault(recommendationDomain.AnomaliesKeep),
+			"What to do with actions flagged as anomalies; downweight scales their score by 0.25").
+		Returns(200, "Recommendations, best first", openapi.Paged(doc.Of(recommendationDomain.StockRecommendation{}))).
+		Fails(400, "Unknown strategy or aggregation, invalid parameters, rating scale or filter, or page beyond the ranking")
+	explain := doc.Operation("GET", "/api/v1/recommendations/:ticker/explain", "recommendations", "How a ticker's recommendation is derived").Secured().
+		Describe("Recommends the ticker as GET /recommendations would and breaks each brokerage's score down into the strategy's signals: raw and normalised value, weight and contribution, with the rating mapping and decay applied. The strategy parameters and rating scale are included.").
+		PathParam("ticker", openapi.String(), "Ticker symbol").
+		Query("strategy", openapi.String().WithDefault(recommendationApp.DefaultStrategy), "Scoring strategy, see /recommendation-strategies, or rule:<id> for one of your scoring rules or a shared one").
+		Query("aggregation", openapi.Enum(string(recommendationDomain.AggregationMean), string(recommendationDomain.AggregationMedian), string(recommendationDomain.AggregationWeighted)),
+			"How brokerage scores are combined, the server's RECOMMENDATION_AGGREGATION when omitted")
+	tuningQuery(explain).
+		Returns(200, "Recommendation with a breakdown per brokerage", openapi.Envelope(doc.Of(recommendationDomain.Explanation{}))).
+		Fails(400, "Unknown strategy or aggregation, or invalid parameters or rating scale").
+		Fails(404, "No action on the ticker within the max age")
+	snapshots := doc.Operation("GET", "/api/v1/recommendations/snapshots", "recommendations", "Past recommendation lists").Secured().
+		Describe("The default ranking is snapshotted after every completed sync and every RECOMMENDATION_SNAPSHOT_INTERVAL. Entries are omitted; fetch a snapshot for them.")
+	pageQuery(rangeQuery(snapshots), 20).
+		Returns(200, "Snapshots, newest first", openapi.Paged(doc.Of(recommendationDomain.Snapshot{}))).
+		Fails(400, "Invalid period")
+	doc.Operation("POST", "/api/v1/recommendations/snapshots", "recommendations", "Snapshot the recommendations now").Admin().
+		Returns(201, "Snapshot", openapi.Envelope(doc.Of(recommendationDomain.Snapshot{})))
+	doc.Operation("GET", "/api/v1/recommendations/snapshots/diff", "recommendations", "How the recommendation list changed").Secured().
+		Describe("Lists the tickers that entered, exited, moved up or moved down between two snapshots. Without to the latest snapshot is compared; without from, the latest snapshot taken at least days before it, or the one right before it.").
+		Query("from", openapi.Integer().Min(1), "Earlier snapshot ID").
+		Query("to", openapi.Integer().Min(1), "Later snapshot ID, the latest when omitted").
+		Query("days", openapi.Integer().Min(1), "Compare with the list as it stood this many days earlier, e.g. 7 for week over week").
+		Returns(200, "Changes between the snapshots", openapi.Envelope(doc.Of(recommendationDomain.SnapshotDiff{}))).
+		Fails(404, "Snapshot not found")
+	doc.Operation("GET", "/api/v1/recommendations/snapshots/:id", "recommendations", "Get a recommendation snapshot").Secured().
+		PathParam("id", openapi.Integer(), "Snapshot ID").
+		Returns(200, "Snapshot with its ranked entries", openapi.Envelope(doc.Of(recommendationDomain.Snapshot{}))).
+		Fails(404, "Snapshot not found")
+	doc.Operation("GET", "/api/v1/recommendation-strategies", "recommendations", "Strategies recommendations can be ranked with").Secured().
+		Returns(200, "Strategies and their parameters", openapi.Envelope(openapi.Array(doc.Of(recommendationDomain.StrategyInfo{}))))
+
+	// Scoring rules
+	doc.Operation("GET", "/api/v1/scoring-rules", "scoring-rules", "Your scoring rules and the shared ones").Secured().
+		Returns(200, "Scoring rules, newest first", openapi.Envelope(openapi.Array(doc.Of(recommendationDomain.ScoringRule{}))))
+	doc.Operation("POST", "/api/v1/scoring-rules", "scoring-rules", "Create a scoring rule").Secured().
+		Describe("Stores an expression that scores every action, e.g. 0.5*target_change + 0.5*rating_delta - 0.2*(age_days/30). It can then rank GET /recommendations as strategy rule:<id>, by you or, once shared, by everyone. Expressions are compiled on save: unknown variables or functions, syntax errors and expressions beyond the limits of /scoring-rules/language are rejected.").
+		Body(doc.Of(recommendationInterfaces.RuleRequest{})).
+		Returns(201, "Created scoring rule", openapi.Envelope(doc.Of(recommendationDomain.ScoringRule{}))).
+		Fails(400, "Invalid name or expression, or too many rules")
+	doc.Operation("GET", "/api/v1/scoring-rules/language", "scoring-rules", "What scoring rule expressions can use").Secured().
+		Returns(200, "Variables, functions, operators and limits", openapi.Envelope(doc.Of(recommendationApp.RuleLanguage{})))
+	doc.Operation("GET", "/api/v1/scoring-rules/:id", "scoring-rules", "Get a scoring rule").Secured().
+		PathParam("id", openapi.Integer(), "Rule ID").
+		Returns(200, "Scoring rule", openapi.Envelope(doc.Of(recommendationDomain.ScoringRule{}))).
+		Fails(404, "Scoring rule not found")
+	doc.Operation("PUT", "/api/v1/scoring-rules/:id", "scoring-rules", "Replace one of your scoring rules").Secured().
+		PathParam("id", openapi.Integer(), "Rule ID").
+		Body(doc.Of(recommendationInterfaces.RuleRequest{})).
+		Returns(200, "Updated scoring rule", openapi.Envelope(doc.Of(recommendationDomain.ScoringRule{}))).
+		Fails(400, "Invalid name or expression").
+		Fails(403, "The rule belongs to another user").
+		Fails(404, "Scoring rule not found")
+	doc.Operation("DELETE", "/api/v1/scoring-rules/:id", "scoring-rules", "Delete one of your scoring rules").Secured().
+		PathParam("id", openapi.Integer(), "Rule ID").
+		Returns(200, "Deleted", openapi.Envelope(message("message"))).
+		Fails(403, "The rule belongs to another user").
+		Fails(404, "Scoring rule not found")
+
+	// Brokerages
+	leaderboard := doc.Operation("GET", "/api/v1/brokerages/leaderboard", "brokerages", "Rank brokerages").Secured()
+	rangeQuery(leaderboard).
+		Query("sort_by", openapi.Enum("actions", "coverage", "bullishness", "upgrade_share", "revision", "deviation_rate").WithDefault("actions"), "Ranking metric").
+		Query("sort_dir", openapi.Enum("asc", "desc").WithDefault("desc"), "Sort direction").
+		Query("limit", openapi.Integer().Min(1).WithDefault(20), "Number of brokerages").
+		Query("min_actions", openapi.Integer().Min(1).WithDefault(1), "Minimum actions in the period").
+		Returns(200, "Leaderboard", openapi.Envelope(openapi.Array(doc.Of(brokerageDomain.LeaderboardEntry{})))).
+		Fails(400, "Invalid period or sort")
+	stats := doc.Operation("GET", "/api/v1/brokerages/:name/stats", "brokerages", "Activity of one brokerage").Secured()
+	rangeQuery(stats).
+		PathParam("name", openapi.String(), "Brokerage name, URL-encoded").
+		Query("bucket", bucket().WithDefault(string(stockDomain.BucketMonth)), "Granularity of the activity series").
+		Query("top", openapi.Integer().Min(1).WithDefault(10), "Number of most covered tickers").
+		Returns(200, "Brokerage stats", openapi.Envelope(doc.Of(brokerageDomain.Stats{}))).
+		Fails(400, "Invalid period or bucket").
+		Fails(404, "Brokerage not found")
+	doc.Operation("GET", "/api/v1/brokerages/credibility", "brokerages", "How well each brokerage's calls were borne out").Secured().
+		Describe("Learned by a background job that compares each action with the price history once the evaluation horizon has passed. Recommendations weigh brokerages by this credibility.").
+		Returns(200, "Credibility, most credible first", openapi.Envelope(openapi.Array(doc.Of(brokerageDomain.Credibility{}))))
+	doc.Operation("POST", "/api/v1/brokerages/credibility/refresh", "brokerages", "Recompute brokerage credibility now").Admin().
+		Returns(200, "Recomputed credibility, most credible first", openapi.Envelope(openapi.Array(doc.Of(brokerageDomain.Credibility{}))))
+	accuracyQuery := func(op *openapi.Operation) *openapi.Operation {
+		return op.
+			Describe("A background job scores every action with a target against the price history after each sync and price import: whether the price reached the target within 3, 6 and 12 months, and the largest moves towards and away from it within 12 months. Hit rates count only the actions whose outcome at the horizon is known.").
+			Query("brokerage", openapi.String(), "Only the actions of this brokerage").
+			Query("ticker", openapi.String(), "Only the actions on this ticker").
+			Query("horizon", openapi.Enum("3", "6", "12").WithDefault("12"), "Months whose hit rate ranks the results").
+			Query("min_actions", openapi.Integer().Min(1).WithDefault(1), "Fewest scored actions").
+			Query("limit", openapi.Integer().Min(1).Max(500).WithDefault(50), "Number of results").
+			Fails(400, "Invalid horizon")
+	}
+	accuracyQuery(doc.Operation("GET", "/api/v1/brokerages/accuracy", "brokerages", "How often each brokerage's targets were reached").Secured()).
+		Returns(200, "Target accuracy per brokerage, best hit rate first", openapi.Envelope(openapi.Array(doc.Of(brokerageDomain.AccuracySummary{}))))
+	accuracyQuery(doc.Operation("GET", "/api/v1/brokerages/accuracy/tickers", "brokerages", "How often the targets on each ticker were reached").Secured()).
+		Returns(200, "Target accuracy per ticker, best hit rate first", openapi.Envelope(openapi.Array(doc.Of(brokerageDomain.AccuracySummary{}))))
+	actionAccuracy := doc.Operation("GET", "/api/v1/brokerages/accuracy/actions", "brokerages", "Target accuracy of each action, newest first").Secured().
+		Describe("Hits are null while the horizon has not passed, or has no prices, and the target has not been reached yet. Prices after a split are scaled back to the shares the target was set on.")
+	pageQuery(actionAccuracy, 20).
+		Query("brokerage", openapi.String(), "Only the actions of this brokerage").
+		Query("ticker", openapi.String(), "Only the actions on this ticker").
+		Returns(200, "Scored actions", openapi.Paged(doc.Of(brokerageDomain.ActionAccuracy{})))
+	doc.Operation("POST", "/api/v1/brokerages/accuracy/refresh", "brokerages", "Score the targets of every action now").Admin().
+		Returns(200, "Scoring summary", openapi.Envelope(doc.Of(brokerageDomain.AccuracyRun{})))
+
+	// Prices
+	doc.Operation("POST", "/api/v1/prices/import", "prices", "Import daily price bars").Admin().
+		Describe("CSV with a ticker,date,close header and optional open, high, low, volume, adj_close and split_factor columns, or NDJSON with one object of the same fields per line. Dates use YYYY-MM-DD and tickers are at most 10 characters. Bulk imports replace the days already imported; incremental imports only add the days after each ticker's latest stored day. Runs of more than one missing weekday are reported as gaps.").
+		Query("format", openapi.Enum(string(priceDomain.FormatCSV), string(priceDomain.FormatNDJSON)), "Body format; NDJSON when the content type mentions ndjson, CSV otherwise").
+		Query("mode", openapi.Enum(string(priceDomain.ImportBulk), string(priceDomain.ImportIncremental)).WithDefault(string(priceDomain.ImportBulk)), "What happens to days already imported").
+		BodyContent("text/csv", openapi.String()).
+		Returns(200, "Imported rows, tickers and gaps", openapi.Envelope(doc.Of(priceDomain.ImportResult{}))).
+		Fails(400, "Malformed body, format or mode")
+	prices := doc.Operation("GET", "/api/v1/tickers/:symbol/prices", "prices", "Daily price bars of a ticker").Secured().
+		Describe("Bars between from and to, the year up to today by default. Gaps list runs of more than one missing weekday between the daily bars.")
+	rangeQuery(prices).
+		PathParam("symbol", openapi.String(), "Ticker symbol").
+		Query("interval", openapi.Enum(string(priceDomain.IntervalDay), string(priceDomain.IntervalWeek), string(priceDomain.IntervalMonth)).WithDefault(string(priceDomain.IntervalDay)),
+			"Combine the daily bars per period, dated at the period start").
+		Query("adjusted", openapi.Boolean(), "Restate prices and volume in the shares after later splits").
+		Returns(200, "Price series", openapi.Envelope(doc.Of(priceDomain.PriceSeries{}))).
+		Fails(400, "Invalid range or interval")
+	doc.Operation("GET", "/api/v1/prices/last", "prices", "Latest prices").Secured().
+		Query("tickers", openapi.String(), "Comma-separated tickers; every ticker with a price when empty").
+		Returns(200, "Latest prices ordered by ticker", openapi.Envelope(openapi.Array(doc.Of(priceDomain.LastPrice{}))))
+	doc.Operation("POST", "/api/v1/prices/last/refresh", "prices", "Read the price feed now").Admin().
+		Describe("Reads the configured PRICE_FEED, a CSV with a ticker,price header and an optional at column or a JSON array of {ticker, price, at} objects, and stores every ticker's latest price. Prices without a time are quoted now; an older quote never replaces a newer one.").
+		Returns(200, "Updated prices and tickers", openapi.Envelope(doc.Of(priceDomain.ImportResult{}))).
+		Fails(502, "The feed could not be read or is malformed").
+		Fails(503, "No price feed is configured")
+
+	// Backtests
+	doc.Operation("POST", "/api/v1/backtests", "backtests", "Backtest a recommendation strategy").Admin().
+		Describe("Replays the action history as known at every rebalance date from from to to, ranks it as GET /recommendations would have that day and measures the top picks' returns over the horizon and until the next rebalance against the imported prices. The run is recorded as pending and executed in the background; runs execute one at a time.").
+		Body(doc.Of(backtestInterfaces.RunRequest{})).
+		Returns(201, "Pending run", openapi.Envelope(doc.Of(backtestDomain.Run{}))).
+		Fails(400, "Invalid dates, sizes, strategy, aggregation or parameters")
+	doc.Operation("GET", "/api/v1/backtests", "backtests", "Compare backtest runs").Admin().
+		Query("strategy", openapi.String(), "Only runs of this strategy").
+		Returns(200, "Runs with their metrics, newest first, without periods", openapi.Envelope(openapi.Array(doc.Of(backtestDomain.Run{}))))
+	doc.Operation("GET", "/api/v1/backtests/:id", "backtests", "Get a backtest run").Admin().
+		PathParam("id", openapi.Integer(), "Run ID").
+		Returns(200, "Run with the picks of every rebalance", openapi.Envelope(doc.Of(backtestDomain.Run{}))).
+		Fails(404, "Run not found")
+
+	// GraphQL
+	doc.Operation("POST", "/api/v1/graphql", "graphql", "Run a GraphQL query").Secured().
+		Body(&openapi.Schema{
+			Type:     "object",
+			Required: []string{"query"},
+			Properties: map[string]*openapi.Schema{
+				"query":         openapi.String(),
+				"operationName": openapi.String(),
+				"variables":     {Type: "object"},
+			},
+		}).
+		Returns(200, "GraphQL result with data and errors", &openapi.Schema{Type: "object"})
+
+	// Operations
+	doc.Operation("GET", "/api/v1/cache/stats", "system", "Read cache hit and miss counters").Admin().
+		Returns(200, "Cache stats", openapi.Envelope(doc.Of(cache.Stats{})))
+
+	return doc
+}